@@ -10,7 +10,9 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"yap-pwkeeper/internal/app/client"
+	"yap-pwkeeper/internal/app/client/clipboard"
 	"yap-pwkeeper/internal/app/client/config"
+	"yap-pwkeeper/internal/app/client/filecache"
 	"yap-pwkeeper/internal/app/client/grpccli"
 	"yap-pwkeeper/internal/app/client/memstore"
 )
@@ -83,11 +85,19 @@ func main() {
 	}
 	defer func() { _ = grpcClient.Close() }()
 
-	store := memstore.New(grpcClient)
+	var storeOpts []func(s *memstore.Store)
+	if conf.CacheDir != "" {
+		storeOpts = append(storeOpts, memstore.WithCache(filecache.New(conf.CacheDir, conf.Address)))
+	}
+	store := memstore.New(grpcClient, storeOpts...)
 
 	ui := client.New(
 		client.WithDataStore(store),
 		client.WithMouse(conf.UseMouse),
+		client.WithClipboard(clipboard.New(conf.Clipboard)),
+		client.WithScreenLock(conf.LockTimeout),
+		client.WithIdleLogout(conf.IdleTimeout),
+		client.WithLockOnBlur(conf.LockOnBlur),
 	)
 	log.Println("starting ui")
 
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"regexp"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,12 +18,29 @@ import (
 	"yap-pwkeeper/internal/app/server/documents"
 	"yap-pwkeeper/internal/app/server/grpcapi"
 	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/boltdb"
+	"yap-pwkeeper/internal/pkg/breached"
 	"yap-pwkeeper/internal/pkg/grpc/interceptors"
 	"yap-pwkeeper/internal/pkg/jwtToken"
 	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
 	"yap-pwkeeper/internal/pkg/mongodb"
+	"yap-pwkeeper/internal/pkg/ratelimit"
 )
 
+// purgeInterval is how often expired documents are purged from trash
+const purgeInterval = 10 * time.Minute
+
+// storage is a database backend, used by all controllers
+type storage interface {
+	documents.DocStorage
+	documents.VaultStorage
+	documents.ShareStorage
+	aaa.UserStorage
+	serial.Source
+	Close(ctx context.Context) error
+}
+
 var (
 	// go build -ldflags " \
 	// -X 'main.buildVersion=$(git describe --tag --always 2>/dev/null)' \
@@ -68,7 +87,7 @@ func main() {
 
 	// connect database
 	logger.Log().Info("connecting database")
-	db, err := mongodb.New(nCtx, conf.DbUri)
+	db, err := openStorage(nCtx, conf.DbUri)
 	if err != nil {
 		logger.Log().WithErr(err).Error("database setup failed")
 		exitCode = 1
@@ -91,11 +110,52 @@ func main() {
 	serial.SetSource(db)
 	serial.SetBatchSize(10)
 
-	// auth controller
-	auth := aaa.New(db)
-
 	// documents controller
-	docs := documents.New(db)
+	var auth *aaa.Controller
+	docs := documents.New(db,
+		documents.WithTrashRetention(conf.Trash),
+		documents.WithHistoryLimit(conf.History),
+		documents.WithVaultKeys(func(ctx context.Context, userId string) ([]byte, error) {
+			return auth.GetVaultKey(ctx, userId)
+		}),
+		documents.WithKeyPairs(func(ctx context.Context, userId string) (models.KeyPair, error) {
+			return auth.GetKeyPair(ctx, userId)
+		}),
+		documents.WithMigrated(func(ctx context.Context, userId string) (bool, error) {
+			return auth.IsMigrated(ctx, userId)
+		}),
+		documents.WithVaults(db, db),
+		documents.WithShares(db),
+	)
+
+	// credentials policy
+	policy, closePolicy, err := credentialsPolicy(conf)
+	if err != nil {
+		logger.Log().WithErr(err).Error("credentials policy setup failed")
+		exitCode = 1
+		return
+	}
+	defer closePolicy()
+
+	// auth controller, account deletion removes all user documents
+	auth = aaa.New(db,
+		aaa.WithAccountCleanup(docs.DeleteUserData),
+		aaa.WithMigration(docs.PurgePlainHistory),
+		aaa.WithPolicy(policy),
+		aaa.WithLockout(ratelimit.Policy{Free: conf.UserFailures, Base: conf.LoginBackoff, Max: conf.LoginLockout}),
+		aaa.WithSessionLimits(conf.SessionMaxAge, conf.SessionMaxIdle),
+		aaa.WithSessionEnd(docs.DropSession),
+	)
+	// brute-force protection of login, registration and share passphrases
+	limiter := ratelimit.New(
+		ratelimit.Policy{Free: conf.LoginFailures, Base: conf.LoginBackoff, Max: conf.LoginLockout},
+		ratelimit.WithRate(conf.LoginRate, time.Minute),
+	)
+	// live subscriptions should not delay graceful shutdown
+	go func() {
+		<-nCtx.Done()
+		docs.Close()
+	}()
 
 	// enable tls
 	var tlsCredentials credentials.TransportCredentials
@@ -112,15 +172,22 @@ func main() {
 	gs := grpcapi.New(
 		grpcapi.WithAddress(conf.Address),
 		grpcapi.WithTransportCredentials(tlsCredentials),
-		grpcapi.WithUnaryInterceptors(interceptors.AuthUnaryServer(auth.Validate, "Docs/")),
+		grpcapi.WithUnaryInterceptors(interceptors.RateLimitUnaryServer(limiter, "Auth/Login", "Auth/Register", "Share/Open",
+			"Auth/ChangePassword", "Auth/DeleteAccount")),
+		grpcapi.WithUnaryInterceptors(interceptors.AuthUnaryServer(auth.Validate, "Docs/", "Auth/GetVaultKey", "Auth/SetVaultKey",
+			"Auth/GetKeyPair", "Auth/SetKeyPair", "Auth/GetPublicKey",
+			"Auth/EnrollTotp", "Auth/ConfirmTotp", "Auth/DisableTotp", "Auth/Logout", "Auth/ListSessions", "Auth/RevokeSession",
+			"Auth/ChangePassword", "Auth/DeleteAccount", "Auth/GetMigrated", "Auth/SetMigrated")),
 		grpcapi.WithStreamInterceptors(interceptors.AuthStreamServer(auth.Validate, "Docs/")),
 		grpcapi.WithAuthHandlers(grpcapi.NewAuthHandlers(auth)),
 		grpcapi.WithDocsHandlers(grpcapi.NewDocsHandlers(docs)),
+		grpcapi.WithShareHandlers(grpcapi.NewShareHandlers(docs)),
 	)
 
 	// init and run server
 	serverApp := server.New(
 		server.WithGRPCServer(gs),
+		server.WithPurger(docs, purgeInterval),
 	)
 	err = serverApp.Run(nCtx)
 	if err != nil {
@@ -129,6 +196,34 @@ func main() {
 	}
 }
 
+// openStorage opens database backend, selected by uri scheme
+func openStorage(ctx context.Context, uri string) (storage, error) {
+	if strings.HasPrefix(uri, boltdb.Scheme) {
+		return boltdb.New(strings.TrimPrefix(uri, boltdb.Scheme))
+	}
+	return mongodb.New(ctx, uri)
+}
+
+// credentialsPolicy makes policy of new credentials from config, breached passwords file
+// is opened if it is set, returned function closes it.
+func credentialsPolicy(conf *config.Config) (aaa.CredentialsPolicy, func(), error) {
+	policy := aaa.CredentialsPolicy{MinLength: conf.PasswordLength, MinClasses: conf.PasswordClasses}
+	var err error
+	if policy.Login, err = regexp.Compile(conf.LoginPattern); err != nil {
+		return policy, nil, fmt.Errorf("bad login pattern: %w", err)
+	}
+	if conf.BreachedPassword == "" {
+		return policy, func() {}, nil
+	}
+	logger.Log().Info("indexing breached passwords")
+	list, err := breached.Open(conf.BreachedPassword)
+	if err != nil {
+		return policy, nil, err
+	}
+	policy.Breached = list.Range
+	return policy, func() { _ = list.Close() }, nil
+}
+
 func version() {
 	_, _ = fmt.Fprintf(
 		os.Stdout,
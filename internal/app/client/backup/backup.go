@@ -0,0 +1,280 @@
+// Package backup writes and reads portable encrypted backups of documents.
+// Backup is a single file, protected by passphrase, which does not depend on
+// account or server: documents are stored decrypted inside, so backup may be
+// restored to any account.
+//
+// File format: magic, length of wrapped archive key, archive key wrapped with
+// the key derived from passphrase (crypt.WrapKey), and then encrypted stream of
+// tar archive. The first tar entry is JSON manifest with documents, the rest are
+// files data named by document id.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const (
+	Version = 1 // manifest version
+
+	magic        = "PWKBAK1\n"
+	manifestName = "manifest.json"
+	filesDir     = "files/"
+	maxWrapped   = 1 << 10 // wrapped key size limit
+	maxManifest  = 1 << 30 // manifest size limit
+)
+
+var (
+	ErrFormat       = errors.New("not a pwKeeper backup")
+	ErrVersion      = errors.New("unsupported backup version")
+	ErrPassphrase   = errors.New("wrong passphrase or damaged backup")
+	ErrNoPassphrase = errors.New("passphrase should not be empty")
+	ErrDamaged      = errors.New("backup is damaged")
+	ErrUnknownFile  = errors.New("backup contains unknown file")
+	ErrWriterClosed = errors.New("backup writer is closed")
+)
+
+// Vault is a shared vault of backed up documents
+type Vault struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Manifest lists backed up documents. Documents keep their ids, vault ids,
+// metadata, folders and tags. Size of files is size of file data in backup.
+type Manifest struct {
+	Version     int                 `json:"version"`
+	CreatedAt   int64               `json:"created_at"`
+	Login       string              `json:"login,omitempty"` // account of backed up documents
+	Vaults      []Vault             `json:"vaults,omitempty"`
+	Notes       []models.Note       `json:"notes,omitempty"`
+	Cards       []models.Card       `json:"cards,omitempty"`
+	Credentials []models.Credential `json:"credentials,omitempty"`
+	Files       []models.File       `json:"files,omitempty"`
+}
+
+// Len returns number of documents in manifest
+func (m Manifest) Len() int {
+	return len(m.Notes) + len(m.Cards) + len(m.Credentials) + len(m.Files)
+}
+
+// Writer writes backup. Manifest is written on creation, then data of every file
+// listed in manifest should be added. Close must be called to complete backup.
+type Writer struct {
+	tw     *tar.Writer
+	pw     *io.PipeWriter
+	done   chan error
+	closed bool
+}
+
+// NewWriter starts backup to w, encrypted with the key protected by passphrase
+func NewWriter(w io.Writer, passphrase string, m Manifest) (*Writer, error) {
+	if passphrase == "" {
+		return nil, ErrNoPassphrase
+	}
+	key, err := crypt.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := crypt.WrapKey(key, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	c, err := crypt.New(key)
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte(magic), binary.BigEndian.AppendUint32(nil, uint32(len(wrapped)))...)
+	if _, err := w.Write(append(header, wrapped...)); err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	er, err := c.NewReader(pr)
+	if err != nil {
+		return nil, err
+	}
+	bw := &Writer{tw: tar.NewWriter(pw), pw: pw, done: make(chan error, 1)}
+	go func() {
+		_, err := io.Copy(w, er)
+		// unblocks tar writer, if output failed
+		_ = pr.CloseWithError(err)
+		bw.done <- err
+	}()
+	m.Version = Version
+	if m.CreatedAt == 0 {
+		m.CreatedAt = time.Now().Unix()
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		_ = bw.Close()
+		return nil, err
+	}
+	if err := bw.add(manifestName, int64(len(data)), bytes.NewReader(data)); err != nil {
+		_ = bw.Close()
+		return nil, err
+	}
+	return bw, nil
+}
+
+// AddFile adds data of file document, size is exact size of data in r
+func (bw *Writer) AddFile(documentId string, size int64, r io.Reader) error {
+	if documentId == "" || strings.Contains(documentId, "/") {
+		return ErrUnknownFile
+	}
+	return bw.add(filesDir+documentId, size, r)
+}
+
+func (bw *Writer) add(name string, size int64, r io.Reader) error {
+	if bw.closed {
+		return ErrWriterClosed
+	}
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: size, Typeflag: tar.TypeReg, Format: tar.FormatPAX}
+	if err := bw.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(bw.tw, r, size); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Close completes backup and waits until all data is written
+func (bw *Writer) Close() error {
+	if bw.closed {
+		return ErrWriterClosed
+	}
+	bw.closed = true
+	err := bw.tw.Close()
+	_ = bw.pw.Close()
+	if errOut := <-bw.done; errOut != nil {
+		return errOut
+	}
+	return err
+}
+
+// Reader reads backup. Manifest is available on open, files data is read by Next.
+type Reader struct {
+	manifest Manifest
+	tr       *tar.Reader
+	pr       *io.PipeReader
+	files    map[string]bool
+}
+
+// Open opens backup, encrypted with the key protected by passphrase, and reads manifest
+func Open(r io.Reader, passphrase string) (*Reader, error) {
+	header := make([]byte, len(magic)+4)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(magic)]) != magic {
+		return nil, ErrFormat
+	}
+	size := binary.BigEndian.Uint32(header[len(magic):])
+	if size > maxWrapped {
+		return nil, ErrFormat
+	}
+	wrapped := make([]byte, size)
+	if _, err := io.ReadFull(r, wrapped); err != nil {
+		return nil, ErrFormat
+	}
+	key, err := crypt.UnwrapKey(wrapped, passphrase)
+	if errors.Is(err, crypt.ErrDecrypt) {
+		return nil, ErrPassphrase
+	}
+	if err != nil {
+		return nil, ErrFormat
+	}
+	c, err := crypt.New(key)
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		dw := c.NewWriter(pw)
+		_, err := io.Copy(dw, r)
+		if err == nil {
+			err = dw.Close()
+		}
+		if errors.Is(err, crypt.ErrDecrypt) || errors.Is(err, crypt.ErrFormat) {
+			err = ErrDamaged
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	br := &Reader{tr: tar.NewReader(pr), pr: pr}
+	if err := br.readManifest(); err != nil {
+		_ = br.Close()
+		return nil, err
+	}
+	return br, nil
+}
+
+func (br *Reader) readManifest() error {
+	hdr, err := br.tr.Next()
+	if err != nil {
+		return damaged(err)
+	}
+	if hdr.Name != manifestName {
+		return ErrFormat
+	}
+	if hdr.Size > maxManifest {
+		return ErrDamaged
+	}
+	if err := json.NewDecoder(br.tr).Decode(&br.manifest); err != nil {
+		return damaged(err)
+	}
+	if br.manifest.Version != Version {
+		return ErrVersion
+	}
+	br.files = make(map[string]bool, len(br.manifest.Files))
+	for _, f := range br.manifest.Files {
+		br.files[f.Id] = true
+	}
+	return nil
+}
+
+// Manifest returns backup manifest
+func (br *Reader) Manifest() Manifest {
+	return br.manifest
+}
+
+// Next returns id of the next file document and reader of its data, which is valid
+// until the next call. Returns io.EOF, when all files are read and backup integrity
+// is verified.
+func (br *Reader) Next() (string, io.Reader, error) {
+	hdr, err := br.tr.Next()
+	if err == io.EOF {
+		// stream is verified, when the rest of it is decrypted
+		if _, err := io.Copy(io.Discard, br.pr); err != nil {
+			return "", nil, damaged(err)
+		}
+		return "", nil, io.EOF
+	}
+	if err != nil {
+		return "", nil, damaged(err)
+	}
+	id, ok := strings.CutPrefix(hdr.Name, filesDir)
+	if !ok || !br.files[id] {
+		return "", nil, ErrUnknownFile
+	}
+	return id, br.tr, nil
+}
+
+// Close stops reading
+func (br *Reader) Close() error {
+	return br.pr.Close()
+}
+
+// damaged replaces tar and json errors of damaged stream
+func damaged(err error) error {
+	if errors.Is(err, ErrDamaged) || errors.Is(err, ErrPassphrase) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrDamaged, err)
+}
@@ -0,0 +1,138 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// writeBackup returns backup of manifest with files data
+func writeBackup(t *testing.T, m Manifest, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "correct horse", m)
+	require.NoError(t, err)
+	for _, f := range m.Files {
+		require.NoError(t, w.AddFile(f.Id, int64(len(files[f.Id])), bytes.NewReader(files[f.Id])))
+	}
+	require.NoError(t, w.Close())
+	require.ErrorIs(t, w.Close(), ErrWriterClosed)
+	return buf.Bytes()
+}
+
+// readBackup returns manifest and files data of backup
+func readBackup(data []byte, passphrase string) (Manifest, map[string][]byte, error) {
+	r, err := Open(bytes.NewReader(data), passphrase)
+	if err != nil {
+		return Manifest{}, nil, err
+	}
+	defer func() { _ = r.Close() }()
+	files := make(map[string][]byte)
+	for {
+		id, fr, err := r.Next()
+		if err == io.EOF {
+			return r.Manifest(), files, nil
+		}
+		if err != nil {
+			return Manifest{}, nil, err
+		}
+		if files[id], err = io.ReadAll(fr); err != nil {
+			return Manifest{}, nil, err
+		}
+	}
+}
+
+func TestBackup(t *testing.T) {
+	big := bytes.Repeat([]byte("0123456789"), 20000) // a few encryption chunks
+	m := Manifest{
+		Login:       "alice",
+		Vaults:      []Vault{{Id: "v1", Name: "team"}},
+		Notes:       []models.Note{{Id: "n1", Name: "note", Text: "text", Metadata: []models.Meta{{Key: "k", Value: "v"}}, Tags: []string{"a"}}},
+		Cards:       []models.Card{{Id: "c1", VaultId: "v1", Name: "card", Number: "4111"}},
+		Credentials: []models.Credential{{Id: "l1", Name: "login", Login: "user", Password: "pw", Folder: "work/mail"}},
+		Files:       []models.File{{Id: "f1", Name: "big", Filename: "big.bin", Size: int64(len(big))}, {Id: "f2", Name: "empty", Filename: "empty.txt"}},
+	}
+	files := map[string][]byte{"f1": big, "f2": {}}
+	data := writeBackup(t, m, files)
+	assert.False(t, bytes.Contains(data, []byte("0123456789")), "backup should be encrypted")
+	assert.False(t, bytes.Contains(data, []byte("alice")), "manifest should be encrypted")
+
+	got, gotFiles, err := readBackup(data, "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, Version, got.Version)
+	assert.NotZero(t, got.CreatedAt)
+	m.Version, m.CreatedAt = got.Version, got.CreatedAt
+	assert.Equal(t, m, got)
+	assert.Equal(t, 5, got.Len())
+	assert.Equal(t, files, gotFiles)
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, _, err := readBackup(data, "wrong")
+		require.ErrorIs(t, err, ErrPassphrase)
+	})
+	t.Run("not backup", func(t *testing.T) {
+		_, _, err := readBackup([]byte("some other file content"), "correct horse")
+		require.ErrorIs(t, err, ErrFormat)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		tampered := append([]byte(nil), data...)
+		tampered[len(tampered)-100] ^= 1
+		_, _, err := readBackup(tampered, "correct horse")
+		require.ErrorIs(t, err, ErrDamaged)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		_, _, err := readBackup(data[:len(data)-20], "correct horse")
+		require.ErrorIs(t, err, ErrDamaged)
+	})
+	t.Run("empty passphrase", func(t *testing.T) {
+		_, err := NewWriter(io.Discard, "", m)
+		require.ErrorIs(t, err, ErrNoPassphrase)
+	})
+	t.Run("unknown file", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, "pass", Manifest{})
+		require.NoError(t, err)
+		require.NoError(t, w.AddFile("x", 1, bytes.NewReader([]byte("x"))))
+		require.NoError(t, w.Close())
+		_, _, err = readBackup(buf.Bytes(), "pass")
+		require.ErrorIs(t, err, ErrUnknownFile, "files not listed in manifest should be rejected")
+	})
+}
+
+func TestDocs(t *testing.T) {
+	m := Manifest{
+		Vaults:      []Vault{{Id: "v1", Name: "team/ops"}, {Id: "v2", Name: "own"}},
+		Credentials: []models.Credential{{Name: "a", VaultId: "v1", Folder: "db"}, {Name: "b", VaultId: "v2"}, {Name: "c"}},
+		Files:       []models.File{{Name: "f", VaultId: "gone"}},
+	}
+	docs := Docs(m, func(vaultId string) bool { return vaultId == "v2" })
+	assert.Equal(t, []interface{}{
+		models.Credential{Name: "a", Folder: "team-ops/db"},
+		models.Credential{Name: "b", VaultId: "v2"},
+		models.Credential{Name: "c"},
+		models.File{Name: "f", Folder: "Shared"},
+	}, docs)
+}
+
+func TestCompare(t *testing.T) {
+	existing := []interface{}{
+		models.Note{Id: "1", UserId: "u", Serial: 5, Name: "Wifi", Text: "pw", State: models.StateActive},
+		models.Credential{Id: "2", Name: "Mail", Login: "user", Password: "old"},
+		models.Note{Id: "3", VaultId: "v1", Name: "Team", Text: "x"},
+	}
+	docs := []interface{}{
+		models.Note{Id: "a", Name: "Wifi", Text: "pw", Metadata: []models.Meta{}},
+		models.Credential{Id: "b", Name: "Mail", Login: "user", Password: "new"},
+		models.Note{Id: "c", Name: "Team", Text: "x"},
+		models.Card{Id: "d", Name: "Wifi"},
+	}
+	plan := Compare(docs, existing)
+	assert.Equal(t, []interface{}{docs[2], docs[3]}, plan.New, "documents of other vault or kind should be new")
+	assert.Equal(t, []interface{}{docs[0]}, plan.Same)
+	assert.Equal(t, []interface{}{docs[1]}, plan.Conflicts)
+}
@@ -0,0 +1,143 @@
+package backup
+
+import (
+	"reflect"
+	"strings"
+
+	"yap-pwkeeper/internal/app/client/docfilter"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Plan sorts backed up documents by their state in target storage
+type Plan struct {
+	New       []interface{} // documents, which do not exist
+	Same      []interface{} // documents, which exist unchanged
+	Conflicts []interface{} // documents, which differ from existing with the same name
+}
+
+// Docs returns all documents of manifest. Documents of vaults, which are not
+// writable (e.g. backup is restored to other account), are moved to personal
+// documents, into folder named after the vault.
+func Docs(m Manifest, writable func(vaultId string) bool) []interface{} {
+	names := make(map[string]string, len(m.Vaults))
+	for _, v := range m.Vaults {
+		names[v.Id] = v.Name
+	}
+	retarget := func(vaultId, folder *string) {
+		if *vaultId == "" || writable(*vaultId) {
+			return
+		}
+		name := names[*vaultId]
+		if name == "" {
+			name = "Shared"
+		}
+		name = strings.ReplaceAll(name, docfilter.Separator, "-")
+		*folder = docfilter.CleanFolder(name + docfilter.Separator + *folder)
+		*vaultId = ""
+	}
+	docs := make([]interface{}, 0, m.Len())
+	for _, d := range m.Credentials {
+		retarget(&d.VaultId, &d.Folder)
+		docs = append(docs, d)
+	}
+	for _, d := range m.Cards {
+		retarget(&d.VaultId, &d.Folder)
+		docs = append(docs, d)
+	}
+	for _, d := range m.Notes {
+		retarget(&d.VaultId, &d.Folder)
+		docs = append(docs, d)
+	}
+	for _, d := range m.Files {
+		retarget(&d.VaultId, &d.Folder)
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// Compare finds documents, which exist in the same vault. Documents are matched by
+// kind and name: document is the same, if content of any match is equal, otherwise
+// it is a conflict.
+func Compare(docs []interface{}, existing []interface{}) Plan {
+	index := make(map[string][]interface{}, len(existing))
+	for _, d := range existing {
+		k := key(d)
+		index[k] = append(index[k], d)
+	}
+	var plan Plan
+	for _, d := range docs {
+		matches, ok := index[key(d)]
+		if !ok {
+			plan.New = append(plan.New, d)
+			continue
+		}
+		same := false
+		for _, m := range matches {
+			if Same(d, m) {
+				same = true
+				break
+			}
+		}
+		if same {
+			plan.Same = append(plan.Same, d)
+		} else {
+			plan.Conflicts = append(plan.Conflicts, d)
+		}
+	}
+	return plan
+}
+
+// key matches documents of the same kind and name in the same vault
+func key(doc interface{}) string {
+	k := func(kind, vaultId, name string) string {
+		return kind + "\x00" + vaultId + "\x00" + strings.ToLower(strings.TrimSpace(name))
+	}
+	switch d := doc.(type) {
+	case models.Note:
+		return k("note", d.VaultId, d.Name)
+	case models.Card:
+		return k("card", d.VaultId, d.Name)
+	case models.Credential:
+		return k("credential", d.VaultId, d.Name)
+	case models.File:
+		return k("file", d.VaultId, d.Name)
+	}
+	return ""
+}
+
+// Same reports whether documents have equal content. Ids, owners, serials and
+// states are not compared. Files are compared by size without data.
+func Same(a, b interface{}) bool {
+	return reflect.DeepEqual(content(a), content(b))
+}
+
+// content returns document without server and storage fields
+func content(doc interface{}) interface{} {
+	switch d := doc.(type) {
+	case models.Note:
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State, d.TrashedAt = "", "", "", 0, "", 0
+		d.Metadata, d.Tags = emptyNil(d.Metadata), emptyNil(d.Tags)
+		return d
+	case models.Card:
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State, d.TrashedAt = "", "", "", 0, "", 0
+		d.Metadata, d.Tags = emptyNil(d.Metadata), emptyNil(d.Tags)
+		return d
+	case models.Credential:
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State, d.TrashedAt = "", "", "", 0, "", 0
+		d.Metadata, d.Tags = emptyNil(d.Metadata), emptyNil(d.Tags)
+		return d
+	case models.File:
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State, d.TrashedAt = "", "", "", 0, "", 0
+		d.BlobId, d.Data, d.Sha265 = "", nil, ""
+		d.Metadata, d.Tags = emptyNil(d.Metadata), emptyNil(d.Tags)
+		return d
+	}
+	return doc
+}
+
+func emptyNil[T any](list []T) []T {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
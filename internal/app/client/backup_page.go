@@ -0,0 +1,219 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/memstore"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const (
+	pageBackup         = "backup"
+	pageRestorePreview = "restorePreview"
+)
+
+// modalBackup is a menu of portable backup actions
+func (a *App) modalBackup() {
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText("Portable backup is a file with all documents and files,\nencrypted with passphrase. It may be restored to any account.").
+		AddButtons([]string{"Create backup", "Restore backup", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			switch buttonIndex {
+			case 0:
+				a.backupPage()
+			case 1:
+				a.restorePage()
+			default:
+				a.ui.SetFocus(a.categories)
+			}
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// backupPage asks for path and passphrase of new backup
+func (a *App) backupPage() {
+	if a.store.IsOffline() {
+		a.modalErr("Backup is not available offline")
+		return
+	}
+	path := "pwkeeper-backup.pwkb"
+	var passphrase, rePass string
+	cancelFunc := func() {
+		a.pages.RemovePage(pageBackup)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("Save to", path, 50, nil, func(text string) {
+		path = text
+	})
+	form.AddPasswordField("Passphrase", "", 30, '*', func(text string) {
+		passphrase = text
+	})
+	form.AddPasswordField("Repeat passphrase", "", 30, '*', func(text string) {
+		rePass = text
+	})
+	form.AddButton("Back up", func() {
+		if path == "" || passphrase == "" {
+			a.modalErr("Path and passphrase should not be empty")
+			return
+		}
+		if passphrase != rePass {
+			a.modalErr("Passphrases do not match!")
+			return
+		}
+		a.pages.RemovePage(pageBackup)
+		a.ui.SetFocus(a.categories)
+		a.modifyRequest(
+			func() error {
+				_, err := a.store.Backup(path, passphrase)
+				return err
+			},
+			"Backup saved to "+path+"\nKeep the passphrase, backup can't be opened without it",
+			"Failed to create backup",
+		)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Create backup ")
+	a.pages.AddPage(pageBackup, center(70, 11, form), true, true)
+}
+
+// restorePage asks for backup path and passphrase and shows restore preview
+func (a *App) restorePage() {
+	if a.store.IsOffline() {
+		a.modalErr("Restore is not available offline")
+		return
+	}
+	var path, passphrase string
+	cancelFunc := func() {
+		a.pages.RemovePage(pageBackup)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("Backup file", "", 50, nil, func(text string) {
+		path = text
+	})
+	form.AddPasswordField("Passphrase", "", 30, '*', func(text string) {
+		passphrase = text
+	})
+	form.AddButton("Preview", func() {
+		if err := checkFile(path); err != nil {
+			a.modalErr(err.Error())
+			return
+		}
+		preview, err := a.store.PreviewRestore(path, passphrase)
+		if err != nil {
+			a.modalErr("Failed to open backup: " + err.Error())
+			return
+		}
+		a.pages.RemovePage(pageBackup)
+		a.restorePreviewPage(preview, path, passphrase)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Restore backup ")
+	a.pages.AddPage(pageBackup, center(70, 9, form), true, true)
+}
+
+// restorePreviewPage shows documents to be restored and conflicts with existing ones,
+// nothing is saved until restore is confirmed
+func (a *App) restorePreviewPage(preview memstore.RestorePreview, path, passphrase string) {
+	closePage := func() {
+		a.pages.RemovePage(pageRestorePreview)
+		a.ui.SetFocus(a.categories)
+	}
+	m, plan := preview.Manifest, preview.Plan
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Backup of [yellow]%s[white] created %s, documents: %d\n",
+		tview.Escape(m.Login), time.Unix(m.CreatedAt, 0).Format(time.DateTime), m.Len())
+	_, _ = fmt.Fprintf(&b, "[green]New: %d[white], unchanged: %d, [red]conflicts: %d[white]\n\n",
+		len(plan.New), len(plan.Same), len(plan.Conflicts))
+	for _, doc := range plan.New {
+		_, _ = fmt.Fprintf(&b, "[green]+[white] %s\n", a.restoredName(doc))
+	}
+	for _, doc := range plan.Conflicts {
+		_, _ = fmt.Fprintf(&b, "[red]![white] %s differs from existing document\n", a.restoredName(doc))
+	}
+	for _, doc := range plan.Same {
+		_, _ = fmt.Fprintf(&b, "[gray]=[white] %s already exists\n", a.restoredName(doc))
+	}
+	text := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetText(b.String())
+
+	restore := func(withConflicts bool) {
+		closePage()
+		total := len(plan.New)
+		if withConflicts {
+			total += len(plan.Conflicts)
+		}
+		a.modifyRequest(
+			func() error {
+				n, err := a.store.Restore(path, passphrase, withConflicts)
+				if err != nil {
+					return fmt.Errorf("%d of %d documents restored: %w", n, total, err)
+				}
+				return nil
+			},
+			fmt.Sprintf("%d documents restored", total),
+			"Restore failed",
+		)
+	}
+	form := tview.NewForm().SetCancelFunc(closePage)
+	if len(plan.New) > 0 {
+		form.AddButton(fmt.Sprintf("Restore %d new", len(plan.New)), func() {
+			restore(false)
+		})
+	}
+	if len(plan.Conflicts) > 0 {
+		form.AddButton("Restore, conflicts as copies", func() {
+			restore(true)
+		})
+	}
+	form.AddButton("<< Cancel", closePage)
+	form.SetButtonsAlign(tview.AlignCenter)
+	text.SetBorder(true).SetTitle(" Restore preview (`Tab` to buttons, `Esc` to close) ")
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closePage()
+			return nil
+		case tcell.KeyTab:
+			a.ui.SetFocus(form)
+			return nil
+		}
+		return event
+	})
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, true).
+		AddItem(form, 3, 0, false)
+	a.pages.AddPage(pageRestorePreview, center(90, 24, flex), true, true)
+	a.ui.SetFocus(text)
+}
+
+// restoredName describes backed up document in restore preview
+func (a *App) restoredName(doc interface{}) string {
+	var name, kind, vaultId string
+	switch d := doc.(type) {
+	case models.Note:
+		name, kind, vaultId = d.Name, "note", d.VaultId
+	case models.Card:
+		name, kind, vaultId = d.Name, "card", d.VaultId
+	case models.Credential:
+		name, kind, vaultId = d.Name, "login", d.VaultId
+	case models.File:
+		name, kind, vaultId = d.Name, "file", d.VaultId
+	}
+	return fmt.Sprintf("%s (%s)", a.itemName("", tview.Escape(name), vaultId), kind)
+}
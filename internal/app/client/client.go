@@ -1,8 +1,14 @@
 package client
 
 import (
+	"context"
+	"time"
+
 	"github.com/rivo/tview"
 
+	"yap-pwkeeper/internal/app/client/clipboard"
+	"yap-pwkeeper/internal/app/client/docfilter"
+	"yap-pwkeeper/internal/app/client/memstore"
 	"yap-pwkeeper/internal/pkg/models"
 )
 
@@ -13,9 +19,22 @@ const (
 // DataStore defines all store methods
 type DataStore interface {
 	Register(login, password string) error
-	Login(login, password string) error
+	Login(login, password, code string) error
+	EnrollTotp() (models.TotpEnrollment, error)
+	ConfirmTotp(code string) error
+	DisableTotp(code string) error
+	ListSessions() ([]models.Session, error)
+	RevokeSession(sessionId string) error
+	Logout()
+	ChangePassword(oldPassword, newPassword string) error
+	VerifyPassword(password string) error
+	DeleteAccount(password string) error
+	ExportAll(path string) error
 
 	Update() error
+	Watch(ctx context.Context, notify func(err error))
+	IsOffline() bool
+	IsUnencrypted(documentId string) bool
 
 	GetCardsList() []*models.Card
 	GetCard(id string) *models.Card
@@ -42,6 +61,33 @@ type DataStore interface {
 	UpdateFileInfo(d models.File) error
 	UpdateFile(d models.File, filename string) error
 	DeleteFile(note models.File) error
+
+	GetHistory(documentId string) ([]interface{}, error)
+	RestoreRevision(documentId string, serial int64) error
+
+	GetVaults() []models.Vault
+	GetVault(vaultId string) (models.Vault, bool)
+	VaultRole(vaultId string) string
+	CreateVault(name string) error
+	RenameVault(vaultId, name string) error
+	DeleteVault(vaultId string) error
+	AddVaultMember(vaultId, login, role string) error
+	SetVaultMemberRole(vaultId, userId, role string) error
+	RemoveVaultMember(vaultId, userId string) error
+	LeaveVault(vaultId string) error
+
+	ShareDocument(doc interface{}, ttl time.Duration, views int, passphrase string) (string, time.Time, error)
+	OpenShare(token, passphrase string) (interface{}, int, error)
+
+	PreviewImport(path, format, vaultId string) (memstore.ImportPreview, error)
+	Import(docs []interface{}, vaultId string) (int, error)
+	Backup(path, passphrase string) (int, error)
+	PreviewRestore(path, passphrase string) (memstore.RestorePreview, error)
+	Restore(path, passphrase string, withConflicts bool) (int, error)
+
+	GetTrashList() []interface{}
+	RestoreFromTrash(doc interface{}) error
+	DeleteFromTrash(doc interface{}) error
 }
 
 type App struct {
@@ -51,8 +97,21 @@ type App struct {
 	categories *tview.List
 	itemsList  *tview.List
 	form       *tview.Form
+	folders    *tview.TreeView
+	tagFilter  *tview.InputField
+	filter     docfilter.Filter // selects documents shown in lists
 	statusBar  *tview.Form
 	useMouse   bool
+	stopWatch  context.CancelFunc
+	clipboard  *clipboard.Clipboard
+	copySeq    int // number of the last copy to clipboard
+
+	lockTimeout  time.Duration // screen is locked after inactivity, zero disables lock
+	idleTimeout  time.Duration // session is finished after inactivity, zero disables logout
+	lockOnBlur   bool          // screen is locked, when terminal loses focus
+	lastActivity int64         // unix nano time of the last user input
+	locked       bool          // screen is locked
+	signedIn     bool          // user documents are opened
 }
 
 // New is UI app constructor
@@ -81,6 +140,13 @@ func WithMouse(m bool) func(a *App) {
 	}
 }
 
+// WithClipboard enables copying of secrets to clipboard
+func WithClipboard(c *clipboard.Clipboard) func(a *App) {
+	return func(a *App) {
+		a.clipboard = c
+	}
+}
+
 // bootstrap creates all ui pages and should be run only once
 func (a *App) bootstrap() {
 	a.pages = tview.NewPages()
@@ -88,14 +154,28 @@ func (a *App) bootstrap() {
 	a.categories = tview.NewList()
 	a.itemsList = tview.NewList()
 	a.form = tview.NewForm()
-	a.statusBar.AddTextView("Quit: `Esc`, Sync `S`", "", 1, 1, true, false)
+	a.folders = tview.NewTreeView()
+	a.tagFilter = tview.NewInputField()
+	a.statusBar.AddTextView("Quit: `Esc`, Sync `S`, Search `/`, Settings `O`", "", 1, 1, true, false)
 	a.ui.SetRoot(a.pages, true).EnableMouse(a.useMouse)
+	a.trackActivity()
 	a.mainPage()
 	a.welcomePage()
 }
 
-// Run starts application
+// Run starts application. Copied secret is removed from clipboard on exit.
 func (a *App) Run() error {
+	if a.clipboard != nil {
+		defer a.clipboard.Clear()
+	}
+	if a.lockOnBlur {
+		if err := a.useFocusScreen(); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.watchIdle(ctx)
 	return a.ui.Run()
 }
 
@@ -0,0 +1,218 @@
+// Package clipboard copies secrets to system clipboard and clears them after timeout.
+//
+// Clipboard tool is detected on start: wl-copy on Wayland, xclip or xsel on X11,
+// pbcopy on macOS. Without them OSC 52 terminal escape sequence is used, it works
+// in most modern terminals, also over ssh.
+package clipboard
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Backend writes text to system clipboard, empty text clears it
+type Backend interface {
+	Copy(text string) error
+}
+
+// reader is implemented by backends, which are able to read clipboard
+type reader interface {
+	Paste() (string, error)
+}
+
+type Clipboard struct {
+	mu       sync.Mutex
+	backend  Backend
+	timeout  time.Duration
+	tick     time.Duration
+	value    string        // copied secret, clipboard is cleared only if it still has it
+	cancel   chan struct{} // stops countdown of the previous copy
+	canClear bool          // clipboard has our value, which is not cleared yet
+}
+
+// New creates clipboard with detected backend. Copied text is cleared after timeout,
+// zero timeout disables clearing.
+func New(timeout time.Duration, options ...func(c *Clipboard)) *Clipboard {
+	c := &Clipboard{
+		timeout: timeout,
+		tick:    time.Second,
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	if c.backend == nil {
+		c.backend = Detect()
+	}
+	return c
+}
+
+// WithBackend sets clipboard backend instead of detected one
+func WithBackend(b Backend) func(c *Clipboard) {
+	return func(c *Clipboard) {
+		c.backend = b
+	}
+}
+
+// Copy places text to clipboard. Countdown function is called every second with time
+// left before clipboard is cleared, and with zero, when it is cleared or replaced by
+// the next copy. Countdown is called from separate goroutine.
+func (c *Clipboard) Copy(text string, countdown func(left time.Duration)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.backend.Copy(text); err != nil {
+		return err
+	}
+	if c.cancel != nil {
+		close(c.cancel)
+		c.cancel = nil
+	}
+	c.value, c.canClear = text, true
+	if c.timeout <= 0 {
+		return nil
+	}
+	cancel := make(chan struct{})
+	c.cancel = cancel
+	go c.countdown(cancel, countdown)
+	return nil
+}
+
+// Clear clears clipboard now, if it still has copied text
+func (c *Clipboard) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		close(c.cancel)
+		c.cancel = nil
+	}
+	c.clear()
+}
+
+// countdown clears clipboard after timeout, unless it is canceled
+func (c *Clipboard) countdown(cancel chan struct{}, notify func(left time.Duration)) {
+	if notify == nil {
+		notify = func(time.Duration) {}
+	}
+	defer notify(0)
+	deadline := time.Now().Add(c.timeout)
+	ticker := time.NewTicker(c.tick)
+	defer ticker.Stop()
+	notify(c.timeout)
+	for {
+		select {
+		case <-cancel:
+			return
+		case now := <-ticker.C:
+			left := deadline.Sub(now)
+			if left > 0 {
+				notify(left.Round(c.tick))
+				continue
+			}
+			c.mu.Lock()
+			select {
+			case <-cancel:
+				// replaced by the next copy, while waiting for lock
+			default:
+				c.cancel = nil
+				c.clear()
+			}
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+// clear clears clipboard, should be called with lock held. Clipboard is not cleared,
+// if user has copied something else since then.
+func (c *Clipboard) clear() {
+	if !c.canClear {
+		return
+	}
+	c.canClear = false
+	if r, ok := c.backend.(reader); ok {
+		if current, err := r.Paste(); err == nil && current != c.value {
+			c.value = ""
+			return
+		}
+	}
+	c.value = ""
+	if err := c.backend.Copy(""); err != nil {
+		log.Printf("failed to clear clipboard: %s", err.Error())
+	}
+}
+
+// Detect selects clipboard tool of current environment
+func Detect() Backend {
+	has := func(name string) bool {
+		_, err := exec.LookPath(name)
+		return err == nil
+	}
+	switch {
+	case runtime.GOOS == "darwin" && has("pbcopy"):
+		return Command{CopyCmd: []string{"pbcopy"}, PasteCmd: []string{"pbpaste"}}
+	case os.Getenv("WAYLAND_DISPLAY") != "" && has("wl-copy"):
+		return Command{CopyCmd: []string{"wl-copy"}, ClearCmd: []string{"wl-copy", "--clear"}, PasteCmd: []string{"wl-paste", "--no-newline"}}
+	case os.Getenv("DISPLAY") != "" && has("xclip"):
+		return Command{CopyCmd: []string{"xclip", "-selection", "clipboard"}, PasteCmd: []string{"xclip", "-selection", "clipboard", "-o"}}
+	case os.Getenv("DISPLAY") != "" && has("xsel"):
+		return Command{CopyCmd: []string{"xsel", "--clipboard", "--input"}, ClearCmd: []string{"xsel", "--clipboard", "--clear"}, PasteCmd: []string{"xsel", "--clipboard", "--output"}}
+	}
+	return OSC52{W: os.Stdout}
+}
+
+// commandTimeout limits run of clipboard tool, so hung tool does not block copy
+const commandTimeout = 5 * time.Second
+
+// Command is clipboard tool, text is passed to stdin of copy command
+type Command struct {
+	CopyCmd  []string
+	ClearCmd []string // command to clear clipboard, copy of empty text if not set
+	PasteCmd []string // command to read clipboard, optional
+}
+
+func (cmd Command) Copy(text string) error {
+	args := cmd.CopyCmd
+	if text == "" && len(cmd.ClearCmd) > 0 {
+		args = cmd.ClearCmd
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	// output is not captured: xclip and xsel keep running in background to serve
+	// clipboard, they would hold output pipes open, and copy would never return
+	c := exec.CommandContext(ctx, args[0], args[1:]...)
+	c.Stdin = strings.NewReader(text)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", args[0], err)
+	}
+	return nil
+}
+
+func (cmd Command) Paste() (string, error) {
+	if len(cmd.PasteCmd) == 0 {
+		return "", fmt.Errorf("%s is not able to read clipboard", cmd.CopyCmd[0])
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	c := exec.CommandContext(ctx, cmd.PasteCmd[0], cmd.PasteCmd[1:]...)
+	c.WaitDelay = time.Second
+	out, err := c.Output()
+	return string(out), err
+}
+
+// OSC52 sets clipboard by terminal escape sequence
+type OSC52 struct {
+	W io.Writer
+}
+
+func (o OSC52) Copy(text string) error {
+	_, err := fmt.Fprintf(o.W, "\x1b]52;c;%s\x07", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
@@ -0,0 +1,119 @@
+package clipboard
+
+import (
+	"bytes"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend is in-memory clipboard
+type fakeBackend struct {
+	mu    sync.Mutex
+	value string
+}
+
+func (f *fakeBackend) Copy(text string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.value = text
+	return nil
+}
+
+func (f *fakeBackend) Paste() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.value, nil
+}
+
+func TestClipboard(t *testing.T) {
+	t.Run("cleared after timeout", func(t *testing.T) {
+		b := &fakeBackend{}
+		c := New(50*time.Millisecond, WithBackend(b))
+		c.tick = 10 * time.Millisecond
+		done := make(chan struct{})
+		var ticks []time.Duration
+		require.NoError(t, c.Copy("secret", func(left time.Duration) {
+			ticks = append(ticks, left)
+			if left == 0 {
+				close(done)
+			}
+		}))
+		v, _ := b.Paste()
+		assert.Equal(t, "secret", v)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("clipboard is not cleared")
+		}
+		v, _ = b.Paste()
+		assert.Equal(t, "", v)
+		require.Greater(t, len(ticks), 2, "expect countdown")
+		assert.Equal(t, 50*time.Millisecond, ticks[0])
+	})
+
+	t.Run("replaced by user", func(t *testing.T) {
+		b := &fakeBackend{}
+		c := New(time.Hour, WithBackend(b))
+		require.NoError(t, c.Copy("secret", nil))
+		require.NoError(t, b.Copy("user text"))
+		c.Clear()
+		v, _ := b.Paste()
+		assert.Equal(t, "user text", v, "clipboard with other text should be kept")
+	})
+
+	t.Run("next copy cancels countdown", func(t *testing.T) {
+		b := &fakeBackend{}
+		c := New(time.Hour, WithBackend(b))
+		canceled := make(chan struct{})
+		require.NoError(t, c.Copy("first", func(left time.Duration) {
+			if left == 0 {
+				close(canceled)
+			}
+		}))
+		require.NoError(t, c.Copy("second", nil))
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatal("first countdown is not canceled")
+		}
+		v, _ := b.Paste()
+		assert.Equal(t, "second", v)
+		c.Clear()
+		v, _ = b.Paste()
+		assert.Equal(t, "", v)
+	})
+}
+
+func TestOSC52(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, OSC52{W: &buf}.Copy("secret"))
+	assert.Equal(t, "\x1b]52;c;c2VjcmV0\x07", buf.String())
+}
+
+func TestCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	t.Run("tool left in background", func(t *testing.T) {
+		// like xclip, tool forks process, which keeps serving clipboard
+		cmd := Command{CopyCmd: []string{"sh", "-c", "cat > /dev/null; sleep 10 &"}}
+		done := make(chan error, 1)
+		go func() { done <- cmd.Copy("secret") }()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(3 * time.Second):
+			t.Fatal("copy is blocked by background process")
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		cmd := Command{CopyCmd: []string{"sh", "-c", "exit 1"}}
+		require.Error(t, cmd.Copy("secret"))
+	})
+}
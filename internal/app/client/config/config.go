@@ -3,12 +3,14 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 )
 
 const (
-	defaultAddress = "127.0.0.1:3200"
+	defaultAddress   = "127.0.0.1:3200"
+	defaultClipboard = "30s"
 )
 
 type Config struct {
@@ -19,6 +21,11 @@ type Config struct {
 	UseMouse      bool
 	TlsCaCertFile string
 	TlsInsecure   bool
+	CacheDir      string
+	Clipboard     time.Duration
+	LockTimeout   time.Duration
+	IdleTimeout   time.Duration
+	LockOnBlur    bool
 }
 
 func New() *Config {
@@ -48,6 +55,26 @@ func New() *Config {
 		"tls-insecure",
 		"disables validation of server certificate, use for testing only",
 	).Envar("TLS_INSECURE").BoolVar(&c.TlsInsecure)
+	kingpin.Flag(
+		"cache-dir",
+		"directory for encrypted offline cache, empty value disables cache",
+	).Envar("CACHE_DIR").StringVar(&c.CacheDir)
+	kingpin.Flag(
+		"clipboard-timeout",
+		"copied secrets are cleared from clipboard after timeout, 0 keeps them",
+	).Envar("CLIPBOARD_TIMEOUT").Default(defaultClipboard).DurationVar(&c.Clipboard)
+	kingpin.Flag(
+		"lock-timeout",
+		"screen is locked after inactivity timeout and asks for password, 0 disables lock",
+	).Envar("LOCK_TIMEOUT").DurationVar(&c.LockTimeout)
+	kingpin.Flag(
+		"idle-timeout",
+		"user is logged out after inactivity timeout, 0 keeps session",
+	).Envar("IDLE_TIMEOUT").DurationVar(&c.IdleTimeout)
+	kingpin.Flag(
+		"lock-on-blur",
+		"screen is locked, when terminal loses focus (terminal should report focus)",
+	).Envar("LOCK_ON_BLUR").BoolVar(&c.LockOnBlur)
 	kingpin.Parse()
 	return &c
 }
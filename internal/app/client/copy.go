@@ -0,0 +1,76 @@
+package client
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// copyFields are labels of form fields, which are copied to clipboard by `Alt` and key
+var copyFields = map[rune]string{
+	'l': "Login",
+	'p': "Password",
+	'n': "Card Number",
+	'c': "CVC or CVV",
+}
+
+// copyCapture copies field of document form to clipboard on `Alt` key bindings.
+// It is used by documents list and form, so secrets may be copied without opening form.
+func (a *App) copyCapture(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() != tcell.KeyRune || event.Modifiers()&tcell.ModAlt == 0 {
+		return event
+	}
+	label, ok := copyFields[unicode.ToLower(event.Rune())]
+	if !ok {
+		return event
+	}
+	field, ok := a.form.GetFormItemByLabel(label).(*tview.InputField)
+	if !ok {
+		return event
+	}
+	a.copySecret(label, field.GetText())
+	return nil
+}
+
+// copySecret copies text to clipboard and shows countdown until clipboard is cleared
+func (a *App) copySecret(name, text string) {
+	if a.clipboard == nil {
+		a.statusFail("Clipboard is not available")
+		return
+	}
+	if text == "" {
+		a.statusFail(name + " is empty")
+		return
+	}
+	a.copySeq++
+	seq := a.copySeq
+	// clipboard tool is run off ui goroutine, so slow tool does not freeze ui
+	go func() {
+		err := a.clipboard.Copy(text, func(left time.Duration) {
+			a.ui.QueueUpdateDraw(func() {
+				if seq != a.copySeq {
+					// status belongs to the next copy
+					return
+				}
+				if left > 0 {
+					a.setStatus(fmt.Sprintf("%s copied, clipboard is cleared in %s", name, left), "yellow")
+				} else {
+					a.statusOK("Clipboard cleared")
+				}
+			})
+		})
+		a.ui.QueueUpdateDraw(func() {
+			switch {
+			case seq != a.copySeq:
+				// status belongs to the next copy
+			case err != nil:
+				a.statusFail("Copy failed: " + err.Error())
+			default:
+				a.statusOK(name + " copied")
+			}
+		})
+	}()
+}
@@ -0,0 +1,155 @@
+// Package docfilter organises documents by nested folders and tags.
+// Folder is a path of names, separated by "/", empty path is the root folder.
+package docfilter
+
+import (
+	"sort"
+	"strings"
+)
+
+// Separator separates folder names in path
+const Separator = "/"
+
+// CleanFolder normalises folder path: names are trimmed, empty names are removed
+func CleanFolder(path string) string {
+	names := strings.Split(path, Separator)
+	clean := names[:0]
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			clean = append(clean, name)
+		}
+	}
+	return strings.Join(clean, Separator)
+}
+
+// InFolder reports whether document folder is folder or any of its subfolders
+func InFolder(path, folder string) bool {
+	return folder == "" || path == folder || strings.HasPrefix(path, folder+Separator)
+}
+
+// ParseTags splits comma separated tags, tags are trimmed, deduplicated and sorted
+func ParseTags(s string) []string {
+	var tags []string
+	seen := make(map[string]struct{})
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if _, ok := seen[tag]; tag == "" || ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// FormatTags joins tags to be parsed by ParseTags
+func FormatTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// HasTags reports whether document tags include all filter tags, tags are case-insensitive
+func HasTags(tags, filter []string) bool {
+	for _, f := range filter {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag, f) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter selects documents by folder and tags, zero value selects all documents
+type Filter struct {
+	Folder string
+	Tags   []string
+}
+
+// Match reports whether document with folder path and tags is selected
+func (f Filter) Match(path string, tags []string) bool {
+	return InFolder(CleanFolder(path), f.Folder) && HasTags(tags, f.Tags)
+}
+
+// IsEmpty reports whether filter selects all documents
+func (f Filter) IsEmpty() bool {
+	return f.Folder == "" && len(f.Tags) == 0
+}
+
+// Node is a folder in folders tree
+type Node struct {
+	Name     string
+	Path     string
+	Children []*Node // ordered by name
+}
+
+// Tree builds folders tree of document folder paths. Root node has empty path,
+// parent folders are created for nested paths.
+func Tree(paths []string) *Node {
+	root := &Node{}
+	for _, path := range paths {
+		path = CleanFolder(path)
+		if path == "" {
+			continue
+		}
+		node := root
+		for _, name := range strings.Split(path, Separator) {
+			node = node.child(name)
+		}
+	}
+	root.sort()
+	return root
+}
+
+// Find returns node with path or nil
+func (n *Node) Find(path string) *Node {
+	if n.Path == path {
+		return n
+	}
+	for _, c := range n.Children {
+		if found := c.Find(path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Paths returns paths of all subfolders, parent folder goes before its children
+func (n *Node) Paths() []string {
+	var paths []string
+	for _, c := range n.Children {
+		paths = append(paths, c.Path)
+		paths = append(paths, c.Paths()...)
+	}
+	return paths
+}
+
+// child returns child node with name, it is created when missing
+func (n *Node) child(name string) *Node {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	path := name
+	if n.Path != "" {
+		path = n.Path + Separator + name
+	}
+	c := &Node{Name: name, Path: path}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+func (n *Node) sort() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		return strings.ToLower(n.Children[i].Name) < strings.ToLower(n.Children[j].Name)
+	})
+	for _, c := range n.Children {
+		c.sort()
+	}
+}
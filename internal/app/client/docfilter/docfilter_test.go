@@ -0,0 +1,58 @@
+package docfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanFolder(t *testing.T) {
+	assert.Equal(t, "", CleanFolder(" / "))
+	assert.Equal(t, "work/projects", CleanFolder("/work// projects /"))
+	assert.Equal(t, "my docs", CleanFolder("my docs"))
+}
+
+func TestInFolder(t *testing.T) {
+	assert.True(t, InFolder("work", ""), "root folder contains all documents")
+	assert.True(t, InFolder("work", "work"))
+	assert.True(t, InFolder("work/projects", "work"), "subfolder documents should be included")
+	assert.False(t, InFolder("workshop", "work"))
+	assert.False(t, InFolder("", "work"))
+}
+
+func TestTags(t *testing.T) {
+	tags := ParseTags(" shop, bank,,shop , ")
+	assert.Equal(t, []string{"bank", "shop"}, tags)
+	assert.Nil(t, ParseTags(" , "))
+	assert.Equal(t, tags, ParseTags(FormatTags(tags)))
+
+	assert.True(t, HasTags(tags, nil))
+	assert.True(t, HasTags(tags, []string{"Bank"}), "tags should be case-insensitive")
+	assert.False(t, HasTags(tags, []string{"bank", "home"}), "all filter tags are required")
+}
+
+func TestFilter(t *testing.T) {
+	assert.True(t, Filter{}.IsEmpty())
+	assert.True(t, Filter{}.Match("", nil))
+	f := Filter{Folder: "work", Tags: []string{"vpn"}}
+	assert.True(t, f.Match("/work/net/", []string{"vpn", "office"}))
+	assert.False(t, f.Match("home", []string{"vpn"}))
+	assert.False(t, f.Match("work", nil))
+}
+
+func TestTree(t *testing.T) {
+	root := Tree([]string{"work/projects/b", "home", "", "work/projects/a", "Bank", "work"})
+	require.Len(t, root.Children, 3)
+	assert.Equal(t, []string{"Bank", "home", "work"},
+		[]string{root.Children[0].Name, root.Children[1].Name, root.Children[2].Name})
+
+	projects := root.Find("work/projects")
+	require.NotNil(t, projects, "parent folders should be created")
+	assert.Equal(t, "projects", projects.Name)
+	require.Len(t, projects.Children, 2)
+	assert.Equal(t, "work/projects/a", projects.Children[0].Path)
+	assert.Equal(t, []string{"work/projects", "work/projects/a", "work/projects/b"}, root.Find("work").Paths())
+	assert.Equal(t, root, root.Find(""))
+	assert.Nil(t, root.Find("missing"))
+}
@@ -0,0 +1,138 @@
+// Package filecache is an on-disk encrypted cache of user documents.
+// Cache file is unique for each server address and user login. It keeps
+// decrypted documents and the serial of the last applied update, encrypted
+// with user vault key. Wrapped vault key is stored along, so cache may be
+// opened with user password without server connection.
+package filecache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const cacheVersion = 1
+
+var ErrNoCache = errors.New("no cached data")
+
+// Snapshot is a state of local documents storage
+type Snapshot struct {
+	Serial      int64               `json:"serial"`
+	Notes       []models.Note       `json:"notes"`
+	Cards       []models.Card       `json:"cards"`
+	Credentials []models.Credential `json:"credentials"`
+	Files       []models.File       `json:"files"`
+	PlainFiles  []string            `json:"plain_files"` // ids of documents and files, saved before encryption
+	Vaults      []models.Vault      `json:"vaults,omitempty"`
+	VaultKeys   map[string][]byte   `json:"vault_keys,omitempty"` // opened keys of shared vaults by id
+}
+
+// cacheFile is cache file content
+type cacheFile struct {
+	Version  int    `json:"version"`
+	VaultKey []byte `json:"vault_key"` // wrapped vault key
+	Data     []byte `json:"data"`      // encrypted Snapshot
+}
+
+type Cache struct {
+	dir     string
+	address string
+}
+
+// New is a cache constructor. Dir is the cache files location,
+// address is server address cache belongs to.
+func New(dir, address string) *Cache {
+	return &Cache{dir: dir, address: address}
+}
+
+// filename returns cache file path for the user
+func (c *Cache) filename(login string) string {
+	h := sha256.Sum256([]byte(c.address + "\x00" + login))
+	return filepath.Join(c.dir, fmt.Sprintf("%x.cache", h))
+}
+
+func (c *Cache) read(login string) (cacheFile, error) {
+	var cf cacheFile
+	b, err := os.ReadFile(c.filename(login))
+	if errors.Is(err, os.ErrNotExist) {
+		return cf, ErrNoCache
+	}
+	if err != nil {
+		return cf, err
+	}
+	if err := json.Unmarshal(b, &cf); err != nil {
+		return cf, fmt.Errorf("invalid cache file: %w", err)
+	}
+	if cf.Version != cacheVersion {
+		return cf, ErrNoCache
+	}
+	return cf, nil
+}
+
+// VaultKey returns wrapped vault key from cache
+func (c *Cache) VaultKey(login string) ([]byte, error) {
+	cf, err := c.read(login)
+	return cf.VaultKey, err
+}
+
+// Load returns cached Snapshot, decrypting it with vault cipher
+func (c *Cache) Load(login string, cipher *crypt.Cipher) (Snapshot, error) {
+	var snap Snapshot
+	cf, err := c.read(login)
+	if err != nil {
+		return snap, err
+	}
+	b, err := cipher.Open(cf.Data)
+	if err != nil {
+		return snap, err
+	}
+	err = json.Unmarshal(b, &snap)
+	return snap, err
+}
+
+// Save encrypts and saves Snapshot to cache. File is replaced atomically.
+func (c *Cache) Save(login string, vaultKey []byte, cipher *crypt.Cipher, snap Snapshot) error {
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	b, err = json.Marshal(cacheFile{
+		Version:  cacheVersion,
+		VaultKey: vaultKey,
+		Data:     cipher.Seal(b),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("unable to create cache dir: %w", err)
+	}
+	f, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.filename(login))
+}
+
+// Remove deletes user cache file, missing cache is not an error
+func (c *Cache) Remove(login string) error {
+	err := os.Remove(c.filename(login))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package filecache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestCache_SaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	key, _ := crypt.NewKey()
+	c, err := crypt.New(key)
+	require.NoError(t, err)
+	wrapped, err := crypt.WrapKey(key, "password")
+	require.NoError(t, err)
+
+	cache := New(dir, "127.0.0.1:3200")
+	_, err = cache.Load("user", c)
+	assert.ErrorIs(t, err, ErrNoCache, "empty cache")
+
+	snap := Snapshot{
+		Serial: 10,
+		Notes:  []models.Note{{Id: "1", Name: "note", Text: "secret text"}},
+	}
+	require.NoError(t, cache.Save("user", wrapped, c, snap))
+
+	gotKey, err := cache.VaultKey("user")
+	require.NoError(t, err)
+	assert.Equal(t, wrapped, gotKey)
+
+	got, err := cache.Load("user", c)
+	require.NoError(t, err)
+	assert.Equal(t, snap.Serial, got.Serial)
+	assert.Equal(t, snap.Notes, got.Notes)
+
+	_, err = New(dir, "other:3200").Load("user", c)
+	assert.ErrorIs(t, err, ErrNoCache, "cache is bound to server address")
+
+	otherKey, _ := crypt.NewKey()
+	oc, _ := crypt.New(otherKey)
+	_, err = cache.Load("user", oc)
+	assert.ErrorIs(t, err, crypt.ErrDecrypt, "wrong key should fail")
+
+	require.NoError(t, cache.Remove("user"))
+	_, err = cache.Load("user", c)
+	assert.ErrorIs(t, err, ErrNoCache, "removed cache")
+	require.NoError(t, cache.Remove("user"), "missing cache is not an error")
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/docfilter"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// filterPane sets up folders tree and tags filter, which select documents shown in lists
+func (a *App) filterPane() tview.Primitive {
+	a.folders.SetBorder(true).SetTitle(" Folders ")
+	a.folders.SetChangedFunc(func(node *tview.TreeNode) {
+		if path, _ := node.GetReference().(string); path != a.filter.Folder {
+			a.filter.Folder = path
+			a.markFolder()
+			a.applyFilter()
+		}
+	})
+	a.folders.SetSelectedFunc(func(node *tview.TreeNode) {
+		a.ui.SetFocus(a.categories)
+	})
+	a.folders.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyRight, tcell.KeyEsc:
+			a.ui.SetFocus(a.categories)
+			return nil
+		}
+		if event.Rune() == '#' {
+			a.ui.SetFocus(a.tagFilter)
+			return nil
+		}
+		return event
+	})
+
+	a.tagFilter.SetLabel("Tags: ").SetPlaceholder("`#` to filter")
+	a.tagFilter.SetBorder(true)
+	a.tagFilter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			a.filter.Tags = docfilter.ParseTags(a.tagFilter.GetText())
+			a.applyFilter()
+		}
+		a.tagFilter.SetText(docfilter.FormatTags(a.filter.Tags))
+		a.ui.SetFocus(a.categories)
+	})
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(a.folders, 0, 1, false).
+		AddItem(a.tagFilter, 3, 0, false)
+}
+
+// applyFilter redraws documents lists, when filter is changed
+func (a *App) applyFilter() {
+	a.drawCounters()
+	a.drawItemsList(a.categories.GetCurrentItem())
+	a.clearForm()
+}
+
+// resetFilter shows all documents
+func (a *App) resetFilter() {
+	a.filter = docfilter.Filter{}
+	a.tagFilter.SetText("")
+	a.drawFolders()
+}
+
+// drawFolders builds folders tree of all documents and selects filtered folder.
+// Filter is reset to the root folder, when filtered folder does not exist anymore.
+func (a *App) drawFolders() {
+	tree := docfilter.Tree(a.folderPaths())
+	if tree.Find(a.filter.Folder) == nil {
+		a.filter.Folder = ""
+	}
+	var add func(n *docfilter.Node) *tview.TreeNode
+	add = func(n *docfilter.Node) *tview.TreeNode {
+		node := tview.NewTreeNode(tview.Escape(n.Name)).SetReference(n.Path)
+		for _, c := range n.Children {
+			node.AddChild(add(c))
+		}
+		return node
+	}
+	tree.Name = "All documents"
+	a.folders.SetRoot(add(tree))
+	a.markFolder()
+}
+
+// markFolder highlights filtered folder and makes it current
+func (a *App) markFolder() {
+	a.folders.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+		if path, _ := node.GetReference().(string); path == a.filter.Folder {
+			node.SetColor(tcell.ColorYellow)
+			a.folders.SetCurrentNode(node)
+		} else {
+			node.SetColor(tview.Styles.PrimaryTextColor)
+		}
+		return true
+	})
+}
+
+// folderPaths returns folders of all documents
+func (a *App) folderPaths() []string {
+	var paths []string
+	for _, d := range a.store.GetCardsList() {
+		paths = append(paths, d.Folder)
+	}
+	for _, d := range a.store.GetCredentialsList() {
+		paths = append(paths, d.Folder)
+	}
+	for _, d := range a.store.GetNotesList() {
+		paths = append(paths, d.Folder)
+	}
+	for _, d := range a.store.GetFilesList() {
+		paths = append(paths, d.Folder)
+	}
+	return paths
+}
+
+// listTitle is a title of documents list, which describes active filter
+func (a *App) listTitle(name string) string {
+	if a.filter.Folder != "" {
+		name += " in " + tview.Escape(a.filter.Folder)
+	}
+	for _, tag := range a.filter.Tags {
+		name += " #" + tview.Escape(tag)
+	}
+	return name
+}
+
+// filterDocs returns documents, selected by filter
+func filterDocs[T any](docs []*T, filter docfilter.Filter, attrs func(d *T) (string, []string)) []*T {
+	if filter.IsEmpty() {
+		return docs
+	}
+	res := make([]*T, 0, len(docs))
+	for _, d := range docs {
+		if filter.Match(attrs(d)) {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+func (a *App) cardsInView() []*models.Card {
+	return filterDocs(a.store.GetCardsList(), a.filter, func(d *models.Card) (string, []string) {
+		return d.Folder, d.Tags
+	})
+}
+
+func (a *App) credentialsInView() []*models.Credential {
+	return filterDocs(a.store.GetCredentialsList(), a.filter, func(d *models.Credential) (string, []string) {
+		return d.Folder, d.Tags
+	})
+}
+
+func (a *App) notesInView() []*models.Note {
+	return filterDocs(a.store.GetNotesList(), a.filter, func(d *models.Note) (string, []string) {
+		return d.Folder, d.Tags
+	})
+}
+
+func (a *App) filesInView() []*models.File {
+	return filterDocs(a.store.GetFilesList(), a.filter, func(d *models.File) (string, []string) {
+		return d.Folder, d.Tags
+	})
+}
+
+// drawFolderTags adds folder and tags fields to document form.
+// Folder field completes paths of existing folders.
+func (a *App) drawFolderTags(folder *string, tags *[]string) {
+	a.form.AddInputField("Folder", *folder, 50, nil, func(text string) {
+		*folder = docfilter.CleanFolder(text)
+	})
+	a.form.AddInputField("Tags", docfilter.FormatTags(*tags), 50, nil, func(text string) {
+		*tags = docfilter.ParseTags(text)
+	})
+	field, ok := a.form.GetFormItemByLabel("Folder").(*tview.InputField)
+	if !ok {
+		return
+	}
+	paths := docfilter.Tree(a.folderPaths()).Paths()
+	field.SetAutocompleteFunc(func(text string) []string {
+		if text == "" {
+			return nil
+		}
+		var entries []string
+		for _, p := range paths {
+			if strings.HasPrefix(strings.ToLower(p), strings.ToLower(text)) && p != text {
+				entries = append(entries, p)
+			}
+		}
+		return entries
+	})
+}
+
+// newDocFolder returns folder and tags of new document, so it is shown with active filter
+func (a *App) newDocFolder() (string, []string) {
+	return a.filter.Folder, append([]string(nil), a.filter.Tags...)
+}
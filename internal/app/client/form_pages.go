@@ -31,6 +31,7 @@ func (a *App) notesForm(note *models.Note, formType int) {
 	switch formType {
 	case formAdd:
 		a.form.SetTitle(" Add Note ")
+		doc.Folder, doc.Tags = a.newDocFolder()
 	case formModify:
 		a.form.SetTitle(" Edit Note ")
 		if note == nil {
@@ -41,12 +42,14 @@ func (a *App) notesForm(note *models.Note, formType int) {
 	default:
 		a.form.SetTitle(" [red]INVALID FORM ")
 	}
+	a.drawVault(&doc.VaultId, formType)
 	a.form.AddInputField("Name", doc.Name, 50, nil, func(text string) {
 		doc.Name = text
 	})
 	a.form.AddTextArea("Text", doc.Text, 50, 5, 4096, func(text string) {
 		doc.Text = text
 	})
+	a.drawFolderTags(&doc.Folder, &doc.Tags)
 	a.drawMetadata(&doc.Metadata)
 	a.form.AddButton("<< Back", func() {
 		a.ui.SetFocus(a.itemsList)
@@ -85,6 +88,12 @@ func (a *App) notesForm(note *models.Note, formType int) {
 				"Failed to save Note",
 			)
 		})
+		a.form.AddButton("History", func() {
+			a.historyPage(doc.Id)
+		})
+		a.form.AddButton("Share", func() {
+			a.shareForm(*note)
+		})
 		a.form.AddButton("[red]Delete", func() {
 			a.modifyRequest(
 				func() error {
@@ -109,6 +118,7 @@ func (a *App) cardsForm(card *models.Card, formType int) {
 	switch formType {
 	case formAdd:
 		a.form.SetTitle(" Add Card ")
+		doc.Folder, doc.Tags = a.newDocFolder()
 	case formModify:
 		a.form.SetTitle(" Edit Card ")
 		if card == nil {
@@ -119,6 +129,7 @@ func (a *App) cardsForm(card *models.Card, formType int) {
 	default:
 		a.form.SetTitle(" [red]INVALID FORM ")
 	}
+	a.drawVault(&doc.VaultId, formType)
 	a.form.AddInputField("Name", doc.Name, 50, nil, func(text string) {
 		doc.Name = text
 	})
@@ -137,6 +148,9 @@ func (a *App) cardsForm(card *models.Card, formType int) {
 	a.form.AddInputField("PIN", doc.Pin, 50, nil, func(text string) {
 		doc.Pin = text
 	})
+	secretField(a.form, "CVC or CVV")
+	secretField(a.form, "PIN")
+	a.drawFolderTags(&doc.Folder, &doc.Tags)
 	a.drawMetadata(&doc.Metadata)
 	a.form.AddButton("<< Back", func() {
 		a.ui.SetFocus(a.itemsList)
@@ -175,6 +189,12 @@ func (a *App) cardsForm(card *models.Card, formType int) {
 				"Failed to save Card",
 			)
 		})
+		a.form.AddButton("History", func() {
+			a.historyPage(doc.Id)
+		})
+		a.form.AddButton("Share", func() {
+			a.shareForm(*card)
+		})
 		a.form.AddButton("[red]Delete", func() {
 			a.modifyRequest(
 				func() error {
@@ -199,6 +219,13 @@ func (a *App) credentialsForm(cred *models.Credential, formType int) {
 	switch formType {
 	case formAdd:
 		a.form.SetTitle(" Add Credential ")
+		if cred != nil {
+			// new credential may be prefilled, e.g. with generated password
+			doc = *cred
+		}
+		if doc.Folder == "" && doc.Tags == nil {
+			doc.Folder, doc.Tags = a.newDocFolder()
+		}
 	case formModify:
 		a.form.SetTitle(" Edit Credential ")
 		if cred == nil {
@@ -209,6 +236,7 @@ func (a *App) credentialsForm(cred *models.Credential, formType int) {
 	default:
 		a.form.SetTitle(" [red]INVALID FORM ")
 	}
+	a.drawVault(&doc.VaultId, formType)
 	a.form.AddInputField("Name", doc.Name, 50, nil, func(text string) {
 		doc.Name = text
 	})
@@ -218,10 +246,14 @@ func (a *App) credentialsForm(cred *models.Credential, formType int) {
 	a.form.AddInputField("Password", doc.Password, 50, nil, func(text string) {
 		doc.Password = text
 	})
+	generate := a.passwordFieldGenerator(a.form, "Password")
+	secretField(a.form, "Password")
+	a.drawFolderTags(&doc.Folder, &doc.Tags)
 	a.drawMetadata(&doc.Metadata)
 	a.form.AddButton("Back", func() {
 		a.ui.SetFocus(a.itemsList)
 	})
+	a.form.AddButton("Generate", generate)
 	a.form.AddButton("Add  Meta", func() {
 		a.addMeta(a.form, &doc.Metadata)
 	})
@@ -256,6 +288,12 @@ func (a *App) credentialsForm(cred *models.Credential, formType int) {
 				"Failed to save Credential",
 			)
 		})
+		a.form.AddButton("History", func() {
+			a.historyPage(doc.Id)
+		})
+		a.form.AddButton("Share", func() {
+			a.shareForm(*cred)
+		})
 		a.form.AddButton("[red]Delete", func() {
 			a.modifyRequest(
 				func() error {
@@ -281,6 +319,7 @@ func (a *App) filesForm(cred *models.File, formType int) {
 	switch formType {
 	case formAdd:
 		a.form.SetTitle(" Add File ")
+		doc.Folder, doc.Tags = a.newDocFolder()
 	case formModify:
 		a.form.SetTitle(" Edit File ")
 		if cred == nil {
@@ -291,6 +330,7 @@ func (a *App) filesForm(cred *models.File, formType int) {
 	default:
 		a.form.SetTitle(" [red]INVALID FORM ")
 	}
+	a.drawVault(&doc.VaultId, formType)
 	a.form.AddInputField("Name", doc.Name, 50, nil, func(text string) {
 		doc.Name = text
 	})
@@ -306,6 +346,7 @@ func (a *App) filesForm(cred *models.File, formType int) {
 		a.form.AddTextView("File Info", fmt.Sprintf("[green]File Name[white]: %s\n[green]File Size: [white]%d bytes", doc.Filename, doc.Size), 50, 2, true, false)
 		a.form.GetFormItemByLabel("File Info").SetDisabled(true)
 	}
+	a.drawFolderTags(&doc.Folder, &doc.Tags)
 	a.drawMetadata(&doc.Metadata)
 	a.form.AddButton("Back", func() {
 		a.ui.SetFocus(a.itemsList)
@@ -369,6 +410,9 @@ func (a *App) filesForm(cred *models.File, formType int) {
 				)
 			}
 		})
+		a.form.AddButton("History", func() {
+			a.historyPage(doc.Id)
+		})
 		a.form.AddButton("[red]Delete", func() {
 			a.modifyRequest(
 				func() error {
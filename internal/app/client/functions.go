@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -29,6 +30,11 @@ func (a *App) statusFail(text string) {
 
 // synchronize run store update
 func (a *App) synchronize() {
+	if a.store.IsOffline() {
+		a.setStatus("Offline mode: cached data, read only", "yellow")
+		a.updateCounters()
+		return
+	}
 	if err := a.store.Update(); err != nil {
 		if errors.Is(memstore.ErrAuthFailed, err) {
 			a.modalUnauthorized()
@@ -37,10 +43,60 @@ func (a *App) synchronize() {
 		}
 	} else {
 		a.statusOK("Synchronized")
-		a.categories.SetItemText(0, fmt.Sprintf("Cards (%d)", len(a.store.GetCardsList())), "[yellow](`C` to add new)")
-		a.categories.SetItemText(1, fmt.Sprintf("Logins (%d)", len(a.store.GetCredentialsList())), "[yellow](`L` to add new)")
-		a.categories.SetItemText(2, fmt.Sprintf("Notes (%d)", len(a.store.GetNotesList())), "[yellow](`N` to add new)")
-		a.categories.SetItemText(3, fmt.Sprintf("Files (%d)", len(a.store.GetFilesList())), "[yellow](`F` to add new)")
+		a.updateCounters()
+	}
+}
+
+// updateCounters updates folders tree and documents count of categories
+func (a *App) updateCounters() {
+	a.drawFolders()
+	a.drawCounters()
+}
+
+// drawCounters updates count of documents, selected by filter, of categories
+func (a *App) drawCounters() {
+	a.categories.SetItemText(0, fmt.Sprintf("Cards (%d)", len(a.cardsInView())), "[yellow](`C` to add new)")
+	a.categories.SetItemText(1, fmt.Sprintf("Logins (%d)", len(a.credentialsInView())), "[yellow](`L` to add new)")
+	a.categories.SetItemText(2, fmt.Sprintf("Notes (%d)", len(a.notesInView())), "[yellow](`N` to add new)")
+	a.categories.SetItemText(3, fmt.Sprintf("Files (%d)", len(a.filesInView())), "[yellow](`F` to add new)")
+	a.categories.SetItemText(4, fmt.Sprintf("Trash (%d)", len(a.store.GetTrashList())), "[yellow]deleted documents")
+}
+
+// startWatch starts receiving live updates from server
+func (a *App) startWatch() {
+	a.endWatch()
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopWatch = cancel
+	go a.store.Watch(ctx, func(err error) {
+		a.ui.QueueUpdateDraw(func() {
+			a.liveUpdate(err)
+		})
+	})
+}
+
+// endWatch stops receiving live updates
+func (a *App) endWatch() {
+	if a.stopWatch != nil {
+		a.stopWatch()
+		a.stopWatch = nil
+	}
+}
+
+// liveUpdate refreshes ui, when live updates are received.
+// Items list is refreshed only if user does not work with it.
+func (a *App) liveUpdate(err error) {
+	if err != nil {
+		if errors.Is(err, memstore.ErrAuthFailed) {
+			a.modalUnauthorized()
+		} else {
+			a.statusFail("Live updates disconnected: " + err.Error())
+		}
+		return
+	}
+	a.statusOK("Synchronized (live)")
+	a.updateCounters()
+	if a.categories.HasFocus() {
+		a.drawItemsList(a.categories.GetCurrentItem())
 	}
 }
 
@@ -71,8 +127,5 @@ func checkFile(filename string) error {
 	if st.IsDir() {
 		return errors.New("it is directory, not a file")
 	}
-	if st.Size() > 14<<20 {
-		return errors.New("file is too big, maximum allowed size is 14 MB")
-	}
 	return nil
 }
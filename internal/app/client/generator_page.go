@@ -0,0 +1,142 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/pwgen"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const pageGenerator = "generator"
+
+// generatorPage generates random password or passphrase. Accepted result is passed to use.
+func (a *App) generatorPage(use func(password string), cancel func()) {
+	pwOpts := pwgen.DefaultOptions
+	phraseOpts := pwgen.DefaultPassphraseOptions
+	passphrase := false
+	var password string
+
+	result := tview.NewTextView().SetLabel("Result").SetSize(2, 0).SetDynamicColors(false)
+	strength := tview.NewTextView().SetLabel("Strength").SetSize(1, 0).SetDynamicColors(true)
+	generate := func() {
+		var entropy float64
+		var err error
+		if passphrase {
+			password, entropy, err = pwgen.Passphrase(phraseOpts)
+		} else {
+			password, entropy, err = pwgen.Password(pwOpts)
+		}
+		if err != nil {
+			password = ""
+			result.SetText("")
+			strength.SetText("[red]" + err.Error())
+			return
+		}
+		result.SetText(password)
+		strength.SetText(strengthText(entropy))
+	}
+	number := func(set *int) func(text string) {
+		return func(text string) {
+			*set, _ = strconv.Atoi(text)
+			generate()
+		}
+	}
+	flag := func(set *bool) func(checked bool) {
+		return func(checked bool) {
+			*set = checked
+			generate()
+		}
+	}
+
+	closePage := func() {
+		a.pages.RemovePage(pageGenerator)
+		cancel()
+	}
+	form := tview.NewForm().SetCancelFunc(closePage)
+	form.AddDropDown("Type", []string{"Password", "Passphrase"}, 0, func(_ string, index int) {
+		passphrase = index == 1
+		generate()
+	})
+	form.AddInputField("Length", strconv.Itoa(pwOpts.Length), 5, tview.InputFieldInteger, number(&pwOpts.Length))
+	form.AddCheckbox("Lowercase", pwOpts.Lower, flag(&pwOpts.Lower))
+	form.AddCheckbox("Uppercase", pwOpts.Upper, flag(&pwOpts.Upper))
+	form.AddCheckbox("Digits", pwOpts.Digits, flag(&pwOpts.Digits))
+	form.AddCheckbox("Symbols", pwOpts.Symbols, flag(&pwOpts.Symbols))
+	form.AddCheckbox("Exclude look-alike", pwOpts.ExcludeSimilar, flag(&pwOpts.ExcludeSimilar))
+	form.AddInputField("Words", strconv.Itoa(phraseOpts.Words), 5, tview.InputFieldInteger, number(&phraseOpts.Words))
+	form.AddInputField("Separator", phraseOpts.Separator, 5, nil, func(text string) {
+		phraseOpts.Separator = text
+		generate()
+	})
+	form.AddCheckbox("Capitalize words", phraseOpts.Capitalize, flag(&phraseOpts.Capitalize))
+	form.AddFormItem(result)
+	form.AddFormItem(strength)
+	form.AddButton("Use", func() {
+		if password == "" {
+			a.modalErr("Nothing is generated, check options")
+			return
+		}
+		a.pages.RemovePage(pageGenerator)
+		use(password)
+	})
+	form.AddButton("Regenerate", generate)
+	form.AddButton("<< Cancel", closePage)
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Password generator ")
+	generate()
+	a.pages.AddPage(pageGenerator, center(70, 19, form), true, true)
+}
+
+// generateCredential opens generator and then new Credential form with generated password
+func (a *App) generateCredential() {
+	a.generatorPage(func(password string) {
+		a.credentialsForm(&models.Credential{Password: password}, formAdd)
+		a.ui.SetFocus(a.form)
+	}, func() {
+		a.ui.SetFocus(a.categories)
+	})
+}
+
+// strengthText describes password strength by its entropy
+func strengthText(entropy float64) string {
+	rating := "[red]weak"
+	switch {
+	case entropy >= 100:
+		rating = "[green]very strong"
+	case entropy >= 75:
+		rating = "[green]strong"
+	case entropy >= 50:
+		rating = "[yellow]fair"
+	}
+	return fmt.Sprintf("%s[-] (%.0f bits of entropy)", rating, entropy)
+}
+
+// passwordFieldGenerator adds generator to Password field of form: result fills the field.
+// Generator is opened by `Ctrl+G` in the field.
+func (a *App) passwordFieldGenerator(form *tview.Form, label string) func() {
+	field, ok := form.GetFormItemByLabel(label).(*tview.InputField)
+	if !ok {
+		return func() {}
+	}
+	open := func() {
+		a.generatorPage(func(password string) {
+			field.SetText(password)
+			a.ui.SetFocus(field)
+		}, func() {
+			a.ui.SetFocus(field)
+		})
+	}
+	field.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlG {
+			open()
+			return nil
+		}
+		return event
+	})
+	return open
+}
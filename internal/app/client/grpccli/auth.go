@@ -3,17 +3,22 @@ package grpccli
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"os"
+	"runtime"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"yap-pwkeeper/internal/pkg/grpc/proto"
 	"yap-pwkeeper/internal/pkg/jwtToken"
+	"yap-pwkeeper/internal/pkg/models"
 )
 
-// Register registers new client on server
+// Register registers new client on server, password is auth value derived from master password
 func (c *Client) Register(login, password string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
 	defer cancel()
@@ -28,15 +33,25 @@ func (c *Client) Register(login, password string) error {
 // Login logins to server and starts token update routine
 // It is safe to call login multiple times.
 // After each successful attempt new token will be used.
-func (c *Client) Login(login, password string) error {
+// Code is one-time or recovery code, ErrTotpRequired is returned if it is required, but empty.
+// ErrLegacyAccount is returned, if server has master password of account instead of auth value.
+func (c *Client) Login(login, password, code string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
 	defer cancel()
 	cred := &proto.LoginCredentials{
 		Login:    login,
 		Password: password,
+		Totp:     code,
+		Device:   device(),
 	}
 	token := &proto.Token{}
 	token, err := c.auth.Login(ctx, cred)
+	switch status.Code(err) {
+	case codes.FailedPrecondition:
+		return ErrTotpRequired
+	case codes.Aborted:
+		return ErrLegacyAccount
+	}
 	if err != nil {
 		return parseErr(err)
 	}
@@ -44,15 +59,256 @@ func (c *Client) Login(login, password string) error {
 	return nil
 }
 
-// Logout flushes token from memory.
+// GetVaultKey returns user wrapped vault key from server.
+// ErrNoVaultKey is returned if key was never set.
+func (c *Client) GetVaultKey() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	key, err := c.auth.GetVaultKey(ctx, &proto.Empty{})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNoVaultKey
+	}
+	if err != nil {
+		return nil, parseErr(err)
+	}
+	return key.GetKey(), nil
+}
+
+// SetVaultKey saves user wrapped vault key on server.
+// ErrVaultKeyExists is returned if key was already set.
+func (c *Client) SetVaultKey(key []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.SetVaultKey(ctx, &proto.VaultKey{Key: key})
+	if status.Code(err) == codes.AlreadyExists {
+		return ErrVaultKeyExists
+	}
+	return parseErr(err)
+}
+
+// GetKeyPair returns user key pair from server, private key is sealed with vault key.
+// ErrNoKeyPair is returned if key pair was never set.
+func (c *Client) GetKeyPair() (models.KeyPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	keys, err := c.auth.GetKeyPair(ctx, &proto.Empty{})
+	if status.Code(err) == codes.NotFound {
+		return models.KeyPair{}, ErrNoKeyPair
+	}
+	if err != nil {
+		return models.KeyPair{}, parseErr(err)
+	}
+	return models.KeyPair{Public: keys.GetPublicKey(), Private: keys.GetPrivateKey()}, nil
+}
+
+// SetKeyPair saves user key pair on server.
+// ErrKeyPairExists is returned if key pair was already set.
+func (c *Client) SetKeyPair(keys models.KeyPair) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.SetKeyPair(ctx, &proto.KeyPair{PublicKey: keys.Public, PrivateKey: keys.Private})
+	if status.Code(err) == codes.AlreadyExists {
+		return ErrKeyPairExists
+	}
+	return parseErr(err)
+}
+
+// GetPublicKey returns public key of user with login
+func (c *Client) GetPublicKey(login string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	keys, err := c.auth.GetPublicKey(ctx, &proto.UserLogin{Login: login})
+	if err != nil {
+		return nil, parseErr(err)
+	}
+	return keys.GetPublicKey(), nil
+}
+
+// EnrollTotp requests new second factor. It is enabled after ConfirmTotp.
+func (c *Client) EnrollTotp() (models.TotpEnrollment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	enrollment, err := c.auth.EnrollTotp(ctx, &proto.Empty{})
+	if err != nil {
+		return models.TotpEnrollment{}, parseErr(err)
+	}
+	return models.TotpEnrollment{
+		Secret:        enrollment.GetSecret(),
+		URI:           enrollment.GetUri(),
+		RecoveryCodes: enrollment.GetRecoveryCodes(),
+	}, nil
+}
+
+// ConfirmTotp enables enrolled second factor with code from authenticator
+func (c *Client) ConfirmTotp(code string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.ConfirmTotp(ctx, &proto.TotpCode{Code: code})
+	return parseErr(err)
+}
+
+// DisableTotp removes second factor, code from authenticator or recovery code is required
+func (c *Client) DisableTotp(code string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.DisableTotp(ctx, &proto.TotpCode{Code: code})
+	return parseErr(err)
+}
+
+// ListSessions returns active sessions of the user, the current one is marked
+func (c *Client) ListSessions() ([]models.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	response, err := c.auth.ListSessions(ctx, &proto.Empty{})
+	if err != nil {
+		return nil, parseErr(err)
+	}
+	sessions := make([]models.Session, 0, len(response.GetSessions()))
+	for _, s := range response.GetSessions() {
+		sessions = append(sessions, models.Session{
+			Id:        s.GetId(),
+			Device:    s.GetDevice(),
+			CreatedAt: s.GetCreatedAt(),
+			LastSeen:  s.GetLastSeen(),
+			Current:   s.GetCurrent(),
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession finishes other session of the user, its tokens are rejected by server
+func (c *Client) RevokeSession(sessionId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.RevokeSession(ctx, &proto.Session{Id: sessionId})
+	return parseErr(err)
+}
+
+// ChangePassword replaces user password, vault key should be re-wrapped with the new password.
+// Other sessions of the user are revoked by server.
+func (c *Client) ChangePassword(oldPassword, newPassword string, vaultKey []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.ChangePassword(ctx, &proto.PasswordChange{
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+		VaultKey:    vaultKey,
+	})
+	if status.Code(err) == codes.PermissionDenied {
+		return ErrBadPassword
+	}
+	return parseErr(err)
+}
+
+// DeleteAccount deletes user account and all documents, password is required to confirm it.
+// Session is finished by server.
+func (c *Client) DeleteAccount(password string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.DeleteAccount(ctx, &proto.Password{Password: password})
+	if status.Code(err) == codes.PermissionDenied {
+		return ErrBadPassword
+	}
+	if err != nil {
+		return parseErr(err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	close(c.ch)
+	c.ch = make(chan struct{})
+	c.token = ""
+	return nil
+}
+
+// GetPasswordPolicy returns server requirements to master password
+func (c *Client) GetPasswordPolicy() (models.PasswordPolicy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	policy, err := c.auth.GetPasswordPolicy(ctx, &proto.Empty{})
+	if err != nil {
+		return models.PasswordPolicy{}, parseErr(err)
+	}
+	return models.PasswordPolicy{
+		MinLength:  int(policy.GetMinLength()),
+		MinClasses: int(policy.GetMinClasses()),
+		Breached:   policy.GetBreached(),
+	}, nil
+}
+
+// GetBreachedRange returns hash suffixes of breached passwords with hash prefix,
+// so password is checked without sending it or its full hash to server
+func (c *Client) GetBreachedRange(prefix string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	suffixes, err := c.auth.GetBreachedRange(ctx, &proto.HashPrefix{Prefix: prefix})
+	if err != nil {
+		return nil, parseErr(err)
+	}
+	return suffixes.GetSuffixes(), nil
+}
+
+// GetMigrated shows whether all user documents are encrypted
+func (c *Client) GetMigrated() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	migrated, err := c.auth.GetMigrated(ctx, &proto.Empty{})
+	if err != nil {
+		return false, parseErr(err)
+	}
+	return migrated.GetMigrated(), nil
+}
+
+// SetMigrated records on server that all user documents are encrypted
+func (c *Client) SetMigrated() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	_, err := c.auth.SetMigrated(ctx, &proto.Empty{})
+	return parseErr(err)
+}
+
+// Logout finishes session on server and flushes token from memory.
+// Server request is best-effort, token is flushed anyway.
 // This will also terminate refresh routine.
 // Safe to be called multiple times.
 func (c *Client) Logout() {
+	if token := c.getToken(); token != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), c.authTimeout)
+		ctx = metadata.AppendToOutgoingContext(ctx, "bearer", token)
+		if _, err := c.auth.Logout(ctx, &proto.Empty{}); err != nil {
+			log.Printf("server logout failed: %s", err.Error())
+		}
+		cancel()
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	close(c.ch)
+	c.ch = make(chan struct{})
 	c.token = ""
 }
 
+// device describes client to identify its session
+func device() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s (%s/%s)", host, runtime.GOOS, runtime.GOARCH)
+}
+
 // getToken safely returns current token
 // this is the ONLY way to get curren token
 func (c *Client) getToken() string {
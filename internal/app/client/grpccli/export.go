@@ -0,0 +1,44 @@
+package grpccli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"google.golang.org/grpc/metadata"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+)
+
+// ErrIncomplete is returned when export stream ends before the whole archive is received
+var ErrIncomplete = errors.New("export archive is incomplete")
+
+// ExportAll receives zip archive with all user documents and file data and writes it to w.
+// Document payload in archive is encrypted with vault key, which is included wrapped.
+func (c *Client) ExportAll(w io.Writer) error {
+	log.Println("grpc export request")
+	ctx, progress, cancel := progressContext(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	stream, err := c.docs.ExportAll(ctx, &proto.Empty{})
+	if err != nil {
+		return parseErr(err)
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return ErrIncomplete
+		}
+		if err != nil {
+			return parseErr(err)
+		}
+		progress()
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+		if chunk.GetEof() {
+			return nil
+		}
+	}
+}
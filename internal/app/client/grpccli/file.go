@@ -2,9 +2,12 @@ package grpccli
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
 	"log"
+	"time"
 
 	"google.golang.org/grpc/metadata"
 
@@ -12,87 +15,15 @@ import (
 	"yap-pwkeeper/internal/pkg/models"
 )
 
-// AddFile saves new File on server
-func (c *Client) AddFile(d models.File, r io.Reader) error {
-	log.Println("grpc add file request")
-	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
-	defer cancel()
-	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
-	stream, err := c.docs.AddFile(ctx)
-	if err != nil {
-		return parseErr(err)
-	}
-	return c.sendFileToStream(d, r, stream)
-}
-
-// UpdateFile updates File on server with data
-func (c *Client) UpdateFile(d models.File, r io.Reader) error {
-	log.Println("grpc add file request")
-	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
-	defer cancel()
-	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
-	stream, err := c.docs.UpdateFile(ctx)
-	if err != nil {
-		return parseErr(err)
-	}
-	return c.sendFileToStream(d, r, stream)
-}
-
-func (c *Client) sendFileToStream(d models.File, r io.Reader, stream proto.Docs_AddFileClient) error {
-	chunk := &proto.FileChunk{}
-	chunkMessage := &proto.FileStream{ChunkedFile: &proto.FileStream_Chunk{Chunk: chunk}}
-	fileMessage := &proto.FileStream{ChunkedFile: &proto.FileStream_File{File: proto.FromFile(d)}}
-	err := stream.Send(fileMessage)
-	if err != nil {
-		return parseErr(err)
-	}
-	chunkBytes := make([]byte, 1<<18)
-	for {
-		n, err := r.Read(chunkBytes)
-		if err == io.EOF {
-			chunk.Eof = true
-			err = nil
-		}
-		if err != nil {
-			return errors.New("fail read failed")
-		}
-		chunk.Data = chunkBytes[:n]
-		err = stream.Send(chunkMessage)
-		if err != nil {
-			return parseErr(err)
-		}
-		if chunk.Eof {
-			_, err = stream.CloseAndRecv()
-			if err != nil {
-				return parseErr(err)
-			}
-			return nil
-		}
-	}
-}
-
-// UpdateFileInfo updates FileInfo on server
+// UpdateFileInfo updates FileInfo on server, file data stays intact
 func (c *Client) UpdateFileInfo(d models.File) error {
-	log.Println("grpc update file request")
+	log.Println("grpc update file info request")
 	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
 	defer cancel()
 	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
-	chunkMessage := &proto.FileStream{ChunkedFile: &proto.FileStream_Chunk{Chunk: &proto.FileChunk{Eof: true}}}
-	fileMessage := &proto.FileStream{ChunkedFile: &proto.FileStream_File{File: proto.FromFile(d)}}
-	stream, err := c.docs.UpdateFile(ctx)
-	if err != nil {
-		return parseErr(err)
-	}
-	if err := stream.Send(fileMessage); err != nil {
-		log.Printf("grpc update file failed: %s", err.Error())
-		return parseErr(err)
-	}
-	if err := stream.Send(chunkMessage); err != nil {
-		log.Printf("grpc update file failed: %s", err.Error())
-		return parseErr(err)
-	}
-	if _, err := stream.CloseAndRecv(); err != nil {
-		log.Printf("grpc update file failed: %s", err.Error())
+	req := proto.FromFile(d)
+	if _, err := c.docs.UpdateFileInfo(ctx, req); err != nil {
+		log.Printf("grpc update file info failed: %s", err.Error())
 		return parseErr(err)
 	}
 	return nil
@@ -112,36 +43,89 @@ func (c *Client) DeleteFile(d models.File) error {
 	return nil
 }
 
-// GetFile receives File from server
-func (c *Client) GetFile(documentId string, w io.Writer) (models.File, error) {
+// GetFile receives File from server. Interrupted download is resumed from the
+// received offset, received data is verified with File hash.
+func (c *Client) GetFile(documentId, vaultId string, w io.Writer) (models.File, error) {
 	log.Println("grpc get file request")
-	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	var file models.File
+	var offset int64
+	hash := sha256.New()
+	w = io.MultiWriter(w, hash)
+	check := func(info models.File) error {
+		if offset > 0 && (info.Sha265 != file.Sha265 || info.Size != file.Size) {
+			return ErrFileChanged
+		}
+		file = info
+		return nil
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(transferRetryDelay)
+		}
+		n, err := c.getFileRange(documentId, vaultId, offset, w, check)
+		offset += n
+		if err == nil {
+			break
+		}
+		if !retryable(err) || attempt == transferRetries {
+			return file, parseErr(err)
+		}
+		log.Printf("grpc download interrupted at %d bytes, retrying: %s", offset, err.Error())
+	}
+	if file.Sha265 != "" && fmt.Sprintf("%x", hash.Sum(nil)) != file.Sha265 {
+		return file, ErrChecksum
+	}
+	return file, nil
+}
+
+// getFileRange receives File data from offset and writes it to w.
+// Received File info is passed to check before data is written.
+// Returns number of bytes written.
+func (c *Client) getFileRange(documentId, vaultId string, offset int64, w io.Writer, check func(models.File) error) (int64, error) {
+	ctx, progress, cancel := progressContext(context.Background(), c.dataTimeout)
 	defer cancel()
 	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
-	file := models.File{}
-	req := &proto.DocumentRequest{Id: documentId}
+	req := &proto.DocumentRequest{Id: documentId, VaultId: vaultId, Offset: offset}
 	stream, err := c.docs.GetFile(ctx, req)
 	if err != nil {
-		return file, parseErr(err)
+		return 0, err
 	}
+	var written int64
 	for {
 		resp, err := stream.Recv()
 		if err == io.EOF {
-			return file, nil
+			return written, nil
 		}
 		if err != nil {
-			return file, parseErr(err)
+			return written, err
 		}
+		progress()
 		switch resp.ChunkedFile.(type) {
 		case *proto.FileStream_File:
-			file, err = resp.ChunkedFile.(*proto.FileStream_File).File.ToFile()
+			file, err := resp.ChunkedFile.(*proto.FileStream_File).File.ToFile()
 			if err != nil {
-				return file, parseErr(err)
+				return written, err
+			}
+			if err := check(file); err != nil {
+				return written, err
 			}
 		case *proto.FileStream_Chunk:
-			if _, err := w.Write(resp.ChunkedFile.(*proto.FileStream_Chunk).Chunk.Data); err != nil {
-				return file, errors.New("failed to write to file")
+			n, err := w.Write(resp.ChunkedFile.(*proto.FileStream_Chunk).Chunk.Data)
+			written += int64(n)
+			if err != nil {
+				return written, errors.New("failed to write to file")
 			}
 		}
 	}
 }
+
+// progressContext returns context, which is cancelled when progress function
+// is not called during timeout. Used for file transfers of any size.
+func progressContext(parent context.Context, timeout time.Duration) (context.Context, func(), context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	t := time.AfterFunc(timeout, cancel)
+	return ctx, func() { t.Reset(timeout) }, func() {
+		t.Stop()
+		cancel()
+	}
+}
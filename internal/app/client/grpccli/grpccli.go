@@ -29,6 +29,7 @@ type Client struct {
 	conn                      *grpc.ClientConn                 // server connection
 	auth                      proto.AuthClient                 // server auth service
 	docs                      proto.DocsClient                 // server documents service
+	share                     proto.ShareClient                // server shared documents service
 	authTimeout               time.Duration                    // timeout for auth service
 	dataTimeout               time.Duration                    // timeout for documents service
 	tokenTimeUntilExpire      time.Duration                    // time left until token expired
@@ -58,6 +59,7 @@ func New(address string, options ...func(c *Client)) (*Client, error) {
 	)
 	cli.auth = proto.NewAuthClient(cli.conn)
 	cli.docs = proto.NewDocsClient(cli.conn)
+	cli.share = proto.NewShareClient(cli.conn)
 	return cli, err
 }
 
@@ -98,8 +100,19 @@ func (c *Client) Close() error {
 var (
 	// ErrAuthFail error indicates that client is no more authenticated on server.
 	// User Login method to authenticate again
-	ErrAuthFail    = errors.New("authorization failed")
-	ErrUnavailable = errors.New("unable to connect server")
+	ErrAuthFail       = errors.New("authorization failed")
+	ErrUnavailable    = errors.New("unable to connect server")
+	ErrNoVaultKey     = errors.New("vault key is not set")
+	ErrVaultKeyExists = errors.New("vault key is already set")
+	ErrNoKeyPair      = errors.New("key pair is not set")
+	ErrKeyPairExists  = errors.New("key pair is already set")
+	// ErrTotpRequired indicates that login and password are accepted, but one-time code is required
+	ErrTotpRequired = errors.New("one-time code required")
+	// ErrLegacyAccount indicates that account was created before master keys,
+	// login should be retried with master password
+	ErrLegacyAccount = errors.New("account requires master password login")
+	// ErrBadPassword indicates that current password does not match on password change
+	ErrBadPassword = errors.New("current password does not match")
 )
 
 // parseErr returns parsed gRPCErrors
@@ -0,0 +1,67 @@
+package grpccli
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"google.golang.org/grpc/metadata"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+)
+
+// GetHistory returns previous revisions of the document, the newest first.
+// VaultId is empty for user documents.
+func (c *Client) GetHistory(documentId, vaultId string) ([]interface{}, error) {
+	log.Println("grpc history request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	stream, err := c.docs.GetHistory(ctx, &proto.DocumentRequest{Id: documentId, VaultId: vaultId})
+	if err != nil {
+		log.Printf("grpc history request failed: %s", err.Error())
+		return nil, parseErr(err)
+	}
+	var revisions []interface{}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return revisions, nil
+		}
+		if err != nil {
+			log.Printf("grpc history stream error: %s", err.Error())
+			return nil, parseErr(err)
+		}
+		if data, ok := parseUpdate(msg); ok {
+			revisions = append(revisions, data)
+		}
+	}
+}
+
+// RestoreRevision restores document revision as a new one
+func (c *Client) RestoreRevision(documentId, vaultId string, serial int64) error {
+	log.Println("grpc restore revision request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	req := &proto.DocumentRequest{Id: documentId, VaultId: vaultId, Serial: serial}
+	if _, err := c.docs.RestoreRevision(ctx, req); err != nil {
+		log.Printf("grpc restore revision failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
+
+// Restore moves document of kind from trash back to active documents
+func (c *Client) Restore(kind, documentId, vaultId string) error {
+	log.Println("grpc restore from trash request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	req := &proto.DocumentRequest{Id: documentId, VaultId: vaultId, Kind: kind}
+	if _, err := c.docs.Restore(ctx, req); err != nil {
+		log.Printf("grpc restore from trash failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package grpccli
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+)
+
+// SharedDocument is a document snapshot, opened by share id
+type SharedDocument struct {
+	Kind      string
+	Data      []byte // snapshot, encrypted by share key
+	ViewsLeft int
+}
+
+// CreateShare saves encrypted document snapshot on server, which may be opened once by
+// returned share id. Zero ttl and views mean server defaults.
+// Returns share id and expiration time.
+func (c *Client) CreateShare(kind, documentId, vaultId string, data []byte, ttl time.Duration, views int, passphrase string) (string, time.Time, error) {
+	log.Println("grpc create share request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	info, err := c.docs.CreateShare(ctx, &proto.ShareRequest{
+		DocumentId: documentId,
+		VaultId:    vaultId,
+		Kind:       kind,
+		Data:       data,
+		Ttl:        int64(ttl / time.Second),
+		MaxViews:   int32(views),
+		Passphrase: passphrase,
+	})
+	if err != nil {
+		log.Printf("grpc create share failed: %s", err.Error())
+		return "", time.Time{}, parseErr(err)
+	}
+	return info.GetId(), time.Unix(info.GetExpiresAt(), 0), nil
+}
+
+// OpenShare returns shared document and uses one of its views.
+// Authorization is not required.
+func (c *Client) OpenShare(shareId, passphrase string) (SharedDocument, error) {
+	log.Println("grpc open share request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	doc, err := c.share.Open(ctx, &proto.ShareOpen{Id: shareId, Passphrase: passphrase})
+	if err != nil {
+		log.Printf("grpc open share failed: %s", err.Error())
+		return SharedDocument{}, parseErr(err)
+	}
+	return SharedDocument{Kind: doc.GetKind(), Data: doc.GetData(), ViewsLeft: int(doc.GetViewsLeft())}, nil
+}
@@ -10,6 +10,9 @@ import (
 	"yap-pwkeeper/internal/pkg/grpc/proto"
 )
 
+// SyncMark is received from subscription, when all stored updates are delivered
+type SyncMark struct{}
+
 // GetUpdateStream requests stream with updates from server
 func (c *Client) GetUpdateStream(serial int64, chData chan interface{}, chErr chan error) {
 	defer func() {
@@ -39,36 +42,80 @@ func (c *Client) GetUpdateStream(serial int64, chData chan interface{}, chErr ch
 			chErr <- parseErr(err)
 			return
 		}
-		switch update := msg.Update.(type) {
-		case *proto.UpdateResponse_Note:
-			note, err := update.Note.ToNote()
-			if err != nil {
-				log.Printf("grpc update: invalid note: %s", err)
-				continue
-			}
-			chData <- note
-		case *proto.UpdateResponse_Credential:
-			cred, err := update.Credential.ToCredential()
-			if err != nil {
-				log.Printf("grpc update: invalid credential: %s", err)
-				continue
-			}
-			chData <- cred
-		case *proto.UpdateResponse_Card:
-			card, err := update.Card.ToCard()
-			if err != nil {
-				log.Printf("grpc update: invalid card: %s", err)
-				continue
-			}
-			chData <- card
-		case *proto.UpdateResponse_File:
-			file, err := update.File.ToFile()
-			if err != nil {
-				log.Printf("grpc update: invalid file: %s", err)
-				continue
+		if data, ok := parseUpdate(msg); ok {
+			chData <- data
+			counter++
+		}
+	}
+}
+
+// Subscribe requests live updates from server. Stored updates since serial are
+// received first, followed by SyncMark, then new documents as soon as they are saved.
+// Subscription lasts until ctx is done or connection is lost.
+func (c *Client) Subscribe(ctx context.Context, serial int64, chData chan interface{}, chErr chan error) {
+	defer func() {
+		close(chData)
+		close(chErr)
+	}()
+	log.Println("grpc subscribe: started")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	req := &proto.UpdateRequest{Serial: serial}
+	stream, err := c.docs.Subscribe(ctx, req)
+	if err != nil {
+		log.Printf("grpc subscribe: request failed: %s", err.Error())
+		chErr <- parseErr(err)
+		return
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			log.Println("grpc subscribe: end of stream")
+			return
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("grpc subscribe: stream error: %s", err.Error())
+				chErr <- parseErr(err)
 			}
-			chData <- file
+			return
 		}
-		counter++
+		data, ok := parseUpdate(msg)
+		if !ok {
+			continue
+		}
+		select {
+		case chData <- data:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// parseUpdate converts update message to document
+func parseUpdate(msg *proto.UpdateResponse) (interface{}, bool) {
+	var data interface{}
+	var err error
+	switch update := msg.Update.(type) {
+	case *proto.UpdateResponse_Note:
+		data, err = update.Note.ToNote()
+	case *proto.UpdateResponse_Credential:
+		data, err = update.Credential.ToCredential()
+	case *proto.UpdateResponse_Card:
+		data, err = update.Card.ToCard()
+	case *proto.UpdateResponse_File:
+		data, err = update.File.ToFile()
+	case *proto.UpdateResponse_Synced:
+		return SyncMark{}, true
+	case *proto.UpdateResponse_VaultsChanged:
+		return VaultsChanged{}, true
+	default:
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("grpc update: invalid document: %s", err)
+		return nil, false
 	}
+	return data, true
 }
@@ -0,0 +1,143 @@
+package grpccli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Interrupted file transfer is resumed transferRetries times with transferRetryDelay pause
+const (
+	transferRetries    = 5
+	transferRetryDelay = 2 * time.Second
+)
+
+var (
+	ErrChecksum         = errors.New("file data checksum mismatch")
+	ErrFileChanged      = errors.New("file changed on server during download")
+	ErrUploadIncomplete = errors.New("file data is not fully uploaded")
+)
+
+// UploadFile saves File on server with resumable upload. New File is added when its id
+// is empty, otherwise existing File is updated. File Size and Sha265 should be set to
+// size and hash of data in r. Interrupted upload is resumed from offset, received by server.
+func (c *Client) UploadFile(d models.File, r io.ReadSeeker) error {
+	log.Println("grpc upload file request")
+	id, err := c.createUpload(d)
+	if err != nil {
+		return parseErr(err)
+	}
+	var offset int64
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(transferRetryDelay)
+			offset, err = c.getUpload(id, d.VaultId)
+		}
+		if err == nil {
+			offset, err = c.uploadData(id, d.VaultId, offset, r)
+		}
+		if err == nil && offset < d.Size {
+			// server keeps upload open, when data stream ends before file size
+			err = ErrUploadIncomplete
+		}
+		if err == nil || !(retryable(err) || errors.Is(err, ErrUploadIncomplete)) || attempt == transferRetries {
+			return parseErr(err)
+		}
+		log.Printf("grpc upload interrupted, retrying: %s", err.Error())
+	}
+}
+
+func (c *Client) createUpload(d models.File) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	upload, err := c.docs.CreateUpload(ctx, proto.FromFile(d))
+	if err != nil {
+		return "", err
+	}
+	return upload.GetId(), nil
+}
+
+func (c *Client) getUpload(id, vaultId string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	upload, err := c.docs.GetUpload(ctx, &proto.Upload{Id: id, VaultId: vaultId})
+	if err != nil {
+		return 0, err
+	}
+	return upload.GetOffset(), nil
+}
+
+// uploadData sends data of r starting from offset and returns number of bytes received by server
+func (c *Client) uploadData(id, vaultId string, offset int64, r io.ReadSeeker) (int64, error) {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("file read failed: %w", err)
+	}
+	ctx, progress, cancel := progressContext(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	stream, err := c.docs.UploadData(ctx)
+	if err != nil {
+		return offset, err
+	}
+	send := func(m *proto.UploadStream) error {
+		if err := stream.Send(m); err != nil {
+			if err == io.EOF {
+				// stream is closed by server, error is returned by CloseAndRecv
+				_, err = stream.CloseAndRecv()
+			}
+			return err
+		}
+		progress()
+		return nil
+	}
+	upload := &proto.Upload{Id: id, VaultId: vaultId, Offset: offset}
+	if err := send(&proto.UploadStream{ChunkedUpload: &proto.UploadStream_Upload{Upload: upload}}); err != nil {
+		return offset, err
+	}
+	chunk := &proto.FileChunk{}
+	chunkMessage := &proto.UploadStream{ChunkedUpload: &proto.UploadStream_Chunk{Chunk: chunk}}
+	chunkBytes := make([]byte, 1<<18)
+	for !chunk.Eof {
+		n, err := io.ReadFull(r, chunkBytes)
+		switch {
+		case err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF):
+			chunk.Eof = true
+		case err != nil:
+			return offset, fmt.Errorf("file read failed: %w", err)
+		}
+		chunk.Data = chunkBytes[:n]
+		if err := send(chunkMessage); err != nil {
+			return offset, err
+		}
+	}
+	received, err := stream.CloseAndRecv()
+	if err != nil {
+		return offset, err
+	}
+	return received.GetOffset(), nil
+}
+
+// retryable returns true for errors of interrupted transfer
+func retryable(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.Aborted, codes.Canceled, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package grpccli
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/metadata"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// VaultsChanged is received from subscription, when shared vaults of user are changed.
+// Subscription is finished by server after it.
+type VaultsChanged struct{}
+
+// CreateVault creates shared vault. Key is vault key, sealed with user public key.
+// Returns id of new vault.
+func (c *Client) CreateVault(name string, key []byte) (string, error) {
+	log.Println("grpc create vault request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	vault, err := c.docs.CreateVault(ctx, &proto.NewVault{Name: name, VaultKey: key})
+	if err != nil {
+		log.Printf("grpc create vault failed: %s", err.Error())
+		return "", parseErr(err)
+	}
+	return vault.GetId(), nil
+}
+
+// ListVaults returns shared vaults of user with their members.
+// Only user own member has sealed vault key.
+func (c *Client) ListVaults() ([]models.Vault, error) {
+	log.Println("grpc list vaults request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	resp, err := c.docs.ListVaults(ctx, &proto.Empty{})
+	if err != nil {
+		log.Printf("grpc list vaults failed: %s", err.Error())
+		return nil, parseErr(err)
+	}
+	vaults := make([]models.Vault, len(resp.GetVaults()))
+	for i, v := range resp.GetVaults() {
+		vaults[i] = v.ToVault()
+	}
+	return vaults, nil
+}
+
+// RenameVault changes name of shared vault
+func (c *Client) RenameVault(vaultId, name string) error {
+	log.Println("grpc rename vault request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	if _, err := c.docs.RenameVault(ctx, &proto.Vault{Id: vaultId, Name: name}); err != nil {
+		log.Printf("grpc rename vault failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
+
+// DeleteVault deletes shared vault with all its documents
+func (c *Client) DeleteVault(vaultId string) error {
+	log.Println("grpc delete vault request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	if _, err := c.docs.DeleteVault(ctx, &proto.Vault{Id: vaultId}); err != nil {
+		log.Printf("grpc delete vault failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
+
+// AddVaultMember shares vault with user. Key is vault key, sealed with public key of the user.
+func (c *Client) AddVaultMember(vaultId, login, role string, key []byte) error {
+	log.Println("grpc add vault member request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	req := &proto.VaultMember{VaultId: vaultId, Login: login, Role: role, VaultKey: key}
+	if _, err := c.docs.AddVaultMember(ctx, req); err != nil {
+		log.Printf("grpc add vault member failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
+
+// UpdateVaultMember changes role of vault member
+func (c *Client) UpdateVaultMember(vaultId, userId, role string) error {
+	log.Println("grpc update vault member request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	req := &proto.VaultMember{VaultId: vaultId, UserId: userId, Role: role}
+	if _, err := c.docs.UpdateVaultMember(ctx, req); err != nil {
+		log.Printf("grpc update vault member failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
+
+// RemoveVaultMember removes member from vault. User leaves vault, removing own member.
+func (c *Client) RemoveVaultMember(vaultId, userId string) error {
+	log.Println("grpc remove vault member request")
+	ctx, cancel := context.WithTimeout(context.Background(), c.dataTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "bearer", c.getToken())
+	req := &proto.VaultMember{VaultId: vaultId, UserId: userId}
+	if _, err := c.docs.RemoveVaultMember(ctx, req); err != nil {
+		log.Printf("grpc remove vault member failed: %s", err.Error())
+		return parseErr(err)
+	}
+	return nil
+}
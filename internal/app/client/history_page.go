@@ -0,0 +1,148 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/docfilter"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const pageHistory = "history"
+
+// historyPage shows previous document revisions and allows to restore one of them
+func (a *App) historyPage(documentId string) {
+	revisions, err := a.store.GetHistory(documentId)
+	if err != nil {
+		a.modalErr("Failed to get document history: " + err.Error())
+		return
+	}
+	if len(revisions) == 0 {
+		a.modalErr("Document has no previous revisions")
+		return
+	}
+
+	closePage := func() {
+		a.pages.RemovePage(pageHistory)
+		a.ui.SetFocus(a.form)
+	}
+
+	preview := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	preview.SetBorder(true).SetTitle(" Revision ")
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" History (`Enter` to restore, `Esc` to close) ")
+	for i, rev := range revisions {
+		name, serial := revisionInfo(rev)
+		list.AddItem(fmt.Sprintf("%d. %s", i+1, name), "", 0, func() {
+			a.modalRestore(documentId, serial, closePage)
+		})
+		if i == 0 {
+			preview.SetText(revisionText(rev))
+		}
+	}
+	list.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		preview.SetText(revisionText(revisions[index])).ScrollToBeginning()
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closePage()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 1, true).
+		AddItem(preview, 0, 2, false)
+	a.pages.AddPage(pageHistory, center(100, 20, flex), true, true)
+	a.ui.SetFocus(list)
+}
+
+// modalRestore asks to confirm revision restore
+func (a *App) modalRestore(documentId string, serial int64, done func()) {
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText("Restore selected revision?\nCurrent document state will be kept in history.").
+		AddButtons([]string{"No", "Yes, restore"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			if buttonIndex != 1 {
+				return
+			}
+			done()
+			a.modifyRequest(
+				func() error {
+					return a.store.RestoreRevision(documentId, serial)
+				},
+				"Revision restored",
+				"Failed to restore revision",
+			)
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// revisionInfo returns revision name and serial
+func revisionInfo(rev interface{}) (string, int64) {
+	switch d := rev.(type) {
+	case models.Note:
+		return d.Name, d.Serial
+	case models.Card:
+		return d.Name, d.Serial
+	case models.Credential:
+		return d.Name, d.Serial
+	case models.File:
+		return d.Name, d.Serial
+	}
+	return "", 0
+}
+
+// revisionText formats revision to display
+func revisionText(rev interface{}) string {
+	var b strings.Builder
+	field := func(k, v string) {
+		_, _ = fmt.Fprintf(&b, "[green]%s:[white] %s\n", k, tview.Escape(v))
+	}
+	var (
+		metadata []models.Meta
+		folder   string
+		tags     []string
+	)
+	switch d := rev.(type) {
+	case models.Note:
+		field("Name", d.Name)
+		field("Text", d.Text)
+		metadata, folder, tags = d.Metadata, d.Folder, d.Tags
+	case models.Card:
+		field("Name", d.Name)
+		field("Cardholder Name", d.Cardholder)
+		field("Card Number", d.Number)
+		field("Expires", d.Expires)
+		field("CVC or CVV", d.Code)
+		field("PIN", d.Pin)
+		metadata, folder, tags = d.Metadata, d.Folder, d.Tags
+	case models.Credential:
+		field("Name", d.Name)
+		field("Login", d.Login)
+		field("Password", d.Password)
+		metadata, folder, tags = d.Metadata, d.Folder, d.Tags
+	case models.File:
+		field("Name", d.Name)
+		field("File Name", d.Filename)
+		field("File Size", fmt.Sprintf("%d bytes", d.Size))
+		metadata, folder, tags = d.Metadata, d.Folder, d.Tags
+	}
+	if folder != "" {
+		field("Folder", folder)
+	}
+	if len(tags) > 0 {
+		field("Tags", docfilter.FormatTags(tags))
+	}
+	for _, m := range metadata {
+		field(m.Key, m.Value)
+	}
+	return b.String()
+}
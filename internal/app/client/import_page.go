@@ -0,0 +1,141 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/importer"
+	"yap-pwkeeper/internal/app/client/memstore"
+)
+
+const (
+	pageImport        = "import"
+	pageImportPreview = "importPreview"
+
+	autoFormat = "Detect by extension"
+)
+
+// importPage asks for export file of other password manager and target vault
+func (a *App) importPage() {
+	if a.store.IsOffline() {
+		a.modalErr("Import is not available offline")
+		return
+	}
+	var path, vaultId string
+	format := autoFormat
+	cancelFunc := func() {
+		a.pages.RemovePage(pageImport)
+		a.ui.SetFocus(a.categories)
+	}
+	formats := append([]string{autoFormat}, importer.Formats...)
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("File", "", 50, nil, func(text string) {
+		path = text
+	})
+	form.AddDropDown("Format", formats, 0, func(option string, _ int) {
+		format = option
+	})
+	if names, ids := a.writableVaults(); len(ids) > 1 {
+		form.AddDropDown("Vault", names, 0, func(_ string, index int) {
+			if index >= 0 {
+				vaultId = ids[index]
+			}
+		})
+	}
+	form.AddButton("Preview", func() {
+		if err := checkFile(path); err != nil {
+			a.modalErr(err.Error())
+			return
+		}
+		f := format
+		if f == autoFormat {
+			var err error
+			if f, err = importer.DetectFormat(path); err != nil {
+				a.modalErr(err.Error() + ", select format")
+				return
+			}
+		}
+		preview, err := a.store.PreviewImport(path, f, vaultId)
+		if err != nil {
+			a.modalErr("Failed to read " + f + " export: " + err.Error())
+			return
+		}
+		a.pages.RemovePage(pageImport)
+		a.importPreviewPage(preview, vaultId)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Import from other password manager ")
+	a.pages.AddPage(pageImport, center(70, 11, form), true, true)
+}
+
+// importPreviewPage shows documents to be imported, duplicates and skipped entries,
+// nothing is saved until import is confirmed
+func (a *App) importPreviewPage(preview memstore.ImportPreview, vaultId string) {
+	closePage := func() {
+		a.pages.RemovePage(pageImportPreview)
+		a.ui.SetFocus(a.categories)
+	}
+	vault := personalVault
+	if vaultId != "" {
+		vault = a.vaultName(vaultId)
+	}
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "[green]New: %d[white], [yellow]duplicates: %d[white], [red]skipped: %d[white]\n\n",
+		len(preview.Docs), len(preview.Duplicates), len(preview.Skipped))
+	for _, doc := range preview.Docs {
+		name, kind := importer.Name(doc)
+		_, _ = fmt.Fprintf(&b, "[green]+[white] %s (%s)\n", tview.Escape(name), kind)
+	}
+	for _, doc := range preview.Duplicates {
+		name, kind := importer.Name(doc)
+		_, _ = fmt.Fprintf(&b, "[yellow]=[white] %s (%s) already exists\n", tview.Escape(name), kind)
+	}
+	for _, reason := range preview.Skipped {
+		_, _ = fmt.Fprintf(&b, "[red]-[white] %s\n", tview.Escape(reason))
+	}
+	text := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetText(b.String())
+
+	form := tview.NewForm().SetCancelFunc(closePage)
+	if len(preview.Docs) > 0 {
+		form.AddButton(fmt.Sprintf("Import %d documents", len(preview.Docs)), func() {
+			closePage()
+			a.modifyRequest(
+				func() error {
+					n, err := a.store.Import(preview.Docs, vaultId)
+					if err != nil {
+						return fmt.Errorf("%d of %d documents imported: %w", n, len(preview.Docs), err)
+					}
+					return nil
+				},
+				fmt.Sprintf("%d documents imported to %s", len(preview.Docs), vault),
+				"Import failed",
+			)
+		})
+	}
+	form.AddButton("<< Cancel", closePage)
+	form.SetButtonsAlign(tview.AlignCenter)
+	text.SetBorder(true).SetTitle(" Import preview (`Tab` to buttons, `Esc` to close) ")
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closePage()
+			return nil
+		case tcell.KeyTab:
+			a.ui.SetFocus(form)
+			return nil
+		}
+		return event
+	})
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, true).
+		AddItem(form, 3, 0, false)
+	a.pages.AddPage(pageImportPreview, center(90, 24, flex), true, true)
+	a.ui.SetFocus(text)
+}
@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Bitwarden item types
+const (
+	bwLogin    = 1
+	bwNote     = 2
+	bwCard     = 3
+	bwIdentity = 4
+)
+
+type bwExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bwItem `json:"items"`
+}
+
+type bwItem struct {
+	Type     int    `json:"type"`
+	Name     string `json:"name"`
+	Notes    string `json:"notes"`
+	FolderId string `json:"folderId"`
+	Fields   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"fields"`
+	Login struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Totp     string `json:"totp"`
+		Uris     []struct {
+			Uri string `json:"uri"`
+		} `json:"uris"`
+	} `json:"login"`
+	Card struct {
+		CardholderName string `json:"cardholderName"`
+		Brand          string `json:"brand"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+	Identity map[string]interface{} `json:"identity"`
+}
+
+// ParseBitwarden parses unencrypted Bitwarden JSON export. Folders are kept,
+// identities are imported as notes with identity fields in metadata.
+func ParseBitwarden(r io.Reader) (Result, error) {
+	var export bwExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return Result{}, err
+	}
+	if export.Encrypted {
+		return Result{}, ErrEncrypted
+	}
+	folders := make(map[string]string, len(export.Folders))
+	for _, f := range export.Folders {
+		folders[f.Id] = folder(f.Name)
+	}
+	var res Result
+	for _, item := range export.Items {
+		var metadata []models.Meta
+		path := folders[item.FolderId]
+		switch item.Type {
+		case bwLogin:
+			var link string
+			for i, u := range item.Login.Uris {
+				if i == 0 {
+					link = u.Uri
+				}
+				meta(&metadata, "URL", u.Uri)
+			}
+			meta(&metadata, "TOTP", item.Login.Totp)
+			meta(&metadata, "Notes", item.Notes)
+			res.add(models.Credential{
+				Name:     nameOf(item.Name, link, item.Login.Username),
+				Login:    item.Login.Username,
+				Password: item.Login.Password,
+				Metadata: bwFields(metadata, item),
+				Folder:   path,
+			})
+		case bwCard:
+			meta(&metadata, "Brand", item.Card.Brand)
+			meta(&metadata, "Notes", item.Notes)
+			res.add(models.Card{
+				Name:       nameOf(item.Name, "", item.Card.Brand),
+				Cardholder: item.Card.CardholderName,
+				Number:     item.Card.Number,
+				Expires:    expires(item.Card.ExpMonth, item.Card.ExpYear),
+				Code:       item.Card.Code,
+				Metadata:   bwFields(metadata, item),
+				Folder:     path,
+			})
+		case bwNote, bwIdentity:
+			keys := make([]string, 0, len(item.Identity))
+			for k := range item.Identity {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if v, ok := item.Identity[k].(string); ok {
+					meta(&metadata, k, v)
+				}
+			}
+			res.add(models.Note{
+				Name:     nameOf(item.Name, "", ""),
+				Text:     item.Notes,
+				Metadata: bwFields(metadata, item),
+				Folder:   path,
+			})
+		default:
+			res.skip("%s: unsupported item type %d", item.Name, item.Type)
+		}
+	}
+	return res, nil
+}
+
+// bwFields appends custom fields of item to metadata
+func bwFields(metadata []models.Meta, item bwItem) []models.Meta {
+	for i, f := range item.Fields {
+		name := f.Name
+		if name == "" {
+			name = fmt.Sprintf("Field %d", i+1)
+		}
+		meta(&metadata, name, f.Value)
+	}
+	return metadata
+}
@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// csvColumns maps lower case header names of known browser exports to credential fields
+var csvColumns = map[string]string{
+	"name":           "name",
+	"title":          "name",
+	"url":            "url",
+	"login_uri":      "url",
+	"username":       "login",
+	"login":          "login",
+	"login_username": "login",
+	"password":       "password",
+	"login_password": "password",
+	"note":           "notes",
+	"notes":          "notes",
+	"extra":          "notes",
+	"folder":         "folder",
+	"grouping":       "folder",
+}
+
+// ParseCSV parses browser passwords export (Chrome, Firefox, Safari and alike).
+// The first row is a header, columns are matched by name, unknown columns
+// are kept in metadata.
+func ParseCSV(r io.Reader) (Result, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return Result{}, err
+	}
+	columns := make([]string, len(header))
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		header[i] = h
+		columns[i] = csvColumns[strings.ToLower(h)]
+	}
+	var res Result
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Result{}, err
+		}
+		values := make(map[string]string)
+		var custom []models.Meta
+		for i, value := range record {
+			if i >= len(columns) {
+				break
+			}
+			if columns[i] == "" {
+				meta(&custom, header[i], value)
+				continue
+			}
+			values[columns[i]] = value
+		}
+		if values["login"] == "" && values["password"] == "" {
+			line, _ := cr.FieldPos(0)
+			res.skip("line %d: no login and password", line)
+			continue
+		}
+		var metadata []models.Meta
+		meta(&metadata, "URL", values["url"])
+		meta(&metadata, "Notes", values["notes"])
+		res.add(models.Credential{
+			Name:     nameOf(values["name"], values["url"], values["login"]),
+			Login:    values["login"],
+			Password: values["password"],
+			Metadata: append(metadata, custom...),
+			Folder:   folder(values["folder"]),
+		})
+	}
+	return res, nil
+}
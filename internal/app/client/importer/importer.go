@@ -0,0 +1,199 @@
+// Package importer parses exports of other password managers into documents.
+// Supported formats are KeePass XML, Bitwarden JSON, 1Password 1PUX and browser CSV.
+// Entries are mapped to credentials, cards and notes, fields without own place in
+// the document are kept in metadata. Parsed documents are not sent anywhere, so they
+// may be previewed and checked for duplicates before they are added to storage.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"yap-pwkeeper/internal/app/client/docfilter"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Formats of supported exports
+const (
+	FormatKeePass   = "KeePass XML"
+	FormatBitwarden = "Bitwarden JSON"
+	Format1Password = "1Password 1PUX"
+	FormatCSV       = "Browser CSV"
+)
+
+// Formats lists supported formats in order they are offered
+var Formats = []string{FormatKeePass, FormatBitwarden, Format1Password, FormatCSV}
+
+var (
+	ErrFormat    = errors.New("unknown export format")
+	ErrEncrypted = errors.New("encrypted export is not supported, export unencrypted data")
+)
+
+// Result is a parsed export
+type Result struct {
+	Docs    []interface{} // models.Credential, models.Card or models.Note
+	Skipped []string      // descriptions of skipped entries
+}
+
+func (r *Result) add(doc interface{}) {
+	r.Docs = append(r.Docs, doc)
+}
+
+func (r *Result) skip(format string, args ...interface{}) {
+	r.Skipped = append(r.Skipped, fmt.Sprintf(format, args...))
+}
+
+// DetectFormat guesses export format by file extension
+func DetectFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return FormatKeePass, nil
+	case ".json":
+		return FormatBitwarden, nil
+	case ".1pux":
+		return Format1Password, nil
+	case ".csv":
+		return FormatCSV, nil
+	}
+	return "", ErrFormat
+}
+
+// ParseFile parses export file of the format
+func ParseFile(path, format string) (Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Result{}, err
+	}
+	defer func() { _ = f.Close() }()
+	switch format {
+	case FormatKeePass:
+		return ParseKeePass(f)
+	case FormatBitwarden:
+		return ParseBitwarden(f)
+	case Format1Password:
+		info, err := f.Stat()
+		if err != nil {
+			return Result{}, err
+		}
+		return Parse1Password(f, info.Size())
+	case FormatCSV:
+		return ParseCSV(f)
+	}
+	return Result{}, ErrFormat
+}
+
+// Split returns documents, which are not found in existing documents, and duplicates.
+// Documents are duplicates, when they have the same kind, name and secret data, so
+// repeated import of the same export adds nothing. Duplicates within the import are
+// detected as well.
+func Split(docs []interface{}, existing []interface{}) (unique []interface{}, duplicates []interface{}) {
+	seen := make(map[string]struct{}, len(existing))
+	for _, d := range existing {
+		seen[Key(d)] = struct{}{}
+	}
+	for _, d := range docs {
+		k := Key(d)
+		if _, ok := seen[k]; ok {
+			duplicates = append(duplicates, d)
+			continue
+		}
+		seen[k] = struct{}{}
+		unique = append(unique, d)
+	}
+	return unique, duplicates
+}
+
+// Key identifies document for duplicates detection
+func Key(doc interface{}) string {
+	norm := func(fields ...string) string {
+		for i, f := range fields {
+			fields[i] = strings.ToLower(strings.TrimSpace(f))
+		}
+		return strings.Join(fields, "\x00")
+	}
+	switch d := doc.(type) {
+	case models.Credential:
+		return norm("credential", d.Name, d.Login, d.Password)
+	case *models.Credential:
+		return Key(*d)
+	case models.Card:
+		return norm("card", strings.ReplaceAll(d.Number, " ", ""), d.Expires)
+	case *models.Card:
+		return Key(*d)
+	case models.Note:
+		return norm("note", d.Name, d.Text)
+	case *models.Note:
+		return Key(*d)
+	}
+	return ""
+}
+
+// Name returns document name and kind to be shown in preview
+func Name(doc interface{}) (string, string) {
+	switch d := doc.(type) {
+	case models.Credential:
+		return d.Name, "login"
+	case models.Card:
+		return d.Name, "card"
+	case models.Note:
+		return d.Name, "note"
+	}
+	return "", ""
+}
+
+// meta appends metadata, empty values are skipped
+func meta(metadata *[]models.Meta, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		*metadata = append(*metadata, models.Meta{Key: key, Value: value})
+	}
+}
+
+// folder joins group names into folder path
+func folder(names ...string) string {
+	for i, name := range names {
+		// separator in names would create extra levels
+		names[i] = strings.ReplaceAll(name, docfilter.Separator, "-")
+	}
+	return docfilter.CleanFolder(strings.Join(names, docfilter.Separator))
+}
+
+// tags normalises list of tags
+func tags(list []string) []string {
+	return docfilter.ParseTags(strings.Join(list, ","))
+}
+
+// nameOf returns the first non-empty name, host of url is used for url
+func nameOf(title, link, login string) string {
+	if title = strings.TrimSpace(title); title != "" {
+		return title
+	}
+	if u, err := url.Parse(link); err == nil && u.Host != "" {
+		return u.Host
+	}
+	if link = strings.TrimSpace(link); link != "" {
+		return link
+	}
+	if login = strings.TrimSpace(login); login != "" {
+		return login
+	}
+	return "Imported"
+}
+
+// expires formats card expiration date as mm/yy
+func expires(month, year string) string {
+	month, year = strings.TrimSpace(month), strings.TrimSpace(year)
+	if month == "" && year == "" {
+		return ""
+	}
+	if len(month) == 1 {
+		month = "0" + month
+	}
+	if len(year) > 2 {
+		year = year[len(year)-2:]
+	}
+	return month + "/" + year
+}
@@ -0,0 +1,244 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestParseKeePass(t *testing.T) {
+	const export = `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+	<Meta><RecycleBinUUID>bin</RecycleBinUUID></Meta>
+	<Root>
+		<Group>
+			<UUID>root</UUID>
+			<Name>Database</Name>
+			<Entry>
+				<Tags>work;mail</Tags>
+				<String><Key>Title</Key><Value>Mail</Value></String>
+				<String><Key>UserName</Key><Value>user@mail</Value></String>
+				<String><Key>Password</Key><Value ProtectInMemory="True">secret</Value></String>
+				<String><Key>URL</Key><Value>https://mail.example.com</Value></String>
+				<String><Key>Notes</Key><Value>main box</Value></String>
+				<String><Key>Recovery</Key><Value>phone</Value></String>
+				<History><Entry><String><Key>Password</Key><Value>old</Value></String></Entry></History>
+			</Entry>
+			<Group>
+				<UUID>g1</UUID>
+				<Name>Home/Net</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>Router</Value></String>
+					<String><Key>Notes</Key><Value>behind the tv</Value></String>
+				</Entry>
+			</Group>
+			<Group>
+				<UUID>bin</UUID>
+				<Name>Recycle Bin</Name>
+				<Entry><String><Key>Title</Key><Value>Deleted</Value></String></Entry>
+			</Group>
+		</Group>
+	</Root>
+</KeePassFile>`
+	res, err := ParseKeePass(strings.NewReader(export))
+	require.NoError(t, err)
+	require.Len(t, res.Docs, 2, "history and recycle bin should be skipped")
+	assert.Equal(t, models.Credential{
+		Name:     "Mail",
+		Login:    "user@mail",
+		Password: "secret",
+		Metadata: []models.Meta{{Key: "URL", Value: "https://mail.example.com"}, {Key: "Notes", Value: "main box"}, {Key: "Recovery", Value: "phone"}},
+		Tags:     []string{"mail", "work"},
+	}, res.Docs[0])
+	assert.Equal(t, models.Note{Name: "Router", Text: "behind the tv", Folder: "Home-Net"}, res.Docs[1],
+		"entry without login and password should be a note")
+
+	_, err = ParseKeePass(strings.NewReader("not xml"))
+	require.Error(t, err)
+}
+
+func TestParseBitwarden(t *testing.T) {
+	const export = `{
+		"encrypted": false,
+		"folders": [{"id": "f1", "name": "Work"}],
+		"items": [
+			{"type": 1, "name": "", "folderId": "f1", "notes": "vpn too",
+				"fields": [{"name": "PIN", "value": "1234", "type": 1}, {"name": "linked", "value": null, "type": 3}],
+				"login": {"username": "jdoe", "password": "pw", "totp": "otpauth://x",
+					"uris": [{"uri": "https://corp.example.com/login"}, {"uri": "https://sso.example.com"}]}},
+			{"type": 3, "name": "Visa", "folderId": null,
+				"card": {"cardholderName": "John Doe", "brand": "Visa", "number": "4111111111111111", "expMonth": "3", "expYear": "2027", "code": "123"}},
+			{"type": 2, "name": "Wifi", "notes": "password123", "secureNote": {"type": 0}},
+			{"type": 4, "name": "Me", "identity": {"firstName": "John", "lastName": "Doe", "ssn": null}},
+			{"type": 5, "name": "Key"}
+		]
+	}`
+	res, err := ParseBitwarden(strings.NewReader(export))
+	require.NoError(t, err)
+	require.Len(t, res.Docs, 4)
+	assert.Equal(t, models.Credential{
+		Name:     "corp.example.com",
+		Login:    "jdoe",
+		Password: "pw",
+		Metadata: []models.Meta{
+			{Key: "URL", Value: "https://corp.example.com/login"},
+			{Key: "URL", Value: "https://sso.example.com"},
+			{Key: "TOTP", Value: "otpauth://x"},
+			{Key: "Notes", Value: "vpn too"},
+			{Key: "PIN", Value: "1234"},
+		},
+		Folder: "Work",
+	}, res.Docs[0])
+	assert.Equal(t, models.Card{
+		Name:       "Visa",
+		Cardholder: "John Doe",
+		Number:     "4111111111111111",
+		Expires:    "03/27",
+		Code:       "123",
+		Metadata:   []models.Meta{{Key: "Brand", Value: "Visa"}},
+	}, res.Docs[1])
+	assert.Equal(t, models.Note{Name: "Wifi", Text: "password123"}, res.Docs[2])
+	assert.Equal(t, models.Note{Name: "Me", Metadata: []models.Meta{{Key: "firstName", Value: "John"}, {Key: "lastName", Value: "Doe"}}}, res.Docs[3])
+	require.Len(t, res.Skipped, 1)
+
+	_, err = ParseBitwarden(strings.NewReader(`{"encrypted": true, "items": []}`))
+	require.ErrorIs(t, err, ErrEncrypted)
+}
+
+func TestParse1Password(t *testing.T) {
+	const data = `{"accounts": [{"attrs": {"accountName": "me"}, "vaults": [{"attrs": {"name": "Private"}, "items": [
+		{"state": "active", "categoryUuid": "001",
+			"overview": {"title": "Shop", "url": "https://shop.example.com", "tags": ["buy"]},
+			"details": {"loginFields": [
+				{"value": "buyer", "designation": "username"},
+				{"value": "pw", "designation": "password"},
+				{"value": "on", "name": "remember"}],
+				"notesPlain": "",
+				"sections": [{"title": "Security", "fields": [{"title": "answer", "id": "q1", "value": {"concealed": "blue"}}]}]}},
+		{"state": "active", "categoryUuid": "002",
+			"overview": {"title": "Master"},
+			"details": {"sections": [{"title": "", "fields": [
+				{"id": "cardholder", "value": {"string": "Jane"}},
+				{"id": "ccnum", "value": {"creditCardNumber": "5500000000000004"}},
+				{"id": "cvv", "value": {"concealed": "321"}},
+				{"id": "expiry", "value": {"monthYear": 202612}},
+				{"id": "type", "title": "type", "value": {"creditCardType": "mc"}}]}]}},
+		{"state": "active", "categoryUuid": "004",
+			"overview": {"title": "Identity"},
+			"details": {"sections": [{"title": "Address", "fields": [{"title": "home", "id": "a", "value": {"address": {"city": "Paris", "street": "Main"}}}]}]}},
+		{"state": "archived", "categoryUuid": "003", "overview": {"title": "Old"}, "details": {"notesPlain": "x"}}
+	]}]}]}`
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(opData)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	res, err := Parse1Password(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, res.Docs, 3)
+	assert.Equal(t, models.Credential{
+		Name:     "Shop",
+		Login:    "buyer",
+		Password: "pw",
+		Metadata: []models.Meta{{Key: "URL", Value: "https://shop.example.com"}, {Key: "Security: answer", Value: "blue"}},
+		Folder:   "Private",
+		Tags:     []string{"buy"},
+	}, res.Docs[0])
+	assert.Equal(t, models.Card{
+		Name:       "Master",
+		Cardholder: "Jane",
+		Number:     "5500000000000004",
+		Expires:    "12/26",
+		Code:       "321",
+		Metadata:   []models.Meta{{Key: "type", Value: "mc"}},
+		Folder:     "Private",
+	}, res.Docs[1])
+	assert.Equal(t, models.Note{
+		Name:     "Identity",
+		Metadata: []models.Meta{{Key: "Address: home", Value: "Paris, Main"}},
+		Folder:   "Private",
+	}, res.Docs[2])
+	assert.Len(t, res.Skipped, 1, "archived item should be skipped")
+
+	_, err = Parse1Password(strings.NewReader("not zip"), 7)
+	require.Error(t, err)
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Run("chrome", func(t *testing.T) {
+		const export = "\ufeffname,url,username,password,note\n" +
+			"Forum,https://forum.example.com,nick,pw,\"multi\nline\"\n" +
+			",https://bank.example.com/login,client,pw2,\n" +
+			"Empty,https://empty.example.com,,,\n"
+		res, err := ParseCSV(strings.NewReader(export))
+		require.NoError(t, err)
+		require.Len(t, res.Docs, 2)
+		assert.Equal(t, models.Credential{
+			Name:     "Forum",
+			Login:    "nick",
+			Password: "pw",
+			Metadata: []models.Meta{{Key: "URL", Value: "https://forum.example.com"}, {Key: "Notes", Value: "multi\nline"}},
+		}, res.Docs[0])
+		assert.Equal(t, "bank.example.com", res.Docs[1].(models.Credential).Name, "url host should be used as name")
+		assert.Equal(t, []string{"line 5: no login and password"}, res.Skipped)
+	})
+	t.Run("firefox", func(t *testing.T) {
+		const export = `"url","username","password","httpRealm","formActionOrigin","guid"
+"https://site.example.com","me","pw","","https://site.example.com","{abc}"
+`
+		res, err := ParseCSV(strings.NewReader(export))
+		require.NoError(t, err)
+		require.Len(t, res.Docs, 1)
+		assert.Equal(t, models.Credential{
+			Name:     "site.example.com",
+			Login:    "me",
+			Password: "pw",
+			Metadata: []models.Meta{
+				{Key: "URL", Value: "https://site.example.com"},
+				{Key: "formActionOrigin", Value: "https://site.example.com"},
+				{Key: "guid", Value: "{abc}"},
+			},
+		}, res.Docs[0])
+	})
+}
+
+func TestSplit(t *testing.T) {
+	existing := []interface{}{
+		&models.Credential{Name: "Mail", Login: "user", Password: "pw"},
+		&models.Card{Name: "Visa", Number: "4111 1111 1111 1111", Expires: "03/27"},
+	}
+	docs := []interface{}{
+		models.Credential{Name: "mail ", Login: "user", Password: "pw"},
+		models.Credential{Name: "Mail", Login: "user", Password: "new"},
+		models.Card{Name: "Old visa", Number: "4111111111111111", Expires: "03/27"},
+		models.Note{Name: "Wifi", Text: "pw"},
+		models.Note{Name: "Wifi", Text: "pw"},
+	}
+	unique, duplicates := Split(docs, existing)
+	assert.Equal(t, []interface{}{docs[1], docs[3]}, unique)
+	assert.Equal(t, []interface{}{docs[0], docs[2], docs[4]}, duplicates)
+}
+
+func TestDetectFormat(t *testing.T) {
+	for path, want := range map[string]string{
+		"db.XML":      FormatKeePass,
+		"vault.json":  FormatBitwarden,
+		"export.1pux": Format1Password,
+		"chrome.csv":  FormatCSV,
+	} {
+		got, err := DetectFormat(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := DetectFormat("data.txt")
+	require.ErrorIs(t, err, ErrFormat)
+}
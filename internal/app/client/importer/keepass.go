@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+type kpFile struct {
+	RecycleBin string    `xml:"Meta>RecycleBinUUID"`
+	Groups     []kpGroup `xml:"Root>Group"`
+}
+
+type kpGroup struct {
+	UUID    string    `xml:"UUID"`
+	Name    string    `xml:"Name"`
+	Entries []kpEntry `xml:"Entry"`
+	Groups  []kpGroup `xml:"Group"`
+}
+
+type kpEntry struct {
+	Tags    string `xml:"Tags"`
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+}
+
+// ParseKeePass parses KeePass 2 XML export. Groups are mapped to folders, the root
+// group is the database itself and is omitted. Recycle bin and entries history are
+// not imported. Entries without login and password are imported as notes.
+func ParseKeePass(r io.Reader) (Result, error) {
+	var f kpFile
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return Result{}, err
+	}
+	var res Result
+	var walk func(g kpGroup, path []string)
+	walk = func(g kpGroup, path []string) {
+		if f.RecycleBin != "" && g.UUID == f.RecycleBin {
+			return
+		}
+		for _, e := range g.Entries {
+			res.add(kpDoc(e, folder(path...)))
+		}
+		for _, child := range g.Groups {
+			walk(child, append(append([]string(nil), path...), child.Name))
+		}
+	}
+	for _, g := range f.Groups {
+		walk(g, nil)
+	}
+	return res, nil
+}
+
+func kpDoc(e kpEntry, path string) interface{} {
+	var title, login, password, link, notes string
+	var custom []models.Meta
+	for _, s := range e.Strings {
+		switch s.Key {
+		case "Title":
+			title = s.Value
+		case "UserName":
+			login = s.Value
+		case "Password":
+			password = s.Value
+		case "URL":
+			link = s.Value
+		case "Notes":
+			notes = s.Value
+		default:
+			meta(&custom, s.Key, s.Value)
+		}
+	}
+	docTags := tags(strings.FieldsFunc(e.Tags, func(r rune) bool { return r == ';' || r == ',' }))
+	var metadata []models.Meta
+	meta(&metadata, "URL", link)
+	if login == "" && password == "" {
+		return models.Note{
+			Name:     nameOf(title, link, ""),
+			Text:     notes,
+			Metadata: append(metadata, custom...),
+			Folder:   path,
+			Tags:     docTags,
+		}
+	}
+	meta(&metadata, "Notes", notes)
+	return models.Credential{
+		Name:     nameOf(title, link, login),
+		Login:    login,
+		Password: password,
+		Metadata: append(metadata, custom...),
+		Folder:   path,
+		Tags:     docTags,
+	}
+}
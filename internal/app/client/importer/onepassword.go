@@ -0,0 +1,212 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// 1Password item categories
+const (
+	opLogin    = "001"
+	opCard     = "002"
+	opPassword = "005"
+)
+
+// opData is a data file of 1PUX archive
+const opData = "export.data"
+
+type opExport struct {
+	Accounts []struct {
+		Vaults []struct {
+			Attrs struct {
+				Name string `json:"name"`
+			} `json:"attrs"`
+			Items []opItem `json:"items"`
+		} `json:"vaults"`
+	} `json:"accounts"`
+}
+
+type opItem struct {
+	State    string `json:"state"`
+	Category string `json:"categoryUuid"`
+	Overview struct {
+		Title string `json:"title"`
+		Url   string `json:"url"`
+		Urls  []struct {
+			Url string `json:"url"`
+		} `json:"urls"`
+		Tags []string `json:"tags"`
+	} `json:"overview"`
+	Details struct {
+		LoginFields []struct {
+			Value       string `json:"value"`
+			Designation string `json:"designation"`
+		} `json:"loginFields"`
+		NotesPlain string `json:"notesPlain"`
+		Password   string `json:"password"`
+		Sections   []struct {
+			Title  string `json:"title"`
+			Fields []struct {
+				Id    string                     `json:"id"`
+				Title string                     `json:"title"`
+				Value map[string]json.RawMessage `json:"value"`
+			} `json:"fields"`
+		} `json:"sections"`
+	} `json:"details"`
+}
+
+// Parse1Password parses 1Password 1PUX export, which is zip archive with JSON data.
+// Vaults are mapped to folders, archived items are not imported.
+func Parse1Password(r io.ReaderAt, size int64) (Result, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Result{}, err
+	}
+	f, err := zr.Open(opData)
+	if err != nil {
+		return Result{}, fmt.Errorf("1PUX archive has no %s: %w", opData, err)
+	}
+	defer func() { _ = f.Close() }()
+	var export opExport
+	if err := json.NewDecoder(f).Decode(&export); err != nil {
+		return Result{}, err
+	}
+	var res Result
+	for _, account := range export.Accounts {
+		for _, vault := range account.Vaults {
+			for _, item := range vault.Items {
+				if item.State == "archived" {
+					res.skip("%s: archived item", item.Overview.Title)
+					continue
+				}
+				res.add(opDoc(item, folder(vault.Attrs.Name)))
+			}
+		}
+	}
+	return res, nil
+}
+
+func opDoc(item opItem, path string) interface{} {
+	title := item.Overview.Title
+	docTags := tags(item.Overview.Tags)
+	var metadata []models.Meta
+	// fields are picked by id for cards, the rest is metadata
+	fields := make(map[string]string)
+	var custom []models.Meta
+	for _, s := range item.Details.Sections {
+		for _, f := range s.Fields {
+			value := opValue(f.Value)
+			if item.Category == opCard {
+				switch f.Id {
+				case "cardholder", "ccnum", "cvv", "expiry", "pin":
+					fields[f.Id] = value
+					continue
+				}
+			}
+			name := f.Title
+			if name == "" {
+				name = f.Id
+			}
+			if s.Title != "" {
+				name = s.Title + ": " + name
+			}
+			meta(&custom, name, value)
+		}
+	}
+	switch item.Category {
+	case opLogin, opPassword:
+		var login, password string
+		for _, f := range item.Details.LoginFields {
+			switch f.Designation {
+			case "username":
+				login = f.Value
+			case "password":
+				password = f.Value
+			}
+		}
+		if item.Category == opPassword {
+			password = item.Details.Password
+		}
+		links := []string{item.Overview.Url}
+		for _, u := range item.Overview.Urls {
+			if u.Url != item.Overview.Url {
+				links = append(links, u.Url)
+			}
+		}
+		for _, link := range links {
+			meta(&metadata, "URL", link)
+		}
+		meta(&metadata, "Notes", item.Details.NotesPlain)
+		return models.Credential{
+			Name:     nameOf(title, item.Overview.Url, login),
+			Login:    login,
+			Password: password,
+			Metadata: append(metadata, custom...),
+			Folder:   path,
+			Tags:     docTags,
+		}
+	case opCard:
+		meta(&metadata, "Notes", item.Details.NotesPlain)
+		return models.Card{
+			Name:       nameOf(title, "", ""),
+			Cardholder: fields["cardholder"],
+			Number:     fields["ccnum"],
+			Expires:    fields["expiry"],
+			Code:       fields["cvv"],
+			Pin:        fields["pin"],
+			Metadata:   append(metadata, custom...),
+			Folder:     path,
+			Tags:       docTags,
+		}
+	}
+	// secure notes and other categories keep their fields in metadata
+	meta(&metadata, "URL", item.Overview.Url)
+	return models.Note{
+		Name:     nameOf(title, "", ""),
+		Text:     item.Details.NotesPlain,
+		Metadata: append(metadata, custom...),
+		Folder:   path,
+		Tags:     docTags,
+	}
+}
+
+// opValue returns text of field value. Value is an object with single typed key,
+// e.g. {"concealed": "secret"} or {"monthYear": 202512}.
+func opValue(value map[string]json.RawMessage) string {
+	for kind, raw := range value {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+		var n int64
+		if err := json.Unmarshal(raw, &n); err == nil {
+			if kind == "monthYear" {
+				return expires(fmt.Sprint(n%100), fmt.Sprint(n/100))
+			}
+			return fmt.Sprint(n)
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(raw, &obj); err == nil {
+			// e.g. address, parts are joined in stable order
+			keys := make([]string, 0, len(obj))
+			for k, v := range obj {
+				if s, ok := v.(string); ok && s != "" {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			parts := make([]string, len(keys))
+			for i, k := range keys {
+				parts[i] = obj[k].(string)
+			}
+			return strings.Join(parts, ", ")
+		}
+	}
+	return ""
+}
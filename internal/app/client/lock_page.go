@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	pageLock       = "lock"
+	pageLockScreen = "lockScreen"
+)
+
+// WithScreenLock enables screen lock after inactivity timeout, zero disables it
+func WithScreenLock(timeout time.Duration) func(a *App) {
+	return func(a *App) {
+		a.lockTimeout = timeout
+	}
+}
+
+// WithIdleLogout enables logout after inactivity timeout, zero disables it
+func WithIdleLogout(timeout time.Duration) func(a *App) {
+	return func(a *App) {
+		a.idleTimeout = timeout
+	}
+}
+
+// WithLockOnBlur enables screen lock, when terminal loses focus.
+// Terminal should support focus reporting.
+func WithLockOnBlur(enable bool) func(a *App) {
+	return func(a *App) {
+		a.lockOnBlur = enable
+	}
+}
+
+// trackActivity records time of the last user input
+func (a *App) trackActivity() {
+	a.touch()
+	a.ui.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		a.touch()
+		return event
+	})
+	a.ui.SetMouseCapture(func(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+		a.touch()
+		return event, action
+	})
+}
+
+func (a *App) touch() {
+	atomic.StoreInt64(&a.lastActivity, time.Now().UnixNano())
+}
+
+// idle returns time since the last user input
+func (a *App) idle() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&a.lastActivity)))
+}
+
+// watchIdle locks screen, when user is inactive longer than lock timeout,
+// and finishes session, when user is inactive longer than idle timeout
+func (a *App) watchIdle(ctx context.Context) {
+	if a.lockTimeout <= 0 && a.idleTimeout <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	// screen is drawn only when state changes, user activity resets state
+	var locked, loggedOut bool
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			idle := a.idle()
+			switch {
+			case a.idleTimeout > 0 && idle >= a.idleTimeout:
+				if !loggedOut {
+					loggedOut, locked = true, true
+					a.ui.QueueUpdateDraw(a.idleLogout)
+				}
+			case a.lockTimeout > 0 && idle >= a.lockTimeout:
+				if !locked {
+					locked = true
+					a.ui.QueueUpdateDraw(a.lockScreen)
+				}
+			default:
+				locked, loggedOut = false, false
+			}
+		}
+	}
+}
+
+// lockScreen covers all pages and asks for password to show them again.
+// Password is checked locally, session stays active.
+func (a *App) lockScreen() {
+	if a.locked || !a.signedIn {
+		return
+	}
+	a.locked = true
+	if a.clipboard != nil {
+		go a.clipboard.Clear()
+	}
+	focus := a.ui.GetFocus()
+	unlock := func() {
+		a.removeLock()
+		a.ui.SetFocus(focus)
+		a.touch()
+	}
+
+	var password string
+	form := tview.NewForm()
+	form.AddPasswordField("password", "", 30, maskChar, func(text string) {
+		password = text
+	})
+	form.AddButton("Unlock", func() {
+		if err := a.store.VerifyPassword(password); err != nil {
+			form.SetTitle(" [red]Wrong password ")
+			form.GetFormItem(0).(*tview.InputField).SetText("")
+			form.SetFocus(0)
+			a.ui.SetFocus(form)
+			return
+		}
+		unlock()
+	})
+	form.AddButton("Log out", func() {
+		a.removeLock()
+		a.logout()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Screen is locked ")
+	// opaque screen hides documents under lock form
+	a.pages.AddPage(pageLockScreen, tview.NewBox(), true, true)
+	a.pages.AddPage(pageLock, center(44, 7, form), true, true)
+	a.ui.SetFocus(form)
+}
+
+// removeLock removes lock pages
+func (a *App) removeLock() {
+	a.locked = false
+	a.pages.RemovePage(pageLock)
+	a.pages.RemovePage(pageLockScreen)
+}
+
+// idleLogout finishes session of inactive user. Documents are removed from memory
+// and token is not refreshed anymore.
+func (a *App) idleLogout() {
+	if !a.signedIn {
+		return
+	}
+	a.removeLock()
+	a.logout()
+}
+
+// useFocusScreen makes app screen, which reports terminal focus change and
+// locks screen, when focus is lost
+func (a *App) useFocusScreen() error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	s := &focusScreen{Screen: screen, onFocus: func(focused bool) {
+		if !focused {
+			a.ui.QueueUpdateDraw(a.lockScreen)
+		}
+	}}
+	// screen is initialized by app
+	a.ui.SetScreen(s)
+	if s.err != nil {
+		return s.err
+	}
+	if a.useMouse {
+		s.EnableMouse()
+	}
+	return nil
+}
+
+// focusScreen enables focus reporting and passes focus events to onFocus
+type focusScreen struct {
+	tcell.Screen
+	onFocus func(focused bool)
+	err     error // initialization error
+}
+
+func (s *focusScreen) Init() error {
+	if s.err = s.Screen.Init(); s.err == nil {
+		s.Screen.EnableFocus()
+	}
+	return s.err
+}
+
+func (s *focusScreen) PollEvent() tcell.Event {
+	event := s.Screen.PollEvent()
+	if e, ok := event.(*tcell.EventFocus); ok {
+		s.onFocus(e.Focused)
+	}
+	return event
+}
@@ -1,22 +1,28 @@
 package client
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"yap-pwkeeper/internal/app/client/memstore"
 	"yap-pwkeeper/internal/pkg/models"
 )
 
 const (
 	pageWelcome = "welcome"
 	pageLogin   = "cred"
+	pageTotp    = "totp"
 )
 
 // welcomePage creates welcome page and switches to it
 func (a *App) welcomePage() {
+	a.signedIn = false
 	p := tview.NewModal().SetBackgroundColor(tcell.ColorBlack)
 	p.SetText("Welcome!")
-	p.AddButtons([]string{"Register New User", "Login Existing User"})
+	p.AddButtons([]string{"Register New User", "Login Existing User", "Open Shared Document"})
 	p.SetFocus(1)
 	p.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 		switch buttonIndex {
@@ -24,6 +30,8 @@ func (a *App) welcomePage() {
 			a.registerPage()
 		case 1:
 			a.loginPage()
+		case 2:
+			a.openSharePage()
 		default:
 			a.Stop()
 		}
@@ -48,14 +56,18 @@ func (a *App) loginPage() {
 		login.Password = text
 	})
 	form.AddButton("login", func() {
-		if err := a.store.Login(login.Login, login.Password); err != nil {
+		err := a.store.Login(login.Login, login.Password, "")
+		if errors.Is(err, memstore.ErrTotpRequired) {
+			a.pages.RemovePage(pageLogin)
+			a.totpLoginPage(login)
+			return
+		}
+		if err != nil {
 			a.modalErr(err.Error())
 			return
 		}
 		a.pages.RemovePage(pageLogin)
-		a.pages.SwitchToPage(pageRoot)
-		a.ui.SetFocus(a.categories)
-
+		a.loggedIn()
 	})
 	form.AddButton("<<Back", func() {
 		cancelFunc()
@@ -69,6 +81,44 @@ func (a *App) loginPage() {
 	a.pages.SwitchToPage(pageLogin)
 }
 
+// totpLoginPage is the second login step, it asks for one-time code,
+// when login and password are accepted
+func (a *App) totpLoginPage(login models.UserCredentials) {
+	cancelFunc := func() {
+		a.welcomePage()
+		a.pages.RemovePage(pageTotp)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("code", "", 20, nil, func(text string) {
+		login.Totp = text
+	})
+	form.AddButton("verify", func() {
+		if err := a.store.Login(login.Login, login.Password, login.Totp); err != nil {
+			a.modalErr(err.Error())
+			return
+		}
+		a.pages.RemovePage(pageTotp)
+		a.loggedIn()
+	})
+	form.AddButton("<<Back", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle("Enter one-time or recovery code")
+	a.pages.AddPage(pageTotp, center(44, 7, form), true, true)
+	a.pages.SwitchToPage(pageTotp)
+}
+
+// loggedIn opens documents after successful login
+func (a *App) loggedIn() {
+	a.signedIn = true
+	a.resetFilter()
+	a.pages.SwitchToPage(pageRoot)
+	a.ui.SetFocus(a.categories)
+	a.startWatch()
+}
+
 // loginPage user registration page
 func (a *App) registerPage() {
 	login := models.UserCredentials{}
@@ -92,22 +142,17 @@ func (a *App) registerPage() {
 			a.modalErr("Passwords do not match!")
 			return
 		}
-		if len(login.Login) < 3 {
-			a.modalErr("login is too short, use at least 3 symbols")
-			return
-		}
 		if err := a.store.Register(login.Login, login.Password); err != nil {
-			a.modalErr("Registration failed: " + err.Error())
+			// all reasons of rejection are shown, one per line
+			a.modalErr("Registration failed:\n" + strings.ReplaceAll(err.Error(), "; ", "\n"))
 			return
 		}
-		if err := a.store.Login(login.Login, login.Password); err != nil {
+		if err := a.store.Login(login.Login, login.Password, ""); err != nil {
 			a.modalErr("User registered, but: " + err.Error())
 			return
 		}
 		a.pages.RemovePage(pageLogin)
-		a.pages.SwitchToPage(pageRoot)
-		a.ui.SetFocus(a.categories)
-
+		a.loggedIn()
 	})
 	form.AddButton("<<Back", func() {
 		cancelFunc()
@@ -17,6 +17,7 @@ func (a *App) mainPage() {
 
 	flex.AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(tview.NewFlex().
+			AddItem(a.filterPane(), 0, 1, false).
 			AddItem(a.categories, 0, 1, false).
 			AddItem(a.itemsList, 0, 1, false).
 			AddItem(a.form, 0, 3, true), 0, 1, true).
@@ -26,6 +27,7 @@ func (a *App) mainPage() {
 	a.categories.AddItem("", "", 0, nil)
 	a.categories.AddItem("", "", 0, nil)
 	a.categories.AddItem("", "", 0, nil)
+	a.categories.AddItem("", "", 0, nil)
 
 	a.categories.SetSelectedFunc(func(i int, s string, s2 string, r rune) {
 		if a.itemsList.GetItemCount() > 0 {
@@ -35,29 +37,11 @@ func (a *App) mainPage() {
 	a.categories.SetFocusFunc(func() {
 		a.clearForm()
 		a.synchronize()
-		switch a.categories.GetCurrentItem() {
-		case 0:
-			a.cardsList()
-		case 1:
-			a.credentialsList()
-		case 2:
-			a.notesList()
-		case 3:
-			a.filesList()
-		}
+		a.drawItemsList(a.categories.GetCurrentItem())
 	})
 
 	a.categories.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
-		switch index {
-		case 0:
-			a.cardsList()
-		case 1:
-			a.credentialsList()
-		case 2:
-			a.notesList()
-		case 3:
-			a.filesList()
-		}
+		a.drawItemsList(index)
 	})
 
 	a.categories.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -68,15 +52,28 @@ func (a *App) mainPage() {
 			}
 			return nil
 		case tcell.KeyLeft:
+			a.ui.SetFocus(a.folders)
 			return nil
 		case tcell.KeyEsc:
 			a.modalExit()
 			return nil
 		}
 		switch event.Rune() {
+		case '#':
+			a.ui.SetFocus(a.tagFilter)
+			return nil
 		case 's':
 			a.synchronize()
 			return nil
+		case 't':
+			a.totpPage()
+			return nil
+		case 'u':
+			a.sessionsPage()
+			return nil
+		case 'o':
+			a.settingsPage()
+			return nil
 		case 'c':
 			a.cardsForm(&models.Card{}, formAdd)
 			a.ui.SetFocus(a.form)
@@ -89,6 +86,12 @@ func (a *App) mainPage() {
 		case 'f':
 			a.filesForm(&models.File{}, formAdd)
 			a.ui.SetFocus(a.form)
+		case 'g':
+			a.generateCredential()
+			return nil
+		case '/':
+			a.searchPage()
+			return nil
 		}
 
 		return event
@@ -99,6 +102,9 @@ func (a *App) mainPage() {
 	a.itemsList.SetBorder(true).SetTitle(" Items ")
 
 	a.itemsList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event = a.copyCapture(event); event == nil {
+			return nil
+		}
 		switch event.Key() {
 		case tcell.KeyRight:
 			a.ui.SetFocus(a.form)
@@ -114,6 +120,9 @@ func (a *App) mainPage() {
 		case 's':
 			a.ui.SetFocus(a.categories)
 			return nil
+		case '/':
+			a.searchPage()
+			return nil
 		case 'c':
 			a.cardsForm(&models.Card{}, formAdd)
 			a.ui.SetFocus(a.form)
@@ -132,17 +141,34 @@ func (a *App) mainPage() {
 
 	// document edit form
 	a.form.SetBorder(true)
+	a.form.SetInputCapture(a.copyCapture)
 	a.clearForm()
 
 	a.pages.AddPage(pageRoot, flex, true, false)
 }
 
+// drawItemsList displays list of documents of category with index
+func (a *App) drawItemsList(index int) {
+	switch index {
+	case 0:
+		a.cardsList()
+	case 1:
+		a.credentialsList()
+	case 2:
+		a.notesList()
+	case 3:
+		a.filesList()
+	case 4:
+		a.trashList()
+	}
+}
+
 // notesList displays list of Notes
 func (a *App) notesList() {
-	a.itemsList.Clear().SetTitle("Notes")
-	for _, v := range a.store.GetNotesList() {
+	a.itemsList.Clear().SetTitle(a.listTitle("Notes"))
+	for _, v := range a.notesInView() {
 		v := *v
-		a.itemsList.AddItem(v.Name, v.Id, 0, func() {
+		a.itemsList.AddItem(a.itemName(v.Id, v.Name, v.VaultId), v.Id, 0, func() {
 			a.ui.SetFocus(a.form)
 		})
 		a.itemsList.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
@@ -162,10 +188,10 @@ func (a *App) notesList() {
 
 // cardsList displays list of Cards
 func (a *App) cardsList() {
-	a.itemsList.Clear().SetTitle("Cards")
-	for _, v := range a.store.GetCardsList() {
+	a.itemsList.Clear().SetTitle(a.listTitle("Cards"))
+	for _, v := range a.cardsInView() {
 		v := *v
-		a.itemsList.AddItem(v.Name, v.Id, 0, func() {
+		a.itemsList.AddItem(a.itemName(v.Id, v.Name, v.VaultId), v.Id, 0, func() {
 			a.ui.SetFocus(a.form)
 		})
 		a.itemsList.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
@@ -184,10 +210,10 @@ func (a *App) cardsList() {
 
 // credentialsList displays list of Credentials
 func (a *App) credentialsList() {
-	a.itemsList.Clear().SetTitle("Credentials")
-	for _, v := range a.store.GetCredentialsList() {
+	a.itemsList.Clear().SetTitle(a.listTitle("Credentials"))
+	for _, v := range a.credentialsInView() {
 		v := *v
-		a.itemsList.AddItem(v.Name, v.Id, 0, func() {
+		a.itemsList.AddItem(a.itemName(v.Id, v.Name, v.VaultId), v.Id, 0, func() {
 			a.ui.SetFocus(a.form)
 		})
 		a.itemsList.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
@@ -206,10 +232,10 @@ func (a *App) credentialsList() {
 
 // filesList displays list of Files
 func (a *App) filesList() {
-	a.itemsList.Clear().SetTitle("Files")
-	for _, v := range a.store.GetFilesList() {
+	a.itemsList.Clear().SetTitle(a.listTitle("Files"))
+	for _, v := range a.filesInView() {
 		v := *v
-		a.itemsList.AddItem(v.Name, v.Id, 0, func() {
+		a.itemsList.AddItem(a.itemName(v.Id, v.Name, v.VaultId), v.Id, 0, func() {
 			a.ui.SetFocus(a.form)
 		})
 		a.itemsList.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
@@ -0,0 +1,54 @@
+package memstore
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"yap-pwkeeper/internal/pkg/crypt"
+)
+
+// ExportAll saves archive with all user documents and file data to path.
+// Archive keeps documents encrypted, wrapped vault key is included, so it may be
+// opened with user password. Archive is written to temporary file and renamed, when complete.
+func (s *Store) ExportAll(path string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create export file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if err := s.server.ExportAll(f); err != nil {
+		_ = f.Close()
+		return s.checkAuthErr(err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// DeleteAccount deletes user account with all documents on server, and clears
+// storage and cache. Password is required to confirm deletion.
+func (s *Store) DeleteAccount(password string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	wrapped, login := s.vaultKey, s.login
+	s.mu.RUnlock()
+	mk := crypt.DeriveMasterKeys(login, password)
+	if err := s.server.DeleteAccount(serverPassword(wrapped, mk, password)); err != nil {
+		return s.checkAuthErr(err)
+	}
+	if s.cache != nil {
+		if err := s.cache.Remove(login); err != nil {
+			log.Printf("unable to remove cache: %s", err.Error())
+		}
+	}
+	s.bootstrap()
+	return nil
+}
@@ -0,0 +1,268 @@
+package memstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"yap-pwkeeper/internal/app/client/backup"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// restoredSuffix is added to names of conflicting documents, restored as copies
+const restoredSuffix = " (restored)"
+
+// Backup saves all documents, including files data, to portable backup, encrypted
+// with passphrase. Documents of shared vaults are included. Backup is written to
+// temporary file and renamed, when complete. Returns number of saved documents.
+func (s *Store) Backup(path, passphrase string) (int, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	m := s.backupManifest()
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("unable to create backup file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	w, err := backup.NewWriter(f, passphrase, m)
+	if err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+	for _, d := range m.Files {
+		if err := s.backupFile(w, d); err != nil {
+			_ = w.Close()
+			_ = f.Close()
+			return 0, fmt.Errorf("failed to save file %s: %w", d.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	return m.Len(), os.Rename(f.Name(), path)
+}
+
+// backupManifest lists documents, which are not in trash
+func (s *Store) backupManifest() backup.Manifest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m := backup.Manifest{Login: s.login}
+	for _, v := range s.vaults {
+		if v.cipher != nil {
+			m.Vaults = append(m.Vaults, backup.Vault{Id: v.Id, Name: v.Name})
+		}
+	}
+	for _, d := range s.notes {
+		if d.State != models.StateTrashed {
+			m.Notes = append(m.Notes, *d)
+		}
+	}
+	for _, d := range s.cards {
+		if d.State != models.StateTrashed {
+			m.Cards = append(m.Cards, *d)
+		}
+	}
+	for _, d := range s.credentials {
+		if d.State != models.StateTrashed {
+			m.Credentials = append(m.Credentials, *d)
+		}
+	}
+	for _, d := range s.files {
+		if d.State != models.StateTrashed {
+			m.Files = append(m.Files, *d)
+		}
+	}
+	return m
+}
+
+// backupFile streams decrypted file data from server to backup
+func (s *Store) backupFile(w *backup.Writer, d models.File) error {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	plain := s.plain[d.Id]
+	s.mu.RUnlock()
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var dst io.WriteCloser = nopWriteCloser{pw}
+		if !plain {
+			dst = c.NewWriter(pw)
+		}
+		_, err := s.server.GetFile(d.Id, d.VaultId, dst)
+		if err == nil {
+			err = dst.Close()
+		}
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+	err = w.AddFile(d.Id, d.Size, pr)
+	if err == nil {
+		// the rest of download is read to verify it, file should not have extra data
+		var n int64
+		if n, err = io.Copy(io.Discard, pr); err == nil && n > 0 {
+			err = errors.New("file size does not match")
+		}
+	}
+	_ = pr.CloseWithError(err)
+	if errDownload := <-done; errDownload != nil {
+		return s.checkAuthErr(errDownload)
+	}
+	return err
+}
+
+// RestorePreview is a dry-run result of backup restore
+type RestorePreview struct {
+	Manifest backup.Manifest // backed up documents
+	Plan     backup.Plan     // documents by their state in storage
+}
+
+// PreviewRestore opens backup and compares its documents with existing ones.
+// Documents of shared vaults, where user can't add documents, are restored to
+// personal documents, to folder named after the vault. Nothing is sent to server.
+func (s *Store) PreviewRestore(path, passphrase string) (RestorePreview, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return RestorePreview{}, err
+	}
+	defer func() { _ = f.Close() }()
+	r, err := backup.Open(f, passphrase)
+	if err != nil {
+		return RestorePreview{}, err
+	}
+	_ = r.Close()
+	m := r.Manifest()
+	return RestorePreview{Manifest: m, Plan: s.restorePlan(m)}, nil
+}
+
+// restorePlan compares backed up documents with documents in storage
+func (s *Store) restorePlan(m backup.Manifest) backup.Plan {
+	docs := backup.Docs(m, func(vaultId string) bool {
+		role := s.VaultRole(vaultId)
+		return role == models.RoleOwner || role == models.RoleWrite
+	})
+	s.mu.RLock()
+	existing := make([]interface{}, 0, len(s.notes)+len(s.cards)+len(s.credentials)+len(s.files))
+	for _, d := range s.notes {
+		existing = append(existing, *d)
+	}
+	for _, d := range s.cards {
+		existing = append(existing, *d)
+	}
+	for _, d := range s.credentials {
+		existing = append(existing, *d)
+	}
+	for _, d := range s.files {
+		existing = append(existing, *d)
+	}
+	s.mu.RUnlock()
+	return backup.Compare(docs, existing)
+}
+
+// Restore adds documents from backup, which do not exist in storage. Conflicting
+// documents, which differ from existing ones with the same name, are skipped, or
+// added as copies with marked name if withConflicts is set. Restore stops on the
+// first failure, files without data in backup are reported as failure.
+// Returns number of added documents.
+func (s *Store) Restore(path, passphrase string, withConflicts bool) (int, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	r, err := backup.Open(f, passphrase)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = r.Close() }()
+	plan := s.restorePlan(r.Manifest())
+	docs := plan.New
+	if withConflicts {
+		for _, doc := range plan.Conflicts {
+			docs = append(docs, renamed(doc))
+		}
+	}
+	// documents are added first, files are added, when their data is read
+	files := make(map[string]models.File)
+	added := 0
+	for _, doc := range docs {
+		var err error
+		switch d := doc.(type) {
+		case models.Note:
+			err = s.AddNote(d)
+		case models.Card:
+			err = s.AddCard(d)
+		case models.Credential:
+			err = s.AddCredential(d)
+		case models.File:
+			files[d.Id] = d
+			continue
+		}
+		if err != nil {
+			return added, err
+		}
+		added++
+	}
+	for {
+		id, data, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return added, err
+		}
+		d, ok := files[id]
+		if !ok {
+			continue
+		}
+		delete(files, id)
+		d.Id = ""
+		if err := s.uploadData(d, io.LimitReader(data, d.Size)); err != nil {
+			return added, fmt.Errorf("failed to restore file %s: %w", d.Name, err)
+		}
+		added++
+	}
+	if len(files) > 0 {
+		// files are listed in manifest, but their data is missing
+		names := make([]string, 0, len(files))
+		for _, d := range files {
+			names = append(names, d.Name)
+		}
+		sort.Strings(names)
+		return added, fmt.Errorf("%w: no data of files %s", backup.ErrDamaged, strings.Join(names, ", "))
+	}
+	return added, nil
+}
+
+// renamed marks name of document restored as a copy
+func renamed(doc interface{}) interface{} {
+	switch d := doc.(type) {
+	case models.Note:
+		d.Name += restoredSuffix
+		return d
+	case models.Card:
+		d.Name += restoredSuffix
+		return d
+	case models.Credential:
+		d.Name += restoredSuffix
+		return d
+	case models.File:
+		d.Name += restoredSuffix
+		return d
+	}
+	return doc
+}
@@ -0,0 +1,104 @@
+package memstore
+
+import (
+	"errors"
+	"log"
+
+	"yap-pwkeeper/internal/app/client/filecache"
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// loadCache places cached documents to the store
+func (s *Store) loadCache() error {
+	if s.cache == nil {
+		return filecache.ErrNoCache
+	}
+	c, err := s.getCipher()
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	login := s.login
+	s.mu.RUnlock()
+	snap, err := s.cache.Load(login, c)
+	if err != nil {
+		if !errors.Is(err, filecache.ErrNoCache) {
+			log.Printf("unable to load cache: %s", err.Error())
+		}
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range snap.Notes {
+		s.notes[snap.Notes[i].Id] = &snap.Notes[i]
+	}
+	for i := range snap.Cards {
+		s.cards[snap.Cards[i].Id] = &snap.Cards[i]
+	}
+	for i := range snap.Credentials {
+		s.credentials[snap.Credentials[i].Id] = &snap.Credentials[i]
+	}
+	for i := range snap.Files {
+		s.files[snap.Files[i].Id] = &snap.Files[i]
+	}
+	for _, id := range snap.PlainFiles {
+		s.plain[id] = true
+	}
+	for _, v := range snap.Vaults {
+		key := snap.VaultKeys[v.Id]
+		if c, err := crypt.New(key); err == nil {
+			s.vaults[v.Id] = &vault{Vault: v, key: key, cipher: c}
+		}
+	}
+	s.serial = snap.Serial
+	return nil
+}
+
+// saveCache saves store documents to cache
+func (s *Store) saveCache() {
+	if s.cache == nil {
+		return
+	}
+	s.mu.RLock()
+	c, login, vaultKey := s.cipher, s.login, s.vaultKey
+	snap := filecache.Snapshot{
+		Serial:      s.serial,
+		Notes:       make([]models.Note, 0, len(s.notes)),
+		Cards:       make([]models.Card, 0, len(s.cards)),
+		Credentials: make([]models.Credential, 0, len(s.credentials)),
+		Files:       make([]models.File, 0, len(s.files)),
+		VaultKeys:   make(map[string][]byte, len(s.vaults)),
+	}
+	for _, v := range s.notes {
+		snap.Notes = append(snap.Notes, *v)
+	}
+	for _, v := range s.cards {
+		snap.Cards = append(snap.Cards, *v)
+	}
+	for _, v := range s.credentials {
+		snap.Credentials = append(snap.Credentials, *v)
+	}
+	for _, v := range s.files {
+		snap.Files = append(snap.Files, *v)
+	}
+	for id, v := range s.vaults {
+		if v.cipher == nil {
+			continue
+		}
+		snap.Vaults = append(snap.Vaults, v.Vault)
+		snap.VaultKeys[id] = v.key
+	}
+	for id, plain := range s.plain {
+		if plain {
+			snap.PlainFiles = append(snap.PlainFiles, id)
+		}
+	}
+	s.mu.RUnlock()
+	if c == nil || login == "" {
+		return
+	}
+	if err := s.cache.Save(login, vaultKey, c, snap); err != nil {
+		log.Printf("unable to save cache: %s", err.Error())
+	}
+}
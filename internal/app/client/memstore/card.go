@@ -19,6 +19,9 @@ func (s *Store) GetCardsList() []*models.Card {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	for _, v := range s.cards {
+		if v.State == models.StateTrashed {
+			continue
+		}
 		list = append(list, v)
 	}
 	sort.Slice(list, func(i, j int) bool {
@@ -29,15 +32,36 @@ func (s *Store) GetCardsList() []*models.Card {
 
 // AddCard saves new Card to server
 func (s *Store) AddCard(d models.Card) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealCard(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.AddCard(d))
 }
 
 // UpdateCard updates Card on server
 func (s *Store) UpdateCard(d models.Card) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealCard(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.UpdateCard(d))
 }
 
 // DeleteCard deletes Card on server
 func (s *Store) DeleteCard(d models.Card) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealCard(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.DeleteCard(d))
 }
@@ -19,6 +19,9 @@ func (s *Store) GetCredentialsList() []*models.Credential {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	for _, v := range s.credentials {
+		if v.State == models.StateTrashed {
+			continue
+		}
 		list = append(list, v)
 	}
 	sort.Slice(list, func(i, j int) bool {
@@ -29,15 +32,36 @@ func (s *Store) GetCredentialsList() []*models.Credential {
 
 // AddCredential saves new Credential to server
 func (s *Store) AddCredential(d models.Credential) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealCredential(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.AddCredential(d))
 }
 
 // UpdateCredential updates Credential on server
 func (s *Store) UpdateCredential(d models.Credential) error {
-	return s.server.UpdateCredential(d)
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealCredential(d)
+	if err != nil {
+		return err
+	}
+	return s.checkAuthErr(s.server.UpdateCredential(d))
 }
 
 // DeleteCredential deletes Credential on server
 func (s *Store) DeleteCredential(d models.Credential) error {
-	return s.server.DeleteCredential(d)
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealCredential(d)
+	if err != nil {
+		return err
+	}
+	return s.checkAuthErr(s.server.DeleteCredential(d))
 }
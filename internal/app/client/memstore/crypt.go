@@ -0,0 +1,361 @@
+package memstore
+
+import (
+	"errors"
+	"fmt"
+
+	"yap-pwkeeper/internal/app/client/grpccli"
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/pwpolicy"
+)
+
+// ErrLocked is returned when vault key is not available
+var ErrLocked = errors.New("vault is locked")
+
+// unlockVault gets user vault key from server and decrypts it with master keys.
+// New vault key is generated and stored on server on the first login.
+// Returns vault cipher and wrapped vault key.
+func (s *Store) unlockVault(mk crypt.MasterKeys, password string) (*crypt.Cipher, []byte, error) {
+	wrapped, err := s.server.GetVaultKey()
+	if errors.Is(err, grpccli.ErrNoVaultKey) {
+		wrapped, err = s.newVaultKey(mk)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get vault key: %w", err)
+	}
+	c, err := openVaultKey(wrapped, mk, password)
+	return c, wrapped, err
+}
+
+// openVaultKey decrypts wrapped vault key and returns vault cipher
+func openVaultKey(wrapped []byte, mk crypt.MasterKeys, password string) (*crypt.Cipher, error) {
+	key, err := unwrapVaultKey(wrapped, mk, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock vault: %w", err)
+	}
+	return crypt.New(key)
+}
+
+// unwrapVaultKey decrypts vault key with master encryption key, or with master
+// password itself, if key was wrapped before master keys were introduced
+func unwrapVaultKey(wrapped []byte, mk crypt.MasterKeys, password string) ([]byte, error) {
+	if crypt.IsPasswordWrapped(wrapped) {
+		return crypt.UnwrapKey(wrapped, password)
+	}
+	return mk.UnwrapKey(wrapped)
+}
+
+// serverPassword returns password, which server has for the user: auth value, or
+// master password itself, while account is not migrated to master keys
+func serverPassword(wrapped []byte, mk crypt.MasterKeys, password string) string {
+	if crypt.IsPasswordWrapped(wrapped) {
+		return password
+	}
+	return mk.Auth
+}
+
+// newVaultKey generates new vault key and saves it on server.
+// If other client has already saved the key, that key is used.
+func (s *Store) newVaultKey(mk crypt.MasterKeys) ([]byte, error) {
+	key, err := crypt.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := mk.WrapKey(key)
+	if err != nil {
+		return nil, err
+	}
+	err = s.server.SetVaultKey(wrapped)
+	if errors.Is(err, grpccli.ErrVaultKeyExists) {
+		return s.server.GetVaultKey()
+	}
+	return wrapped, err
+}
+
+// migrateAccount moves account, created before master keys were introduced, to them:
+// master password, which server has as login password, is replaced by auth value, and
+// vault key is re-wrapped with master encryption key in the same update.
+// Returns re-wrapped vault key.
+func (s *Store) migrateAccount(mk crypt.MasterKeys, password string, wrapped []byte) ([]byte, error) {
+	key, err := unwrapVaultKey(wrapped, mk, password)
+	if err != nil {
+		return nil, err
+	}
+	rewrapped, err := mk.WrapKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.server.ChangePassword(password, mk.Auth, rewrapped); err != nil {
+		return nil, err
+	}
+	return rewrapped, nil
+}
+
+// checkPassword checks master password against password policy of server.
+// Breached passwords are checked by hash prefix, password is not revealed.
+func (s *Store) checkPassword(login, password string) error {
+	policy, err := s.server.GetPasswordPolicy()
+	if err != nil {
+		return err
+	}
+	return pwpolicy.Check(policy, login, password, s.server.GetBreachedRange)
+}
+
+// ChangePassword replaces user password. Vault key is re-wrapped with the new master keys
+// and sent to server together with the new auth value, so server never keeps key and
+// password, which do not match. Documents are encrypted with vault key itself and are
+// not changed. New password is checked against server password policy.
+func (s *Store) ChangePassword(oldPassword, newPassword string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	wrapped, login := s.vaultKey, s.login
+	s.mu.RUnlock()
+	if wrapped == nil {
+		return ErrLocked
+	}
+	oldKeys := crypt.DeriveMasterKeys(login, oldPassword)
+	key, err := unwrapVaultKey(wrapped, oldKeys, oldPassword)
+	if err != nil {
+		return ErrBadPassword
+	}
+	if err := s.checkPassword(login, newPassword); err != nil {
+		return err
+	}
+	newKeys := crypt.DeriveMasterKeys(login, newPassword)
+	rewrapped, err := newKeys.WrapKey(key)
+	if err != nil {
+		return err
+	}
+	if err := s.server.ChangePassword(serverPassword(wrapped, oldKeys, oldPassword), newKeys.Auth, rewrapped); err != nil {
+		return s.checkAuthErr(err)
+	}
+	s.mu.Lock()
+	s.vaultKey = rewrapped
+	s.mu.Unlock()
+	// cached vault key should be opened with the new password
+	s.saveCache()
+	return nil
+}
+
+// VerifyPassword checks user password locally: it should open wrapped vault key.
+// It is used to unlock screen without new login.
+func (s *Store) VerifyPassword(password string) error {
+	s.mu.RLock()
+	wrapped, login := s.vaultKey, s.login
+	s.mu.RUnlock()
+	if wrapped == nil {
+		return ErrLocked
+	}
+	if _, err := unwrapVaultKey(wrapped, crypt.DeriveMasterKeys(login, password), password); err != nil {
+		return ErrBadPassword
+	}
+	return nil
+}
+
+// getCipher returns vault cipher. Until user is migrated, it accepts
+// plain text of documents, saved before encryption was introduced.
+func (s *Store) getCipher() (*crypt.Cipher, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cipher == nil {
+		return nil, ErrLocked
+	}
+	if !s.migrated {
+		return s.cipher.Legacy(), nil
+	}
+	return s.cipher, nil
+}
+
+func encryptMetadata(c *crypt.Cipher, metadata []models.Meta) []models.Meta {
+	res := make([]models.Meta, len(metadata))
+	for i, v := range metadata {
+		res[i] = models.Meta{
+			Key:   c.EncryptString(v.Key),
+			Value: c.EncryptString(v.Value),
+		}
+	}
+	return res
+}
+
+func decryptMetadata(c *crypt.Cipher, metadata []models.Meta) ([]models.Meta, error) {
+	var err error
+	res := make([]models.Meta, len(metadata))
+	for i, v := range metadata {
+		if res[i].Key, err = c.DecryptString(v.Key); err != nil {
+			return nil, err
+		}
+		if res[i].Value, err = c.DecryptString(v.Value); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func encryptTags(c *crypt.Cipher, tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	res := make([]string, len(tags))
+	for i, v := range tags {
+		res[i] = c.EncryptString(v)
+	}
+	return res
+}
+
+func decryptTags(c *crypt.Cipher, tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	var err error
+	res := make([]string, len(tags))
+	for i, v := range tags {
+		if res[i], err = c.DecryptString(v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// decryptStrings decrypts strings in place
+func decryptStrings(c *crypt.Cipher, fields ...*string) error {
+	for _, f := range fields {
+		v, err := c.DecryptString(*f)
+		if err != nil {
+			return err
+		}
+		*f = v
+	}
+	return nil
+}
+
+// sealNote encrypts Note payload, leaving routing fields as is
+func (s *Store) sealNote(d models.Note) (models.Note, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	d.Name = c.EncryptString(d.Name)
+	d.Text = c.EncryptString(d.Text)
+	d.Metadata = encryptMetadata(c, d.Metadata)
+	d.Folder = c.EncryptString(d.Folder)
+	d.Tags = encryptTags(c, d.Tags)
+	return d, nil
+}
+
+// openNote decrypts Note payload
+func (s *Store) openNote(d models.Note) (models.Note, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	if err := decryptStrings(c, &d.Name, &d.Text, &d.Folder); err != nil {
+		return d, err
+	}
+	if d.Tags, err = decryptTags(c, d.Tags); err != nil {
+		return d, err
+	}
+	d.Metadata, err = decryptMetadata(c, d.Metadata)
+	return d, err
+}
+
+// sealCard encrypts Card payload, leaving routing fields as is
+func (s *Store) sealCard(d models.Card) (models.Card, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	d.Name = c.EncryptString(d.Name)
+	d.Cardholder = c.EncryptString(d.Cardholder)
+	d.Number = c.EncryptString(d.Number)
+	d.Expires = c.EncryptString(d.Expires)
+	d.Pin = c.EncryptString(d.Pin)
+	d.Code = c.EncryptString(d.Code)
+	d.Metadata = encryptMetadata(c, d.Metadata)
+	d.Folder = c.EncryptString(d.Folder)
+	d.Tags = encryptTags(c, d.Tags)
+	return d, nil
+}
+
+// openCard decrypts Card payload
+func (s *Store) openCard(d models.Card) (models.Card, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	if err := decryptStrings(c, &d.Name, &d.Cardholder, &d.Number, &d.Expires, &d.Pin, &d.Code, &d.Folder); err != nil {
+		return d, err
+	}
+	if d.Tags, err = decryptTags(c, d.Tags); err != nil {
+		return d, err
+	}
+	d.Metadata, err = decryptMetadata(c, d.Metadata)
+	return d, err
+}
+
+// sealCredential encrypts Credential payload, leaving routing fields as is
+func (s *Store) sealCredential(d models.Credential) (models.Credential, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	d.Name = c.EncryptString(d.Name)
+	d.Login = c.EncryptString(d.Login)
+	d.Password = c.EncryptString(d.Password)
+	d.Metadata = encryptMetadata(c, d.Metadata)
+	d.Folder = c.EncryptString(d.Folder)
+	d.Tags = encryptTags(c, d.Tags)
+	return d, nil
+}
+
+// openCredential decrypts Credential payload
+func (s *Store) openCredential(d models.Credential) (models.Credential, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	if err := decryptStrings(c, &d.Name, &d.Login, &d.Password, &d.Folder); err != nil {
+		return d, err
+	}
+	if d.Tags, err = decryptTags(c, d.Tags); err != nil {
+		return d, err
+	}
+	d.Metadata, err = decryptMetadata(c, d.Metadata)
+	return d, err
+}
+
+// sealFile encrypts File info. File data is encrypted as a stream on upload.
+func (s *Store) sealFile(d models.File) (models.File, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	d.Name = c.EncryptString(d.Name)
+	d.Filename = c.EncryptString(d.Filename)
+	d.Metadata = encryptMetadata(c, d.Metadata)
+	d.Folder = c.EncryptString(d.Folder)
+	d.Tags = encryptTags(c, d.Tags)
+	return d, nil
+}
+
+// openFile decrypts File info. Size is converted to plaintext file size.
+func (s *Store) openFile(d models.File) (models.File, error) {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return d, err
+	}
+	encrypted := crypt.IsEncrypted(d.Filename)
+	if err := decryptStrings(c, &d.Name, &d.Filename, &d.Folder); err != nil {
+		return d, err
+	}
+	if encrypted {
+		d.Size = crypt.PlainSize(d.Size)
+	}
+	if d.Tags, err = decryptTags(c, d.Tags); err != nil {
+		return d, err
+	}
+	d.Metadata, err = decryptMetadata(c, d.Metadata)
+	return d, err
+}
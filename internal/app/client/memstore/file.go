@@ -1,8 +1,10 @@
 package memstore
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 
@@ -22,6 +24,9 @@ func (s *Store) GetFilesList() []*models.File {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	for _, v := range s.files {
+		if v.State == models.StateTrashed {
+			continue
+		}
 		list = append(list, v)
 	}
 	sort.Slice(list, func(i, j int) bool {
@@ -32,56 +37,125 @@ func (s *Store) GetFilesList() []*models.File {
 
 // AddFile stores File to server
 func (s *Store) AddFile(d models.File, filename string) error {
-	f, err := os.Open(filename)
-	if err != nil {
-		return errors.New("failed to open file")
+	if err := s.checkOnline(); err != nil {
+		return err
 	}
-	defer func() { _ = f.Close() }()
-	st, err := os.Stat(filename)
-	if err != nil {
-		return errors.New("failed to stat file")
-	}
-	d.Size = st.Size()
-	d.Filename = st.Name()
-	return s.checkAuthErr(s.server.AddFile(d, f))
+	d.Id = ""
+	return s.uploadFile(d, filename)
 }
 
-// GetFile downloads File from server
+// GetFile downloads File from server and decrypts it
 func (s *Store) GetFile(documentId string, path string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	vaultId := s.docVaultId(documentId)
+	c, err := s.docCipher(vaultId)
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	plain := s.plain[documentId]
+	s.mu.RUnlock()
 	f, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("unable to open file for writing: %w", err)
 	}
 	defer func() { _ = f.Close() }()
-	_, err = s.server.GetFile(documentId, f)
+	var w io.WriteCloser = nopWriteCloser{f}
+	if !plain {
+		w = c.NewWriter(f)
+	}
+	_, err = s.server.GetFile(documentId, vaultId, w)
 	if err != nil {
 		return s.checkAuthErr(err)
 	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("file decryption failed: %w", err)
+	}
 	return nil
 }
 
-// UpdateFile Updates File on server
-func (s *Store) UpdateFile(d models.File, filename string) error {
+// uploadFile uploads file data, document file name is set to the file name
+func (s *Store) uploadFile(d models.File, filename string) error {
 	f, err := os.Open(filename)
 	if err != nil {
 		return errors.New("failed to open file")
 	}
 	defer func() { _ = f.Close() }()
-	st, err := os.Stat(filename)
+	st, err := f.Stat()
 	if err != nil {
 		return errors.New("failed to stat file")
 	}
-	d.Size = st.Size()
 	d.Filename = st.Name()
-	return s.checkAuthErr(s.server.UpdateFile(d, f))
+	return s.uploadData(d, f)
+}
+
+// uploadData encrypts file data to temporary spool file and uploads it to server.
+// Spool keeps encrypted data unchanged, so interrupted upload may be resumed.
+func (s *Store) uploadData(d models.File, r io.Reader) error {
+	c, err := s.docCipher(d.VaultId)
+	if err != nil {
+		return err
+	}
+	if d, err = s.sealFile(d); err != nil {
+		return err
+	}
+	er, err := c.NewReader(r)
+	if err != nil {
+		return err
+	}
+	spool, err := os.CreateTemp("", "pwkeeper-upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create upload spool: %w", err)
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+	hash := sha256.New()
+	d.Size, err = io.Copy(io.MultiWriter(spool, hash), er)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt file: %w", err)
+	}
+	d.Sha265 = fmt.Sprintf("%x", hash.Sum(nil))
+	return s.checkAuthErr(s.server.UploadFile(d, spool))
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// UpdateFile Updates File on server
+func (s *Store) UpdateFile(d models.File, filename string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	return s.uploadFile(d, filename)
 }
 
 // DeleteFile deletes File on server
 func (s *Store) DeleteFile(d models.File) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealFile(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.DeleteFile(d))
 }
 
 // UpdateFileInfo updates FileInfo on server
 func (s *Store) UpdateFileInfo(d models.File) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealFile(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.UpdateFileInfo(d))
 }
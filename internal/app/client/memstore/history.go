@@ -0,0 +1,49 @@
+package memstore
+
+import (
+	"log"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// GetHistory returns decrypted previous revisions of the document, the newest first.
+// Revisions, that can't be decrypted, are skipped.
+func (s *Store) GetHistory(documentId string) ([]interface{}, error) {
+	if err := s.checkOnline(); err != nil {
+		return nil, err
+	}
+	revisions, err := s.server.GetHistory(documentId, s.docVaultId(documentId))
+	if err != nil {
+		return nil, s.checkAuthErr(err)
+	}
+	res := make([]interface{}, 0, len(revisions))
+	for _, rev := range revisions {
+		var doc interface{}
+		switch d := rev.(type) {
+		case models.Note:
+			doc, err = s.openNote(d)
+		case models.Card:
+			doc, err = s.openCard(d)
+		case models.Credential:
+			doc, err = s.openCredential(d)
+		case models.File:
+			doc, err = s.openFile(d)
+		default:
+			continue
+		}
+		if err != nil {
+			log.Printf("unable to decrypt revision: %s", err.Error())
+			continue
+		}
+		res = append(res, doc)
+	}
+	return res, nil
+}
+
+// RestoreRevision restores document revision on server
+func (s *Store) RestoreRevision(documentId string, serial int64) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	return s.checkAuthErr(s.server.RestoreRevision(documentId, s.docVaultId(documentId), serial))
+}
@@ -0,0 +1,72 @@
+package memstore
+
+import (
+	"yap-pwkeeper/internal/app/client/importer"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// ImportPreview is a dry-run result of import
+type ImportPreview struct {
+	Docs       []interface{} // documents to be added
+	Duplicates []interface{} // documents, which already exist in the vault
+	Skipped    []string      // entries, which can't be imported
+}
+
+// PreviewImport parses export of other password manager and finds documents, which
+// already exist in target vault (empty vaultId is personal documents).
+// Nothing is sent to server.
+func (s *Store) PreviewImport(path, format, vaultId string) (ImportPreview, error) {
+	res, err := importer.ParseFile(path, format)
+	if err != nil {
+		return ImportPreview{}, err
+	}
+	var existing []interface{}
+	s.mu.RLock()
+	for _, d := range s.notes {
+		if d.VaultId == vaultId {
+			existing = append(existing, d)
+		}
+	}
+	for _, d := range s.cards {
+		if d.VaultId == vaultId {
+			existing = append(existing, d)
+		}
+	}
+	for _, d := range s.credentials {
+		if d.VaultId == vaultId {
+			existing = append(existing, d)
+		}
+	}
+	s.mu.RUnlock()
+	docs, duplicates := importer.Split(res.Docs, existing)
+	return ImportPreview{Docs: docs, Duplicates: duplicates, Skipped: res.Skipped}, nil
+}
+
+// Import adds previewed documents to vault one by one. Import stops on the first
+// failure, number of added documents is returned. Documents received from server
+// are found as duplicates by the next preview, so failed import may be repeated.
+func (s *Store) Import(docs []interface{}, vaultId string) (int, error) {
+	if err := s.checkOnline(); err != nil {
+		return 0, err
+	}
+	for i, doc := range docs {
+		var err error
+		switch d := doc.(type) {
+		case models.Credential:
+			d.VaultId = vaultId
+			err = s.AddCredential(d)
+		case models.Card:
+			d.VaultId = vaultId
+			err = s.AddCard(d)
+		case models.Note:
+			d.VaultId = vaultId
+			err = s.AddNote(d)
+		default:
+			err = ErrBadDocument
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(docs), nil
+}
@@ -1,29 +1,62 @@
 // Package memstore is a local documents cache. Cache is fully verified by server.
 // No local changes are allowed. All changes are sent directly to server and are applied
 // locally on receiving as updates from server side.
-// Update method should be used to get server updates.
+// Update method should be used to get server updates, or Watch to receive them live.
 // Documents in local storage are available until server authorisation exists.
 // Any unauthorised request leads to empty local storage and requires new authentication.
+// Documents payload is encrypted with user vault key before it is sent to server
+// and decrypted when update is received, so server never sees plaintext data.
+// Documents, saved before encryption was introduced, are re-encrypted after sync,
+// then server records that user is migrated, and plain text is rejected since.
+// Documents of shared vaults are encrypted with the shared vault key, which is
+// sealed for each vault member with the member public key.
+// Optional on-disk cache keeps documents between application runs: updates are
+// requested starting from cached serial, and cached documents are available
+// read-only when server is unreachable.
 package memstore
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/singleflight"
 
+	"yap-pwkeeper/internal/app/client/filecache"
 	"yap-pwkeeper/internal/app/client/grpccli"
+	"yap-pwkeeper/internal/pkg/crypt"
 	"yap-pwkeeper/internal/pkg/models"
 )
 
 // DocServer is interface that implements server client
 type DocServer interface {
 	Register(login, password string) error
-	Login(login, password string) error
+	Login(login, password, code string) error
+	GetVaultKey() ([]byte, error)
+	SetVaultKey(key []byte) error
+	GetKeyPair() (models.KeyPair, error)
+	SetKeyPair(keys models.KeyPair) error
+	GetPublicKey(login string) ([]byte, error)
+	EnrollTotp() (models.TotpEnrollment, error)
+	ConfirmTotp(code string) error
+	DisableTotp(code string) error
+	ListSessions() ([]models.Session, error)
+	RevokeSession(sessionId string) error
+	Logout()
+	ChangePassword(oldPassword, newPassword string, vaultKey []byte) error
+	DeleteAccount(password string) error
+	GetPasswordPolicy() (models.PasswordPolicy, error)
+	GetBreachedRange(prefix string) ([]string, error)
+	GetMigrated() (bool, error)
+	SetMigrated() error
+	ExportAll(w io.Writer) error
 
 	GetUpdateStream(serial int64, chData chan interface{}, chErr chan error)
+	Subscribe(ctx context.Context, serial int64, chData chan interface{}, chErr chan error)
 
 	AddNote(note models.Note) error
 	UpdateNote(d models.Note) error
@@ -37,16 +70,38 @@ type DocServer interface {
 	UpdateCredential(d models.Credential) error
 	DeleteCredential(d models.Credential) error
 
-	GetFile(documentId string, w io.Writer) (models.File, error)
-	AddFile(d models.File, r io.Reader) error
+	GetFile(documentId, vaultId string, w io.Writer) (models.File, error)
+	UploadFile(d models.File, r io.ReadSeeker) error
 	UpdateFileInfo(d models.File) error
-	UpdateFile(d models.File, r io.Reader) error
 	DeleteFile(d models.File) error
+
+	GetHistory(documentId, vaultId string) ([]interface{}, error)
+	RestoreRevision(documentId, vaultId string, serial int64) error
+	Restore(kind, documentId, vaultId string) error
+
+	CreateVault(name string, key []byte) (string, error)
+	ListVaults() ([]models.Vault, error)
+	RenameVault(vaultId, name string) error
+	DeleteVault(vaultId string) error
+	AddVaultMember(vaultId, login, role string, key []byte) error
+	UpdateVaultMember(vaultId, userId, role string) error
+	RemoveVaultMember(vaultId, userId string) error
+
+	CreateShare(kind, documentId, vaultId string, data []byte, ttl time.Duration, views int, passphrase string) (string, time.Time, error)
+	OpenShare(shareId, passphrase string) (grpccli.SharedDocument, error)
 }
 
 var (
 	// ErrAuthFailed notifies that server authorisation is lost and no data may be accessed
 	ErrAuthFailed = errors.New("authorization failed, You need to login again")
+	// ErrOffline notifies that store works with cached data only, no server requests are possible
+	ErrOffline = errors.New("offline mode, server is not available")
+	// ErrBadDocument is returned for unknown document type
+	ErrBadDocument = errors.New("unknown document type")
+	// ErrTotpRequired notifies that login requires one-time code
+	ErrTotpRequired = grpccli.ErrTotpRequired
+	// ErrBadPassword notifies that current password does not match
+	ErrBadPassword = grpccli.ErrBadPassword
 )
 
 type Store struct {
@@ -54,22 +109,44 @@ type Store struct {
 	cards       map[string]*models.Card
 	credentials map[string]*models.Credential
 	files       map[string]*models.File
+	plain       map[string]bool // documents saved before encryption was introduced, file data is not encrypted too
 	serial      int64
+	cipher      *crypt.Cipher
+	vaultKey    []byte         // wrapped vault key
+	keys        models.KeyPair // user key pair with opened private key
+	vaults      map[string]*vault
+	login       string
+	migrated    bool // all user documents are encrypted, plain text is rejected
+	offline     bool
+	live        bool       // live subscription is synchronized
+	syncMu      sync.Mutex // serializes updates of Update and Watch
+	migrateMu   sync.Mutex // serializes re-encryption of documents, saved before encryption
 	mu          sync.RWMutex
 	server      DocServer
+	cache       *filecache.Cache
 	updateGroup *singleflight.Group
 }
 
 // New is a storage constructor
-func New(server DocServer) *Store {
+func New(server DocServer, options ...func(s *Store)) *Store {
 	store := &Store{
 		server:      server,
 		updateGroup: new(singleflight.Group),
 	}
+	for _, opt := range options {
+		opt(store)
+	}
 	store.bootstrap()
 	return store
 }
 
+// WithCache enables on-disk documents cache
+func WithCache(cache *filecache.Cache) func(s *Store) {
+	return func(s *Store) {
+		s.cache = cache
+	}
+}
+
 // bootstrap creates new empty storage
 func (s *Store) bootstrap() {
 	s.mu.Lock()
@@ -78,23 +155,131 @@ func (s *Store) bootstrap() {
 	s.cards = make(map[string]*models.Card)
 	s.credentials = make(map[string]*models.Credential)
 	s.files = make(map[string]*models.File)
+	s.plain = make(map[string]bool)
+	s.vaults = make(map[string]*vault)
 	s.serial = -1
+	s.cipher = nil
+	s.vaultKey = nil
+	s.keys = models.KeyPair{}
+	s.login = ""
+	s.migrated = false
+	s.offline = false
+	s.live = false
 }
 
-// Register registers new server user
+// Register registers new server user. Master password is checked against server
+// password policy, server gets only auth value, derived from it.
 func (s *Store) Register(login, password string) error {
-	if err := s.server.Register(login, password); err != nil {
+	if err := s.checkPassword(login, password); err != nil {
+		return fmt.Errorf("registraition failed: %w", err)
+	}
+	mk := crypt.DeriveMasterKeys(login, password)
+	if err := s.server.Register(login, mk.Auth); err != nil {
 		return fmt.Errorf("registraition failed: %w", err)
 	}
-	return s.Login(login, password)
+	return s.Login(login, password, "")
 }
 
-// Login creates new server authorization session
-func (s *Store) Login(login, password string) error {
-	if err := s.server.Login(login, password); err != nil {
+// Login creates new server authorization session and unlocks user vault.
+// Code is required, if user enabled two-factor authentication.
+// If server is not available, but cache exists, store is opened in offline mode.
+// Server gets auth value, derived from master password. Accounts, created before
+// master keys were introduced, are logged in with master password and migrated,
+// only when server reports such account.
+func (s *Store) Login(login, password, code string) error {
+	mk := crypt.DeriveMasterKeys(login, password)
+	err := s.server.Login(login, mk.Auth, code)
+	legacy := errors.Is(err, grpccli.ErrLegacyAccount)
+	if legacy {
+		// server has master password hash of the account and requires it once for migration
+		err = s.server.Login(login, password, code)
+	}
+	if err != nil {
+		if errors.Is(err, grpccli.ErrUnavailable) && s.cache != nil {
+			if offErr := s.loginOffline(login, password, mk); offErr == nil {
+				return nil
+			}
+		}
 		return fmt.Errorf("login failed: %w", err)
 	}
+	c, wrapped, err := s.unlockVault(mk, password)
+	if err != nil {
+		// session is useless without vault key, it should not stay open on server
+		s.server.Logout()
+		return s.checkAuthErr(err)
+	}
+	if legacy {
+		if rewrapped, err := s.migrateAccount(mk, password, wrapped); err != nil {
+			// account keeps working with master password, migration is retried on next login
+			log.Printf("unable to migrate account to master keys: %s", s.checkAuthErr(err).Error())
+		} else {
+			wrapped = rewrapped
+		}
+	}
+	keys, err := s.loadKeyPair(c)
+	if err != nil {
+		// shared vaults are not available, user documents are
+		log.Printf("unable to load key pair: %s", s.checkAuthErr(err).Error())
+	}
+	migrated, err := s.server.GetMigrated()
+	if err != nil {
+		// documents are opened as not migrated, plain text is accepted
+		log.Printf("unable to get migration state: %s", s.checkAuthErr(err).Error())
+	}
+	s.bootstrap()
+	s.mu.Lock()
+	s.cipher = c
+	s.vaultKey = wrapped
+	s.keys = keys
+	s.login = login
+	s.migrated = migrated
+	s.mu.Unlock()
+	if migrated {
+		// until migration, all documents are requested from server to find not encrypted ones
+		s.loadCache()
+	}
+	return nil
+}
+
+// loginOffline opens cached documents, using password to decrypt vault key
+func (s *Store) loginOffline(login, password string, mk crypt.MasterKeys) error {
+	wrapped, err := s.cache.VaultKey(login)
+	if err != nil {
+		return err
+	}
+	c, err := openVaultKey(wrapped, mk, password)
+	if err != nil {
+		return err
+	}
 	s.bootstrap()
+	s.mu.Lock()
+	s.cipher = c
+	s.vaultKey = wrapped
+	s.login = login
+	s.mu.Unlock()
+	if err := s.loadCache(); err != nil {
+		s.bootstrap()
+		return err
+	}
+	s.mu.Lock()
+	s.offline = true
+	s.mu.Unlock()
+	log.Println("working offline with cached data")
+	return nil
+}
+
+// IsOffline shows whether store works with cached data only
+func (s *Store) IsOffline() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.offline
+}
+
+// checkOnline returns ErrOffline in offline mode
+func (s *Store) checkOnline() error {
+	if s.IsOffline() {
+		return ErrOffline
+	}
 	return nil
 }
 
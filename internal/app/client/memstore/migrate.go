@@ -0,0 +1,120 @@
+package memstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// IsUnencrypted shows whether document was saved before encryption was introduced
+// and is not re-encrypted yet
+func (s *Store) IsUnencrypted(documentId string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.plain[documentId]
+}
+
+// migrate re-encrypts user documents, saved before encryption was introduced.
+// Trashed documents can't be changed: they are re-encrypted after restore, or
+// are purged with trash. When no such documents are left, server removes plain
+// text revisions from history and records that user is migrated, and documents
+// without encryption are rejected since.
+// It is run after store is synchronized with server.
+func (s *Store) migrate() {
+	if !s.migrateMu.TryLock() {
+		return
+	}
+	defer s.migrateMu.Unlock()
+	s.mu.RLock()
+	done := s.migrated || s.offline || s.cipher == nil
+	s.mu.RUnlock()
+	if done {
+		return
+	}
+	docs, trashed := s.plainDocs()
+	left := trashed
+	for _, doc := range docs {
+		var err error
+		switch d := doc.(type) {
+		case models.Note:
+			err = s.UpdateNote(d)
+		case models.Card:
+			err = s.UpdateCard(d)
+		case models.Credential:
+			err = s.UpdateCredential(d)
+		case models.File:
+			err = s.reencryptFile(d)
+		}
+		if errors.Is(err, ErrAuthFailed) {
+			return
+		}
+		if err != nil {
+			log.Printf("unable to encrypt document: %s", err.Error())
+			left++
+		}
+	}
+	if left > 0 {
+		log.Printf("%d documents are not encrypted yet", left)
+		return
+	}
+	if err := s.server.SetMigrated(); err != nil {
+		log.Printf("unable to save migration state: %s", s.checkAuthErr(err).Error())
+		return
+	}
+	s.mu.Lock()
+	s.migrated = true
+	s.mu.Unlock()
+	log.Printf("%d documents are encrypted, migration is finished", len(docs))
+}
+
+// plainDocs returns user documents, saved before encryption was introduced,
+// and number of such documents in trash
+func (s *Store) plainDocs() ([]interface{}, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var docs []interface{}
+	trashed := 0
+	add := func(doc interface{}, state string) {
+		if state == models.StateTrashed {
+			trashed++
+			return
+		}
+		docs = append(docs, doc)
+	}
+	for id := range s.plain {
+		switch {
+		case s.notes[id] != nil && s.notes[id].VaultId == "":
+			add(*s.notes[id], s.notes[id].State)
+		case s.cards[id] != nil && s.cards[id].VaultId == "":
+			add(*s.cards[id], s.cards[id].State)
+		case s.credentials[id] != nil && s.credentials[id].VaultId == "":
+			add(*s.credentials[id], s.credentials[id].State)
+		case s.files[id] != nil && s.files[id].VaultId == "":
+			add(*s.files[id], s.files[id].State)
+		}
+	}
+	return docs, trashed
+}
+
+// reencryptFile downloads not encrypted file data and uploads it encrypted with file info
+func (s *Store) reencryptFile(d models.File) error {
+	spool, err := os.CreateTemp("", "pwkeeper-migrate-*")
+	if err != nil {
+		return fmt.Errorf("failed to create download spool: %w", err)
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+	if _, err := s.server.GetFile(d.Id, d.VaultId, spool); err != nil {
+		return s.checkAuthErr(err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return s.uploadData(d, spool)
+}
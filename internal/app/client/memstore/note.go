@@ -19,6 +19,9 @@ func (s *Store) GetNotesList() []*models.Note {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	for _, v := range s.notes {
+		if v.State == models.StateTrashed {
+			continue
+		}
 		list = append(list, v)
 	}
 	sort.Slice(list, func(i, j int) bool {
@@ -29,15 +32,36 @@ func (s *Store) GetNotesList() []*models.Note {
 
 // AddNote saves new Note to server
 func (s *Store) AddNote(d models.Note) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealNote(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.AddNote(d))
 }
 
 // UpdateNote updates Note on server
 func (s *Store) UpdateNote(d models.Note) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealNote(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.UpdateNote(d))
 }
 
 // DeleteNote deletes Note on server
 func (s *Store) DeleteNote(d models.Note) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	d, err := s.sealNote(d)
+	if err != nil {
+		return err
+	}
 	return s.checkAuthErr(s.server.DeleteNote(d))
 }
@@ -0,0 +1,28 @@
+package memstore
+
+import "yap-pwkeeper/internal/pkg/models"
+
+// ListSessions returns active server sessions of the user
+func (s *Store) ListSessions() ([]models.Session, error) {
+	if err := s.checkOnline(); err != nil {
+		return nil, err
+	}
+	sessions, err := s.server.ListSessions()
+	return sessions, s.checkAuthErr(err)
+}
+
+// RevokeSession finishes other server session of the user, e.g. on lost device
+func (s *Store) RevokeSession(sessionId string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	return s.checkAuthErr(s.server.RevokeSession(sessionId))
+}
+
+// Logout finishes server session and clears storage
+func (s *Store) Logout() {
+	if !s.IsOffline() {
+		s.server.Logout()
+	}
+	s.bootstrap()
+}
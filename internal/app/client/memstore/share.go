@@ -0,0 +1,105 @@
+package memstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// ErrBadShareToken is returned for malformed share token
+var ErrBadShareToken = errors.New("malformed share token")
+
+// ShareDocument creates one-time share link of the document. Document snapshot is
+// encrypted with new share key, which is never sent to server. Returned token is
+// share id with the share key and should be passed to recipient.
+// Zero ttl and views mean server defaults.
+func (s *Store) ShareDocument(doc interface{}, ttl time.Duration, views int, passphrase string) (string, time.Time, error) {
+	if err := s.checkOnline(); err != nil {
+		return "", time.Time{}, err
+	}
+	var kind, id, vaultId string
+	switch d := doc.(type) {
+	case models.Note:
+		kind, id, vaultId = kindNote, d.Id, d.VaultId
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State = "", "", "", 0, ""
+		doc = d
+	case models.Card:
+		kind, id, vaultId = kindCard, d.Id, d.VaultId
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State = "", "", "", 0, ""
+		doc = d
+	case models.Credential:
+		kind, id, vaultId = kindCredential, d.Id, d.VaultId
+		d.Id, d.UserId, d.VaultId, d.Serial, d.State = "", "", "", 0, ""
+		doc = d
+	default:
+		return "", time.Time{}, ErrBadDocument
+	}
+	plain, err := json.Marshal(doc)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	key, err := crypt.NewKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	c, err := crypt.New(key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	shareId, expires, err := s.server.CreateShare(kind, id, vaultId, c.Seal(plain), ttl, views, passphrase)
+	if err != nil {
+		return "", time.Time{}, s.checkAuthErr(err)
+	}
+	return shareId + "." + base64.RawURLEncoding.EncodeToString(key), expires, nil
+}
+
+// OpenShare returns shared document by share token and uses one of its views.
+// It does not require login. Returns number of views left.
+func (s *Store) OpenShare(token, passphrase string) (interface{}, int, error) {
+	shareId, encodedKey, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || shareId == "" {
+		return nil, 0, ErrBadShareToken
+	}
+	key, err := base64.RawURLEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, 0, ErrBadShareToken
+	}
+	c, err := crypt.New(key)
+	if err != nil {
+		return nil, 0, ErrBadShareToken
+	}
+	shared, err := s.server.OpenShare(shareId, passphrase)
+	if err != nil {
+		return nil, 0, err
+	}
+	plain, err := c.Open(shared.Data)
+	if err != nil {
+		return nil, 0, err
+	}
+	var doc interface{}
+	switch shared.Kind {
+	case kindNote:
+		var d models.Note
+		err = json.Unmarshal(plain, &d)
+		doc = d
+	case kindCard:
+		var d models.Card
+		err = json.Unmarshal(plain, &d)
+		doc = d
+	case kindCredential:
+		var d models.Credential
+		err = json.Unmarshal(plain, &d)
+		doc = d
+	default:
+		return nil, 0, ErrBadDocument
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return doc, shared.ViewsLeft, nil
+}
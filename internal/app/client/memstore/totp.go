@@ -0,0 +1,29 @@
+package memstore
+
+import "yap-pwkeeper/internal/pkg/models"
+
+// EnrollTotp requests new second factor. Secret should be added to authenticator
+// application, and then confirmed with a code by ConfirmTotp.
+func (s *Store) EnrollTotp() (models.TotpEnrollment, error) {
+	if err := s.checkOnline(); err != nil {
+		return models.TotpEnrollment{}, err
+	}
+	enrollment, err := s.server.EnrollTotp()
+	return enrollment, s.checkAuthErr(err)
+}
+
+// ConfirmTotp enables enrolled second factor, it will be required on next login
+func (s *Store) ConfirmTotp(code string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	return s.checkAuthErr(s.server.ConfirmTotp(code))
+}
+
+// DisableTotp removes second factor, code from authenticator or recovery code is required
+func (s *Store) DisableTotp(code string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	return s.checkAuthErr(s.server.DisableTotp(code))
+}
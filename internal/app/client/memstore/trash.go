@@ -0,0 +1,90 @@
+package memstore
+
+import (
+	"sort"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// document kinds, as they are known by server
+const (
+	kindNote       = "note"
+	kindCard       = "card"
+	kindCredential = "credential"
+	kindFile       = "file"
+)
+
+// GetTrashList returns documents of any kind from trash, recently deleted first
+func (s *Store) GetTrashList() []interface{} {
+	type item struct {
+		doc       interface{}
+		trashedAt int64
+	}
+	var items []item
+	s.mu.RLock()
+	for _, v := range s.notes {
+		if v.State == models.StateTrashed {
+			items = append(items, item{*v, v.TrashedAt})
+		}
+	}
+	for _, v := range s.cards {
+		if v.State == models.StateTrashed {
+			items = append(items, item{*v, v.TrashedAt})
+		}
+	}
+	for _, v := range s.credentials {
+		if v.State == models.StateTrashed {
+			items = append(items, item{*v, v.TrashedAt})
+		}
+	}
+	for _, v := range s.files {
+		if v.State == models.StateTrashed {
+			items = append(items, item{*v, v.TrashedAt})
+		}
+	}
+	s.mu.RUnlock()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].trashedAt > items[j].trashedAt
+	})
+	list := make([]interface{}, len(items))
+	for i, v := range items {
+		list[i] = v.doc
+	}
+	return list
+}
+
+// RestoreFromTrash moves document back from trash on server
+func (s *Store) RestoreFromTrash(doc interface{}) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	var kind, id, vaultId string
+	switch d := doc.(type) {
+	case models.Note:
+		kind, id, vaultId = kindNote, d.Id, d.VaultId
+	case models.Card:
+		kind, id, vaultId = kindCard, d.Id, d.VaultId
+	case models.Credential:
+		kind, id, vaultId = kindCredential, d.Id, d.VaultId
+	case models.File:
+		kind, id, vaultId = kindFile, d.Id, d.VaultId
+	default:
+		return ErrBadDocument
+	}
+	return s.checkAuthErr(s.server.Restore(kind, id, vaultId))
+}
+
+// DeleteFromTrash deletes document from trash permanently
+func (s *Store) DeleteFromTrash(doc interface{}) error {
+	switch d := doc.(type) {
+	case models.Note:
+		return s.DeleteNote(d)
+	case models.Card:
+		return s.DeleteCard(d)
+	case models.Credential:
+		return s.DeleteCredential(d)
+	case models.File:
+		return s.DeleteFile(d)
+	}
+	return ErrBadDocument
+}
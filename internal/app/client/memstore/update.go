@@ -3,6 +3,7 @@ package memstore
 import (
 	"log"
 
+	"yap-pwkeeper/internal/pkg/crypt"
 	"yap-pwkeeper/internal/pkg/models"
 )
 
@@ -32,6 +33,18 @@ func (s *Store) getSerial() int64 {
 
 // update request updates from server and applies them to the store
 func (s *Store) update() error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	if s.isLive() {
+		// store is kept up to date by subscription
+		return nil
+	}
+	if err := s.loadVaults(); err != nil {
+		return err
+	}
 	chData := make(chan interface{})
 	chErr := make(chan error, 1)
 	serial := s.getSerial()
@@ -42,35 +55,93 @@ func (s *Store) update() error {
 		if !ok {
 			break
 		}
-		switch data.(type) {
-		case models.Note:
-			d := data.(models.Note)
-			s.placeNote(d)
-			serial = incSerial(serial, d.Serial)
-		case models.Credential:
-			d := data.(models.Credential)
-			s.placeCredential(d)
-			serial = incSerial(serial, d.Serial)
-		case models.Card:
-			d := data.(models.Card)
-			s.placeCard(d)
-			serial = incSerial(serial, d.Serial)
-		case models.File:
-			d := data.(models.File)
-			s.placeFile(d)
-			serial = incSerial(serial, d.Serial)
-		}
+		serial = incSerial(serial, s.apply(data))
 	}
 	err := <-chErr
 	if err == nil {
 		s.mu.Lock()
 		s.serial = serial
 		s.mu.Unlock()
+		s.saveCache()
+		go s.migrate()
 	}
 	log.Printf("Serial after update %d", serial)
 	return err
 }
 
+// apply decrypts update and places it to local storage. Returns update serial.
+// Documents, that can't be decrypted, are skipped.
+func (s *Store) apply(data interface{}) int64 {
+	if vaultId := updateVaultId(data); vaultId != "" && !s.hasVault(vaultId) {
+		// vault may be shared after vaults were loaded
+		if err := s.loadVaults(); err != nil {
+			log.Printf("unable to load vaults: %s", err.Error())
+		}
+	}
+	var err error
+	var serial int64
+	switch d := data.(type) {
+	case models.Note:
+		serial = d.Serial
+		plain := !crypt.IsEncrypted(d.Name)
+		if d, err = s.openNote(d); err == nil {
+			s.placeNote(d)
+			s.setPlain(d.Id, plain && d.State != models.StateDeleted)
+		}
+	case models.Credential:
+		serial = d.Serial
+		plain := !crypt.IsEncrypted(d.Name)
+		if d, err = s.openCredential(d); err == nil {
+			s.placeCredential(d)
+			s.setPlain(d.Id, plain && d.State != models.StateDeleted)
+		}
+	case models.Card:
+		serial = d.Serial
+		plain := !crypt.IsEncrypted(d.Name)
+		if d, err = s.openCard(d); err == nil {
+			s.placeCard(d)
+			s.setPlain(d.Id, plain && d.State != models.StateDeleted)
+		}
+	case models.File:
+		serial = d.Serial
+		plain := !crypt.IsEncrypted(d.Filename)
+		if d, err = s.openFile(d); err == nil {
+			s.placeFile(d)
+			s.setPlain(d.Id, plain && d.State != models.StateDeleted)
+		}
+	}
+	if err != nil {
+		log.Printf("unable to decrypt document: %s", err.Error())
+	}
+	return serial
+}
+
+// updateVaultId returns shared vault of the update, empty for user documents
+func updateVaultId(data interface{}) string {
+	switch d := data.(type) {
+	case models.Note:
+		return d.VaultId
+	case models.Credential:
+		return d.VaultId
+	case models.Card:
+		return d.VaultId
+	case models.File:
+		return d.VaultId
+	}
+	return ""
+}
+
+// setPlain marks document, saved before encryption was introduced
+func (s *Store) setPlain(documentId string, plain bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if plain {
+		s.plain[documentId] = true
+	} else {
+		delete(s.plain, documentId)
+	}
+}
+
 // placeNote updates or adds Note to local storage
 func (s *Store) placeNote(d models.Note) {
 	s.mu.Lock()
@@ -0,0 +1,347 @@
+package memstore
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"yap-pwkeeper/internal/app/client/grpccli"
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// ErrNoVault is returned for shared vault, which is not available to user
+var ErrNoVault = errors.New("shared vault is not available")
+
+// vault is a shared vault with opened vault key. Name is decrypted.
+// Cipher is nil for vault, which key can't be opened.
+type vault struct {
+	models.Vault
+	key    []byte
+	cipher *crypt.Cipher
+}
+
+// loadKeyPair gets user key pair from server and opens private key with vault cipher.
+// New key pair is generated and stored on server on the first login.
+func (s *Store) loadKeyPair(c *crypt.Cipher) (models.KeyPair, error) {
+	keys, err := s.server.GetKeyPair()
+	if errors.Is(err, grpccli.ErrNoKeyPair) {
+		keys, err = s.newKeyPair(c)
+	}
+	if err != nil {
+		return models.KeyPair{}, fmt.Errorf("failed to get key pair: %w", err)
+	}
+	if keys.Private, err = c.Open(keys.Private); err != nil {
+		return models.KeyPair{}, fmt.Errorf("failed to open private key: %w", err)
+	}
+	return keys, nil
+}
+
+// newKeyPair generates new key pair and saves it on server.
+// If other client has already saved key pair, that key pair is used.
+func (s *Store) newKeyPair(c *crypt.Cipher) (models.KeyPair, error) {
+	public, private, err := crypt.NewKeyPair()
+	if err != nil {
+		return models.KeyPair{}, err
+	}
+	keys := models.KeyPair{Public: public, Private: c.Seal(private)}
+	err = s.server.SetKeyPair(keys)
+	if errors.Is(err, grpccli.ErrKeyPairExists) {
+		return s.server.GetKeyPair()
+	}
+	return keys, err
+}
+
+// loadVaults gets shared vaults from server and opens their keys.
+// Documents of vaults, which are not available anymore, are removed from the store.
+func (s *Store) loadVaults() error {
+	list, err := s.server.ListVaults()
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	private, login, known := s.keys.Private, s.login, s.vaults
+	s.mu.RUnlock()
+	vaults := make(map[string]*vault, len(list))
+	for _, v := range list {
+		key, err := openMemberKey(v, login, private)
+		if k, ok := known[v.Id]; err != nil && ok && k.cipher != nil {
+			key, err = k.key, nil
+		}
+		if err == nil {
+			vaults[v.Id], err = newVault(v, key)
+		}
+		if err != nil {
+			// vault is kept known, so its documents do not cause reloads
+			log.Printf("unable to open vault %s: %s", v.Id, err.Error())
+			vaults[v.Id] = &vault{Vault: v}
+		}
+	}
+	s.mu.Lock()
+	s.vaults = vaults
+	s.dropVaultDocs()
+	s.mu.Unlock()
+	return nil
+}
+
+// openMemberKey opens vault key of user member with private key
+func openMemberKey(v models.Vault, login string, private []byte) ([]byte, error) {
+	if private == nil {
+		return nil, ErrLocked
+	}
+	for _, m := range v.Members {
+		if m.Login == login {
+			return crypt.OpenSealed(private, m.VaultKey)
+		}
+	}
+	return nil, ErrNoVault
+}
+
+// newVault creates vault cipher and decrypts vault name
+func newVault(v models.Vault, key []byte) (*vault, error) {
+	c, err := crypt.New(key)
+	if err != nil {
+		return nil, err
+	}
+	if v.Name, err = c.DecryptString(v.Name); err != nil {
+		return nil, err
+	}
+	return &vault{Vault: v, key: key, cipher: c}, nil
+}
+
+// dropVaultDocs removes documents of unavailable vaults. Should be called with lock held.
+func (s *Store) dropVaultDocs() {
+	for id, d := range s.notes {
+		if d.VaultId != "" && s.vaults[d.VaultId] == nil {
+			delete(s.notes, id)
+		}
+	}
+	for id, d := range s.cards {
+		if d.VaultId != "" && s.vaults[d.VaultId] == nil {
+			delete(s.cards, id)
+		}
+	}
+	for id, d := range s.credentials {
+		if d.VaultId != "" && s.vaults[d.VaultId] == nil {
+			delete(s.credentials, id)
+		}
+	}
+	for id, d := range s.files {
+		if d.VaultId != "" && s.vaults[d.VaultId] == nil {
+			delete(s.files, id)
+		}
+	}
+}
+
+// hasVault checks whether shared vault is available
+func (s *Store) hasVault(vaultId string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.vaults[vaultId] != nil
+}
+
+// docCipher returns cipher of the document: shared vault cipher or user vault cipher
+func (s *Store) docCipher(vaultId string) (*crypt.Cipher, error) {
+	if vaultId == "" {
+		return s.getCipher()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.vaults[vaultId]
+	if !ok || v.cipher == nil {
+		return nil, ErrNoVault
+	}
+	return v.cipher, nil
+}
+
+// docVaultId returns shared vault of the stored document, empty for user documents
+func (s *Store) docVaultId(documentId string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	switch {
+	case s.notes[documentId] != nil:
+		return s.notes[documentId].VaultId
+	case s.cards[documentId] != nil:
+		return s.cards[documentId].VaultId
+	case s.credentials[documentId] != nil:
+		return s.credentials[documentId].VaultId
+	case s.files[documentId] != nil:
+		return s.files[documentId].VaultId
+	}
+	return ""
+}
+
+// GetVaults returns shared vaults of user sorted by name
+func (s *Store) GetVaults() []models.Vault {
+	s.mu.RLock()
+	list := make([]models.Vault, 0, len(s.vaults))
+	for _, v := range s.vaults {
+		if v.cipher != nil {
+			list = append(list, v.Vault)
+		}
+	}
+	s.mu.RUnlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// GetVault returns shared vault
+func (s *Store) GetVault(vaultId string) (models.Vault, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.vaults[vaultId]
+	if !ok || v.cipher == nil {
+		return models.Vault{}, false
+	}
+	return v.Vault, true
+}
+
+// VaultRole returns role of user in shared vault, empty if vault is not available
+func (s *Store) VaultRole(vaultId string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if m, ok := s.ownMember(vaultId); ok {
+		return m.Role
+	}
+	return ""
+}
+
+// ownMember returns user member of shared vault. Should be called with lock held.
+func (s *Store) ownMember(vaultId string) (models.Member, bool) {
+	v, ok := s.vaults[vaultId]
+	if !ok {
+		return models.Member{}, false
+	}
+	for _, m := range v.Members {
+		if m.Login == s.login {
+			return m, true
+		}
+	}
+	return models.Member{}, false
+}
+
+// CreateVault creates shared vault with new vault key. Key is sealed with user public key.
+func (s *Store) CreateVault(name string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	public := s.keys.Public
+	s.mu.RUnlock()
+	if public == nil {
+		return ErrLocked
+	}
+	key, err := crypt.NewKey()
+	if err != nil {
+		return err
+	}
+	c, err := crypt.New(key)
+	if err != nil {
+		return err
+	}
+	sealed, err := crypt.SealTo(public, key)
+	if err != nil {
+		return err
+	}
+	if _, err := s.server.CreateVault(c.EncryptString(name), sealed); err != nil {
+		return s.checkAuthErr(err)
+	}
+	return s.reloadVaults()
+}
+
+// RenameVault changes name of shared vault
+func (s *Store) RenameVault(vaultId, name string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	c, err := s.docCipher(vaultId)
+	if err != nil {
+		return err
+	}
+	if err := s.server.RenameVault(vaultId, c.EncryptString(name)); err != nil {
+		return s.checkAuthErr(err)
+	}
+	return s.reloadVaults()
+}
+
+// DeleteVault deletes shared vault with all its documents
+func (s *Store) DeleteVault(vaultId string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	if err := s.server.DeleteVault(vaultId); err != nil {
+		return s.checkAuthErr(err)
+	}
+	return s.reloadVaults()
+}
+
+// AddVaultMember shares vault with user. Vault key is sealed with public key of the user.
+func (s *Store) AddVaultMember(vaultId, login, role string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	v, ok := s.vaults[vaultId]
+	s.mu.RUnlock()
+	if !ok || v.cipher == nil {
+		return ErrNoVault
+	}
+	public, err := s.server.GetPublicKey(login)
+	if err != nil {
+		return s.checkAuthErr(err)
+	}
+	sealed, err := crypt.SealTo(public, v.key)
+	if err != nil {
+		return err
+	}
+	if err := s.server.AddVaultMember(vaultId, login, role, sealed); err != nil {
+		return s.checkAuthErr(err)
+	}
+	return s.reloadVaults()
+}
+
+// SetVaultMemberRole changes role of vault member
+func (s *Store) SetVaultMemberRole(vaultId, userId, role string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	if err := s.server.UpdateVaultMember(vaultId, userId, role); err != nil {
+		return s.checkAuthErr(err)
+	}
+	return s.reloadVaults()
+}
+
+// RemoveVaultMember removes member from shared vault.
+// Removed member keeps vault key, vault key is not rotated.
+func (s *Store) RemoveVaultMember(vaultId, userId string) error {
+	if err := s.checkOnline(); err != nil {
+		return err
+	}
+	if err := s.server.RemoveVaultMember(vaultId, userId); err != nil {
+		return s.checkAuthErr(err)
+	}
+	return s.reloadVaults()
+}
+
+// LeaveVault removes user from shared vault members
+func (s *Store) LeaveVault(vaultId string) error {
+	s.mu.RLock()
+	m, ok := s.ownMember(vaultId)
+	s.mu.RUnlock()
+	if !ok {
+		return ErrNoVault
+	}
+	return s.RemoveVaultMember(vaultId, m.UserId)
+}
+
+// reloadVaults loads changed vaults and saves them to cache
+func (s *Store) reloadVaults() error {
+	if err := s.loadVaults(); err != nil {
+		return s.checkAuthErr(err)
+	}
+	s.saveCache()
+	return nil
+}
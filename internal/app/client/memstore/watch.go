@@ -0,0 +1,145 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"yap-pwkeeper/internal/app/client/grpccli"
+)
+
+// watchRetryInterval is a delay before subscription reconnect
+const watchRetryInterval = 5 * time.Second
+
+// errVaultsChanged finishes subscription, when shared vaults of user are changed
+var errVaultsChanged = errors.New("shared vaults changed")
+
+// Watch keeps live subscription to server updates, reconnecting on failures,
+// until ctx is done or authorisation is lost. Notify is called with nil error
+// after updates are applied and with error when subscription fails.
+// While subscription is synchronized, Update does not request server.
+func (s *Store) Watch(ctx context.Context, notify func(err error)) {
+	for {
+		if s.IsOffline() {
+			return
+		}
+		err := s.watch(ctx, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, errVaultsChanged) {
+			// subscription is finished by server, vaults are reloaded on resubscribe
+			continue
+		}
+		if err != nil {
+			err = s.checkAuthErr(err)
+			notify(err)
+			if errors.Is(err, ErrAuthFailed) {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// watch runs one subscription session
+func (s *Store) watch(ctx context.Context, notify func(err error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+
+	// stored updates are applied same way as by update: serial is saved only
+	// when all of them are received
+	s.syncMu.Lock()
+	if err := s.loadVaults(); err != nil {
+		s.syncMu.Unlock()
+		return err
+	}
+	serial := s.getSerial()
+	log.Printf("subscribe from serial %d", serial)
+	go s.server.Subscribe(ctx, serial, chData, chErr)
+	synced := false
+	for data := range chData {
+		if _, ok := data.(grpccli.SyncMark); ok {
+			synced = true
+			break
+		}
+		serial = incSerial(serial, s.apply(data))
+	}
+	if !synced {
+		// stream has finished before sync mark
+		s.syncMu.Unlock()
+		return <-chErr
+	}
+	s.mu.Lock()
+	s.serial = incSerial(s.serial, serial)
+	s.live = true
+	owners := s.ownerSerials(s.serial)
+	s.mu.Unlock()
+	s.syncMu.Unlock()
+	s.saveCache()
+	log.Printf("subscription synchronized at serial %d", serial)
+	notify(nil)
+	go s.migrate()
+
+	defer func() {
+		s.mu.Lock()
+		s.live = false
+		s.mu.Unlock()
+	}()
+	for data := range chData {
+		if _, ok := data.(grpccli.VaultsChanged); ok {
+			log.Println("shared vaults changed")
+			return errVaultsChanged
+		}
+		s.syncMu.Lock()
+		serial := s.apply(data)
+		owner := updateVaultId(data)
+		owners[owner] = incSerial(owners[owner], serial)
+		s.mu.Lock()
+		s.serial = minSerial(owners)
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		s.saveCache()
+		notify(nil)
+	}
+	return <-chErr
+}
+
+// ownerSerials returns live serials of documents owners: user and shared vaults.
+// Serials of different owners are committed under separate server reservations,
+// so their live updates may come out of order, and subscription is resumed from
+// the least serial of all owners, not to miss updates, which are still in flight.
+// Should be called with lock held.
+func (s *Store) ownerSerials(synced int64) map[string]int64 {
+	owners := map[string]int64{"": synced}
+	for vaultId := range s.vaults {
+		owners[vaultId] = synced
+	}
+	return owners
+}
+
+// minSerial returns the least serial of owners
+func minSerial(owners map[string]int64) int64 {
+	first := true
+	var least int64
+	for _, serial := range owners {
+		if first || serial < least {
+			least, first = serial, false
+		}
+	}
+	return least
+}
+
+// isLive shows whether store is synchronized by live subscription
+func (s *Store) isLive() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.live
+}
@@ -41,6 +41,7 @@ func (a *App) modalOk(text string) {
 
 // modalUnauthorized shows Unauthorized modal and returns to application start
 func (a *App) modalUnauthorized() {
+	a.endWatch()
 	m := tview.NewModal().
 		SetBackgroundColor(tcell.ColorRed).
 		SetText("Session authorisation failed!\nYou need to Login again.\nAll data is cleared.").
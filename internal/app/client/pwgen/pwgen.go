@@ -0,0 +1,169 @@
+// Package pwgen generates random passwords and diceware passphrases.
+// All randomness comes from crypto/rand, each character or word is chosen uniformly.
+package pwgen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	lower   = "abcdefghijklmnopqrstuvwxyz"
+	upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits  = "0123456789"
+	symbols = "!#$%&()*+,-./:;<=>?@[]^_{}~"
+	// similar are characters, which are easily confused with each other
+	similar = "Il1|oO0"
+
+	MaxLength = 128
+	MaxWords  = 20
+)
+
+var (
+	ErrNoClasses = errors.New("no character classes selected")
+	ErrLength    = errors.New("password length is out of range")
+	ErrWords     = errors.New("number of words is out of range")
+)
+
+// wordlist is EFF large wordlist for passphrases: 7776 words, one per five dice roll
+//
+//go:embed eff_large_wordlist.txt
+var wordlist []byte
+
+var words = sync.OnceValue(func() []string {
+	list := make([]string, 0, 7776)
+	s := bufio.NewScanner(bytes.NewReader(wordlist))
+	for s.Scan() {
+		// line is dice roll and word, separated by tab
+		if _, word, ok := strings.Cut(s.Text(), "\t"); ok {
+			list = append(list, word)
+		}
+	}
+	return list
+})
+
+// Options of random password
+type Options struct {
+	Length         int
+	Lower          bool
+	Upper          bool
+	Digits         bool
+	Symbols        bool
+	ExcludeSimilar bool // exclude look-alike characters, such as l, 1, O and 0
+}
+
+// DefaultOptions are strong password options
+var DefaultOptions = Options{Length: 20, Lower: true, Upper: true, Digits: true, Symbols: true, ExcludeSimilar: true}
+
+// PassphraseOptions of diceware passphrase
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool // capitalize first letter of each word
+}
+
+// DefaultPassphraseOptions are options of passphrase, which is strong enough for most cases
+var DefaultPassphraseOptions = PassphraseOptions{Words: 6, Separator: "-"}
+
+// Password generates random password, which has at least one character of each selected class.
+// Returns password and its entropy in bits.
+func Password(o Options) (string, float64, error) {
+	var classes []string
+	for _, c := range []struct {
+		enabled bool
+		chars   string
+	}{{o.Lower, lower}, {o.Upper, upper}, {o.Digits, digits}, {o.Symbols, symbols}} {
+		if !c.enabled {
+			continue
+		}
+		if o.ExcludeSimilar {
+			c.chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(similar, r) {
+					return -1
+				}
+				return r
+			}, c.chars)
+		}
+		classes = append(classes, c.chars)
+	}
+	if len(classes) == 0 {
+		return "", 0, ErrNoClasses
+	}
+	if o.Length < len(classes) || o.Length > MaxLength {
+		return "", 0, ErrLength
+	}
+	alphabet := strings.Join(classes, "")
+	password := make([]byte, o.Length)
+	for {
+		for i := range password {
+			n, err := randomInt(len(alphabet))
+			if err != nil {
+				return "", 0, err
+			}
+			password[i] = alphabet[n]
+		}
+		// rejection keeps characters uniform, password without some class is generated again
+		if hasAll(string(password), classes) {
+			break
+		}
+	}
+	return string(password), Entropy(len(alphabet), o.Length), nil
+}
+
+// Passphrase generates diceware passphrase of random words from EFF large wordlist.
+// Returns passphrase and its entropy in bits.
+func Passphrase(o PassphraseOptions) (string, float64, error) {
+	if o.Words < 1 || o.Words > MaxWords {
+		return "", 0, ErrWords
+	}
+	list := words()
+	chosen := make([]string, o.Words)
+	for i := range chosen {
+		n, err := randomInt(len(list))
+		if err != nil {
+			return "", 0, err
+		}
+		chosen[i] = list[n]
+		if o.Capitalize {
+			r := []rune(chosen[i])
+			r[0] = unicode.ToUpper(r[0])
+			chosen[i] = string(r)
+		}
+	}
+	return strings.Join(chosen, o.Separator), Entropy(len(list), o.Words), nil
+}
+
+// Entropy returns entropy in bits of n symbols, chosen uniformly from alphabet of size
+func Entropy(size int, n int) float64 {
+	if size < 2 || n < 1 {
+		return 0
+	}
+	return float64(n) * math.Log2(float64(size))
+}
+
+// randomInt returns uniform random number in [0, n)
+func randomInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
+
+// hasAll checks whether password has character of each class
+func hasAll(password string, classes []string) bool {
+	for _, chars := range classes {
+		if !strings.ContainsAny(password, chars) {
+			return false
+		}
+	}
+	return true
+}
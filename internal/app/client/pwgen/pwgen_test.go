@@ -0,0 +1,64 @@
+package pwgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassword(t *testing.T) {
+	t.Run("all classes", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			p, entropy, err := Password(Options{Length: 4, Lower: true, Upper: true, Digits: true, Symbols: true})
+			require.NoError(t, err)
+			require.Len(t, p, 4)
+			for _, chars := range []string{lower, upper, digits, symbols} {
+				require.True(t, strings.ContainsAny(p, chars), "password %q should have one of %q", p, chars)
+			}
+			require.InDelta(t, 25.90, entropy, 0.01, "4 characters of 89")
+		}
+	})
+
+	t.Run("exclude similar", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			p, _, err := Password(Options{Length: 64, Lower: true, Upper: true, Digits: true, ExcludeSimilar: true})
+			require.NoError(t, err)
+			require.False(t, strings.ContainsAny(p, similar), "password %q should not have look-alike characters", p)
+		}
+	})
+
+	t.Run("digits only", func(t *testing.T) {
+		p, entropy, err := Password(Options{Length: 6, Digits: true})
+		require.NoError(t, err)
+		assert.Len(t, strings.Trim(p, digits), 0)
+		assert.InDelta(t, 19.93, entropy, 0.01)
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		_, _, err := Password(Options{Length: 10})
+		assert.ErrorIs(t, err, ErrNoClasses)
+		_, _, err = Password(Options{Length: 2, Lower: true, Upper: true, Digits: true})
+		assert.ErrorIs(t, err, ErrLength, "length should fit all classes")
+		_, _, err = Password(Options{Length: MaxLength + 1, Lower: true})
+		assert.ErrorIs(t, err, ErrLength)
+	})
+}
+
+func TestPassphrase(t *testing.T) {
+	require.Len(t, words(), 7776, "wordlist should have word for each five dice roll")
+
+	p, entropy, err := Passphrase(PassphraseOptions{Words: 6, Separator: " ", Capitalize: true})
+	require.NoError(t, err)
+	chosen := strings.Split(p, " ")
+	require.Len(t, chosen, 6)
+	for _, w := range chosen {
+		assert.Equal(t, strings.ToUpper(w[:1]), w[:1], "word should be capitalized")
+		assert.Contains(t, words(), strings.ToLower(w[:1])+w[1:])
+	}
+	assert.InDelta(t, 77.55, entropy, 0.01)
+
+	_, _, err = Passphrase(PassphraseOptions{Words: 0})
+	assert.ErrorIs(t, err, ErrWords)
+}
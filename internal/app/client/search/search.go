@@ -0,0 +1,152 @@
+// Package search ranks documents by fuzzy match of query against their text fields.
+// Matching is case-insensitive, every query word should match at least one field.
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// scores of substring match
+	substringScore = 100
+	prefixBonus    = 50
+	wordStartBonus = 25
+	equalBonus     = 100
+	// scores of subsequence match, it always ranks below substring
+	charScore        = 1
+	consecutiveBonus = 5
+	charStartBonus   = 3
+	maxFuzzyScore    = substringScore - 1
+	// nameWeight multiplies score of document name match
+	nameWeight = 2
+)
+
+// Field is a searchable text of document
+type Field struct {
+	Name string // field label, shown in results
+	Text string
+}
+
+// Item is a searchable document
+type Item struct {
+	Kind   string
+	Id     string
+	Name   string
+	Fields []Field // other fields, name is searched anyway
+}
+
+// Result is a matched document
+type Result struct {
+	Item
+	Score int
+	Field Field // field with the best match, empty Name means document name
+}
+
+// Find returns items, matching all query words, ordered by descending score.
+// Equal scores are ordered by name. Zero limit returns all matches.
+func Find(query string, items []Item, limit int) []Result {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return nil
+	}
+	var results []Result
+	for _, item := range items {
+		if r, ok := match(words, item); ok {
+			results = append(results, r)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// match scores item by the best matching field of each word
+func match(words []string, item Item) (Result, bool) {
+	r := Result{Item: item}
+	best := 0
+	for _, word := range words {
+		wordScore := nameWeight * Score(word, item.Name)
+		field := Field{}
+		for _, f := range item.Fields {
+			if s := Score(word, f.Text); s > wordScore {
+				wordScore, field = s, f
+			}
+		}
+		if wordScore == 0 {
+			return r, false
+		}
+		if wordScore > best {
+			best, r.Field = wordScore, field
+		}
+		r.Score += wordScore
+	}
+	return r, true
+}
+
+// Score returns how well query matches text, zero is no match.
+// Substring match is scored higher than characters matched in order with gaps.
+func Score(query, text string) int {
+	q, t := strings.ToLower(query), strings.ToLower(text)
+	if q == "" || t == "" {
+		return 0
+	}
+	if i := strings.Index(t, q); i >= 0 {
+		score := substringScore
+		switch {
+		case q == t:
+			score += prefixBonus + equalBonus
+		case i == 0:
+			score += prefixBonus
+		case isWordStart(t, i):
+			score += wordStartBonus
+		}
+		return score
+	}
+	return fuzzyScore([]rune(q), []rune(t))
+}
+
+// fuzzyScore matches query characters in order, consecutive characters and
+// characters at word start score more
+func fuzzyScore(q, t []rune) int {
+	score, qi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score += charScore
+		if ti == prev+1 {
+			score += consecutiveBonus
+		}
+		if ti == 0 || !isWordChar(t[ti-1]) {
+			score += charStartBonus
+		}
+		prev = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0
+	}
+	return min(score, maxFuzzyScore)
+}
+
+// isWordStart reports whether byte offset i of s starts a word
+func isWordStart(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r := []rune(s[:i])
+	return !isWordChar(r[len(r)-1])
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
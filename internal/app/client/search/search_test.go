@@ -0,0 +1,58 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	assert.Zero(t, Score("", "text"))
+	assert.Zero(t, Score("abc", "cba"), "characters should match in order")
+	assert.Greater(t, Score("amazon", "Amazon"), Score("amaz", "Amazon"), "equal text ranks above prefix")
+	assert.Greater(t, Score("amaz", "Amazon shop"), Score("shop", "Amazon shop"), "prefix ranks above word start")
+	assert.Greater(t, Score("shop", "Amazon shop"), Score("hop", "Amazon shop"), "word start ranks above substring")
+	assert.Greater(t, Score("hop", "Amazon shop"), Score("amzn", "Amazon"), "substring ranks above fuzzy match")
+	assert.Greater(t, Score("amzn", "Amazon"), 0)
+	assert.Greater(t, Score("as", "Amazon shop"), Score("ao", "Amazon shop"), "word starts rank above gaps")
+	assert.Equal(t, Score("Ünï", "ÜNÏCODE"), Score("ünï", "ünïcode"), "case should be ignored")
+}
+
+func TestFind(t *testing.T) {
+	items := []Item{
+		{Kind: "card", Id: "1", Name: "Visa", Fields: []Field{{Name: "account", Text: "amazon"}}},
+		{Kind: "login", Id: "2", Name: "Amazon", Fields: []Field{{Name: "Login", Text: "user@mail"}}},
+		{Kind: "note", Id: "3", Name: "Shopping list", Fields: []Field{{Name: "Text", Text: "milk, bread"}}},
+		{Kind: "file", Id: "4", Name: "Scan", Fields: []Field{{Name: "Filename", Text: "amazon-invoice.pdf"}}},
+	}
+
+	t.Run("ranked", func(t *testing.T) {
+		results := Find("amazon", items, 0)
+		require.Len(t, results, 3)
+		assert.Equal(t, "2", results[0].Id, "name match should be the first")
+		assert.Empty(t, results[0].Field.Name)
+		assert.Equal(t, "1", results[1].Id, "equal field text ranks above prefix")
+		assert.Equal(t, "account", results[1].Field.Name)
+		assert.Equal(t, "4", results[2].Id)
+		assert.Equal(t, "Filename", results[2].Field.Name)
+	})
+
+	t.Run("all words", func(t *testing.T) {
+		results := Find("amazon invoice", items, 0)
+		require.Len(t, results, 1)
+		assert.Equal(t, "4", results[0].Id)
+		assert.Empty(t, Find("amazon milk", items, 0))
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		results := Find("brd", items, 0)
+		require.Len(t, results, 1)
+		assert.Equal(t, "3", results[0].Id)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		assert.Len(t, Find("a", items, 2), 2)
+		assert.Empty(t, Find("  ", items, 0))
+	})
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/search"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const (
+	pageSearch = "search"
+
+	searchLimit   = 50 // max number of shown results
+	searchPreview = 50 // max length of matched text in results
+)
+
+// categoryIndex is index of document kind in categories list
+var categoryIndex = map[string]int{"card": 0, "login": 1, "note": 2, "file": 3}
+
+// searchPage searches documents of all kinds and opens the selected one
+func (a *App) searchPage() {
+	items := a.searchItems()
+	closePage := func() {
+		a.pages.RemovePage(pageSearch)
+		a.ui.SetFocus(a.categories)
+	}
+
+	input := tview.NewInputField().SetLabel("Search: ")
+	list := tview.NewList()
+	list.SetBorder(true)
+
+	var results []search.Result
+	input.SetChangedFunc(func(text string) {
+		results = search.Find(text, items, searchLimit)
+		list.Clear()
+		for _, r := range results {
+			r := r
+			list.AddItem(fmt.Sprintf("%s (%s)", tview.Escape(r.Name), r.Kind), matchedText(r.Field), 0, func() {
+				a.pages.RemovePage(pageSearch)
+				a.openDocument(r.Kind, r.Id)
+			})
+		}
+		list.SetTitle(fmt.Sprintf(" Found: %d ", len(results)))
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEsc:
+			closePage()
+		case tcell.KeyEnter, tcell.KeyTab:
+			if list.GetItemCount() > 0 {
+				a.ui.SetFocus(list)
+			}
+		}
+	})
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyDown && list.GetItemCount() > 0 {
+			a.ui.SetFocus(list)
+			return nil
+		}
+		return event
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closePage()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			a.ui.SetFocus(input)
+			return nil
+		case tcell.KeyUp:
+			if list.GetCurrentItem() == 0 {
+				a.ui.SetFocus(input)
+				return nil
+			}
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	flex.SetBorder(true).SetTitle(" Search (`Enter` to open, `Esc` to close) ")
+	a.pages.AddPage(pageSearch, center(80, 24, flex), true, true)
+	a.ui.SetFocus(input)
+}
+
+// openDocument selects document category and shows document form
+func (a *App) openDocument(kind string, id string) {
+	index, ok := categoryIndex[kind]
+	if !ok {
+		return
+	}
+	a.categories.SetCurrentItem(index)
+	a.drawItemsList(index)
+	item := a.findItem(id)
+	if item < 0 && !a.filter.IsEmpty() {
+		// document is hidden by filter
+		a.resetFilter()
+		a.applyFilter()
+		item = a.findItem(id)
+	}
+	if item >= 0 {
+		a.itemsList.SetCurrentItem(item)
+		// focused list shows form of the current document
+		a.ui.SetFocus(a.itemsList)
+		a.ui.SetFocus(a.form)
+		return
+	}
+	a.statusFail("Document is not found, it may be deleted")
+	a.ui.SetFocus(a.categories)
+}
+
+// findItem returns index of document in items list or -1
+func (a *App) findItem(id string) int {
+	for i := 0; i < a.itemsList.GetItemCount(); i++ {
+		if _, itemId := a.itemsList.GetItemText(i); itemId == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// searchItems returns searchable fields of all documents.
+// Secrets (passwords, card numbers and codes) are not searched.
+func (a *App) searchItems() []search.Item {
+	var items []search.Item
+	for _, d := range a.store.GetCardsList() {
+		items = append(items, search.Item{Kind: "card", Id: d.Id, Name: d.Name,
+			Fields: docFields(d.Folder, d.Tags, d.Metadata, search.Field{Name: "Cardholder", Text: d.Cardholder})})
+	}
+	for _, d := range a.store.GetCredentialsList() {
+		items = append(items, search.Item{Kind: "login", Id: d.Id, Name: d.Name,
+			Fields: docFields(d.Folder, d.Tags, d.Metadata, search.Field{Name: "Login", Text: d.Login})})
+	}
+	for _, d := range a.store.GetNotesList() {
+		items = append(items, search.Item{Kind: "note", Id: d.Id, Name: d.Name,
+			Fields: docFields(d.Folder, d.Tags, d.Metadata, search.Field{Name: "Text", Text: d.Text})})
+	}
+	for _, d := range a.store.GetFilesList() {
+		items = append(items, search.Item{Kind: "file", Id: d.Id, Name: d.Name,
+			Fields: docFields(d.Folder, d.Tags, d.Metadata, search.Field{Name: "Filename", Text: d.Filename})})
+	}
+	return items
+}
+
+// docFields appends folder, tags, metadata keys and values to fields
+func docFields(folder string, tags []string, metadata []models.Meta, fields ...search.Field) []search.Field {
+	if folder != "" {
+		fields = append(fields, search.Field{Name: "Folder", Text: folder})
+	}
+	for _, tag := range tags {
+		fields = append(fields, search.Field{Name: "Tag", Text: tag})
+	}
+	for _, m := range metadata {
+		fields = append(fields,
+			search.Field{Name: "Metadata", Text: m.Key},
+			search.Field{Name: m.Key, Text: m.Value},
+		)
+	}
+	return fields
+}
+
+// matchedText describes field, which matched search query
+func matchedText(f search.Field) string {
+	if f.Name == "" {
+		return ""
+	}
+	text := []rune(strings.Join(strings.Fields(f.Text), " "))
+	if len(text) > searchPreview {
+		text = append(text[:searchPreview], '…')
+	}
+	return fmt.Sprintf("[yellow]%s:[white] %s", tview.Escape(f.Name), tview.Escape(string(text)))
+}
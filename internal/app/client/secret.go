@@ -0,0 +1,40 @@
+package client
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// maskChar hides characters of secret fields
+const maskChar = '*'
+
+// secretField masks form field with label. `Ctrl+R` in the field toggles reveal,
+// field is masked again, when it loses focus.
+func secretField(form *tview.Form, label string) {
+	field, ok := form.GetFormItemByLabel(label).(*tview.InputField)
+	if !ok {
+		return
+	}
+	revealed := false
+	field.SetMaskCharacter(maskChar)
+	capture := field.GetInputCapture()
+	field.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlR {
+			revealed = !revealed
+			if revealed {
+				field.SetMaskCharacter(0)
+			} else {
+				field.SetMaskCharacter(maskChar)
+			}
+			return nil
+		}
+		if capture != nil {
+			return capture(event)
+		}
+		return event
+	})
+	field.SetBlurFunc(func() {
+		revealed = false
+		field.SetMaskCharacter(maskChar)
+	})
+}
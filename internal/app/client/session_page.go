@@ -0,0 +1,110 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/memstore"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const pageSessions = "sessions"
+
+// sessionsPage shows active sessions of the user and allows to revoke one of them
+func (a *App) sessionsPage() {
+	sessions, err := a.store.ListSessions()
+	if err != nil {
+		if errors.Is(err, memstore.ErrAuthFailed) {
+			a.modalUnauthorized()
+		} else {
+			a.modalErr("Failed to get sessions: " + err.Error())
+		}
+		return
+	}
+
+	closePage := func() {
+		a.pages.RemovePage(pageSessions)
+		a.ui.SetFocus(a.categories)
+	}
+
+	list := tview.NewList()
+	list.SetBorder(true).SetTitle(" Sessions (`Enter` to revoke, `Esc` to close) ")
+	for _, s := range sessions {
+		s := s
+		name := s.Device
+		if name == "" {
+			name = "unknown device"
+		}
+		if s.Current {
+			name += " [green](this device)"
+		}
+		list.AddItem(name, sessionText(s), 0, func() {
+			a.modalRevoke(s, closePage)
+		})
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closePage()
+			return nil
+		}
+		return event
+	})
+	a.pages.AddPage(pageSessions, center(80, 2*len(sessions)+2, list), true, true)
+	a.ui.SetFocus(list)
+}
+
+// modalRevoke asks to confirm session revocation. Revoking current session logs out.
+func (a *App) modalRevoke(session models.Session, done func()) {
+	text := "Revoke selected session?\nDevice will need to login again."
+	if session.Current {
+		text = "It is the current session.\nLog out?"
+	}
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText(text).
+		AddButtons([]string{"No", "Yes, revoke"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			if buttonIndex != 1 {
+				return
+			}
+			done()
+			if session.Current {
+				a.logout()
+				return
+			}
+			a.modifyRequest(
+				func() error {
+					return a.store.RevokeSession(session.Id)
+				},
+				"Session revoked",
+				"Failed to revoke session",
+			)
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// logout finishes current session and returns to welcome page
+func (a *App) logout() {
+	a.endWatch()
+	if a.clipboard != nil {
+		go a.clipboard.Clear()
+	}
+	a.store.Logout()
+	// documents of finished session should not be left on hidden page
+	a.clearForm()
+	a.itemsList.Clear()
+	a.pages.HidePage(pageRoot)
+	a.welcomePage()
+}
+
+// sessionText describes session times
+func sessionText(s models.Session) string {
+	return fmt.Sprintf("signed in %s, last seen %s",
+		time.Unix(s.CreatedAt, 0).Format(time.DateTime),
+		time.Unix(s.LastSeen, 0).Format(time.DateTime))
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/memstore"
+)
+
+const (
+	pagePassword      = "password"
+	pageExport        = "export"
+	pageDeleteAccount = "deleteAccount"
+)
+
+// settingsPage is a menu of account settings
+func (a *App) settingsPage() {
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText("Account settings").
+		AddButtons([]string{"Change password", "Two-factor", "Sessions", "Shared vaults", "Export data", "Import data", "Backup", "Delete account", "Log out", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			switch buttonIndex {
+			case 0:
+				a.passwordPage()
+			case 1:
+				a.totpPage()
+			case 2:
+				a.sessionsPage()
+			case 3:
+				a.vaultsPage()
+			case 4:
+				a.exportPage()
+			case 5:
+				a.importPage()
+			case 6:
+				a.modalBackup()
+			case 7:
+				a.deleteAccountPage()
+			case 8:
+				a.logout()
+			default:
+				a.ui.SetFocus(a.categories)
+			}
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// passwordPage asks for current and new passwords to change password
+func (a *App) passwordPage() {
+	var oldPassword, newPassword, rePass string
+	cancelFunc := func() {
+		a.pages.RemovePage(pagePassword)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddPasswordField("current password", "", 30, '*', func(text string) {
+		oldPassword = text
+	})
+	form.AddPasswordField("new password", "", 30, '*', func(text string) {
+		newPassword = text
+	})
+	form.AddPasswordField("repeat new password", "", 30, '*', func(text string) {
+		rePass = text
+	})
+	form.AddButton("Change", func() {
+		if newPassword != rePass {
+			a.modalErr("Passwords do not match!")
+			return
+		}
+		if newPassword == "" {
+			a.modalErr("New password is empty")
+			return
+		}
+		a.pages.RemovePage(pagePassword)
+		a.ui.SetFocus(a.categories)
+		a.modifyRequest(
+			func() error { return a.store.ChangePassword(oldPassword, newPassword) },
+			"Password changed, other sessions are logged out",
+			"Failed to change password",
+		)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Change password ")
+	a.pages.AddPage(pagePassword, center(55, 11, form), true, true)
+}
+
+// exportPage asks for path to save archive with all user data
+func (a *App) exportPage() {
+	path := "pwkeeper-export.zip"
+	cancelFunc := func() {
+		a.pages.RemovePage(pageExport)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("Save to", path, 50, nil, func(text string) {
+		path = text
+	})
+	form.AddButton("Export", func() {
+		if path == "" {
+			a.modalErr("Path is empty")
+			return
+		}
+		a.pages.RemovePage(pageExport)
+		a.ui.SetFocus(a.categories)
+		a.modifyRequest(
+			func() error { return a.store.ExportAll(path) },
+			"Data exported to "+path+"\nDocuments stay encrypted, use your password to open them",
+			"Failed to export data",
+		)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Export all data ")
+	a.pages.AddPage(pageExport, center(70, 7, form), true, true)
+}
+
+// deleteAccountPage asks for password to confirm account deletion
+func (a *App) deleteAccountPage() {
+	var password string
+	cancelFunc := func() {
+		a.pages.RemovePage(pageDeleteAccount)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddTextView("", "All documents will be deleted permanently.\nExport data first, if you need it.", 50, 2, false, false)
+	form.AddPasswordField("password", "", 30, '*', func(text string) {
+		password = text
+	})
+	form.AddButton("Delete account", func() {
+		if err := a.store.DeleteAccount(password); err != nil {
+			if errors.Is(err, memstore.ErrAuthFailed) {
+				a.pages.RemovePage(pageDeleteAccount)
+				a.modalUnauthorized()
+			} else {
+				a.modalErr("Failed to delete account: " + err.Error())
+			}
+			return
+		}
+		a.pages.RemovePage(pageDeleteAccount)
+		a.endWatch()
+		a.pages.HidePage(pageRoot)
+		a.welcomePage()
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Delete account ")
+	a.pages.AddPage(pageDeleteAccount, center(60, 10, form), true, true)
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	pageShare       = "share"
+	pageSharedToken = "sharedToken"
+	pageOpenShare   = "openShare"
+	pageSharedDoc   = "sharedDoc"
+
+	defaultShareHours = 24
+)
+
+// shareForm asks for share link limits and creates one-time share of the document
+func (a *App) shareForm(doc interface{}) {
+	if a.store.IsOffline() {
+		a.modalErr("Sharing is not available offline")
+		return
+	}
+	hours, views := defaultShareHours, 1
+	var passphrase string
+	cancelFunc := func() {
+		a.pages.RemovePage(pageShare)
+		a.ui.SetFocus(a.form)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("Expires in hours", strconv.Itoa(hours), 5, tview.InputFieldInteger, func(text string) {
+		hours, _ = strconv.Atoi(text)
+	})
+	form.AddInputField("Max views", strconv.Itoa(views), 5, tview.InputFieldInteger, func(text string) {
+		views, _ = strconv.Atoi(text)
+	})
+	form.AddPasswordField("Passphrase (optional)", "", 30, '*', func(text string) {
+		passphrase = text
+	})
+	form.AddButton("Share", func() {
+		if hours <= 0 || views <= 0 {
+			a.modalErr("Expiration and views should be positive")
+			return
+		}
+		token, expires, err := a.store.ShareDocument(doc, time.Duration(hours)*time.Hour, views, passphrase)
+		if err != nil {
+			a.modalErr("Failed to share document: " + err.Error())
+			return
+		}
+		a.pages.RemovePage(pageShare)
+		a.sharedTokenPage(token, expires, views)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Share document once ")
+	a.pages.AddPage(pageShare, center(55, 11, form), true, true)
+}
+
+// sharedTokenPage shows share token, which should be passed to recipient
+func (a *App) sharedTokenPage(token string, expires time.Time, views int) {
+	closePage := func() {
+		a.pages.RemovePage(pageSharedToken)
+		a.ui.SetFocus(a.form)
+	}
+	text := tview.NewTextView().SetDynamicColors(true).SetWrap(true).
+		SetText(fmt.Sprintf("Pass this token to recipient, it is shown only once:\n\n[yellow]%s[white]\n\n"+
+			"Share may be opened %d time(s) until %s.\nToken contains decryption key, server never sees the document.",
+			token, views, expires.Format(time.DateTime)))
+	form := tview.NewForm().
+		AddButton("Copy", func() { a.copySecret("Share token", token) }).
+		AddButton("Close", closePage).
+		SetButtonsAlign(tview.AlignCenter).
+		SetCancelFunc(closePage)
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, false).
+		AddItem(form, 3, 0, true)
+	flex.SetBorder(true).SetTitle(" Document shared ")
+	a.pages.AddPage(pageSharedToken, center(80, 12, flex), true, true)
+	a.ui.SetFocus(form)
+}
+
+// openSharePage asks for share token and passphrase and shows shared document.
+// Login is not required.
+func (a *App) openSharePage() {
+	var token, passphrase string
+	cancelFunc := func() {
+		a.welcomePage()
+		a.pages.RemovePage(pageOpenShare)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("token", "", 60, nil, func(text string) {
+		token = text
+	})
+	form.AddPasswordField("passphrase", "", 30, '*', func(text string) {
+		passphrase = text
+	})
+	form.AddButton("Open", func() {
+		doc, viewsLeft, err := a.store.OpenShare(token, passphrase)
+		if err != nil {
+			a.modalErr("Failed to open share: " + err.Error())
+			return
+		}
+		a.pages.RemovePage(pageOpenShare)
+		a.sharedDocPage(doc, viewsLeft)
+	})
+	form.AddButton("<<Back", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle("Open shared document")
+	a.pages.AddPage(pageOpenShare, center(80, 9, form), true, true)
+	a.pages.SwitchToPage(pageOpenShare)
+}
+
+// sharedDocPage shows opened shared document read-only
+func (a *App) sharedDocPage(doc interface{}, viewsLeft int) {
+	closePage := func() {
+		a.welcomePage()
+		a.pages.RemovePage(pageSharedDoc)
+	}
+	text := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetText(revisionText(doc))
+	text.SetBorder(true).SetTitle(fmt.Sprintf(" Shared document, views left: %d (`Esc` to close) ", viewsLeft))
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closePage()
+			return nil
+		}
+		return event
+	})
+	a.pages.AddPage(pageSharedDoc, center(80, 20, text), true, true)
+	a.pages.SwitchToPage(pageSharedDoc)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/app/client/memstore"
+)
+
+// totpPage manages two-factor authentication
+func (a *App) totpPage() {
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText("Two-factor authentication requires one-time code from authenticator application on login").
+		AddButtons([]string{"Enable", "Disable", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			switch buttonIndex {
+			case 0:
+				a.totpEnrollPage()
+			case 1:
+				a.totpDisablePage()
+			default:
+				a.ui.SetFocus(a.categories)
+			}
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// totpEnrollPage shows new second factor secret and recovery codes, and asks for code to confirm it
+func (a *App) totpEnrollPage() {
+	enrollment, err := a.store.EnrollTotp()
+	if err != nil {
+		a.ui.SetFocus(a.categories)
+		if errors.Is(err, memstore.ErrAuthFailed) {
+			a.modalUnauthorized()
+		} else {
+			a.modalErr("Failed to enable two-factor authentication: " + err.Error())
+		}
+		return
+	}
+	var code string
+	cancelFunc := func() {
+		a.pages.RemovePage(pageTotp)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddTextView("secret", enrollment.Secret, 0, 1, true, false)
+	form.AddTextView("key uri", enrollment.URI, 0, 3, true, false)
+	form.AddTextView("recovery codes", strings.Join(enrollment.RecoveryCodes, "\n"), 0, len(enrollment.RecoveryCodes), true, false)
+	form.AddInputField("code", "", 20, nil, func(text string) {
+		code = text
+	})
+	form.AddButton("Confirm", func() {
+		a.pages.RemovePage(pageTotp)
+		a.modifyRequest(
+			func() error { return a.store.ConfirmTotp(code) },
+			"Two-factor authentication enabled",
+			"Failed to enable two-factor authentication",
+		)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Add secret to authenticator, save recovery codes and enter code ")
+	a.pages.AddPage(pageTotp, center(90, len(enrollment.RecoveryCodes)+13, form), true, true)
+}
+
+// totpDisablePage asks for code to disable second factor
+func (a *App) totpDisablePage() {
+	var code string
+	cancelFunc := func() {
+		a.pages.RemovePage(pageTotp)
+		a.ui.SetFocus(a.categories)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("code", "", 20, nil, func(text string) {
+		code = text
+	})
+	form.AddButton("Disable", func() {
+		a.pages.RemovePage(pageTotp)
+		a.modifyRequest(
+			func() error { return a.store.DisableTotp(code) },
+			"Two-factor authentication disabled",
+			"Failed to disable two-factor authentication",
+		)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Enter one-time or recovery code ")
+	a.pages.AddPage(pageTotp, center(44, 7, form), true, true)
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// trashList displays list of deleted documents
+func (a *App) trashList() {
+	a.itemsList.Clear().SetTitle("Trash")
+	docs := make(map[string]interface{})
+	for _, v := range a.store.GetTrashList() {
+		name, id, kind := trashItemInfo(v)
+		docs[id] = v
+		a.itemsList.AddItem(fmt.Sprintf("%s (%s)", a.itemName(id, name, ""), kind), id, 0, func() {
+			a.ui.SetFocus(a.form)
+		})
+	}
+	a.itemsList.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		if a.itemsList.HasFocus() {
+			a.trashForm(docs[secondaryText])
+		} else {
+			a.clearForm()
+		}
+	})
+	a.itemsList.SetFocusFunc(func() {
+		_, id := a.itemsList.GetItemText(a.itemsList.GetCurrentItem())
+		a.trashForm(docs[id])
+	})
+}
+
+// trashForm displays deleted document and allows to restore it or delete permanently
+func (a *App) trashForm(doc interface{}) {
+	a.form.Clear(true)
+	if doc == nil {
+		a.form.SetTitle(" [red]INVALID DOCUMENT ")
+		return
+	}
+	a.form.SetTitle(" Deleted Document ")
+	text := revisionText(doc)
+	if at := trashedAt(doc); at > 0 {
+		text += fmt.Sprintf("[green]Deleted at:[white] %s\n", time.Unix(at, 0).Format(time.DateTime))
+	}
+	a.form.AddTextView("", text, 60, 12, true, true)
+	a.form.AddButton("Back", func() {
+		a.ui.SetFocus(a.itemsList)
+	})
+	a.form.AddButton("Restore", func() {
+		a.modifyRequest(
+			func() error {
+				return a.store.RestoreFromTrash(doc)
+			},
+			"Document restored",
+			"Failed to restore document",
+		)
+	})
+	a.form.AddButton("[red]Delete forever", func() {
+		a.modifyRequest(
+			func() error {
+				return a.store.DeleteFromTrash(doc)
+			},
+			"Document deleted permanently",
+			"Failed to delete document",
+		)
+	})
+	a.form.SetButtonsAlign(tview.AlignCenter)
+	a.form.SetCancelFunc(func() {
+		a.ui.SetFocus(a.itemsList)
+	})
+}
+
+// trashItemInfo returns name, id and kind name of document
+func trashItemInfo(doc interface{}) (string, string, string) {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.Name, d.Id, "note"
+	case models.Card:
+		return d.Name, d.Id, "card"
+	case models.Credential:
+		return d.Name, d.Id, "login"
+	case models.File:
+		return d.Name, d.Id, "file"
+	}
+	return "", "", ""
+}
+
+// trashedAt returns time document was moved to trash
+func trashedAt(doc interface{}) int64 {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.TrashedAt
+	case models.Card:
+		return d.TrashedAt
+	case models.Credential:
+		return d.TrashedAt
+	case models.File:
+		return d.TrashedAt
+	}
+	return 0
+}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+const (
+	pageVaults       = "vaults"
+	pageVaultMembers = "vaultMembers"
+	pageVaultForm    = "vaultForm"
+
+	personalVault = "Personal"
+)
+
+// roles are member roles in order they are offered
+var roles = []string{models.RoleRead, models.RoleWrite, models.RoleOwner}
+
+// vaultsPage shows shared vaults of the user and allows to create and manage them
+func (a *App) vaultsPage() {
+	if a.store.IsOffline() {
+		a.modalErr("Shared vaults are not available offline")
+		return
+	}
+	closePage := func() {
+		a.pages.RemovePage(pageVaults)
+		a.ui.SetFocus(a.categories)
+	}
+	vaults := a.store.GetVaults()
+	list := tview.NewList()
+	list.SetBorder(true).SetTitle(" Shared vaults (`Enter` to manage, `Esc` to close) ")
+	list.AddItem("[green]Create vault", "new vault is shared with added members", 0, func() {
+		a.vaultNameForm("", "", closePage)
+	})
+	for _, v := range vaults {
+		v := v
+		text := fmt.Sprintf("your role: %s, members: %d", a.store.VaultRole(v.Id), len(v.Members))
+		list.AddItem(tview.Escape(v.Name), text, 0, func() {
+			a.modalVault(v, closePage)
+		})
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closePage()
+			return nil
+		}
+		return event
+	})
+	a.pages.AddPage(pageVaults, center(70, 2*len(vaults)+4, list), true, true)
+	a.ui.SetFocus(list)
+}
+
+// modalVault is a menu of shared vault actions
+func (a *App) modalVault(v models.Vault, done func()) {
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText("Vault " + tview.Escape(v.Name)).
+		AddButtons([]string{"Members", "Rename", "Leave", "Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			switch buttonIndex {
+			case 0:
+				done()
+				a.membersPage(v.Id)
+			case 1:
+				a.vaultNameForm(v.Id, v.Name, done)
+			case 2:
+				a.modalConfirmVault("Leave vault "+tview.Escape(v.Name)+"?\nIts documents will not be available.", "Left vault", "Failed to leave vault",
+					func() error { return a.store.LeaveVault(v.Id) }, done)
+			case 3:
+				a.modalConfirmVault("Delete vault "+tview.Escape(v.Name)+"?\nAll its documents will be deleted for all members.", "Vault deleted", "Failed to delete vault",
+					func() error { return a.store.DeleteVault(v.Id) }, done)
+			}
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// modalConfirmVault asks to confirm vault change, which can't be undone
+func (a *App) modalConfirmVault(text, okMsg, failMsg string, fn func() error, done func()) {
+	m := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText(text).
+		AddButtons([]string{"No", "Yes"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			if buttonIndex != 1 {
+				return
+			}
+			done()
+			a.modifyRequest(fn, okMsg, failMsg)
+		})
+	a.pages.AddPage(pageModal, m, true, true)
+}
+
+// vaultNameForm asks for name of new vault, or new name of existing vault
+func (a *App) vaultNameForm(vaultId, name string, done func()) {
+	cancelFunc := func() {
+		a.pages.RemovePage(pageVaultForm)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("Name", name, 40, nil, func(text string) {
+		name = text
+	})
+	form.AddButton("Save", func() {
+		if name == "" {
+			a.modalErr("Vault name should not be empty")
+			return
+		}
+		a.pages.RemovePage(pageVaultForm)
+		done()
+		if vaultId == "" {
+			a.modifyRequest(func() error { return a.store.CreateVault(name) }, "Vault created", "Failed to create vault")
+			return
+		}
+		a.modifyRequest(func() error { return a.store.RenameVault(vaultId, name) }, "Vault renamed", "Failed to rename vault")
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	if vaultId == "" {
+		form.SetTitle(" Create vault ")
+	} else {
+		form.SetTitle(" Rename vault ")
+	}
+	a.pages.AddPage(pageVaultForm, center(55, 7, form), true, true)
+}
+
+// membersPage shows members of shared vault and allows owners to manage them
+func (a *App) membersPage(vaultId string) {
+	v, ok := a.store.GetVault(vaultId)
+	if !ok {
+		a.modalErr("Vault is not available")
+		return
+	}
+	closePage := func() {
+		a.pages.RemovePage(pageVaultMembers)
+		a.ui.SetFocus(a.categories)
+	}
+	list := tview.NewList()
+	list.SetBorder(true).SetTitle(" Members of " + tview.Escape(v.Name) + " (`Enter` to manage, `Esc` to close) ")
+	list.AddItem("[green]Add member", "member gets access to all vault documents", 0, func() {
+		a.memberForm(vaultId, closePage)
+	})
+	for _, m := range v.Members {
+		m := m
+		list.AddItem(tview.Escape(m.Login), "role: "+m.Role, 0, func() {
+			a.modalMember(vaultId, m, closePage)
+		})
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closePage()
+			return nil
+		}
+		return event
+	})
+	a.pages.AddPage(pageVaultMembers, center(70, 2*len(v.Members)+4, list), true, true)
+	a.ui.SetFocus(list)
+}
+
+// memberForm asks for login and role of new vault member
+func (a *App) memberForm(vaultId string, done func()) {
+	var login string
+	role := roles[0]
+	cancelFunc := func() {
+		a.pages.RemovePage(pageVaultForm)
+	}
+	form := tview.NewForm().SetCancelFunc(cancelFunc)
+	form.AddInputField("Login", "", 30, nil, func(text string) {
+		login = text
+	})
+	form.AddDropDown("Role", roles, 0, func(option string, _ int) {
+		role = option
+	})
+	form.AddButton("Add", func() {
+		if login == "" {
+			a.modalErr("Login should not be empty")
+			return
+		}
+		a.pages.RemovePage(pageVaultForm)
+		done()
+		a.modifyRequest(
+			func() error { return a.store.AddVaultMember(vaultId, login, role) },
+			"Vault shared with "+login,
+			"Failed to add member",
+		)
+	})
+	form.AddButton("<< Cancel", func() {
+		cancelFunc()
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetBorder(true)
+	form.SetTitle(" Add member ")
+	a.pages.AddPage(pageVaultForm, center(50, 9, form), true, true)
+}
+
+// modalMember changes role of vault member or removes it
+func (a *App) modalMember(vaultId string, m models.Member, done func()) {
+	buttons := append(append([]string(nil), roles...), "Remove", "Cancel")
+	modal := tview.NewModal().
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetText(fmt.Sprintf("Member %s has role %s.\nRemoved member may keep copies of documents,\nvault key is not changed.", tview.Escape(m.Login), m.Role)).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage(pageModal)
+			switch {
+			case buttonIndex >= 0 && buttonIndex < len(roles):
+				done()
+				a.modifyRequest(
+					func() error { return a.store.SetVaultMemberRole(vaultId, m.UserId, roles[buttonIndex]) },
+					"Role of "+m.Login+" changed",
+					"Failed to change role",
+				)
+			case buttonIndex == len(roles):
+				done()
+				a.modifyRequest(
+					func() error { return a.store.RemoveVaultMember(vaultId, m.UserId) },
+					m.Login+" removed from vault",
+					"Failed to remove member",
+				)
+			}
+		})
+	a.pages.AddPage(pageModal, modal, true, true)
+}
+
+// drawVault adds vault field to document form. New document may be placed to any
+// writable shared vault, vault of existing document is not changed.
+func (a *App) drawVault(vaultId *string, formType int) {
+	if formType != formAdd {
+		if *vaultId != "" {
+			a.form.AddTextView("Vault", tview.Escape(a.vaultName(*vaultId)), 50, 1, true, false)
+		}
+		return
+	}
+	options, ids := a.writableVaults()
+	if len(ids) == 1 {
+		return
+	}
+	a.form.AddDropDown("Vault", options, 0, func(_ string, index int) {
+		if index >= 0 {
+			*vaultId = ids[index]
+		}
+	})
+}
+
+// writableVaults returns names and ids of vaults, where user may add documents.
+// Personal documents are the first.
+func (a *App) writableVaults() ([]string, []string) {
+	options := []string{personalVault}
+	ids := []string{""}
+	for _, v := range a.store.GetVaults() {
+		if role := a.store.VaultRole(v.Id); role == models.RoleOwner || role == models.RoleWrite {
+			options = append(options, tview.Escape(v.Name))
+			ids = append(ids, v.Id)
+		}
+	}
+	return options, ids
+}
+
+// vaultName returns name of shared vault
+func (a *App) vaultName(vaultId string) string {
+	if v, ok := a.store.GetVault(vaultId); ok {
+		return v.Name
+	}
+	return "unavailable vault"
+}
+
+// itemName is a documents list item text, documents of shared vaults are marked with vault name,
+// documents, saved before encryption was introduced, are marked until they are re-encrypted
+func (a *App) itemName(id, name, vaultId string) string {
+	if vaultId == "" {
+		if a.store.IsUnencrypted(id) {
+			return name + " [red](unencrypted)[-]"
+		}
+		return name
+	}
+	return name + " [darkcyan]@" + tview.Escape(a.vaultName(vaultId))
+}
@@ -6,12 +6,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
-	"yap-pwkeeper/internal/pkg/jwtToken"
 	"yap-pwkeeper/internal/pkg/logger"
 	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/ratelimit"
 )
 
 var (
@@ -19,6 +21,10 @@ var (
 	ErrBadAuth   = errors.New("invalid auth credentials")
 	ErrNotFound  = errors.New("user not found")
 	ErrToken     = errors.New("token generation failed")
+	ErrKeyExists = errors.New("vault key already exists")
+	ErrNoKey     = errors.New("vault key not found")
+	ErrNoSession = errors.New("session not found")
+	ErrLegacy    = errors.New("account password is master password, login with it to migrate account")
 )
 
 // UserStorage is an interface where users login credentials are secure stored
@@ -27,22 +33,69 @@ var (
 type UserStorage interface {
 	AddUser(ctx context.Context, user models.User) (models.User, error)
 	GetUserByLogin(ctx context.Context, login string) (models.User, error)
+	GetUserById(ctx context.Context, userId string) (models.User, error)
+	SetVaultKey(ctx context.Context, userId string, key []byte) error
+	SetKeyPair(ctx context.Context, userId string, keys models.KeyPair) error
+	SetTotp(ctx context.Context, userId string, totp *models.Totp) error
+	SetMigrated(ctx context.Context, userId string) error
+	SetPassword(ctx context.Context, userId string, passwordHash string, vaultKey []byte) error
+	DeleteUser(ctx context.Context, userId string) error
+	SetLoginFailures(ctx context.Context, userId string, failures int, lockedUntil int64) error
+	AddLoginFailure(ctx context.Context, userId string) (int, error)
+	LockUser(ctx context.Context, userId string, lockedUntil int64) error
+
+	AddSession(ctx context.Context, session models.Session) error
+	GetSession(ctx context.Context, sessionId string) (models.Session, error)
+	GetSessions(ctx context.Context, userId string) ([]models.Session, error)
+	TouchSession(ctx context.Context, sessionId string, lastSeen int64) error
+	DeleteSession(ctx context.Context, sessionId string, userId string) error
 }
 
 type Controller struct {
-	store UserStorage
+	store   UserStorage
+	totpMu  sync.Mutex                                     // serializes second factor changes, so one-time codes are used once
+	cleanup func(ctx context.Context, userId string) error // removes user data on account deletion
+	migrate func(ctx context.Context, userId string) error // removes plain text user data on migration
+	lockout ratelimit.Policy                               // temporary lockout after failed logins, disabled by default
+	policy  CredentialsPolicy                              // requirements to new logins and passwords
+	maxAge  time.Duration                                  // session lifetime since login, 0 is unlimited
+	maxIdle time.Duration                                  // session lifetime since last refresh, token lifetime by default
+	onEnd   func(sessionId string)                         // notifies about finished session, e.g. to close its streams
 }
 
 // New is AAA constructor
-func New(store UserStorage) *Controller {
-	c := &Controller{store: store}
+func New(store UserStorage, options ...func(c *Controller)) *Controller {
+	c := &Controller{store: store, policy: DefaultPolicy}
+	for _, opt := range options {
+		opt(c)
+	}
 	return c
 }
 
+// WithAccountCleanup sets function, which removes all user data on account deletion
+func WithAccountCleanup(fn func(ctx context.Context, userId string) error) func(c *Controller) {
+	return func(c *Controller) {
+		c.cleanup = fn
+	}
+}
+
+// WithMigration sets function, which removes plain text user data, e.g. document revisions,
+// saved before encryption was introduced. It is called before user is marked as migrated.
+func WithMigration(fn func(ctx context.Context, userId string) error) func(c *Controller) {
+	return func(c *Controller) {
+		c.migrate = fn
+	}
+}
+
 // Register registers new user, and stores login/password in database backend.
+// ErrPolicy with rejection reasons is returned, if credentials do not match policy.
 func (c *Controller) Register(ctx context.Context, cred models.UserCredentials) error {
 	log := logger.Log().WithCtxRequestId(ctx).With("login", cred.Login)
 	log.Debug("new user registration")
+	if err := c.policy.Check(ctx, cred.Login, cred.Password); err != nil {
+		log.Infof("user registration rejected: %s", err.Error())
+		return err
+	}
 	user := models.User{}
 	pwHash, err := bcrypt.GenerateFromPassword([]byte(cred.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -53,6 +106,8 @@ func (c *Controller) Register(ctx context.Context, cred models.UserCredentials)
 			Login:        cred.Login,
 			PasswordHash: string(pwHash),
 			State:        models.StateActive,
+			Migrated:     true, // new user has no documents, saved before encryption
+			Derived:      true,
 		})
 	if err != nil {
 		log.Warnf("user registration failed: %s", err.Error())
@@ -63,6 +118,11 @@ func (c *Controller) Register(ctx context.Context, cred models.UserCredentials)
 }
 
 // Login authenticates users by login and password and returns authorization token.
+// If user has enabled second factor, one-time code is required too, ErrTotpRequired
+// is returned without it. ErrLocked is returned, while user is locked after failed logins.
+// Accounts, created before clients derived passwords, have master password hash: ErrLegacy
+// is returned instead of ErrBadAuth, when such account gets derived auth value, so client
+// retries with master password only when server requires it.
 func (c *Controller) Login(ctx context.Context, cred models.UserCredentials) (string, error) {
 	log := logger.Log().WithCtxRequestId(ctx).With("login", cred.Login)
 	log.Debug("new user login")
@@ -75,46 +135,96 @@ func (c *Controller) Login(ctx context.Context, cred models.UserCredentials) (st
 		}
 		return "", err
 	}
+	if c.isLocked(user) {
+		log.With("userId", user.Id).Warn("user login rejected: user is locked")
+		return "", ErrLocked
+	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(cred.Password)); err != nil {
+		if !user.Derived && authPattern.MatchString(cred.Password) {
+			// not a failure: client has not tried master password yet
+			log.With("userId", user.Id).Info("user login requires master password of legacy account")
+			return "", ErrLegacy
+		}
 		log.Warnf("user login failed: %s", err.Error())
+		c.loginFailed(ctx, user)
 		return "", ErrBadAuth
 	}
+	if user.Totp != nil && user.Totp.Enabled {
+		if cred.Totp == "" {
+			log.With("userId", user.Id).Info("user login requires one-time code")
+			return "", ErrTotpRequired
+		}
+		if err := c.checkTotp(ctx, user.Id, cred.Totp); err != nil {
+			log.Warnf("user login failed: %s", err.Error())
+			if errors.Is(err, ErrBadCode) {
+				c.loginFailed(ctx, user)
+				return "", ErrBadAuth
+			}
+			return "", err
+		}
+	}
+	c.loginSucceeded(ctx, user)
 	log.With("userId", user.Id).Info("user login succeeded")
-	return newSession(ctx, user.Id)
+	return c.newSession(ctx, user.Id, cred.Device)
 }
 
-func newSession(ctx context.Context, userid string) (string, error) {
-	log := logger.Log().WithCtxRequestId(ctx).With("userId", userid)
-	log.Debug("new user session")
-	token, err := jwtToken.NewToken(userid)
+// GetVaultKey returns wrapped user vault key. Server is not able to unwrap it,
+// the key is only stored to be shared between user clients.
+func (c *Controller) GetVaultKey(ctx context.Context, userId string) ([]byte, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("get vault key")
+	user, err := c.store.GetUserById(ctx, userId)
 	if err != nil {
-		log.Errorf("user session failed: %s: %s", ErrToken.Error(), err.Error())
-		return "", fmt.Errorf("%w: %w", ErrToken, err)
+		log.Warnf("get vault key failed: %s", err.Error())
+		return nil, err
+	}
+	if len(user.VaultKey) == 0 {
+		return nil, ErrNoKey
 	}
-	log.With("sessionId", jwtToken.GetTokenSession(token)).Info("user session succeeded")
-	return token, err
+	return user.VaultKey, nil
 }
 
-// Refresh issues new token instead of actual to provide continuous sessions.
-func (c *Controller) Refresh(ctx context.Context, token string) (string, error) {
-	log := logger.Log().WithCtxRequestId(ctx).
-		With("userId", jwtToken.GetTokenSubject(token), "sessionId", jwtToken.GetTokenSession(token))
-	log.Debug("session refresh")
-	newToken, err := jwtToken.RefreshToken(token)
+// IsMigrated shows whether all user documents are encrypted. Until client
+// re-encrypts documents, saved before encryption was introduced, it is false.
+func (c *Controller) IsMigrated(ctx context.Context, userId string) (bool, error) {
+	user, err := c.store.GetUserById(ctx, userId)
 	if err != nil {
-		if errors.Is(jwtToken.ErrInvalid, err) {
-			log.Warnf("session refresh failed: %s", err.Error())
-			return "", ErrBadAuth
-		} else {
-			log.Errorf("session refresh failed: %s", err.Error())
+		logger.Log().WithCtxRequestId(ctx).With("userId", userId).Warnf("get migration state failed: %s", err.Error())
+		return false, err
+	}
+	return user.Migrated, nil
+}
+
+// SetMigrated records that client has re-encrypted all user documents.
+// Plain text user data is removed first, so migration may be retried if it fails.
+func (c *Controller) SetMigrated(ctx context.Context, userId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	if c.migrate != nil {
+		if err := c.migrate(ctx, userId); err != nil {
+			log.Warnf("remove plain text data failed: %s", err.Error())
+			return err
 		}
-		return "", err
 	}
-	log.Info("session refresh succeeded")
-	return newToken, err
+	if err := c.store.SetMigrated(ctx, userId); err != nil {
+		log.Warnf("set migrated failed: %s", err.Error())
+		return err
+	}
+	log.Info("user documents migrated")
+	return nil
 }
 
-// Validate is a method to check token validity
-func (c *Controller) Validate(_ context.Context, token string) bool {
-	return jwtToken.Valid(token)
+// SetVaultKey stores wrapped user vault key. Key may be set only once,
+// ErrKeyExists is returned if user already has vault key.
+func (c *Controller) SetVaultKey(ctx context.Context, userId string, key []byte) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("set vault key")
+	if len(key) == 0 {
+		return ErrNoKey
+	}
+	if err := c.store.SetVaultKey(ctx, userId, key); err != nil {
+		log.Warnf("set vault key failed: %s", err.Error())
+		return err
+	}
+	log.Info("vault key set")
+	return nil
 }
@@ -3,7 +3,9 @@ package aaa
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -48,7 +50,7 @@ func TestController_Register(t *testing.T) {
 			controller := New(userStorage)
 			ctx := context.Background()
 			userStorage.EXPECT().AddUser(ctx, gomock.Any()).Return(models.User{}, tt.retErr).Times(1)
-			err := controller.Register(context.Background(), models.UserCredentials{})
+			err := controller.Register(context.Background(), models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)})
 			assert.ErrorIs(t, err, tt.wantErr, "expected error %s, got error %s", tt.wantErr, err)
 		})
 	}
@@ -102,7 +104,15 @@ func TestController_Login(t *testing.T) {
 			ctx := context.Background()
 			userStorage.EXPECT().GetUserByLogin(ctx, gomock.Any()).
 				Return(models.User{Id: "any", PasswordHash: string(tt.retHash)}, tt.retErr).Times(1)
-			token, err := controller.Login(ctx, models.UserCredentials{Password: tt.password})
+			if tt.wantErr == nil {
+				userStorage.EXPECT().AddSession(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, session models.Session) error {
+						assert.Equal(t, "any", session.UserId)
+						assert.Equal(t, "device", session.Device)
+						return nil
+					}).Times(1)
+			}
+			token, err := controller.Login(ctx, models.UserCredentials{Password: tt.password, Device: "device"})
 			if tt.wantErr == nil {
 				require.NoError(t, err, "no error expected")
 				require.NotEqual(t, "", token, "expected not empty token")
@@ -124,10 +134,21 @@ func TestController_Refresh(t *testing.T) {
 	defer mockController.Finish()
 	userStorage := mocks.NewMockUserStorage(mockController)
 	controller := New(userStorage)
+	session := jwtToken.GetTokenSession(valid)
 	t.Run("valid token", func(t *testing.T) {
+		userStorage.EXPECT().GetSession(gomock.Any(), session).
+			Return(models.Session{Id: session, UserId: "valid", CreatedAt: time.Now().Unix(), LastSeen: time.Now().Unix()}, nil).Times(1)
+		userStorage.EXPECT().TouchSession(gomock.Any(), session, gomock.Any()).Return(nil).Times(1)
 		token, err := controller.Refresh(context.Background(), valid)
 		require.NoError(t, err, "no error expected")
 		assert.NotEqual(t, "", token, "token should not be empty")
+		assert.Equal(t, session, jwtToken.GetTokenSession(token), "session should be kept")
+	})
+	t.Run("revoked session", func(t *testing.T) {
+		userStorage.EXPECT().GetSession(gomock.Any(), session).Return(models.Session{}, ErrNoSession).Times(1)
+		token, err := controller.Refresh(context.Background(), valid)
+		require.ErrorIs(t, err, ErrBadAuth)
+		assert.Equal(t, "", token, "token should be empty")
 	})
 	t.Run("invalid token", func(t *testing.T) {
 		token, err := controller.Refresh(context.Background(), invalid)
@@ -136,3 +157,130 @@ func TestController_Refresh(t *testing.T) {
 	})
 
 }
+
+func TestController_GetVaultKey(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	userStorage := mocks.NewMockUserStorage(mockController)
+
+	customError := errors.New("some custom error")
+
+	tests := []struct {
+		name    string
+		retKey  []byte
+		retErr  error
+		wantErr error
+	}{
+		{
+			name:   "key exists",
+			retKey: []byte("wrapped key"),
+		},
+		{
+			name:    "no key",
+			retKey:  nil,
+			wantErr: ErrNoKey,
+		},
+		{
+			name:    "user not found",
+			retErr:  ErrNotFound,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "other error",
+			retErr:  customError,
+			wantErr: customError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := New(userStorage)
+			ctx := context.Background()
+			userStorage.EXPECT().GetUserById(ctx, "userId").
+				Return(models.User{Id: "userId", VaultKey: tt.retKey}, tt.retErr).Times(1)
+			key, err := controller.GetVaultKey(ctx, "userId")
+			if tt.wantErr == nil {
+				require.NoError(t, err, "no error expected")
+				require.Equal(t, tt.retKey, key, "key mismatch")
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr, "expected error %s, got error %s", tt.wantErr, err)
+		})
+	}
+}
+
+func TestController_SetVaultKey(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	userStorage := mocks.NewMockUserStorage(mockController)
+
+	tests := []struct {
+		name      string
+		key       []byte
+		retErr    error
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "ok",
+			key:       []byte("wrapped key"),
+			wantCalls: 1,
+		},
+		{
+			name:      "already set",
+			key:       []byte("wrapped key"),
+			retErr:    ErrKeyExists,
+			wantErr:   ErrKeyExists,
+			wantCalls: 1,
+		},
+		{
+			name:    "empty key",
+			key:     nil,
+			wantErr: ErrNoKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := New(userStorage)
+			ctx := context.Background()
+			userStorage.EXPECT().SetVaultKey(ctx, "userId", tt.key).Return(tt.retErr).Times(tt.wantCalls)
+			err := controller.SetVaultKey(ctx, "userId", tt.key)
+			require.ErrorIs(t, err, tt.wantErr, "expected error %v, got error %v", tt.wantErr, err)
+		})
+	}
+}
+
+func TestController_Migrated(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	userStorage := mocks.NewMockUserStorage(mockController)
+	controller := New(userStorage)
+	ctx := context.Background()
+
+	userStorage.EXPECT().AddUser(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user models.User) (models.User, error) {
+		assert.True(t, user.Migrated, "new user should have nothing to migrate")
+		return user, nil
+	})
+	require.NoError(t, controller.Register(ctx, models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}))
+
+	userStorage.EXPECT().GetUserById(ctx, "userId").Return(models.User{Id: "userId"}, nil)
+	migrated, err := controller.IsMigrated(ctx, "userId")
+	require.NoError(t, err)
+	assert.False(t, migrated)
+	userStorage.EXPECT().GetUserById(ctx, "other").Return(models.User{}, ErrNotFound)
+	_, err = controller.IsMigrated(ctx, "other")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	userStorage.EXPECT().SetMigrated(ctx, "userId").Return(nil)
+	require.NoError(t, controller.SetMigrated(ctx, "userId"))
+	userStorage.EXPECT().SetMigrated(ctx, "other").Return(ErrNotFound)
+	require.ErrorIs(t, controller.SetMigrated(ctx, "other"), ErrNotFound)
+
+	purgeErr := errors.New("purge failed")
+	purged := ""
+	controller = New(userStorage, WithMigration(func(_ context.Context, userId string) error {
+		purged = userId
+		return purgeErr
+	}))
+	require.ErrorIs(t, controller.SetMigrated(ctx, "userId"), purgeErr, "user should not be marked migrated")
+	assert.Equal(t, "userId", purged)
+}
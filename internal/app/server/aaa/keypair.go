@@ -0,0 +1,55 @@
+package aaa
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// GetKeyPair returns user key pair. Private key is sealed by client with vault key,
+// so server is not able to use it.
+func (c *Controller) GetKeyPair(ctx context.Context, userId string) (models.KeyPair, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("get key pair")
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		log.Warnf("get key pair failed: %s", err.Error())
+		return models.KeyPair{}, err
+	}
+	if len(user.PublicKey) == 0 {
+		return models.KeyPair{}, ErrNoKey
+	}
+	return models.KeyPair{Public: user.PublicKey, Private: user.PrivateKey}, nil
+}
+
+// SetKeyPair stores user key pair. Key pair may be set only once,
+// ErrKeyExists is returned if user already has it.
+func (c *Controller) SetKeyPair(ctx context.Context, userId string, keys models.KeyPair) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("set key pair")
+	if len(keys.Public) == 0 || len(keys.Private) == 0 {
+		return ErrNoKey
+	}
+	if err := c.store.SetKeyPair(ctx, userId, keys); err != nil {
+		log.Warnf("set key pair failed: %s", err.Error())
+		return err
+	}
+	log.Info("key pair set")
+	return nil
+}
+
+// GetPublicKey returns public key of user with login, so vault key may be shared with the user.
+// ErrNoKey is returned, if user has not logged in with vaults support yet.
+func (c *Controller) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("login", login)
+	log.Debug("get public key")
+	user, err := c.store.GetUserByLogin(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.PublicKey) == 0 {
+		return nil, ErrNoKey
+	}
+	return user.PublicKey, nil
+}
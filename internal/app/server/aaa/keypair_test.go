@@ -0,0 +1,41 @@
+package aaa_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestController_KeyPair(t *testing.T) {
+	db := memdb.New()
+	c := aaa.New(db)
+	ctx := context.Background()
+	user, err := db.AddUser(ctx, models.User{Login: "user", State: models.StateActive})
+	require.NoError(t, err)
+
+	_, err = c.GetKeyPair(ctx, user.Id)
+	require.ErrorIs(t, err, aaa.ErrNoKey)
+	_, err = c.GetPublicKey(ctx, "user")
+	require.ErrorIs(t, err, aaa.ErrNoKey)
+	require.ErrorIs(t, c.SetKeyPair(ctx, user.Id, models.KeyPair{Public: []byte("public")}), aaa.ErrNoKey,
+		"private key is required")
+
+	keys := models.KeyPair{Public: []byte("public"), Private: []byte("sealed private")}
+	require.NoError(t, c.SetKeyPair(ctx, user.Id, keys))
+	require.ErrorIs(t, c.SetKeyPair(ctx, user.Id, keys), aaa.ErrKeyExists, "key pair should be set once")
+	got, err := c.GetKeyPair(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, keys, got)
+
+	public, err := c.GetPublicKey(ctx, "user")
+	require.NoError(t, err)
+	assert.Equal(t, keys.Public, public)
+	_, err = c.GetPublicKey(ctx, "unknown")
+	require.ErrorIs(t, err, aaa.ErrNotFound)
+}
@@ -0,0 +1,60 @@
+package aaa
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/ratelimit"
+)
+
+var ErrLocked = errors.New("account is temporarily locked, retry later")
+
+// WithLockout enables temporary user lockout after failed logins. Lockout time
+// grows exponentially with number of consecutive failures, according to policy.
+func WithLockout(policy ratelimit.Policy) func(c *Controller) {
+	return func(c *Controller) {
+		c.lockout = policy
+	}
+}
+
+// isLocked checks whether user login is temporarily locked
+func (c *Controller) isLocked(user models.User) bool {
+	return user.LockedUntil > time.Now().Unix()
+}
+
+// loginFailed records failed login of user and locks user, when failures exceed policy
+func (c *Controller) loginFailed(ctx context.Context, user models.User) {
+	if c.lockout.Base <= 0 {
+		return
+	}
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", user.Id)
+	// counter is incremented by storage, so concurrent failures are all counted
+	failures, err := c.store.AddLoginFailure(ctx, user.Id)
+	if err != nil {
+		log.Warnf("failed to record login failure: %s", err.Error())
+		return
+	}
+	d := c.lockout.Delay(failures)
+	if d <= 0 {
+		return
+	}
+	if err := c.store.LockUser(ctx, user.Id, time.Now().Add(d).Unix()); err != nil {
+		log.Warnf("failed to lock user: %s", err.Error())
+		return
+	}
+	log.Warnf("user is locked after %d failed logins", failures)
+}
+
+// loginSucceeded resets failed logins of user
+func (c *Controller) loginSucceeded(ctx context.Context, user models.User) {
+	if user.FailedLogins == 0 && user.LockedUntil == 0 {
+		return
+	}
+	if err := c.store.SetLoginFailures(ctx, user.Id, 0, 0); err != nil {
+		logger.Log().WithCtxRequestId(ctx).With("userId", user.Id).
+			Warnf("failed to reset login failures: %s", err.Error())
+	}
+}
@@ -0,0 +1,100 @@
+package aaa
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/ratelimit"
+	"yap-pwkeeper/mocks"
+)
+
+func TestController_LoginLockout(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	userStorage := mocks.NewMockUserStorage(mockController)
+	controller := New(userStorage, WithLockout(ratelimit.Policy{Free: 2, Base: time.Minute, Max: time.Hour}))
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := models.User{Id: "user", PasswordHash: string(hash), State: models.StateActive}
+
+	t.Run("free failure", func(t *testing.T) {
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(user, nil).Times(1)
+		userStorage.EXPECT().AddLoginFailure(ctx, "user").Return(1, nil).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: "bad"})
+		require.ErrorIs(t, err, ErrBadAuth)
+	})
+
+	t.Run("failure locks user", func(t *testing.T) {
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(user, nil).Times(1)
+		// stale user record: failures counted by concurrent logins are returned by storage
+		userStorage.EXPECT().AddLoginFailure(ctx, "user").Return(3, nil).Times(1)
+		userStorage.EXPECT().LockUser(ctx, "user", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, lockedUntil int64) error {
+				assert.InDelta(t, time.Now().Add(time.Minute).Unix(), lockedUntil, 2, "expect lockout for base delay")
+				return nil
+			}).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: "bad"})
+		require.ErrorIs(t, err, ErrBadAuth)
+	})
+
+	t.Run("locked user", func(t *testing.T) {
+		locked := user
+		locked.FailedLogins = 3
+		locked.LockedUntil = time.Now().Add(time.Minute).Unix()
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(locked, nil).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: "password"})
+		require.ErrorIs(t, err, ErrLocked, "even valid password is rejected")
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		expired := user
+		expired.FailedLogins = 3
+		expired.LockedUntil = time.Now().Add(-time.Second).Unix()
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(expired, nil).Times(1)
+		userStorage.EXPECT().SetLoginFailures(ctx, "user", 0, int64(0)).Return(nil).Times(1)
+		userStorage.EXPECT().AddSession(ctx, gomock.Any()).Return(nil).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: "password"})
+		require.NoError(t, err)
+	})
+}
+
+func TestController_LoginLegacy(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	userStorage := mocks.NewMockUserStorage(mockController)
+	controller := New(userStorage, WithLockout(ratelimit.Policy{Free: 2, Base: time.Minute, Max: time.Hour}))
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("master password"), bcrypt.MinCost)
+	legacy := models.User{Id: "user", PasswordHash: string(hash), State: models.StateActive}
+	auth := strings.Repeat("ab", 32)
+
+	t.Run("auth value of legacy account", func(t *testing.T) {
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(legacy, nil).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: auth})
+		require.ErrorIs(t, err, ErrLegacy, "master password is required, failure is not counted")
+	})
+
+	t.Run("bad master password of legacy account", func(t *testing.T) {
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(legacy, nil).Times(1)
+		userStorage.EXPECT().AddLoginFailure(ctx, "user").Return(1, nil).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: "bad"})
+		require.ErrorIs(t, err, ErrBadAuth)
+	})
+
+	t.Run("bad auth value of migrated account", func(t *testing.T) {
+		derived := legacy
+		derived.Derived = true
+		userStorage.EXPECT().GetUserByLogin(ctx, "login").Return(derived, nil).Times(1)
+		userStorage.EXPECT().AddLoginFailure(ctx, "user").Return(1, nil).Times(1)
+		_, err := controller.Login(ctx, models.UserCredentials{Login: "login", Password: auth})
+		require.ErrorIs(t, err, ErrBadAuth, "legacy login is never suggested for migrated account")
+	})
+}
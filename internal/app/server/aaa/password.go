@@ -0,0 +1,119 @@
+package aaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// ErrNoPassword is returned when new password is empty
+var ErrNoPassword = errors.New("new password is empty")
+
+// ChangePassword checks old user password and replaces it by the new one. User vault key
+// is wrapped by client with the key, derived from master password, so the key, re-wrapped
+// with the new one, should be provided, if user has vault key. Password and vault key are stored in one
+// update. All user sessions, except the current one, are revoked. New password should match policy.
+// Wrong old password is counted as failed login, ErrLocked is returned, while user is locked.
+func (c *Controller) ChangePassword(ctx context.Context, userId string, sessionId string, oldPassword, newPassword string, vaultKey []byte) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId, "sessionId", sessionId)
+	log.Debug("change password")
+	if newPassword == "" {
+		return ErrNoPassword
+	}
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		log.Warnf("change password failed: %s", err.Error())
+		return err
+	}
+	if c.isLocked(user) {
+		log.Warn("change password rejected: user is locked")
+		return ErrLocked
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
+		log.Warnf("change password failed: %s", err.Error())
+		c.loginFailed(ctx, user)
+		return ErrBadAuth
+	}
+	c.loginSucceeded(ctx, user)
+	if err := c.policy.CheckPassword(ctx, newPassword); err != nil {
+		log.Infof("change password rejected: %s", err.Error())
+		return err
+	}
+	if len(user.VaultKey) > 0 && len(vaultKey) == 0 {
+		log.Warn("change password failed: vault key is not re-wrapped")
+		return ErrNoKey
+	}
+	pwHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to generate passsword hash: %w", err)
+	}
+	if len(vaultKey) == 0 {
+		vaultKey = nil
+	}
+	if err := c.store.SetPassword(ctx, userId, string(pwHash), vaultKey); err != nil {
+		log.Warnf("change password failed: %s", err.Error())
+		return err
+	}
+	log.Info("password changed")
+	c.revokeOtherSessions(ctx, userId, sessionId)
+	return nil
+}
+
+// DeleteAccount checks user password and deletes user account. User data is removed
+// first, so deletion may be retried if it fails. Then user is deactivated and all user
+// sessions are revoked. Wrong password is counted as failed login, like in ChangePassword.
+func (c *Controller) DeleteAccount(ctx context.Context, userId string, password string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("delete account")
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		log.Warnf("delete account failed: %s", err.Error())
+		return err
+	}
+	if c.isLocked(user) {
+		log.Warn("delete account rejected: user is locked")
+		return ErrLocked
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		log.Warnf("delete account failed: %s", err.Error())
+		c.loginFailed(ctx, user)
+		return ErrBadAuth
+	}
+	if c.cleanup != nil {
+		if err := c.cleanup(ctx, userId); err != nil {
+			log.Errorf("delete account data failed: %s", err.Error())
+			return err
+		}
+	}
+	if err := c.store.DeleteUser(ctx, userId); err != nil {
+		log.Errorf("delete account failed: %s", err.Error())
+		return err
+	}
+	c.revokeOtherSessions(ctx, userId, "")
+	log.Info("account deleted")
+	return nil
+}
+
+// revokeOtherSessions removes all user sessions, except the current one
+func (c *Controller) revokeOtherSessions(ctx context.Context, userId string, currentId string) {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	sessions, err := c.store.GetSessions(ctx, userId)
+	if err != nil {
+		log.Errorf("failed to get sessions to revoke: %s", err.Error())
+		return
+	}
+	for _, session := range sessions {
+		if session.Id == currentId {
+			continue
+		}
+		if err := c.endSession(ctx, session.Id, userId); err != nil && !errors.Is(err, ErrNoSession) {
+			log.With("sessionId", session.Id).Errorf("failed to revoke session: %s", err.Error())
+			continue
+		}
+		log.With("sessionId", session.Id).Info("session revoked")
+	}
+}
@@ -0,0 +1,111 @@
+package aaa_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/jwtToken"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/ratelimit"
+)
+
+func TestController_ChangePassword(t *testing.T) {
+	db := memdb.New()
+	c := aaa.New(db)
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, c.Register(ctx, cred))
+	current, err := c.Login(ctx, cred)
+	require.NoError(t, err)
+	other, err := c.Login(ctx, cred)
+	require.NoError(t, err)
+	userId, sessionId := jwtToken.GetTokenSubject(current), jwtToken.GetTokenSession(current)
+	require.NoError(t, c.SetVaultKey(ctx, userId, []byte("wrapped")))
+
+	err = c.ChangePassword(ctx, userId, sessionId, "bad", strings.Repeat("cd", 32), []byte("rewrapped"))
+	require.ErrorIs(t, err, aaa.ErrBadAuth, "old password should be checked")
+	err = c.ChangePassword(ctx, userId, sessionId, cred.Password, strings.Repeat("cd", 32), nil)
+	require.ErrorIs(t, err, aaa.ErrNoKey, "vault key should be re-wrapped")
+	err = c.ChangePassword(ctx, userId, sessionId, cred.Password, "", []byte("rewrapped"))
+	require.ErrorIs(t, err, aaa.ErrNoPassword)
+	assert.True(t, c.Validate(ctx, other), "failed change should not revoke sessions")
+
+	require.NoError(t, c.ChangePassword(ctx, userId, sessionId, cred.Password, strings.Repeat("cd", 32), []byte("rewrapped")))
+	key, err := c.GetVaultKey(ctx, userId)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("rewrapped"), key)
+	assert.True(t, c.Validate(ctx, current), "current session should be kept")
+	assert.False(t, c.Validate(ctx, other), "other sessions should be revoked")
+
+	_, err = c.Login(ctx, cred)
+	require.ErrorIs(t, err, aaa.ErrBadAuth, "old password should not be accepted")
+	cred.Password = strings.Repeat("cd", 32)
+	_, err = c.Login(ctx, cred)
+	require.NoError(t, err)
+}
+
+func TestController_DeleteAccount(t *testing.T) {
+	db := memdb.New()
+	var cleaned []string
+	cleanupErr := errors.New("cleanup failed")
+	var failCleanup bool
+	c := aaa.New(db, aaa.WithAccountCleanup(func(_ context.Context, userId string) error {
+		if failCleanup {
+			return cleanupErr
+		}
+		cleaned = append(cleaned, userId)
+		return nil
+	}))
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, c.Register(ctx, cred))
+	token, err := c.Login(ctx, cred)
+	require.NoError(t, err)
+	userId := jwtToken.GetTokenSubject(token)
+
+	require.ErrorIs(t, c.DeleteAccount(ctx, userId, "bad"), aaa.ErrBadAuth)
+	failCleanup = true
+	require.ErrorIs(t, c.DeleteAccount(ctx, userId, cred.Password), cleanupErr)
+	assert.True(t, c.Validate(ctx, token), "account should be kept, if data is not removed")
+	failCleanup = false
+
+	require.NoError(t, c.DeleteAccount(ctx, userId, cred.Password))
+	assert.Equal(t, []string{userId}, cleaned)
+	assert.False(t, c.Validate(ctx, token), "sessions should be revoked")
+	_, err = c.Login(ctx, cred)
+	require.ErrorIs(t, err, aaa.ErrBadAuth, "deleted user should not login")
+	require.ErrorIs(t, c.Register(ctx, cred), aaa.ErrDuplicate, "login should stay reserved")
+}
+
+func TestController_PasswordCheckLockout(t *testing.T) {
+	db := memdb.New()
+	c := aaa.New(db, aaa.WithLockout(ratelimit.Policy{Free: 2, Base: time.Minute, Max: time.Hour}))
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, c.Register(ctx, cred))
+	token, err := c.Login(ctx, cred)
+	require.NoError(t, err)
+	userId, sessionId := jwtToken.GetTokenSubject(token), jwtToken.GetTokenSession(token)
+
+	// failures of both password checks are counted together with failed logins
+	err = c.ChangePassword(ctx, userId, sessionId, "bad", strings.Repeat("cd", 32), nil)
+	require.ErrorIs(t, err, aaa.ErrBadAuth)
+	require.ErrorIs(t, c.DeleteAccount(ctx, userId, "bad"), aaa.ErrBadAuth)
+	_, err = c.Login(ctx, models.UserCredentials{Login: cred.Login, Password: "bad"})
+	require.ErrorIs(t, err, aaa.ErrBadAuth)
+
+	err = c.ChangePassword(ctx, userId, sessionId, cred.Password, strings.Repeat("cd", 32), nil)
+	require.ErrorIs(t, err, aaa.ErrLocked, "even valid password is rejected")
+	require.ErrorIs(t, c.DeleteAccount(ctx, userId, cred.Password), aaa.ErrLocked, "even valid password is rejected")
+	_, err = c.Login(ctx, cred)
+	require.ErrorIs(t, err, aaa.ErrLocked)
+	assert.True(t, c.Validate(ctx, token), "locked user should keep session")
+}
@@ -0,0 +1,100 @@
+package aaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+var ErrPolicy = errors.New("credentials do not match policy")
+
+// DefaultLoginPattern allows logins of letters, digits and a few separators, e-mail addresses fit it
+var DefaultLoginPattern = regexp.MustCompile(`^[\p{L}\p{N}._@+-]{3,64}$`)
+
+// authPattern is the form of password, derived by client from user master password
+var authPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// CredentialsPolicy is a set of requirements to new logins and passwords.
+// Server never sees master password: password requirements are provided to
+// clients (see Controller.PasswordPolicy) and are checked by them, server checks
+// logins and accepts only passwords derived by client. It is a trade-off: modified
+// client may set weak master password of its own account, but master password is
+// not exposed to server, which stores encrypted data of all users.
+type CredentialsPolicy struct {
+	MinLength  int            // minimal password length in characters
+	MinClasses int            // minimal number of character classes in password: lower, upper, digits, other
+	Login      *regexp.Regexp // allowed logins, nil allows any non-empty login
+	// Breached returns hash suffixes of breached passwords by hash prefix, nil disables check
+	Breached func(ctx context.Context, prefix string) ([]string, error)
+}
+
+// DefaultPolicy is used, unless other policy is set by WithPolicy
+var DefaultPolicy = CredentialsPolicy{
+	MinLength:  8,
+	MinClasses: 1,
+	Login:      DefaultLoginPattern,
+}
+
+// WithPolicy sets requirements to logins and passwords of new users and password changes
+func WithPolicy(policy CredentialsPolicy) func(c *Controller) {
+	return func(c *Controller) {
+		c.policy = policy
+	}
+}
+
+// Check returns ErrPolicy with all reasons of rejection, when login does not match
+// policy or password is not derived by client from master password.
+func (p CredentialsPolicy) Check(_ context.Context, login, password string) error {
+	var reasons []string
+	switch {
+	case login == "":
+		reasons = append(reasons, "login is empty")
+	case p.Login != nil && !p.Login.MatchString(login):
+		reasons = append(reasons, "login should match "+p.Login.String())
+	}
+	return policyErr(append(reasons, passwordReasons(password)...))
+}
+
+// CheckPassword is Check of password only, login of existing user is not checked
+// again on password change, it may be registered with other policy
+func (p CredentialsPolicy) CheckPassword(_ context.Context, password string) error {
+	return policyErr(passwordReasons(password))
+}
+
+// passwordReasons returns reasons of password rejection
+func passwordReasons(password string) []string {
+	if !authPattern.MatchString(password) {
+		return []string{"password should be derived from master password, update client application"}
+	}
+	return nil
+}
+
+// policyErr joins rejection reasons into ErrPolicy, nil is returned without reasons
+func policyErr(reasons []string) error {
+	if len(reasons) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrPolicy, strings.Join(reasons, "; "))
+}
+
+// PasswordPolicy returns requirements to master passwords, which are checked by clients
+func (c *Controller) PasswordPolicy(_ context.Context) models.PasswordPolicy {
+	return models.PasswordPolicy{
+		MinLength:  c.policy.MinLength,
+		MinClasses: c.policy.MinClasses,
+		Breached:   c.policy.Breached != nil,
+	}
+}
+
+// BreachedRange returns hash suffixes of breached passwords with hash prefix, so client
+// checks password without revealing it. Nothing is returned, if check is disabled.
+func (c *Controller) BreachedRange(ctx context.Context, prefix string) ([]string, error) {
+	if c.policy.Breached == nil {
+		return nil, nil
+	}
+	return c.policy.Breached(ctx, prefix)
+}
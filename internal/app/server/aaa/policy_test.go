@@ -0,0 +1,71 @@
+package aaa
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestCredentialsPolicy_Check(t *testing.T) {
+	auth := strings.Repeat("0f", 32)
+	policy := CredentialsPolicy{MinLength: 8, MinClasses: 3, Login: DefaultLoginPattern}
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		reasons  []string
+	}{
+		{name: "good", login: "user@example.com", password: auth},
+		{name: "empty", reasons: []string{"login is empty", "derived from master password"}},
+		{name: "bad login", login: "us", password: auth, reasons: []string{"login should match"}},
+		{name: "master password", login: "user", password: "Good password 1", reasons: []string{"derived from master password"}},
+		{name: "upper case", login: "user", password: strings.ToUpper(auth), reasons: []string{"derived from master password"}},
+		{name: "short", login: "user", password: auth[2:], reasons: []string{"derived from master password"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(context.Background(), tt.login, tt.password)
+			if len(tt.reasons) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrPolicy)
+			for _, reason := range tt.reasons {
+				assert.Contains(t, err.Error(), reason)
+			}
+		})
+	}
+
+	t.Run("password only", func(t *testing.T) {
+		require.NoError(t, policy.CheckPassword(context.Background(), auth))
+		require.ErrorIs(t, policy.CheckPassword(context.Background(), "Good password 1"), ErrPolicy)
+	})
+
+	t.Run("default policy rejects empty credentials", func(t *testing.T) {
+		c := New(nil)
+		require.ErrorIs(t, c.Register(context.Background(), models.UserCredentials{}), ErrPolicy)
+	})
+}
+
+func TestController_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	c := New(nil, WithPolicy(CredentialsPolicy{MinLength: 12, MinClasses: 2}))
+	assert.Equal(t, models.PasswordPolicy{MinLength: 12, MinClasses: 2}, c.PasswordPolicy(ctx))
+	suffixes, err := c.BreachedRange(ctx, "5BAA6")
+	require.NoError(t, err)
+	assert.Empty(t, suffixes, "disabled check should return nothing")
+
+	breachedRange := func(_ context.Context, prefix string) ([]string, error) {
+		return []string{prefix + "-suffix"}, nil
+	}
+	c = New(nil, WithPolicy(CredentialsPolicy{MinLength: 8, Breached: breachedRange}))
+	assert.True(t, c.PasswordPolicy(ctx).Breached)
+	suffixes, err = c.BreachedRange(ctx, "5BAA6")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5BAA6-suffix"}, suffixes)
+}
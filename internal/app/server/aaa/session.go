@@ -0,0 +1,185 @@
+package aaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/jwtToken"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// WithSessionLimits limits session lifetime since login and since its last token refresh.
+// Zero maxAge leaves sessions unlimited, zero maxIdle expires session with its last token.
+func WithSessionLimits(maxAge time.Duration, maxIdle time.Duration) func(c *Controller) {
+	return func(c *Controller) {
+		c.maxAge, c.maxIdle = maxAge, maxIdle
+	}
+}
+
+// WithSessionEnd sets function, which is called when session is finished: on logout,
+// revocation, password change or expiration. It should close live streams of session.
+func WithSessionEnd(fn func(sessionId string)) func(c *Controller) {
+	return func(c *Controller) {
+		c.onEnd = fn
+	}
+}
+
+// endSession removes session and notifies about it
+func (c *Controller) endSession(ctx context.Context, sessionId string, userId string) error {
+	if err := c.store.DeleteSession(ctx, sessionId, userId); err != nil {
+		return err
+	}
+	if c.onEnd != nil {
+		c.onEnd(sessionId)
+	}
+	return nil
+}
+
+// expired checks whether session exceeds its lifetime limits
+func (c *Controller) expired(session models.Session) bool {
+	now := time.Now()
+	maxIdle := c.maxIdle
+	if maxIdle <= 0 {
+		maxIdle = jwtToken.TTL()
+	}
+	if session.LastSeen < now.Add(-maxIdle).Unix() {
+		return true
+	}
+	return c.maxAge > 0 && session.CreatedAt < now.Add(-c.maxAge).Unix()
+}
+
+// newSession records new user session and returns its first token
+func (c *Controller) newSession(ctx context.Context, userId string, device string) (string, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("new user session")
+	token, err := jwtToken.NewToken(userId)
+	if err != nil {
+		log.Errorf("user session failed: %s: %s", ErrToken.Error(), err.Error())
+		return "", fmt.Errorf("%w: %w", ErrToken, err)
+	}
+	now := time.Now().Unix()
+	session := models.Session{
+		Id:        jwtToken.GetTokenSession(token),
+		UserId:    userId,
+		Device:    device,
+		CreatedAt: now,
+		LastSeen:  now,
+	}
+	if err := c.store.AddSession(ctx, session); err != nil {
+		log.Errorf("user session failed: %s", err.Error())
+		return "", err
+	}
+	log.With("sessionId", session.Id).Info("user session succeeded")
+	return token, nil
+}
+
+// Refresh issues new token instead of actual to provide continuous sessions.
+// Tokens of revoked and expired sessions are not refreshed.
+func (c *Controller) Refresh(ctx context.Context, token string) (string, error) {
+	sessionId := jwtToken.GetTokenSession(token)
+	log := logger.Log().WithCtxRequestId(ctx).
+		With("userId", jwtToken.GetTokenSubject(token), "sessionId", sessionId)
+	log.Debug("session refresh")
+	if !c.Validate(ctx, token) {
+		log.Warn("session refresh failed: invalid token or revoked session")
+		return "", ErrBadAuth
+	}
+	newToken, err := jwtToken.RefreshToken(token)
+	if err != nil {
+		if errors.Is(jwtToken.ErrInvalid, err) {
+			log.Warnf("session refresh failed: %s", err.Error())
+			return "", ErrBadAuth
+		} else {
+			log.Errorf("session refresh failed: %s", err.Error())
+		}
+		return "", err
+	}
+	if err := c.store.TouchSession(ctx, sessionId, time.Now().Unix()); err != nil {
+		log.Warnf("session refresh failed: %s", err.Error())
+		if errors.Is(err, ErrNoSession) {
+			return "", ErrBadAuth
+		}
+		return "", err
+	}
+	log.Info("session refresh succeeded")
+	return newToken, nil
+}
+
+// Validate is a method to check token validity. Token session should not be revoked
+// or expired, expired session is removed.
+func (c *Controller) Validate(ctx context.Context, token string) bool {
+	if !jwtToken.Valid(token) {
+		return false
+	}
+	session, err := c.store.GetSession(ctx, jwtToken.GetTokenSession(token))
+	if err != nil {
+		if !errors.Is(err, ErrNoSession) {
+			logger.Log().WithCtxRequestId(ctx).Errorf("session validation failed: %s", err.Error())
+		}
+		return false
+	}
+	if session.UserId != jwtToken.GetTokenSubject(token) {
+		return false
+	}
+	if c.expired(session) {
+		log := logger.Log().WithCtxRequestId(ctx).With("userId", session.UserId, "sessionId", session.Id)
+		if err := c.endSession(ctx, session.Id, session.UserId); err != nil && !errors.Is(err, ErrNoSession) {
+			log.Warnf("expired session removal failed: %s", err.Error())
+		} else {
+			log.Info("session expired")
+		}
+		return false
+	}
+	return true
+}
+
+// Logout finishes user session, its tokens become invalid
+func (c *Controller) Logout(ctx context.Context, userId string, sessionId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId, "sessionId", sessionId)
+	log.Debug("logout")
+	if err := c.endSession(ctx, sessionId, userId); err != nil {
+		log.Warnf("logout failed: %s", err.Error())
+		return err
+	}
+	log.Info("logout succeeded")
+	return nil
+}
+
+// ListSessions returns active user sessions, the oldest first. Session with currentId
+// is marked as current. Expired sessions are removed.
+func (c *Controller) ListSessions(ctx context.Context, userId string, currentId string) ([]models.Session, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("list sessions")
+	sessions, err := c.store.GetSessions(ctx, userId)
+	if err != nil {
+		log.Warnf("list sessions failed: %s", err.Error())
+		return nil, err
+	}
+	active := make([]models.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if c.expired(session) && session.Id != currentId {
+			if err := c.endSession(ctx, session.Id, userId); err != nil && !errors.Is(err, ErrNoSession) {
+				log.With("sessionId", session.Id).Warnf("expired session removal failed: %s", err.Error())
+			}
+			continue
+		}
+		session.Current = session.Id == currentId
+		active = append(active, session)
+	}
+	return active, nil
+}
+
+// RevokeSession finishes other user session, e.g. on lost device
+func (c *Controller) RevokeSession(ctx context.Context, userId string, sessionId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId, "sessionId", sessionId)
+	log.Debug("revoke session")
+	if err := c.endSession(ctx, sessionId, userId); err != nil {
+		log.Warnf("revoke session failed: %s", err.Error())
+		return err
+	}
+	log.Info("session revoked")
+	return nil
+}
@@ -0,0 +1,115 @@
+package aaa_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/jwtToken"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestController_Sessions(t *testing.T) {
+	db := memdb.New()
+	c := aaa.New(db)
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, c.Register(ctx, cred))
+
+	login := func(t *testing.T, device string) string {
+		withDevice := cred
+		withDevice.Device = device
+		token, err := c.Login(ctx, withDevice)
+		require.NoError(t, err)
+		require.True(t, c.Validate(ctx, token))
+		return token
+	}
+	laptop, phone := login(t, "laptop"), login(t, "phone")
+	userId := jwtToken.GetTokenSubject(laptop)
+	laptopId, phoneId := jwtToken.GetTokenSession(laptop), jwtToken.GetTokenSession(phone)
+
+	t.Run("list", func(t *testing.T) {
+		sessions, err := c.ListSessions(ctx, userId, laptopId)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		for _, s := range sessions {
+			assert.Equal(t, s.Id == laptopId, s.Current, "only requester session should be current")
+		}
+		assert.ElementsMatch(t, []string{"laptop", "phone"}, []string{sessions[0].Device, sessions[1].Device})
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		old := models.Session{Id: "old", UserId: userId, LastSeen: time.Now().Add(-jwtToken.TTL() - time.Minute).Unix()}
+		require.NoError(t, db.AddSession(ctx, old))
+		sessions, err := c.ListSessions(ctx, userId, laptopId)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 2, "expired session should not be listed")
+		_, err = db.GetSession(ctx, old.Id)
+		require.ErrorIs(t, err, aaa.ErrNoSession, "expired session should be removed")
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		require.ErrorIs(t, c.RevokeSession(ctx, "other", phoneId), aaa.ErrNoSession,
+			"session of other user should not be revoked")
+		require.NoError(t, c.RevokeSession(ctx, userId, phoneId))
+		assert.False(t, c.Validate(ctx, phone), "token of revoked session should be rejected")
+		_, err := c.Refresh(ctx, phone)
+		require.ErrorIs(t, err, aaa.ErrBadAuth, "token of revoked session should not be refreshed")
+		assert.True(t, c.Validate(ctx, laptop), "other sessions should stay valid")
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		refreshed, err := c.Refresh(ctx, laptop)
+		require.NoError(t, err)
+		require.NoError(t, c.Logout(ctx, userId, laptopId))
+		assert.False(t, c.Validate(ctx, laptop))
+		assert.False(t, c.Validate(ctx, refreshed), "all tokens of session should be rejected")
+		require.ErrorIs(t, c.Logout(ctx, userId, laptopId), aaa.ErrNoSession)
+	})
+}
+
+func TestController_SessionLimits(t *testing.T) {
+	db := memdb.New()
+	c := aaa.New(db, aaa.WithSessionLimits(24*time.Hour, time.Hour))
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, c.Register(ctx, cred))
+	token, err := c.Login(ctx, cred)
+	require.NoError(t, err)
+	userId, sessionId := jwtToken.GetTokenSubject(token), jwtToken.GetTokenSession(token)
+	session, err := db.GetSession(ctx, sessionId)
+	require.NoError(t, err)
+
+	t.Run("active", func(t *testing.T) {
+		assert.True(t, c.Validate(ctx, token))
+		refreshed, err := c.Refresh(ctx, token)
+		require.NoError(t, err)
+		assert.True(t, c.Validate(ctx, refreshed))
+	})
+
+	t.Run("idle", func(t *testing.T) {
+		require.NoError(t, db.TouchSession(ctx, sessionId, time.Now().Add(-2*time.Hour).Unix()))
+		assert.False(t, c.Validate(ctx, token), "idle session should be rejected")
+		_, err := db.GetSession(ctx, sessionId)
+		require.ErrorIs(t, err, aaa.ErrNoSession, "idle session should be removed")
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		session.CreatedAt = time.Now().Add(-25 * time.Hour).Unix()
+		session.LastSeen = time.Now().Unix()
+		require.NoError(t, db.AddSession(ctx, session))
+		_, err := c.Refresh(ctx, token)
+		require.ErrorIs(t, err, aaa.ErrBadAuth, "session older than max age should not be refreshed")
+		_, err = db.GetSession(ctx, sessionId)
+		require.ErrorIs(t, err, aaa.ErrNoSession, "old session should be removed")
+		sessions, err := c.ListSessions(ctx, userId, "")
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
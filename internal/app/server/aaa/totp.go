@@ -0,0 +1,192 @@
+package aaa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/totp"
+)
+
+var (
+	ErrTotpRequired    = errors.New("one-time code required")
+	ErrTotpEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTotpNotEnrolled = errors.New("two-factor authentication is not enrolled")
+	ErrBadCode         = errors.New("invalid one-time code")
+)
+
+const (
+	// totpIssuer is shown by authenticator applications next to account name
+	totpIssuer = "yap-pwkeeper"
+	// recoveryCodes is number of recovery codes, issued on enrollment
+	recoveryCodes = 10
+	// recoveryCodeSize is number of random bytes of recovery code
+	recoveryCodeSize = 5
+)
+
+// EnrollTotp generates new second factor secret and recovery codes. Second factor is not
+// required to log in until it is confirmed by ConfirmTotp. Repeated enrollment replaces
+// unconfirmed secret.
+func (c *Controller) EnrollTotp(ctx context.Context, userId string) (models.TotpEnrollment, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("totp enrollment")
+	c.totpMu.Lock()
+	defer c.totpMu.Unlock()
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		return models.TotpEnrollment{}, err
+	}
+	if user.Totp != nil && user.Totp.Enabled {
+		return models.TotpEnrollment{}, ErrTotpEnabled
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return models.TotpEnrollment{}, err
+	}
+	enrollment := models.TotpEnrollment{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, user.Login, secret),
+	}
+	pending := &models.Totp{Secret: secret}
+	for i := 0; i < recoveryCodes; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return models.TotpEnrollment{}, err
+		}
+		enrollment.RecoveryCodes = append(enrollment.RecoveryCodes, code)
+		pending.RecoveryCodes = append(pending.RecoveryCodes, hashRecoveryCode(code))
+	}
+	if err := c.store.SetTotp(ctx, userId, pending); err != nil {
+		log.Warnf("totp enrollment failed: %s", err.Error())
+		return models.TotpEnrollment{}, err
+	}
+	log.Info("totp enrolled")
+	return enrollment, nil
+}
+
+// ConfirmTotp enables enrolled second factor, when code from authenticator is valid
+func (c *Controller) ConfirmTotp(ctx context.Context, userId string, code string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("totp confirmation")
+	c.totpMu.Lock()
+	defer c.totpMu.Unlock()
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.Totp == nil {
+		return ErrTotpNotEnrolled
+	}
+	if user.Totp.Enabled {
+		return ErrTotpEnabled
+	}
+	t, err := verifyCode(user.Totp, code, false)
+	if err != nil {
+		log.Warnf("totp confirmation failed: %s", err.Error())
+		return err
+	}
+	t.Enabled = true
+	if err := c.store.SetTotp(ctx, userId, t); err != nil {
+		return err
+	}
+	log.Info("totp enabled")
+	return nil
+}
+
+// DisableTotp removes second factor. Code from authenticator or unused recovery code is required.
+func (c *Controller) DisableTotp(ctx context.Context, userId string, code string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("totp disable")
+	c.totpMu.Lock()
+	defer c.totpMu.Unlock()
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.Totp == nil || !user.Totp.Enabled {
+		return ErrTotpNotEnrolled
+	}
+	if _, err := verifyCode(user.Totp, code, true); err != nil {
+		log.Warnf("totp disable failed: %s", err.Error())
+		return err
+	}
+	if err := c.store.SetTotp(ctx, userId, nil); err != nil {
+		return err
+	}
+	log.Info("totp disabled")
+	return nil
+}
+
+// checkTotp verifies second factor of user login. Used code is stored, so it may not be used again.
+func (c *Controller) checkTotp(ctx context.Context, userId string, code string) error {
+	c.totpMu.Lock()
+	defer c.totpMu.Unlock()
+	// user is read again, as concurrent login may use the code
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.Totp == nil || !user.Totp.Enabled {
+		return nil
+	}
+	t, err := verifyCode(user.Totp, code, true)
+	if err != nil {
+		return err
+	}
+	return c.store.SetTotp(ctx, userId, t)
+}
+
+// verifyCode checks code from authenticator or, if recovery is allowed, recovery code.
+// Returns second factor, updated to reject the same code next time.
+func verifyCode(t *models.Totp, code string, recovery bool) (*models.Totp, error) {
+	updated := *t
+	code = normalizeCode(code)
+	if step, ok := totp.Validate(t.Secret, code, time.Now()); ok {
+		if step <= t.LastStep {
+			return nil, ErrBadCode
+		}
+		updated.LastStep = step
+		return &updated, nil
+	}
+	if !recovery {
+		return nil, ErrBadCode
+	}
+	hash := hashRecoveryCode(code)
+	updated.RecoveryCodes = nil
+	for _, h := range t.RecoveryCodes {
+		if h != hash {
+			updated.RecoveryCodes = append(updated.RecoveryCodes, h)
+		}
+	}
+	if len(updated.RecoveryCodes) == len(t.RecoveryCodes) {
+		return nil, ErrBadCode
+	}
+	return &updated, nil
+}
+
+// newRecoveryCode returns random code formatted as xxxx-xxxx
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:len(code)/2] + "-" + code[len(code)/2:], nil
+}
+
+// normalizeCode removes formatting, users may type
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package aaa_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/totp"
+)
+
+func TestController_Totp(t *testing.T) {
+	db := memdb.New()
+	c := aaa.New(db)
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, c.Register(ctx, cred))
+	user, err := db.GetUserByLogin(ctx, cred.Login)
+	require.NoError(t, err)
+
+	// codes of different time steps, so each of them may be used once
+	code := func(t *testing.T, secret string, steps int) string {
+		c, err := totp.Code(secret, time.Now().Add(time.Duration(steps)*totp.Step))
+		require.NoError(t, err)
+		return c
+	}
+
+	require.ErrorIs(t, c.ConfirmTotp(ctx, user.Id, "000000"), aaa.ErrTotpNotEnrolled)
+	enrollment, err := c.EnrollTotp(ctx, user.Id)
+	require.NoError(t, err)
+	require.Len(t, enrollment.RecoveryCodes, 10)
+	u, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, enrollment.Secret, u.Query().Get("secret"))
+
+	_, err = c.Login(ctx, cred)
+	require.NoError(t, err, "unconfirmed second factor should not be required")
+	require.ErrorIs(t, c.ConfirmTotp(ctx, user.Id, enrollment.RecoveryCodes[0]), aaa.ErrBadCode,
+		"recovery code should not confirm second factor")
+	require.NoError(t, c.ConfirmTotp(ctx, user.Id, code(t, enrollment.Secret, -1)))
+	_, err = c.EnrollTotp(ctx, user.Id)
+	require.ErrorIs(t, err, aaa.ErrTotpEnabled)
+
+	t.Run("login", func(t *testing.T) {
+		_, err := c.Login(ctx, cred)
+		require.ErrorIs(t, err, aaa.ErrTotpRequired)
+		bad := cred
+		bad.Password = "bad"
+		bad.Totp = code(t, enrollment.Secret, 0)
+		_, err = c.Login(ctx, bad)
+		require.ErrorIs(t, err, aaa.ErrBadAuth, "password should be checked first")
+
+		withCode := cred
+		withCode.Totp = code(t, enrollment.Secret, 0)
+		token, err := c.Login(ctx, withCode)
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		_, err = c.Login(ctx, withCode)
+		require.ErrorIs(t, err, aaa.ErrBadAuth, "code should not be reused")
+
+		withCode.Totp = code(t, enrollment.Secret, -1)
+		_, err = c.Login(ctx, withCode)
+		require.ErrorIs(t, err, aaa.ErrBadAuth, "code older than the last used should be rejected")
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		withCode := cred
+		withCode.Totp = " " + enrollment.RecoveryCodes[1] + " "
+		_, err := c.Login(ctx, withCode)
+		require.NoError(t, err)
+		_, err = c.Login(ctx, withCode)
+		require.ErrorIs(t, err, aaa.ErrBadAuth, "recovery code should be used once")
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		require.ErrorIs(t, c.DisableTotp(ctx, user.Id, "bad"), aaa.ErrBadCode)
+		require.NoError(t, c.DisableTotp(ctx, user.Id, enrollment.RecoveryCodes[2]))
+		_, err := c.Login(ctx, cred)
+		require.NoError(t, err)
+		require.ErrorIs(t, c.DisableTotp(ctx, user.Id, "000000"), aaa.ErrTotpNotEnrolled)
+	})
+}
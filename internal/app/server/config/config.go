@@ -3,14 +3,31 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+
+	"yap-pwkeeper/internal/app/server/aaa"
 )
 
 const (
 	defaultLogLevel = "0"
 	defaultDbUri    = "mongodb://mongo:27017"
 	defaultAddress  = "0.0.0.0:3200"
+	defaultTrash    = "720h"
+	defaultHistory  = "50"
+
+	defaultSessionMaxAge  = "720h"
+	defaultSessionMaxIdle = "0s"
+
+	defaultLoginRate     = "20"
+	defaultLoginFailures = "5"
+	defaultLoginBackoff  = "1s"
+	defaultLoginLockout  = "15m"
+	defaultUserFailures  = "10"
+
+	defaultPasswordLength  = "8"
+	defaultPasswordClasses = "2"
 )
 
 type Config struct {
@@ -22,6 +39,22 @@ type Config struct {
 	TokenKey    string
 	TLSCertFile string
 	TLSKeyFile  string
+	Trash       time.Duration
+	History     int // revisions kept per document
+	// user session limits
+	SessionMaxAge  time.Duration // lifetime since login
+	SessionMaxIdle time.Duration // lifetime since the last token refresh
+	// brute-force protection of login and registration
+	LoginRate     int           // requests per minute of one address or login
+	LoginFailures int           // failures of one address or login allowed without delay
+	LoginBackoff  time.Duration // delay after the first extra failure, doubled on each next one
+	LoginLockout  time.Duration // maximal delay and maximal user lockout
+	UserFailures  int           // failed logins of user allowed before user is locked
+	// credentials policy of new users and password changes
+	PasswordLength   int
+	PasswordClasses  int
+	LoginPattern     string
+	BreachedPassword string // path to file of breached password hashes
 }
 
 func New() *Config {
@@ -36,7 +69,7 @@ func New() *Config {
 	kingpin.Flag("debug", "enable debug mode").
 		BoolVar(&c.Debug)
 	kingpin.Flag("version", "print version and exit").Short('v').BoolVar(&c.Version)
-	kingpin.Flag("db-uri", "database connection string").
+	kingpin.Flag("db-uri", "database connection string: mongodb://host:port or bolt:///path/to/file.db").
 		Short('d').
 		Envar("DB_URI").
 		Default(defaultDbUri).
@@ -58,6 +91,57 @@ func New() *Config {
 		"tls-key-file",
 		"path to server tls certificate key file",
 	).Envar("TLS_KEY_FILE").StringVar(&c.TLSKeyFile)
+	kingpin.Flag("trash-retention", "how long deleted documents are kept in trash, 0 disables trash").
+		Envar("TRASH_RETENTION").
+		Default(defaultTrash).
+		DurationVar(&c.Trash)
+	kingpin.Flag("history-limit", "previous revisions kept for each document, 0 keeps all").
+		Envar("HISTORY_LIMIT").
+		Default(defaultHistory).
+		IntVar(&c.History)
+	kingpin.Flag("session-max-age", "session lifetime since login, 0 is unlimited").
+		Envar("SESSION_MAX_AGE").
+		Default(defaultSessionMaxAge).
+		DurationVar(&c.SessionMaxAge)
+	kingpin.Flag("session-max-idle", "session lifetime since the last token refresh, 0 is token lifetime").
+		Envar("SESSION_MAX_IDLE").
+		Default(defaultSessionMaxIdle).
+		DurationVar(&c.SessionMaxIdle)
+	kingpin.Flag("login-rate", "login and registration requests per minute of one address or login, 0 is unlimited").
+		Envar("LOGIN_RATE").
+		Default(defaultLoginRate).
+		IntVar(&c.LoginRate)
+	kingpin.Flag("login-failures", "failed attempts of one address or login allowed without delay").
+		Envar("LOGIN_FAILURES").
+		Default(defaultLoginFailures).
+		IntVar(&c.LoginFailures)
+	kingpin.Flag("login-backoff", "delay after extra failed attempt, doubled on each next one, 0 disables backoff and lockout").
+		Envar("LOGIN_BACKOFF").
+		Default(defaultLoginBackoff).
+		DurationVar(&c.LoginBackoff)
+	kingpin.Flag("login-lockout", "maximal delay of attempts and maximal temporary user lockout").
+		Envar("LOGIN_LOCKOUT").
+		Default(defaultLoginLockout).
+		DurationVar(&c.LoginLockout)
+	kingpin.Flag("user-failures", "failed logins allowed before user is temporarily locked").
+		Envar("USER_FAILURES").
+		Default(defaultUserFailures).
+		IntVar(&c.UserFailures)
+	kingpin.Flag("password-length", "minimal master password length, checked by client").
+		Envar("PASSWORD_LENGTH").
+		Default(defaultPasswordLength).
+		IntVar(&c.PasswordLength)
+	kingpin.Flag("password-classes", "minimal number of character classes in master password, checked by client: lowercase, uppercase, digits, other").
+		Envar("PASSWORD_CLASSES").
+		Default(defaultPasswordClasses).
+		IntVar(&c.PasswordClasses)
+	kingpin.Flag("login-pattern", "regular expression of allowed logins").
+		Envar("LOGIN_PATTERN").
+		Default(aaa.DefaultLoginPattern.String()).
+		StringVar(&c.LoginPattern)
+	kingpin.Flag("breached-passwords", "path to file of SHA-1 hashes of breached passwords (HASH:COUNT lines, sorted by hash), clients reject passwords from it by hash prefix").
+		Envar("BREACHED_PASSWORDS").
+		StringVar(&c.BreachedPassword)
 	kingpin.Parse()
 	return &c
 }
@@ -0,0 +1,297 @@
+package documents
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// ExportFormat identifies archive, produced by ExportAll
+const ExportFormat = "yap-pwkeeper-export"
+
+// Export archive entries. File data is stored in data directory, named by blob id.
+const (
+	exportManifest    = "manifest.json"
+	exportNotes       = "notes.json"
+	exportCards       = "cards.json"
+	exportCredentials = "credentials.json"
+	exportFiles       = "files.json"
+	exportHistory     = "history.json"
+	exportVaults      = "vaults.json"
+	exportDataDir     = "data/"
+)
+
+// ExportManifest describes export archive
+type ExportManifest struct {
+	Format     string          `json:"format"`
+	Version    int             `json:"version"`
+	UserId     string          `json:"user_id"`
+	ExportedAt int64           `json:"exported_at"`
+	VaultKey   []byte          `json:"vault_key,omitempty"` // vault key, wrapped with user password
+	KeyPair    *models.KeyPair `json:"key_pair,omitempty"`  // private key is sealed with vault key
+}
+
+// exportRevision is a previous document revision in export archive
+type exportRevision struct {
+	Kind     string      `json:"kind"`
+	Revision interface{} `json:"revision"`
+}
+
+// WithVaultKeys sets source of wrapped user vault keys. Vault key is added to export
+// archive, so exported documents may be decrypted with user password.
+func WithVaultKeys(fn func(ctx context.Context, userId string) ([]byte, error)) func(c *Controller) {
+	return func(c *Controller) {
+		c.vaultKeys = fn
+	}
+}
+
+// WithKeyPairs sets source of user key pairs. Key pair is added to export archive,
+// so documents of exported shared vaults may be decrypted with user password.
+func WithKeyPairs(fn func(ctx context.Context, userId string) (models.KeyPair, error)) func(c *Controller) {
+	return func(c *Controller) {
+		c.keyPairs = fn
+	}
+}
+
+// ExportAll writes zip archive with all user documents, their revisions and file data to w.
+// Documents of shared vaults, owned by user, are exported too, vault members with sealed
+// vault key of the user are listed in vaults entry. Vault documents are owned by vault id.
+// Documents are exported as they are stored, payload stays encrypted by client.
+// Permanently deleted documents are not exported.
+func (c *Controller) ExportAll(ctx context.Context, userId string, w io.Writer) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("export request")
+	vaults, err := c.ListVaults(ctx, userId)
+	if err != nil {
+		return err
+	}
+	owned := vaults[:0]
+	for _, v := range vaults {
+		if member, _ := v.Member(userId); member.Role == models.RoleOwner {
+			owned = append(owned, v)
+		}
+	}
+	manifest := ExportManifest{
+		Format:     ExportFormat,
+		Version:    2,
+		UserId:     userId,
+		ExportedAt: time.Now().Unix(),
+	}
+	if c.vaultKeys != nil {
+		if manifest.VaultKey, err = c.vaultKeys(ctx, userId); err != nil {
+			log.Infof("export without vault key: %s", err.Error())
+		}
+	}
+	if c.keyPairs != nil && len(owned) > 0 {
+		if keys, err := c.keyPairs(ctx, userId); err != nil {
+			log.Infof("export without key pair: %s", err.Error())
+		} else {
+			manifest.KeyPair = &keys
+		}
+	}
+
+	var notes, cards, credentials, files []interface{}
+	var history []exportRevision
+	blobs := make(map[string]struct{})
+	owners := []string{userId}
+	for _, v := range owned {
+		owners = append(owners, v.Id)
+	}
+	for _, owner := range owners {
+		docs, err := c.userDocuments(ctx, owner)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			if docState(doc) == models.StateDeleted {
+				continue
+			}
+			docId, _ := docOwner(doc)
+			var kind string
+			switch d := doc.(type) {
+			case models.Note:
+				notes, kind = append(notes, d), KindNote
+			case models.Card:
+				cards, kind = append(cards, d), KindCard
+			case models.Credential:
+				credentials, kind = append(credentials, d), KindCredential
+			case models.File:
+				files, kind = append(files, d), KindFile
+				blobs[d.BlobId] = struct{}{}
+			}
+			revisions, err := c.revisions(ctx, docId, owner)
+			if err != nil {
+				return err
+			}
+			for _, rev := range revisions {
+				history = append(history, exportRevision{Kind: kind, Revision: rev})
+				if f, ok := rev.(models.File); ok {
+					blobs[f.BlobId] = struct{}{}
+				}
+			}
+		}
+	}
+
+	zw := zip.NewWriter(w)
+	entries := []struct {
+		name string
+		v    interface{}
+	}{
+		{exportManifest, manifest},
+		{exportNotes, notes},
+		{exportCards, cards},
+		{exportCredentials, credentials},
+		{exportFiles, files},
+		{exportHistory, history},
+		{exportVaults, owned},
+	}
+	for _, e := range entries {
+		if err := writeJSON(zw, e.name, e.v); err != nil {
+			return err
+		}
+	}
+	for blobId := range blobs {
+		if blobId == "" {
+			continue
+		}
+		if err := c.exportBlob(ctx, zw, blobId); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	log.Infof("exported %d documents of %d vaults and %d file data blobs", len(notes)+len(cards)+len(credentials)+len(files), len(owned), len(blobs))
+	return nil
+}
+
+// DeleteUserData permanently removes all user documents, their revisions and file data.
+// User leaves shared vaults. Active subscriptions of the user are dropped.
+func (c *Controller) DeleteUserData(ctx context.Context, userId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("delete user data request")
+	qLock := c.queue.Reserve(userId)
+	defer qLock.Release()
+
+	if err := c.leaveVaults(ctx, userId); err != nil {
+		log.Errorf("leave vaults failed: %s", err.Error())
+		return err
+	}
+	if err := c.deleteDocuments(ctx, userId); err != nil {
+		return err
+	}
+	c.broker.dropUser(userId)
+	return nil
+}
+
+// deleteDocuments permanently removes documents of owner, their revisions, shares and file data.
+// Should be called with owner reservation held.
+func (c *Controller) deleteDocuments(ctx context.Context, ownerId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("ownerId", ownerId)
+	docs, err := c.userDocuments(ctx, ownerId)
+	if err != nil {
+		return err
+	}
+	// file data is referenced by documents, it is collected before documents are deleted
+	blobs := make(map[string]struct{})
+	for _, doc := range docs {
+		if f, ok := doc.(models.File); ok {
+			fileBlobs, err := c.fileBlobs(ctx, f)
+			if err != nil {
+				return err
+			}
+			for blobId := range fileBlobs {
+				blobs[blobId] = struct{}{}
+			}
+		}
+	}
+	uploads, err := c.store.DeleteUserUploads(ctx, ownerId)
+	if err != nil {
+		log.Errorf("delete uploads failed: %s", err.Error())
+		return err
+	}
+	for _, u := range uploads {
+		blobs[u.BlobId] = struct{}{}
+	}
+
+	if err := c.store.DeleteUserDocuments(ctx, ownerId); err != nil {
+		log.Errorf("delete documents failed: %s", err.Error())
+		return err
+	}
+	if c.shares != nil {
+		if err := c.shares.DeleteUserShares(ctx, ownerId); err != nil {
+			log.Errorf("delete shares failed: %s", err.Error())
+			return err
+		}
+	}
+	for blobId := range blobs {
+		if blobId != "" {
+			c.dropBlob(ctx, blobId)
+		}
+	}
+	log.Infof("deleted %d documents and %d file data blobs", len(docs), len(blobs))
+	return nil
+}
+
+// userDocuments returns all user documents of every kind, including deleted ones
+func (c *Controller) userDocuments(ctx context.Context, userId string) ([]interface{}, error) {
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go func() {
+		defer close(chData)
+		chErr <- c.streamUpdates(ctx, userId, -1, math.MaxInt64, chData)
+	}()
+	var docs []interface{}
+	for doc := range chData {
+		docs = append(docs, doc)
+	}
+	return docs, <-chErr
+}
+
+// revisions returns previous revisions of the document, the newest first
+func (c *Controller) revisions(ctx context.Context, docId string, userId string) ([]interface{}, error) {
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go func() {
+		defer close(chData)
+		chErr <- c.store.GetHistoryStream(ctx, docId, userId, chData)
+	}()
+	var list []interface{}
+	for doc := range chData {
+		list = append(list, doc)
+	}
+	return list, <-chErr
+}
+
+// exportBlob copies file data to archive
+func (c *Controller) exportBlob(ctx context.Context, zw *zip.Writer, blobId string) error {
+	r, err := c.store.OpenBlob(ctx, blobId, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	// data is encrypted by client, so it is not compressed
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: exportDataDir + blobId, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// writeJSON adds JSON encoded v to archive
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,158 @@
+package documents_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestController_ExportAndDeleteUserData(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	vaultKeys := func(_ context.Context, userId string) ([]byte, error) {
+		return []byte("wrapped " + userId), nil
+	}
+	c := documents.New(db, documents.WithTrashRetention(time.Hour), documents.WithVaultKeys(vaultKeys))
+	ctx := context.Background()
+
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: "user", Name: "note", Text: "v1"}))
+	require.NoError(t, c.AddCard(ctx, models.Card{UserId: "user", Name: "card"}))
+	require.NoError(t, c.AddCredential(ctx, models.Credential{UserId: "user", Name: "credential"}))
+	require.NoError(t, c.AddFile(ctx, models.File{UserId: "user", Name: "file"}, bytes.NewReader([]byte("v1"))))
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: "other", Name: "other"}))
+	var note models.Note
+	var file models.File
+	for _, doc := range updates(t, c, "user", -1) {
+		switch d := doc.(type) {
+		case models.Note:
+			note = d
+		case models.File:
+			file = d
+		}
+	}
+	note.Text = "v2"
+	require.NoError(t, c.UpdateNote(ctx, note))
+	require.NoError(t, c.UpdateFile(ctx, file, bytes.NewReader([]byte("v2"))))
+
+	t.Run("export", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, c.ExportAll(ctx, "user", &buf))
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		entries := make(map[string][]byte)
+		for _, f := range zr.File {
+			r, err := f.Open()
+			require.NoError(t, err)
+			entries[f.Name], err = io.ReadAll(r)
+			require.NoError(t, err)
+		}
+
+		var manifest documents.ExportManifest
+		require.NoError(t, json.Unmarshal(entries["manifest.json"], &manifest))
+		assert.Equal(t, documents.ExportFormat, manifest.Format)
+		assert.Equal(t, "user", manifest.UserId)
+		assert.Equal(t, []byte("wrapped user"), manifest.VaultKey)
+
+		var notes []models.Note
+		require.NoError(t, json.Unmarshal(entries["notes.json"], &notes))
+		require.Len(t, notes, 1, "documents of other user should not be exported")
+		assert.Equal(t, "v2", notes[0].Text)
+		for _, name := range []string{"cards.json", "credentials.json", "files.json"} {
+			var docs []interface{}
+			require.NoError(t, json.Unmarshal(entries[name], &docs))
+			assert.Len(t, docs, 1, name)
+		}
+		var history []json.RawMessage
+		require.NoError(t, json.Unmarshal(entries["history.json"], &history))
+		assert.Len(t, history, 2, "note and file revisions should be exported")
+
+		var data []string
+		for name, b := range entries {
+			if len(name) > 5 && name[:5] == "data/" {
+				data = append(data, string(b))
+			}
+		}
+		assert.ElementsMatch(t, []string{"v1", "v2"}, data, "data of file and its revision should be exported")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, c.DeleteUserData(ctx, "user"))
+		assert.Empty(t, updates(t, c, "user", -1))
+		assert.Len(t, updates(t, c, "other", -1), 1, "documents of other user should be kept")
+		_, err := db.GetRevision(ctx, note.Id, "user", note.Serial)
+		require.ErrorIs(t, err, documents.ErrNotFound, "history should be removed")
+		r, err := db.OpenBlob(ctx, file.BlobId, 0)
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Empty(t, b, "file data should be removed")
+	})
+}
+
+func TestController_ExportOwnedVaults(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	keyPairs := func(_ context.Context, userId string) (models.KeyPair, error) {
+		return models.KeyPair{Public: []byte("public " + userId)}, nil
+	}
+	c := documents.New(db, documents.WithVaults(db, db), documents.WithKeyPairs(keyPairs))
+	ctx := context.Background()
+	ids := addUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+	vaultId, err := c.CreateVault(ctx, alice, "team", []byte("alice key"))
+	require.NoError(t, err)
+	require.NoError(t, c.AddMember(ctx, alice, vaultId, "bob", models.RoleWrite, []byte("bob key")))
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: vaultId, Name: "vault note"}))
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: alice, Name: "personal"}))
+
+	export := func(userId string) map[string][]byte {
+		var buf bytes.Buffer
+		require.NoError(t, c.ExportAll(ctx, userId, &buf))
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		entries := make(map[string][]byte)
+		for _, f := range zr.File {
+			r, err := f.Open()
+			require.NoError(t, err)
+			entries[f.Name], err = io.ReadAll(r)
+			require.NoError(t, err)
+		}
+		return entries
+	}
+
+	entries := export(alice)
+	var notes []models.Note
+	require.NoError(t, json.Unmarshal(entries["notes.json"], &notes))
+	require.Len(t, notes, 2, "documents of owned vault should be exported")
+	var vaults []models.Vault
+	require.NoError(t, json.Unmarshal(entries["vaults.json"], &vaults))
+	require.Len(t, vaults, 1)
+	assert.Equal(t, vaultId, vaults[0].Id)
+	for _, m := range vaults[0].Members {
+		if m.UserId != alice {
+			assert.Empty(t, m.VaultKey, "vault keys of other members should not be exported")
+		}
+	}
+	var manifest documents.ExportManifest
+	require.NoError(t, json.Unmarshal(entries["manifest.json"], &manifest))
+	require.NotNil(t, manifest.KeyPair)
+	assert.Equal(t, []byte("public "+alice), manifest.KeyPair.Public)
+
+	entries = export(bob)
+	require.NoError(t, json.Unmarshal(entries["notes.json"], &notes))
+	assert.Empty(t, notes, "documents of vault owned by other user should not be exported")
+	require.NoError(t, json.Unmarshal(entries["vaults.json"], &vaults))
+	assert.Empty(t, vaults)
+}
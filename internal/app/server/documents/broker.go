@@ -0,0 +1,144 @@
+package documents
+
+import (
+	"sync"
+)
+
+// subscriberBuffer is a number of updates, that may wait for delivery to subscriber.
+// Subscriber, that is not able to keep up, is dropped.
+const subscriberBuffer = 256
+
+// subscription is a live updates channel of one subscriber
+type subscription struct {
+	keys    []string // owners of documents: user and shared vaults
+	session string   // user session of subscriber
+	ch      chan interface{}
+	// lost is closed when subscription is dropped by broker, err is the reason
+	lost chan struct{}
+	err  error
+	once sync.Once
+}
+
+func (s *subscription) drop(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.lost)
+	})
+}
+
+// broker delivers committed documents to subscribers of the document owner.
+// Publishing is done under namedq reservation, so subscribers of one owner
+// receive updates in commit order.
+type broker struct {
+	mu       sync.Mutex
+	subs     map[string]map[*subscription]struct{}
+	sessions map[string]map[*subscription]struct{}
+	closed   bool
+}
+
+func newBroker() *broker {
+	return &broker{
+		subs:     make(map[string]map[*subscription]struct{}),
+		sessions: make(map[string]map[*subscription]struct{}),
+	}
+}
+
+// subscribe registers new subscription for user documents in user session
+func (b *broker) subscribe(userId string, sessionId string) *subscription {
+	s := &subscription{
+		session: sessionId,
+		ch:      make(chan interface{}, subscriberBuffer),
+		lost:    make(chan struct{}),
+	}
+	b.mu.Lock()
+	if b.sessions[sessionId] == nil {
+		b.sessions[sessionId] = make(map[*subscription]struct{})
+	}
+	b.sessions[sessionId][s] = struct{}{}
+	b.mu.Unlock()
+	b.watch(s, userId)
+	return s
+}
+
+// watch adds documents of owners to subscription
+func (b *broker) watch(s *subscription, owners ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.drop(ErrSubscriptionLost)
+		return
+	}
+	for _, owner := range owners {
+		if b.subs[owner] == nil {
+			b.subs[owner] = make(map[*subscription]struct{})
+		}
+		b.subs[owner][s] = struct{}{}
+		s.keys = append(s.keys, owner)
+	}
+}
+
+// unsubscribe removes subscription
+func (b *broker) unsubscribe(s *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, owner := range s.keys {
+		delete(b.subs[owner], s)
+		if len(b.subs[owner]) == 0 {
+			delete(b.subs, owner)
+		}
+	}
+	delete(b.sessions[s.session], s)
+	if len(b.sessions[s.session]) == 0 {
+		delete(b.sessions, s.session)
+	}
+}
+
+// publish sends document to all subscribers of owner without blocking.
+// Subscribers with full buffer are dropped.
+func (b *broker) publish(userId string, doc interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs[userId] {
+		select {
+		case s.ch <- doc:
+		default:
+			s.drop(ErrSubscriptionLost)
+			delete(b.subs[userId], s)
+		}
+	}
+	if len(b.subs[userId]) == 0 {
+		delete(b.subs, userId)
+	}
+}
+
+// dropUser drops all subscriptions of user
+func (b *broker) dropUser(userId string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs[userId] {
+		s.drop(ErrSessionClosed)
+	}
+	delete(b.subs, userId)
+}
+
+// dropSession drops all subscriptions of user session
+func (b *broker) dropSession(sessionId string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.sessions[sessionId] {
+		s.drop(ErrSessionClosed)
+	}
+}
+
+// close drops all subscriptions and denies new ones
+func (b *broker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for userId, subs := range b.subs {
+		for s := range subs {
+			s.drop(ErrSubscriptionLost)
+		}
+		delete(b.subs, userId)
+	}
+}
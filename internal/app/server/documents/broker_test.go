@@ -0,0 +1,62 @@
+package documents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	b := newBroker()
+	s1 := b.subscribe("user1", "session1")
+	s2 := b.subscribe("user2", "session2")
+
+	b.publish("user1", "doc")
+	assert.Equal(t, "doc", <-s1.ch, "subscriber should receive user document")
+	assert.Len(t, s2.ch, 0, "other user subscriber should not receive document")
+
+	// overflow drops subscriber
+	for i := 0; i <= subscriberBuffer; i++ {
+		b.publish("user1", i)
+	}
+	select {
+	case <-s1.lost:
+		assert.ErrorIs(t, s1.err, ErrSubscriptionLost)
+	default:
+		t.Error("slow subscriber should be dropped")
+	}
+	assert.NotContains(t, b.subs, "user1", "dropped subscriber should be removed")
+
+	b.unsubscribe(s2)
+	assert.Empty(t, b.subs, "no subscriptions expected")
+
+	// session drop closes only subscriptions of the session
+	s5 := b.subscribe("user5", "session5")
+	s6 := b.subscribe("user5", "session6")
+	b.dropSession("session5")
+	select {
+	case <-s5.lost:
+		assert.ErrorIs(t, s5.err, ErrSessionClosed)
+	default:
+		t.Error("subscriber of dropped session should be dropped")
+	}
+	b.publish("user5", "doc")
+	assert.Equal(t, "doc", <-s6.ch, "subscriber of other session should receive document")
+	b.unsubscribe(s5)
+	b.unsubscribe(s6)
+	assert.NotContains(t, b.sessions, "session5", "session of removed subscriber should be removed")
+
+	s3 := b.subscribe("user3", "session3")
+	b.close()
+	select {
+	case <-s3.lost:
+	default:
+		t.Error("close should drop subscribers")
+	}
+	s4 := b.subscribe("user3", "session4")
+	select {
+	case <-s4.lost:
+	default:
+		t.Error("closed broker should not accept subscriptions")
+	}
+}
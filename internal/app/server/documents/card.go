@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"time"
 
 	"yap-pwkeeper/internal/app/server/serial"
 	"yap-pwkeeper/internal/pkg/logger"
@@ -12,6 +13,9 @@ import (
 func (c *Controller) AddCard(ctx context.Context, card models.Card) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add card request")
+	if err := c.checkEncrypted(ctx, card); err != nil {
+		return err
+	}
 	qLock := c.queue.Reserve(card.UserId)
 	defer qLock.Release()
 	s, err := serial.Next(ctx)
@@ -26,6 +30,8 @@ func (c *Controller) AddCard(ctx context.Context, card models.Card) error {
 		logger.Log().Warnf("add card failed: %s", err.Error())
 	} else {
 		log.With("documentId", oid).Info("card added")
+		card.Id = oid
+		c.broker.publish(card.UserId, card)
 	}
 	return err
 }
@@ -39,7 +45,8 @@ func (c *Controller) DeleteCard(ctx context.Context, card models.Card) error {
 	qLock := c.queue.Reserve(card.UserId)
 	defer qLock.Release()
 
-	if err := c.validateCardUpdate(ctx, card); err != nil {
+	stored, err := c.validateCardUpdate(ctx, card)
+	if err != nil {
 		return err
 	}
 
@@ -49,6 +56,21 @@ func (c *Controller) DeleteCard(ctx context.Context, card models.Card) error {
 	}
 	card.Serial = s
 
+	if c.trashRetention > 0 && stored.State != models.StateTrashed {
+		stored.Serial = s
+		stored.State = models.StateTrashed
+		stored.TrashedAt = time.Now().Unix()
+		err = c.store.ModifyCard(ctx, stored)
+		if err != nil {
+			log.Warnf("card move to trash failed: %s", err.Error())
+		} else {
+			log.Info("card moved to trash")
+			c.broker.publish(stored.UserId, stored)
+			c.trimHistory(ctx, stored.Id, stored.UserId)
+		}
+		return err
+	}
+
 	deleted := models.Card{
 		Id:     card.Id,
 		UserId: card.UserId,
@@ -61,6 +83,10 @@ func (c *Controller) DeleteCard(ctx context.Context, card models.Card) error {
 		logger.Log().Warnf("card delete failed: %s", err.Error())
 	} else {
 		logger.Log().Info("card deleted")
+		c.broker.publish(deleted.UserId, deleted)
+		if stored.State == models.StateTrashed {
+			c.dropHistory(ctx, deleted.Id, deleted.UserId)
+		}
 	}
 	return err
 }
@@ -69,13 +95,20 @@ func (c *Controller) DeleteCard(ctx context.Context, card models.Card) error {
 func (c *Controller) UpdateCard(ctx context.Context, card models.Card) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", card.Id)
 	log.Debug("update card request")
+	if err := c.checkEncrypted(ctx, card); err != nil {
+		return err
+	}
 
 	qLock := c.queue.Reserve(card.UserId)
 	defer qLock.Release()
 
-	if err := c.validateCardUpdate(ctx, card); err != nil {
+	stored, err := c.validateCardUpdate(ctx, card)
+	if err != nil {
 		return err
 	}
+	if stored.State == models.StateTrashed {
+		return ErrDeleted
+	}
 
 	s, err := serial.Next(ctx)
 	if err != nil {
@@ -89,21 +122,23 @@ func (c *Controller) UpdateCard(ctx context.Context, card models.Card) error {
 		logger.Log().Warnf("card update failed: %s", err.Error())
 	} else {
 		logger.Log().Info("card updated")
+		c.broker.publish(card.UserId, card)
+		c.trimHistory(ctx, card.Id, card.UserId)
 	}
 	return err
 }
 
-func (c *Controller) validateCardUpdate(ctx context.Context, card models.Card) error {
+func (c *Controller) validateCardUpdate(ctx context.Context, card models.Card) (models.Card, error) {
 	// get stored card
 	stored, err := c.store.GetCard(ctx, card.Id, card.UserId)
 	if err != nil {
-		return err
+		return stored, err
 	}
 	if stored.State == models.StateDeleted {
-		return ErrDeleted
+		return stored, ErrDeleted
 	}
 	if stored.Serial > card.Serial {
-		return ErrChanged
+		return stored, ErrChanged
 	}
-	return nil
+	return stored, nil
 }
@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"time"
 
 	"yap-pwkeeper/internal/app/server/serial"
 	"yap-pwkeeper/internal/pkg/logger"
@@ -12,6 +13,9 @@ import (
 func (c *Controller) AddCredential(ctx context.Context, credential models.Credential) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add credential request")
+	if err := c.checkEncrypted(ctx, credential); err != nil {
+		return err
+	}
 	qLock := c.queue.Reserve(credential.UserId)
 	defer qLock.Release()
 	s, err := serial.Next(ctx)
@@ -26,6 +30,8 @@ func (c *Controller) AddCredential(ctx context.Context, credential models.Creden
 		logger.Log().Warnf("add credential failed: %s", err.Error())
 	} else {
 		log.With("documentId", oid).Info("credential added")
+		credential.Id = oid
+		c.broker.publish(credential.UserId, credential)
 	}
 	return err
 }
@@ -39,7 +45,8 @@ func (c *Controller) DeleteCredential(ctx context.Context, credential models.Cre
 	qLock := c.queue.Reserve(credential.UserId)
 	defer qLock.Release()
 
-	if err := c.validateCredentialUpdate(ctx, credential); err != nil {
+	stored, err := c.validateCredentialUpdate(ctx, credential)
+	if err != nil {
 		return err
 	}
 
@@ -49,6 +56,21 @@ func (c *Controller) DeleteCredential(ctx context.Context, credential models.Cre
 	}
 	credential.Serial = s
 
+	if c.trashRetention > 0 && stored.State != models.StateTrashed {
+		stored.Serial = s
+		stored.State = models.StateTrashed
+		stored.TrashedAt = time.Now().Unix()
+		err = c.store.ModifyCredential(ctx, stored)
+		if err != nil {
+			log.Warnf("credential move to trash failed: %s", err.Error())
+		} else {
+			log.Info("credential moved to trash")
+			c.broker.publish(stored.UserId, stored)
+			c.trimHistory(ctx, stored.Id, stored.UserId)
+		}
+		return err
+	}
+
 	deleted := models.Credential{
 		Id:     credential.Id,
 		UserId: credential.UserId,
@@ -61,6 +83,10 @@ func (c *Controller) DeleteCredential(ctx context.Context, credential models.Cre
 		logger.Log().Warnf("credential delete failed: %s", err.Error())
 	} else {
 		logger.Log().Info("credential deleted")
+		c.broker.publish(deleted.UserId, deleted)
+		if stored.State == models.StateTrashed {
+			c.dropHistory(ctx, deleted.Id, deleted.UserId)
+		}
 	}
 	return err
 }
@@ -69,13 +95,20 @@ func (c *Controller) DeleteCredential(ctx context.Context, credential models.Cre
 func (c *Controller) UpdateCredential(ctx context.Context, credential models.Credential) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", credential.Id)
 	log.Debug("update credential request")
+	if err := c.checkEncrypted(ctx, credential); err != nil {
+		return err
+	}
 
 	qLock := c.queue.Reserve(credential.UserId)
 	defer qLock.Release()
 
-	if err := c.validateCredentialUpdate(ctx, credential); err != nil {
+	stored, err := c.validateCredentialUpdate(ctx, credential)
+	if err != nil {
 		return err
 	}
+	if stored.State == models.StateTrashed {
+		return ErrDeleted
+	}
 
 	s, err := serial.Next(ctx)
 	if err != nil {
@@ -89,21 +122,23 @@ func (c *Controller) UpdateCredential(ctx context.Context, credential models.Cre
 		logger.Log().Warnf("credential update failed: %s", err.Error())
 	} else {
 		logger.Log().Info("credential updated")
+		c.broker.publish(credential.UserId, credential)
+		c.trimHistory(ctx, credential.Id, credential.UserId)
 	}
 	return err
 }
 
-func (c *Controller) validateCredentialUpdate(ctx context.Context, credential models.Credential) error {
+func (c *Controller) validateCredentialUpdate(ctx context.Context, credential models.Credential) (models.Credential, error) {
 	// get stored credential
 	stored, err := c.store.GetCredential(ctx, credential.Id, credential.UserId)
 	if err != nil {
-		return err
+		return stored, err
 	}
 	if stored.State == models.StateDeleted {
-		return ErrDeleted
+		return stored, ErrDeleted
 	}
 	if stored.Serial > credential.Serial {
-		return ErrChanged
+		return stored, ErrChanged
 	}
-	return nil
+	return stored, nil
 }
@@ -6,6 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -20,8 +24,26 @@ var (
 	ErrBadRequest = errors.New("invalid request data")
 	ErrNotFound   = errors.New("document not found")
 	ErrChanged    = errors.New("document server version mismatch")
+	ErrNotTrashed = errors.New("document is not in trash")
+	ErrPlainText  = errors.New("document is not encrypted")
+	// ErrSubscriptionLost is returned when subscriber was not able to receive updates in time
+	ErrSubscriptionLost = errors.New("subscription lost, resubscribe required")
+	// ErrSessionClosed is returned to subscriber, when its session is finished or revoked
+	ErrSessionClosed = errors.New("session is closed")
 )
 
+// Document kinds
+const (
+	KindNote       = "note"
+	KindCard       = "card"
+	KindCredential = "credential"
+	KindFile       = "file"
+)
+
+// SyncMark is sent to subscriber, when all updates before subscription are delivered.
+// All the following updates are live.
+type SyncMark struct{}
+
 // DocStorage defines interface to be implemented by storage backend
 //
 //go:generate mockgen -source $GOFILE -package=mocks -destination ../../../../mocks/server_docstorage_mock.go
@@ -42,28 +64,80 @@ type DocStorage interface {
 	GetCredentialsStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error
 
 	AddFile(ctx context.Context, file models.File) (string, error)
-	GetFile(ctx context.Context, docId string, userId string) (models.File, error)
 	GetFileInfo(ctx context.Context, docId string, userId string) (models.File, error)
 	ModifyFile(ctx context.Context, file models.File) error
 	ModifyFileInfo(ctx context.Context, file models.File) error
 	GetFilesInfoStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error
+
+	PutBlob(ctx context.Context, userId string, r io.Reader) (string, error)
+	AppendBlob(ctx context.Context, blobId string, userId string, offset int64, r io.Reader) (int64, error)
+	OpenBlob(ctx context.Context, blobId string, offset int64) (io.ReadCloser, error)
+	DeleteBlob(ctx context.Context, blobId string) error
+
+	GetHistoryStream(ctx context.Context, docId string, userId string, chData chan interface{}) error
+	GetRevision(ctx context.Context, docId string, userId string, serial int64) (interface{}, error)
+	DeleteHistory(ctx context.Context, docId string, userId string) error
+	TrimHistory(ctx context.Context, docId string, userId string, keep int) ([]interface{}, error)
+	DeleteRevisions(ctx context.Context, docId string, userId string, serials []int64) error
+
+	GetTrashStream(ctx context.Context, trashedBefore int64, chData chan interface{}) error
+
+	DeleteUserDocuments(ctx context.Context, userId string) error
+
+	AddUpload(ctx context.Context, upload models.Upload) error
+	GetUpload(ctx context.Context, uploadId string) (models.Upload, error)
+	SetUploadOffset(ctx context.Context, uploadId string, offset int64, updatedAt int64) error
+	DeleteUpload(ctx context.Context, uploadId string) error
+	GetExpiredUploads(ctx context.Context, before int64) ([]models.Upload, error)
+	DeleteUserUploads(ctx context.Context, userId string) ([]models.Upload, error)
 }
 
 type Controller struct {
-	store DocStorage
-	queue *namedq.NamedQ
+	store          DocStorage
+	queue          *namedq.NamedQ
+	broker         *broker
+	trashRetention time.Duration
+	historyLimit   int                 // revisions kept per document, 0 is unlimited
+	busy           map[string]struct{} // uploads, which data is being written
+	uploadsMu      sync.Mutex
+	uploadTTL      time.Duration
+	vaultKeys      func(ctx context.Context, userId string) ([]byte, error)
+	migrated       func(ctx context.Context, userId string) (bool, error) // user documents are encrypted, plain text is rejected
+	keyPairs       func(ctx context.Context, userId string) (models.KeyPair, error)
+	vaults         VaultStorage
+	users          Users
+	shares         ShareStorage
 }
 
-func New(store DocStorage) *Controller {
+func New(store DocStorage, options ...func(c *Controller)) *Controller {
 	c := &Controller{
-		store: store,
-		queue: namedq.New(),
+		store:     store,
+		queue:     namedq.New(),
+		broker:    newBroker(),
+		busy:      make(map[string]struct{}),
+		uploadTTL: defaultUploadTTL,
+	}
+	for _, opt := range options {
+		opt(c)
 	}
 	return c
 }
 
+// WithTrashRetention enables trash: deleted documents keep payload for retention
+// period and may be restored. Zero retention deletes documents immediately.
+func WithTrashRetention(retention time.Duration) func(c *Controller) {
+	return func(c *Controller) {
+		c.trashRetention = retention
+	}
+}
+
+// Close terminates all active subscriptions
+func (c *Controller) Close() {
+	c.broker.close()
+}
+
 // GetUpdatesStream combines updates of documents of any kind into a single stream.
-// This makes update process one-step.
+// This makes update process one-step. Documents of user shared vaults are included.
 func (c *Controller) GetUpdatesStream(ctx context.Context, userId string, minSerial int64, chData chan interface{}, chErr chan error) {
 	defer func() {
 		close(chData)
@@ -76,6 +150,136 @@ func (c *Controller) GetUpdatesStream(ctx context.Context, userId string, minSer
 		chErr <- fmt.Errorf("unable to get new serial: %w", err)
 		return
 	}
+	vaults, err := c.userVaults(ctx, userId)
+	if err != nil {
+		chErr <- fmt.Errorf("unable to get user vaults: %w", err)
+		return
+	}
+	if err := c.streamAll(ctx, userId, vaults, minSerial, maxSerial, chData); err != nil {
+		log.WithErr(err).Error("updates stream failed")
+		chErr <- err
+	}
+}
+
+// Subscribe sends updates since minSerial, then SyncMark, and then all new
+// documents as they are committed, until ctx is done. Subscription is registered
+// under user queue reservation, so no update is missed between stream and live updates.
+// Returns ErrSubscriptionLost if subscriber is too slow to receive updates, and
+// ErrSessionClosed when session of subscriber is finished, see DropSession.
+// Subscription is finished after VaultsChanged is sent. Live updates are ordered by
+// serial per owner only: documents of user and of each vault are committed under
+// separate reservations, so clients should resume from the least serial of owners.
+func (c *Controller) Subscribe(ctx context.Context, userId string, minSerial int64, chData chan interface{}, chErr chan error) {
+	defer func() {
+		close(chData)
+		close(chErr)
+	}()
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("subscribe request")
+
+	// all documents with serial less than maxSerial are already stored,
+	// all new documents will have greater serial and will be published
+	sessionId, _ := logger.GetSessionId(ctx)
+	vaults, maxSerial, sub, err := c.reserveSubscription(ctx, userId, sessionId)
+	defer c.broker.unsubscribe(sub)
+	if err != nil {
+		chErr <- err
+		return
+	}
+
+	if err := c.streamAll(ctx, userId, vaults, minSerial, maxSerial, chData); err != nil {
+		log.WithErr(err).Error("subscription updates stream failed")
+		chErr <- err
+		return
+	}
+	select {
+	case chData <- SyncMark{}:
+	case <-ctx.Done():
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("subscription closed")
+			return
+		case <-sub.lost:
+			select {
+			case <-ctx.Done():
+			default:
+				log.Warnf("subscription dropped: %s", sub.err.Error())
+				chErr <- sub.err
+			}
+			return
+		case doc := <-sub.ch:
+			select {
+			case chData <- doc:
+			case <-ctx.Done():
+				return
+			}
+			if _, ok := doc.(VaultsChanged); ok {
+				log.Debug("subscription finished on vaults change")
+				return
+			}
+		}
+	}
+}
+
+// DropSession finishes live updates subscriptions of user session, it is called
+// when session is closed, revoked or expired.
+func (c *Controller) DropSession(sessionId string) {
+	c.broker.dropSession(sessionId)
+}
+
+// reserveSubscription subscribes to documents of user and user vaults under their queue
+// reservations. Vaults are read after user subscription, so joined vault is either
+// returned or VaultsChanged is published to subscription.
+func (c *Controller) reserveSubscription(ctx context.Context, userId string, sessionId string) ([]models.Vault, int64, *subscription, error) {
+	qLock := c.queue.Reserve(userId)
+	defer qLock.Release()
+	sub := c.broker.subscribe(userId, sessionId)
+	vaults, err := c.userVaults(ctx, userId)
+	if err != nil {
+		return nil, 0, sub, fmt.Errorf("unable to get user vaults: %w", err)
+	}
+	// vaults are reserved in the same order by every subscriber
+	ids := make([]string, len(vaults))
+	for i, v := range vaults {
+		ids[i] = v.Id
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		vLock := c.queue.Reserve(id)
+		defer vLock.Release()
+	}
+	c.broker.watch(sub, ids...)
+	maxSerial, err := serial.Next(ctx)
+	if err != nil {
+		return nil, 0, sub, fmt.Errorf("unable to get new serial: %w", err)
+	}
+	return vaults, maxSerial, sub, nil
+}
+
+// streamAll sends updates of user documents and documents of user vaults. All vault
+// documents are sent, if user has joined vault after minSerial.
+func (c *Controller) streamAll(ctx context.Context, userId string, vaults []models.Vault, minSerial, maxSerial int64, chData chan interface{}) error {
+	if err := c.streamUpdates(ctx, userId, minSerial, maxSerial, chData); err != nil {
+		return err
+	}
+	for _, v := range vaults {
+		from := minSerial
+		if member, _ := v.Member(userId); member.Serial > minSerial {
+			from = -1
+		}
+		if err := c.streamUpdates(ctx, v.Id, from, maxSerial, chData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamUpdates sends documents of any kind with serial in (minSerial, maxSerial) range
+func (c *Controller) streamUpdates(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
 	g, gCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
 		return c.store.GetNotesStream(gCtx, userId, minSerial, maxSerial, chData)
@@ -89,10 +293,5 @@ func (c *Controller) GetUpdatesStream(ctx context.Context, userId string, minSer
 	g.Go(func() error {
 		return c.store.GetFilesInfoStream(gCtx, userId, minSerial, maxSerial, chData)
 	})
-
-	err = g.Wait()
-	if err != nil {
-		log.WithErr(err).Error("updates stream failed")
-		chErr <- err
-	}
+	return g.Wait()
 }
@@ -0,0 +1,63 @@
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/mocks"
+)
+
+func TestController_Subscribe(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+	serial.SetSource(new(serial.SimpleSerialSource))
+
+	c := New(docStore)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stored := models.Note{Id: "stored", UserId: "user", Serial: 1}
+	docStore.EXPECT().GetNotesStream(gomock.Any(), "user", int64(0), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _, _ int64, chData chan interface{}) error {
+			chData <- stored
+			return nil
+		})
+	docStore.EXPECT().GetCardsStream(gomock.Any(), "user", int64(0), gomock.Any(), gomock.Any()).Return(nil)
+	docStore.EXPECT().GetCredentialsStream(gomock.Any(), "user", int64(0), gomock.Any(), gomock.Any()).Return(nil)
+	docStore.EXPECT().GetFilesInfoStream(gomock.Any(), "user", int64(0), gomock.Any(), gomock.Any()).Return(nil)
+
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go c.Subscribe(ctx, "user", 0, chData, chErr)
+
+	assert.Equal(t, stored, <-chData, "stored document expected")
+	assert.Equal(t, SyncMark{}, <-chData, "sync mark expected after stored documents")
+
+	docStore.EXPECT().AddNote(gomock.Any(), gomock.Any()).Return("new", nil)
+	require.NoError(t, c.AddNote(context.Background(), models.Note{UserId: "user", Name: "name"}))
+	docStore.EXPECT().AddNote(gomock.Any(), gomock.Any()).Return("other", nil)
+	require.NoError(t, c.AddNote(context.Background(), models.Note{UserId: "other"}))
+
+	select {
+	case d := <-chData:
+		note, ok := d.(models.Note)
+		require.True(t, ok, "note expected")
+		assert.Equal(t, "new", note.Id, "committed note should be pushed")
+		assert.Equal(t, "name", note.Name)
+	case <-time.After(time.Second):
+		t.Fatal("live update timeout")
+	}
+
+	c.Close()
+	_, ok := <-chData
+	assert.False(t, ok, "stream should be closed")
+	assert.ErrorIs(t, <-chErr, ErrSubscriptionLost)
+}
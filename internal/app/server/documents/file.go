@@ -2,27 +2,66 @@ package documents
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
 
 	"yap-pwkeeper/internal/app/server/serial"
 	"yap-pwkeeper/internal/pkg/logger"
 	"yap-pwkeeper/internal/pkg/models"
 )
 
-// GetFile returns file from the DataStorage, including binary data.
-func (c *Controller) GetFile(ctx context.Context, docId string, userId string) (models.File, error) {
+// GetFile returns file from the DataStorage and reader of its binary data range.
+// Range starts at offset and has length bytes, zero length is up to the end of data.
+// Reader should be closed by caller.
+func (c *Controller) GetFile(ctx context.Context, docId string, userId string, offset, length int64) (models.File, io.ReadCloser, error) {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("get file request")
-	return c.store.GetFile(ctx, docId, userId)
+	if offset < 0 || length < 0 {
+		return models.File{}, nil, ErrBadRequest
+	}
+	file, err := c.store.GetFileInfo(ctx, docId, userId)
+	if err != nil {
+		return file, nil, err
+	}
+	if offset > file.Size {
+		return file, nil, ErrBadRequest
+	}
+	r, err := c.store.OpenBlob(ctx, file.BlobId, offset)
+	if err != nil {
+		return file, nil, err
+	}
+	if length > 0 {
+		r = limitReadCloser{Reader: io.LimitReader(r, length), Closer: r}
+	}
+	return file, r, nil
 }
 
-// AddFile stores new File in DataStorage
-func (c *Controller) AddFile(ctx context.Context, file models.File) error {
+// AddFile stores new File in DataStorage. File data is read from r
+// and streamed to the storage, file size and hash are set from data.
+func (c *Controller) AddFile(ctx context.Context, file models.File, r io.Reader) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add file request")
+	if err := c.checkEncrypted(ctx, file); err != nil {
+		return err
+	}
+	// data is stored before reservation, as upload may take long
+	if err := c.putBlob(ctx, &file, r); err != nil {
+		log.Warnf("add file data failed: %s", err.Error())
+		return err
+	}
+	return c.addFile(ctx, file)
+}
+
+// addFile stores new File, which data is already stored. Data is removed on failure.
+func (c *Controller) addFile(ctx context.Context, file models.File) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	qLock := c.queue.Reserve(file.UserId)
 	defer qLock.Release()
 	s, err := serial.Next(ctx)
 	if err != nil {
+		c.dropBlob(ctx, file.BlobId)
 		return err
 	}
 	file.Serial = s
@@ -31,8 +70,11 @@ func (c *Controller) AddFile(ctx context.Context, file models.File) error {
 	oid, err := c.store.AddFile(ctx, file)
 	if err != nil {
 		logger.Log().Warnf("add file failed: %s", err.Error())
+		c.dropBlob(ctx, file.BlobId)
 	} else {
 		log.With("documentId", oid).Info("file added")
+		file.Id = oid
+		c.broker.publish(file.UserId, file)
 	}
 	return err
 }
@@ -46,7 +88,8 @@ func (c *Controller) DeleteFile(ctx context.Context, file models.File) error {
 	qLock := c.queue.Reserve(file.UserId)
 	defer qLock.Release()
 
-	if _, err := c.validateFileUpdate(ctx, file); err != nil {
+	stored, err := c.validateFileUpdate(ctx, file)
+	if err != nil {
 		return err
 	}
 
@@ -56,6 +99,23 @@ func (c *Controller) DeleteFile(ctx context.Context, file models.File) error {
 	}
 	file.Serial = s
 
+	if c.trashRetention > 0 && stored.State != models.StateTrashed {
+		// trashed file keeps binary data
+		trashed := stored
+		trashed.Serial = s
+		trashed.State = models.StateTrashed
+		trashed.TrashedAt = time.Now().Unix()
+		err = c.store.ModifyFile(ctx, trashed)
+		if err != nil {
+			log.Warnf("file move to trash failed: %s", err.Error())
+		} else {
+			log.Info("file moved to trash")
+			c.broker.publish(trashed.UserId, trashed)
+			c.trimHistory(ctx, trashed.Id, trashed.UserId)
+		}
+		return err
+	}
+
 	deleted := models.File{
 		Id:       file.Id,
 		UserId:   file.UserId,
@@ -69,22 +129,51 @@ func (c *Controller) DeleteFile(ctx context.Context, file models.File) error {
 		logger.Log().Warnf("file delete failed: %s", err.Error())
 	} else {
 		logger.Log().Info("file deleted")
+		c.broker.publish(deleted.UserId, deleted)
+		if stored.State == models.StateTrashed {
+			c.dropFileData(ctx, stored)
+			c.dropHistory(ctx, deleted.Id, deleted.UserId)
+		}
 	}
 	return err
 }
 
-// UpdateFile modifies the whole File, leaving id intact.
-func (c *Controller) UpdateFile(ctx context.Context, file models.File) error {
+// UpdateFile modifies the whole File, leaving id intact. File data is read from r.
+// When data is not changed, previously stored data is kept.
+func (c *Controller) UpdateFile(ctx context.Context, file models.File, r io.Reader) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", file.Id)
 	log.Debug("update file request")
+	if err := c.checkEncrypted(ctx, file); err != nil {
+		return err
+	}
+
+	if err := c.putBlob(ctx, &file, r); err != nil {
+		log.Warnf("update file data failed: %s", err.Error())
+		return err
+	}
+	return c.updateFile(ctx, file)
+}
+
+// updateFile modifies File, which new data is already stored.
+// New data is removed on failure or when it is the same as stored one.
+func (c *Controller) updateFile(ctx context.Context, file models.File) error {
+	newBlob := file.BlobId
+	defer func() {
+		if newBlob != "" {
+			c.dropBlob(ctx, newBlob)
+		}
+	}()
 
 	qLock := c.queue.Reserve(file.UserId)
 	defer qLock.Release()
 
-	hash, err := c.validateFileUpdate(ctx, file)
+	stored, err := c.validateFileUpdate(ctx, file)
 	if err != nil {
 		return err
 	}
+	if stored.State == models.StateTrashed {
+		return ErrDeleted
+	}
 
 	s, err := serial.Next(ctx)
 	if err != nil {
@@ -93,32 +182,164 @@ func (c *Controller) UpdateFile(ctx context.Context, file models.File) error {
 	file.Serial = s
 	file.State = models.StateActive
 
-	if file.Sha265 == hash {
+	if file.Sha265 == stored.Sha265 {
+		file.BlobId = stored.BlobId
 		err = c.store.ModifyFileInfo(ctx, file)
 	} else {
 		err = c.store.ModifyFile(ctx, file)
+		if err == nil {
+			newBlob = ""
+		}
 	}
 	if err != nil {
 		logger.Log().Warnf("file update failed: %s", err.Error())
 	} else {
 		logger.Log().Info("file updated")
+		c.broker.publish(file.UserId, file)
+		c.trimHistory(ctx, file.Id, file.UserId)
+	}
+	return err
+}
+
+// UpdateFileInfo modifies File name and metadata, leaving file data intact.
+func (c *Controller) UpdateFileInfo(ctx context.Context, file models.File) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", file.Id)
+	log.Debug("update file info request")
+	if err := c.checkEncrypted(ctx, file); err != nil {
+		return err
+	}
+
+	qLock := c.queue.Reserve(file.UserId)
+	defer qLock.Release()
+
+	stored, err := c.validateFileUpdate(ctx, file)
+	if err != nil {
+		return err
+	}
+	if stored.State == models.StateTrashed {
+		return ErrDeleted
+	}
+
+	s, err := serial.Next(ctx)
+	if err != nil {
+		return err
+	}
+	stored.Serial = s
+	stored.Name = file.Name
+	stored.Metadata = file.Metadata
+	stored.Folder = file.Folder
+	stored.Tags = file.Tags
+	stored.State = models.StateActive
+
+	err = c.store.ModifyFileInfo(ctx, stored)
+	if err != nil {
+		log.Warnf("file info update failed: %s", err.Error())
+	} else {
+		log.Info("file info updated")
+		c.broker.publish(stored.UserId, stored)
+		c.trimHistory(ctx, stored.Id, stored.UserId)
 	}
 	return err
 }
 
-func (c *Controller) validateFileUpdate(ctx context.Context, file models.File) (string, error) {
-	hash := ""
+func (c *Controller) validateFileUpdate(ctx context.Context, file models.File) (models.File, error) {
 	// get stored file
 	stored, err := c.store.GetFileInfo(ctx, file.Id, file.UserId)
 	if err != nil {
-		return hash, err
+		return stored, err
 	}
-	hash = stored.Sha265
 	if stored.State == models.StateDeleted {
-		return hash, ErrDeleted
+		return stored, ErrDeleted
 	}
 	if stored.Serial > file.Serial {
-		return hash, ErrChanged
+		return stored, ErrChanged
+	}
+	return stored, nil
+}
+
+// putBlob streams file data to storage and sets file blob id, size and hash
+func (c *Controller) putBlob(ctx context.Context, file *models.File, r io.Reader) error {
+	hash := sha256.New()
+	cr := &countingReader{r: io.TeeReader(r, hash)}
+	blobId, err := c.store.PutBlob(ctx, file.UserId, cr)
+	if err != nil {
+		return err
+	}
+	file.BlobId = blobId
+	file.Size = cr.n
+	file.Sha265 = fmt.Sprintf("%x", hash.Sum(nil))
+	return nil
+}
+
+// dropBlob removes file data, which is not referenced anymore
+func (c *Controller) dropBlob(ctx context.Context, blobId string) {
+	if err := c.store.DeleteBlob(context.WithoutCancel(ctx), blobId); err != nil {
+		logger.Log().WithCtxRequestId(ctx).With("blobId", blobId).
+			Warnf("failed to delete file data: %s", err.Error())
+	}
+}
+
+// dropFileData removes data of permanently deleted file, including data of all its revisions
+func (c *Controller) dropFileData(ctx context.Context, file models.File) {
+	blobs, err := c.fileBlobs(ctx, file)
+	if err != nil {
+		logger.Log().WithCtxRequestId(ctx).With("documentId", file.Id).
+			Warnf("failed to get file revisions: %s", err.Error())
+		return
+	}
+	for blobId := range blobs {
+		if blobId != "" {
+			c.dropBlob(ctx, blobId)
+		}
 	}
-	return hash, nil
+}
+
+// fileBlobs returns ids of data of file and all its revisions
+func (c *Controller) fileBlobs(ctx context.Context, file models.File) (map[string]struct{}, error) {
+	blobs := map[string]struct{}{file.BlobId: {}}
+	revisions, err := c.revisions(ctx, file.Id, file.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range revisions {
+		if rev, ok := doc.(models.File); ok {
+			blobs[rev.BlobId] = struct{}{}
+		}
+	}
+	return blobs, nil
+}
+
+// verifyBlob reads stored file data and checks its size and hash
+func (c *Controller) verifyBlob(ctx context.Context, file models.File) error {
+	r, err := c.store.OpenBlob(ctx, file.BlobId, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	hash := sha256.New()
+	n, err := io.Copy(hash, r)
+	if err != nil {
+		return err
+	}
+	if n != file.Size || fmt.Sprintf("%x", hash.Sum(nil)) != file.Sha265 {
+		return ErrChecksum
+	}
+	return nil
+}
+
+type limitReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// countingReader counts bytes read
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
 }
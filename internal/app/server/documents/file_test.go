@@ -1,7 +1,11 @@
 package documents
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
 	"testing"
 
 	fake "github.com/brianvoe/gofakeit/v6"
@@ -21,23 +25,36 @@ func TestController_AddFile(t *testing.T) {
 
 	c := New(docStore)
 	someErr := fake.Error()
+	data := []byte(fake.Sentence(64))
 
 	tests := []struct {
-		name    string
-		file    models.File
-		retErr  error
-		wantErr error
+		name          string
+		file          models.File
+		putErr        error
+		retErr        error
+		wantErr       error
+		wantAddCalls  int
+		wantDropCalls int
 	}{
 		{
-			name:    "ok",
-			file:    models.File{},
-			retErr:  nil,
-			wantErr: nil,
+			name:         "ok",
+			file:         models.File{},
+			retErr:       nil,
+			wantErr:      nil,
+			wantAddCalls: 1,
+		},
+		{
+			name:          "error",
+			file:          models.File{},
+			retErr:        someErr,
+			wantErr:       someErr,
+			wantAddCalls:  1,
+			wantDropCalls: 1,
 		},
 		{
-			name:    "error",
+			name:    "data error",
 			file:    models.File{},
-			retErr:  someErr,
+			putErr:  someErr,
 			wantErr: someErr,
 		},
 	}
@@ -46,15 +63,27 @@ func TestController_AddFile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			require.NoError(t, fake.Struct(&tt.file))
+			blobId := fake.UUID()
+			docStore.EXPECT().PutBlob(ctx, tt.file.UserId, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, userId string, r io.Reader) (string, error) {
+					b, err := io.ReadAll(r)
+					require.NoError(t, err)
+					assert.Equal(t, data, b, "file data should be passed to storage")
+					return blobId, tt.putErr
+				}).Times(1)
 			docStore.EXPECT().AddFile(ctx, gomock.Any()).
 				DoAndReturn(func(ctx context.Context, doc models.File) (string, error) {
 					s, _ := serial.Next(ctx)
 					assert.Equal(t, s-1, doc.Serial, "serial should be from serials source")
 					assert.Equal(t, models.StateActive, doc.State, "state should be active")
 					assert.Equal(t, "", doc.Id, "id should be empty")
+					assert.Equal(t, blobId, doc.BlobId, "blob id should be set")
+					assert.Equal(t, int64(len(data)), doc.Size, "size should be set from data")
+					assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256(data)), doc.Sha265, "hash should be set from data")
 					return fake.Word(), tt.retErr
-				}).Times(1)
-			err := c.AddFile(ctx, tt.file)
+				}).Times(tt.wantAddCalls)
+			docStore.EXPECT().DeleteBlob(gomock.Any(), blobId).Return(nil).Times(tt.wantDropCalls)
+			err := c.AddFile(ctx, tt.file, bytes.NewReader(data))
 			require.ErrorIs(t, err, tt.wantErr, "expect error %s, got %s", tt.wantErr, err)
 		})
 	}
@@ -168,18 +197,19 @@ func TestController_GetFile(t *testing.T) {
 	someErr := fake.Error()
 
 	tests := []struct {
-		name    string
-		docId   string
-		userId  string
-		retErr  error
-		wantErr error
+		name          string
+		docId         string
+		userId        string
+		retErr        error
+		openErr       error
+		wantErr       error
+		wantOpenCalls int
 	}{
 		{
-			name:    "ok",
-			docId:   fake.Word(),
-			userId:  fake.Word(),
-			retErr:  nil,
-			wantErr: nil,
+			name:          "ok",
+			docId:         fake.Word(),
+			userId:        fake.Word(),
+			wantOpenCalls: 1,
 		},
 		{
 			name:    "failed",
@@ -188,15 +218,47 @@ func TestController_GetFile(t *testing.T) {
 			retErr:  someErr,
 			wantErr: someErr,
 		},
+		{
+			name:          "data failed",
+			docId:         fake.Word(),
+			userId:        fake.Word(),
+			openErr:       someErr,
+			wantErr:       someErr,
+			wantOpenCalls: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			docStore.EXPECT().GetFile(ctx, tt.docId, tt.userId).Return(models.File{}, tt.retErr).Times(1)
-			_, err := c.GetFile(ctx, tt.docId, tt.userId)
+			blobId := fake.UUID()
+			docStore.EXPECT().GetFileInfo(ctx, tt.docId, tt.userId).Return(models.File{BlobId: blobId}, tt.retErr).Times(1)
+			docStore.EXPECT().OpenBlob(ctx, blobId, int64(0)).Return(io.NopCloser(bytes.NewReader(nil)), tt.openErr).Times(tt.wantOpenCalls)
+			_, _, err := c.GetFile(ctx, tt.docId, tt.userId, 0, 0)
 			require.ErrorIs(t, err, tt.wantErr, "expect error %s, got %s", tt.wantErr, err)
 		})
 	}
+
+	t.Run("range", func(t *testing.T) {
+		ctx := context.Background()
+		data := []byte("0123456789")
+		docStore.EXPECT().GetFileInfo(ctx, "id", "user").Return(models.File{BlobId: "blob", Size: 10}, nil).Times(1)
+		docStore.EXPECT().OpenBlob(ctx, "blob", int64(2)).Return(io.NopCloser(bytes.NewReader(data[2:])), nil).Times(1)
+		_, r, err := c.GetFile(ctx, "id", "user", 2, 5)
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, data[2:7], b, "expect requested range")
+		require.NoError(t, r.Close())
+	})
+
+	t.Run("bad range", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, err := c.GetFile(ctx, "id", "user", -1, 0)
+		require.ErrorIs(t, err, ErrBadRequest)
+		docStore.EXPECT().GetFileInfo(ctx, "id", "user").Return(models.File{BlobId: "blob", Size: 10}, nil).Times(1)
+		_, _, err = c.GetFile(ctx, "id", "user", 11, 0)
+		require.ErrorIs(t, err, ErrBadRequest)
+	})
 }
 
 func TestController_UpdateFile(t *testing.T) {
@@ -206,7 +268,8 @@ func TestController_UpdateFile(t *testing.T) {
 
 	c := New(docStore)
 	someErr := fake.Error()
-	goodhash := fake.DigitN(32)
+	data := []byte(fake.Sentence(64))
+	goodhash := fmt.Sprintf("%x", sha256.Sum256(data))
 	badhash := fake.DigitN(32)
 
 	tests := []struct {
@@ -222,6 +285,7 @@ func TestController_UpdateFile(t *testing.T) {
 		wantErr             error
 		wantUpdateCalls     int
 		wantUpdateInfoCalls int
+		wantDropCalls       int
 	}{
 		{
 			name:            "ok updateFile",
@@ -248,6 +312,7 @@ func TestController_UpdateFile(t *testing.T) {
 			retErr:              nil,
 			wantErr:             nil,
 			wantUpdateInfoCalls: 1,
+			wantDropCalls:       1,
 		},
 		{
 			name:            "updateFile error",
@@ -261,6 +326,7 @@ func TestController_UpdateFile(t *testing.T) {
 			retErr:          someErr,
 			wantErr:         someErr,
 			wantUpdateCalls: 1,
+			wantDropCalls:   1,
 		},
 		{
 			name:                "updateFileInfo error",
@@ -274,37 +340,41 @@ func TestController_UpdateFile(t *testing.T) {
 			retErr:              someErr,
 			wantErr:             someErr,
 			wantUpdateInfoCalls: 1,
+			wantDropCalls:       1,
 		},
 		{
-			name:         "not found",
-			file:         models.File{},
-			fileSerial:   128,
-			fileHash:     goodhash,
-			storedSerial: 128,
-			storedState:  models.StateActive,
-			findErr:      ErrNotFound,
-			retErr:       someErr,
-			wantErr:      ErrNotFound,
+			name:          "not found",
+			file:          models.File{},
+			fileSerial:    128,
+			fileHash:      goodhash,
+			storedSerial:  128,
+			storedState:   models.StateActive,
+			findErr:       ErrNotFound,
+			retErr:        someErr,
+			wantErr:       ErrNotFound,
+			wantDropCalls: 1,
 		},
 		{
-			name:         "already deleted",
-			file:         models.File{},
-			fileSerial:   128,
-			storedSerial: 128,
-			storedState:  models.StateDeleted,
-			findErr:      nil,
-			retErr:       someErr,
-			wantErr:      ErrDeleted,
+			name:          "already deleted",
+			file:          models.File{},
+			fileSerial:    128,
+			storedSerial:  128,
+			storedState:   models.StateDeleted,
+			findErr:       nil,
+			retErr:        someErr,
+			wantErr:       ErrDeleted,
+			wantDropCalls: 1,
 		},
 		{
-			name:         "serial mismatch",
-			file:         models.File{},
-			fileSerial:   128,
-			storedSerial: 128 + 1,
-			storedState:  models.StateActive,
-			findErr:      nil,
-			retErr:       someErr,
-			wantErr:      ErrChanged,
+			name:          "serial mismatch",
+			file:          models.File{},
+			fileSerial:    128,
+			storedSerial:  128 + 1,
+			storedState:   models.StateActive,
+			findErr:       nil,
+			retErr:        someErr,
+			wantErr:       ErrChanged,
+			wantDropCalls: 1,
 		},
 	}
 	serial.SetSource(new(serial.SimpleSerialSource))
@@ -314,11 +384,18 @@ func TestController_UpdateFile(t *testing.T) {
 			require.NoError(t, fake.Struct(&tt.file))
 			tt.file.Serial = tt.fileSerial
 			tt.file.Sha265 = tt.fileHash
+			blobId, storedBlobId := fake.UUID(), fake.UUID()
+			docStore.EXPECT().PutBlob(ctx, tt.file.UserId, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, userId string, r io.Reader) (string, error) {
+					_, err := io.Copy(io.Discard, r)
+					return blobId, err
+				}).Times(1)
 			docStore.EXPECT().ModifyFile(ctx, gomock.Any()).
 				DoAndReturn(func(ctx context.Context, doc models.File) error {
 					s, _ := serial.Next(ctx)
 					assert.Equal(t, s-1, doc.Serial, "expect correct serial")
 					assert.Equal(t, models.StateActive, doc.State, "expect state active")
+					assert.Equal(t, blobId, doc.BlobId, "expect new data")
 					return tt.retErr
 				}).Times(tt.wantUpdateCalls)
 			docStore.EXPECT().ModifyFileInfo(ctx, gomock.Any()).
@@ -326,11 +403,88 @@ func TestController_UpdateFile(t *testing.T) {
 					s, _ := serial.Next(ctx)
 					assert.Equal(t, s-1, doc.Serial, "expect correct serial")
 					assert.Equal(t, models.StateActive, doc.State, "expect state active")
+					assert.Equal(t, storedBlobId, doc.BlobId, "expect stored data")
 					return tt.retErr
 				}).Times(tt.wantUpdateInfoCalls)
 			docStore.EXPECT().GetFileInfo(ctx, tt.file.Id, tt.file.UserId).
-				Return(models.File{Serial: tt.storedSerial, State: tt.storedState, Sha265: tt.storedHash}, tt.findErr).Times(1)
-			err := c.UpdateFile(ctx, tt.file)
+				Return(models.File{Serial: tt.storedSerial, State: tt.storedState, Sha265: tt.storedHash, BlobId: storedBlobId}, tt.findErr).Times(1)
+			docStore.EXPECT().DeleteBlob(gomock.Any(), blobId).Return(nil).Times(tt.wantDropCalls)
+			err := c.UpdateFile(ctx, tt.file, bytes.NewReader(data))
+			require.ErrorIs(t, err, tt.wantErr, "expect error %s, got %s", tt.wantErr, err)
+		})
+	}
+}
+
+func TestController_UpdateFileInfo(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+
+	c := New(docStore)
+	someErr := fake.Error()
+
+	tests := []struct {
+		name         string
+		storedSerial int64
+		storedState  string
+		findErr      error
+		retErr       error
+		wantErr      error
+		wantCalls    int
+	}{
+		{
+			name:         "ok",
+			storedSerial: 128,
+			storedState:  models.StateActive,
+			wantCalls:    1,
+		},
+		{
+			name:         "update error",
+			storedSerial: 128,
+			storedState:  models.StateActive,
+			retErr:       someErr,
+			wantErr:      someErr,
+			wantCalls:    1,
+		},
+		{
+			name:         "not found",
+			storedSerial: 128,
+			storedState:  models.StateActive,
+			findErr:      ErrNotFound,
+			wantErr:      ErrNotFound,
+		},
+		{
+			name:         "trashed",
+			storedSerial: 128,
+			storedState:  models.StateTrashed,
+			wantErr:      ErrDeleted,
+		},
+		{
+			name:         "serial mismatch",
+			storedSerial: 128 + 1,
+			storedState:  models.StateActive,
+			wantErr:      ErrChanged,
+		},
+	}
+	serial.SetSource(new(serial.SimpleSerialSource))
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var file, stored models.File
+			require.NoError(t, fake.Struct(&file))
+			require.NoError(t, fake.Struct(&stored))
+			file.Serial = 128
+			stored.Serial, stored.State = tt.storedSerial, tt.storedState
+			docStore.EXPECT().GetFileInfo(ctx, file.Id, file.UserId).Return(stored, tt.findErr).Times(1)
+			docStore.EXPECT().ModifyFileInfo(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, doc models.File) error {
+					assert.Equal(t, file.Name, doc.Name, "expect new name")
+					assert.Equal(t, file.Metadata, doc.Metadata, "expect new metadata")
+					assert.Equal(t, stored.BlobId, doc.BlobId, "expect stored data")
+					assert.Equal(t, stored.Sha265, doc.Sha265, "expect stored data hash")
+					return tt.retErr
+				}).Times(tt.wantCalls)
+			err := c.UpdateFileInfo(ctx, file)
 			require.ErrorIs(t, err, tt.wantErr, "expect error %s, got %s", tt.wantErr, err)
 		})
 	}
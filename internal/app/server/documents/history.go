@@ -0,0 +1,132 @@
+package documents
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// WithHistoryLimit sets number of previous revisions kept for each document,
+// the oldest revisions above limit are removed. Zero limit keeps all revisions.
+func WithHistoryLimit(limit int) func(c *Controller) {
+	return func(c *Controller) {
+		c.historyLimit = limit
+	}
+}
+
+// GetHistoryStream sends previous revisions of the document, the newest first.
+// Files revisions do not contain binary data.
+func (c *Controller) GetHistoryStream(ctx context.Context, docId string, userId string, chData chan interface{}, chErr chan error) {
+	defer func() {
+		close(chData)
+		close(chErr)
+	}()
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", docId)
+	log.Debug("history stream request")
+	if err := c.store.GetHistoryStream(ctx, docId, userId, chData); err != nil {
+		log.Warnf("history stream failed: %s", err.Error())
+		chErr <- err
+	}
+}
+
+// RestoreRevision saves document revision from history as a new document revision.
+// Current revision is kept in history. Deleted documents may be restored too.
+func (c *Controller) RestoreRevision(ctx context.Context, docId string, userId string, revision int64) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", docId)
+	log.Debug("restore revision request")
+
+	qLock := c.queue.Reserve(userId)
+	defer qLock.Release()
+
+	doc, err := c.store.GetRevision(ctx, docId, userId, revision)
+	if err != nil {
+		return err
+	}
+	if err := c.checkEncrypted(ctx, doc); err != nil {
+		return err
+	}
+
+	s, err := serial.Next(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch d := doc.(type) {
+	case models.Note:
+		d.Id, d.UserId, d.Serial, d.State = docId, userId, s, models.StateActive
+		if err = c.store.ModifyNote(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	case models.Card:
+		d.Id, d.UserId, d.Serial, d.State = docId, userId, s, models.StateActive
+		if err = c.store.ModifyCard(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	case models.Credential:
+		d.Id, d.UserId, d.Serial, d.State = docId, userId, s, models.StateActive
+		if err = c.store.ModifyCredential(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	case models.File:
+		d.Id, d.UserId, d.Serial, d.State = docId, userId, s, models.StateActive
+		if err = c.store.ModifyFile(ctx, d); err == nil {
+			d.Data = nil
+			c.broker.publish(userId, d)
+		}
+	default:
+		return ErrBadRequest
+	}
+	if err != nil {
+		log.Warnf("revision restore failed: %s", err.Error())
+	} else {
+		log.With("revision", revision).Info("revision restored")
+		c.trimHistory(ctx, docId, userId)
+	}
+	return err
+}
+
+// trimHistory removes the oldest document revisions above history limit. Data of removed
+// file revisions is dropped, unless file or its kept revisions still refer to it.
+func (c *Controller) trimHistory(ctx context.Context, docId string, userId string) {
+	if c.historyLimit <= 0 {
+		return
+	}
+	log := logger.Log().WithCtxRequestId(ctx).With("documentId", docId)
+	removed, err := c.store.TrimHistory(ctx, docId, userId, c.historyLimit)
+	if err != nil {
+		log.Warnf("failed to trim document history: %s", err.Error())
+		return
+	}
+	c.dropRevisionsData(ctx, docId, userId, removed)
+}
+
+// dropRevisionsData drops data of removed file revisions, unless file or its kept revisions still refer to it
+func (c *Controller) dropRevisionsData(ctx context.Context, docId string, userId string, removed []interface{}) {
+	log := logger.Log().WithCtxRequestId(ctx).With("documentId", docId)
+	blobs := make(map[string]struct{})
+	for _, doc := range removed {
+		if f, ok := doc.(models.File); ok && f.BlobId != "" {
+			blobs[f.BlobId] = struct{}{}
+		}
+	}
+	if len(blobs) == 0 {
+		return
+	}
+	file, err := c.store.GetFileInfo(ctx, docId, userId)
+	if err != nil {
+		log.Warnf("failed to get trimmed file: %s", err.Error())
+		return
+	}
+	kept, err := c.fileBlobs(ctx, file)
+	if err != nil {
+		log.Warnf("failed to get file revisions: %s", err.Error())
+		return
+	}
+	for blobId := range blobs {
+		if _, ok := kept[blobId]; !ok {
+			c.dropBlob(ctx, blobId)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package documents
+
+import (
+	"context"
+	"testing"
+
+	fake "github.com/brianvoe/gofakeit/v6"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/mocks"
+)
+
+func TestController_RestoreRevision(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+
+	c := New(docStore)
+	serial.SetSource(new(serial.SimpleSerialSource))
+	ctx := context.Background()
+
+	t.Run("note", func(t *testing.T) {
+		rev := models.Note{Id: "id", UserId: "user", Serial: 5, Name: fake.Word(), State: models.StateActive}
+		docStore.EXPECT().GetRevision(ctx, "id", "user", int64(5)).Return(rev, nil)
+		docStore.EXPECT().ModifyNote(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, doc models.Note) error {
+				s, _ := serial.Next(ctx)
+				assert.Equal(t, s-1, doc.Serial, "restored revision should get new serial")
+				assert.Equal(t, rev.Name, doc.Name, "revision payload expected")
+				assert.Equal(t, models.StateActive, doc.State)
+				return nil
+			})
+		require.NoError(t, c.RestoreRevision(ctx, "id", "user", 5))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		rev := models.File{Id: "id", UserId: "user", Serial: 3, BlobId: "blob", State: models.StateActive}
+		docStore.EXPECT().GetRevision(ctx, "id", "user", int64(3)).Return(rev, nil)
+		docStore.EXPECT().ModifyFile(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, doc models.File) error {
+				assert.Equal(t, rev.BlobId, doc.BlobId, "file data should be restored")
+				assert.Greater(t, doc.Serial, rev.Serial)
+				return nil
+			})
+		require.NoError(t, c.RestoreRevision(ctx, "id", "user", 3))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		docStore.EXPECT().GetRevision(ctx, "id", "user", int64(1)).Return(nil, ErrNotFound)
+		require.ErrorIs(t, c.RestoreRevision(ctx, "id", "user", 1), ErrNotFound)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		someErr := fake.Error()
+		docStore.EXPECT().GetRevision(ctx, "id", "user", int64(2)).Return(models.Card{}, nil)
+		docStore.EXPECT().ModifyCard(ctx, gomock.Any()).Return(someErr)
+		require.ErrorIs(t, c.RestoreRevision(ctx, "id", "user", 2), someErr)
+	})
+}
+
+func TestController_GetHistoryStream(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+
+	c := New(docStore)
+	ctx := context.Background()
+	rev := models.Credential{Id: "id", Serial: 2}
+	docStore.EXPECT().GetHistoryStream(ctx, "id", "user", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, chData chan interface{}) error {
+			chData <- rev
+			return ErrBadRequest
+		})
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go c.GetHistoryStream(ctx, "id", "user", chData, chErr)
+	var got []interface{}
+	for d := range chData {
+		got = append(got, d)
+	}
+	assert.Equal(t, []interface{}{rev}, got)
+	assert.ErrorIs(t, <-chErr, ErrBadRequest)
+}
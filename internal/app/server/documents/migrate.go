@@ -0,0 +1,124 @@
+package documents
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/pkg/crypt"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// WithMigrated sets function, which shows whether all user documents are re-encrypted
+// by client. Since then, documents with plain text payload are rejected with ErrPlainText.
+func WithMigrated(fn func(ctx context.Context, userId string) (bool, error)) func(c *Controller) {
+	return func(c *Controller) {
+		c.migrated = fn
+	}
+}
+
+// checkEncrypted returns ErrPlainText, if document has plain text payload and user is migrated.
+// Migration state of requesting user is checked, as shared vault documents are stored by vault id.
+func (c *Controller) checkEncrypted(ctx context.Context, doc interface{}) error {
+	if c.migrated == nil || isEncrypted(doc) {
+		return nil
+	}
+	userId, ok := logger.GetUserId(ctx)
+	if !ok {
+		_, userId = docOwner(doc)
+	}
+	migrated, err := c.migrated(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if migrated {
+		logger.Log().WithCtxRequestId(ctx).With("userId", userId).Warn("plain text document rejected")
+		return ErrPlainText
+	}
+	return nil
+}
+
+// PurgePlainHistory removes revisions of user documents, saved before encryption was
+// introduced, and data of removed file revisions. It is called, when client has re-encrypted
+// user documents, so plain text revisions can't be restored or exported anymore.
+func (c *Controller) PurgePlainHistory(ctx context.Context, userId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId)
+	log.Debug("purge plain text history request")
+	qLock := c.queue.Reserve(userId)
+	defer qLock.Release()
+
+	docs, err := c.userDocuments(ctx, userId)
+	if err != nil {
+		log.Warnf("purge plain text history failed: %s", err.Error())
+		return err
+	}
+	purged := 0
+	for _, doc := range docs {
+		docId, _ := docOwner(doc)
+		revisions, err := c.revisions(ctx, docId, userId)
+		if err != nil {
+			log.Warnf("purge plain text history failed: %s", err.Error())
+			return err
+		}
+		var serials []int64
+		var removed []interface{}
+		for _, rev := range revisions {
+			if !isEncrypted(rev) {
+				serials, removed = append(serials, docSerial(rev)), append(removed, rev)
+			}
+		}
+		if len(serials) == 0 {
+			continue
+		}
+		if err := c.store.DeleteRevisions(ctx, docId, userId, serials); err != nil {
+			log.With("documentId", docId).Warnf("purge plain text history failed: %s", err.Error())
+			return err
+		}
+		c.dropRevisionsData(ctx, docId, userId, removed)
+		purged += len(serials)
+	}
+	log.Infof("purged %d plain text revisions", purged)
+	return nil
+}
+
+// isEncrypted checks whether all payload strings of document are encrypted by client.
+// Documents, saved before encryption was introduced, have plain text payload. Empty
+// strings have no plain text: fields, added later, are empty in documents saved before.
+func isEncrypted(doc interface{}) bool {
+	var fields, tags []string
+	var metadata []models.Meta
+	switch d := doc.(type) {
+	case models.Note:
+		fields, metadata, tags = []string{d.Name, d.Text, d.Folder}, d.Metadata, d.Tags
+	case models.Card:
+		fields, metadata, tags = []string{d.Name, d.Cardholder, d.Number, d.Expires, d.Pin, d.Code, d.Folder}, d.Metadata, d.Tags
+	case models.Credential:
+		fields, metadata, tags = []string{d.Name, d.Login, d.Password, d.Folder}, d.Metadata, d.Tags
+	case models.File:
+		fields, metadata, tags = []string{d.Name, d.Filename, d.Folder}, d.Metadata, d.Tags
+	default:
+		return false
+	}
+	for _, m := range metadata {
+		fields = append(fields, m.Key, m.Value)
+	}
+	for _, f := range append(fields, tags...) {
+		if f != "" && !crypt.IsEncrypted(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func docSerial(doc interface{}) int64 {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.Serial
+	case models.Card:
+		return d.Serial
+	case models.Credential:
+		return d.Serial
+	case models.File:
+		return d.Serial
+	}
+	return 0
+}
@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"time"
 
 	"yap-pwkeeper/internal/app/server/serial"
 	"yap-pwkeeper/internal/pkg/logger"
@@ -12,6 +13,9 @@ import (
 func (c *Controller) AddNote(ctx context.Context, note models.Note) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add note request")
+	if err := c.checkEncrypted(ctx, note); err != nil {
+		return err
+	}
 	qLock := c.queue.Reserve(note.UserId)
 	defer qLock.Release()
 	s, err := serial.Next(ctx)
@@ -26,6 +30,8 @@ func (c *Controller) AddNote(ctx context.Context, note models.Note) error {
 		logger.Log().Warnf("add note failed: %s", err.Error())
 	} else {
 		log.With("documentId", oid).Info("note added")
+		note.Id = oid
+		c.broker.publish(note.UserId, note)
 	}
 	return err
 }
@@ -40,7 +46,8 @@ func (c *Controller) DeleteNote(ctx context.Context, note models.Note) error {
 	qLock := c.queue.Reserve(note.UserId)
 	defer qLock.Release()
 
-	if err := c.validateNoteUpdate(ctx, note); err != nil {
+	stored, err := c.validateNoteUpdate(ctx, note)
+	if err != nil {
 		return err
 	}
 
@@ -50,6 +57,21 @@ func (c *Controller) DeleteNote(ctx context.Context, note models.Note) error {
 	}
 	note.Serial = s
 
+	if c.trashRetention > 0 && stored.State != models.StateTrashed {
+		stored.Serial = s
+		stored.State = models.StateTrashed
+		stored.TrashedAt = time.Now().Unix()
+		err = c.store.ModifyNote(ctx, stored)
+		if err != nil {
+			log.Warnf("note move to trash failed: %s", err.Error())
+		} else {
+			log.Info("note moved to trash")
+			c.broker.publish(stored.UserId, stored)
+			c.trimHistory(ctx, stored.Id, stored.UserId)
+		}
+		return err
+	}
+
 	deleted := models.Note{
 		Id:     note.Id,
 		UserId: note.UserId,
@@ -62,6 +84,10 @@ func (c *Controller) DeleteNote(ctx context.Context, note models.Note) error {
 		logger.Log().Warnf("note delete failed: %s", err.Error())
 	} else {
 		logger.Log().Info("note deleted")
+		c.broker.publish(deleted.UserId, deleted)
+		if stored.State == models.StateTrashed {
+			c.dropHistory(ctx, deleted.Id, deleted.UserId)
+		}
 	}
 	return err
 }
@@ -70,13 +96,20 @@ func (c *Controller) DeleteNote(ctx context.Context, note models.Note) error {
 func (c *Controller) UpdateNote(ctx context.Context, note models.Note) error {
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", note.Id)
 	log.Debug("update note request")
+	if err := c.checkEncrypted(ctx, note); err != nil {
+		return err
+	}
 
 	qLock := c.queue.Reserve(note.UserId)
 	defer qLock.Release()
 
-	if err := c.validateNoteUpdate(ctx, note); err != nil {
+	stored, err := c.validateNoteUpdate(ctx, note)
+	if err != nil {
 		return err
 	}
+	if stored.State == models.StateTrashed {
+		return ErrDeleted
+	}
 
 	s, err := serial.Next(ctx)
 	if err != nil {
@@ -90,21 +123,23 @@ func (c *Controller) UpdateNote(ctx context.Context, note models.Note) error {
 		logger.Log().Warnf("note update failed: %s", err.Error())
 	} else {
 		logger.Log().Info("note updated")
+		c.broker.publish(note.UserId, note)
+		c.trimHistory(ctx, note.Id, note.UserId)
 	}
 	return err
 }
 
-func (c *Controller) validateNoteUpdate(ctx context.Context, note models.Note) error {
+func (c *Controller) validateNoteUpdate(ctx context.Context, note models.Note) (models.Note, error) {
 	// get stored note
 	stored, err := c.store.GetNote(ctx, note.Id, note.UserId)
 	if err != nil {
-		return err
+		return stored, err
 	}
 	if stored.State == models.StateDeleted {
-		return ErrDeleted
+		return stored, ErrDeleted
 	}
 	if stored.Serial > note.Serial {
-		return ErrChanged
+		return stored, ErrChanged
 	}
-	return nil
+	return stored, nil
 }
@@ -0,0 +1,72 @@
+package documents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/jwtToken"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// TestController_SubscribeRevokedSession checks that live updates stream is closed,
+// when its session is revoked, and streams of other sessions are kept
+func TestController_SubscribeRevokedSession(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db)
+	defer c.Close()
+	auth := aaa.New(db, aaa.WithSessionEnd(c.DropSession))
+	ctx := context.Background()
+	cred := models.UserCredentials{Login: "user", Password: strings.Repeat("ab", 32)}
+	require.NoError(t, auth.Register(ctx, cred))
+
+	type stream struct {
+		chData chan interface{}
+		chErr  chan error
+	}
+	subscribe := func(token string) stream {
+		sctx := logger.WithSessionId(ctx, jwtToken.GetTokenSession(token))
+		s := stream{chData: make(chan interface{}), chErr: make(chan error, 1)}
+		go c.Subscribe(sctx, jwtToken.GetTokenSubject(token), 0, s.chData, s.chErr)
+		require.Equal(t, documents.SyncMark{}, <-s.chData)
+		return s
+	}
+	laptop, err := auth.Login(ctx, cred)
+	require.NoError(t, err)
+	phone, err := auth.Login(ctx, cred)
+	require.NoError(t, err)
+	userId := jwtToken.GetTokenSubject(laptop)
+	laptopStream, phoneStream := subscribe(laptop), subscribe(phone)
+
+	require.NoError(t, auth.RevokeSession(ctx, userId, jwtToken.GetTokenSession(phone)))
+	select {
+	case _, ok := <-phoneStream.chData:
+		assert.False(t, ok, "stream of revoked session should be closed")
+	case <-time.After(time.Second):
+		t.Fatal("stream of revoked session is not closed")
+	}
+	assert.ErrorIs(t, <-phoneStream.chErr, documents.ErrSessionClosed)
+
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: userId, Name: "note"}))
+	select {
+	case d := <-laptopStream.chData:
+		assert.IsType(t, models.Note{}, d, "stream of other session should receive updates")
+	case <-time.After(time.Second):
+		t.Fatal("live update timeout")
+	}
+
+	require.NoError(t, auth.Logout(ctx, userId, jwtToken.GetTokenSession(laptop)))
+	_, ok := <-laptopStream.chData
+	assert.False(t, ok, "stream should be closed on logout")
+	assert.ErrorIs(t, <-laptopStream.chErr, documents.ErrSessionClosed)
+}
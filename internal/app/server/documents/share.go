@@ -0,0 +1,162 @@
+package documents
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+var (
+	ErrNoShare         = errors.New("share not found or expired")
+	ErrSharePassphrase = errors.New("share passphrase does not match")
+)
+
+// Share limits
+const (
+	defaultShareTTL  = 24 * time.Hour
+	maxShareTTL      = 30 * 24 * time.Hour
+	maxShareViews    = 100
+	maxShareSize     = 64 << 10 // documents with files data are not shared
+	maxShareFailures = 5        // share is destroyed after wrong passphrase attempts
+)
+
+// ShareStorage defines interface of document shares storage backend
+type ShareStorage interface {
+	AddShare(ctx context.Context, share models.Share) error
+	GetShare(ctx context.Context, shareId string) (models.Share, error)
+	ModifyShare(ctx context.Context, share models.Share) error
+	DeleteShare(ctx context.Context, shareId string) error
+	DeleteExpiredShares(ctx context.Context, before int64) (int, error)
+	DeleteUserShares(ctx context.Context, userId string) error
+}
+
+// WithShares enables one-time document shares
+func WithShares(store ShareStorage) func(c *Controller) {
+	return func(c *Controller) {
+		c.shares = store
+	}
+}
+
+// CreateShare saves snapshot of the document, which may be opened by share id without
+// authorization. Share UserId is owner of the document, Data is the snapshot encrypted
+// by client. Share expires after ttl (default is used if zero) or when ViewsLeft views
+// are used (one view if zero). Optional passphrase is required to open share.
+func (c *Controller) CreateShare(ctx context.Context, share models.Share, passphrase string, ttl time.Duration) (models.Share, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", share.DocumentId)
+	log.Debug("create share request")
+	if c.shares == nil {
+		return share, ErrBadRequest
+	}
+	if ttl == 0 {
+		ttl = defaultShareTTL
+	}
+	if share.ViewsLeft == 0 {
+		share.ViewsLeft = 1
+	}
+	if ttl < 0 || ttl > maxShareTTL || share.ViewsLeft < 0 || share.ViewsLeft > maxShareViews ||
+		len(share.Data) == 0 || len(share.Data) > maxShareSize {
+		return share, ErrBadRequest
+	}
+	var doc interface{}
+	var err error
+	switch share.Kind {
+	case KindNote:
+		doc, err = c.store.GetNote(ctx, share.DocumentId, share.UserId)
+	case KindCard:
+		doc, err = c.store.GetCard(ctx, share.DocumentId, share.UserId)
+	case KindCredential:
+		doc, err = c.store.GetCredential(ctx, share.DocumentId, share.UserId)
+	default:
+		return share, ErrBadRequest
+	}
+	if err != nil {
+		return share, err
+	}
+	if docState(doc) != models.StateActive {
+		return share, ErrDeleted
+	}
+	if passphrase != "" {
+		if share.PassHash, err = bcrypt.GenerateFromPassword([]byte(passphrase), bcrypt.DefaultCost); err != nil {
+			return share, ErrBadRequest
+		}
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return share, err
+	}
+	share.Id = hex.EncodeToString(id)
+	share.ExpiresAt = time.Now().Add(ttl).Unix()
+	share.Failures = 0
+	if err := c.shares.AddShare(ctx, share); err != nil {
+		log.Warnf("create share failed: %s", err.Error())
+		return share, err
+	}
+	log.Info("document shared")
+	return share, nil
+}
+
+// OpenShare returns document share and uses one of its views. Share is destroyed, when
+// the last view is used, when it is expired, or after too many wrong passphrases.
+func (c *Controller) OpenShare(ctx context.Context, shareId string, passphrase string) (models.Share, error) {
+	log := logger.Log().WithCtxRequestId(ctx).With("shareId", shareId)
+	log.Debug("open share request")
+	if c.shares == nil {
+		return models.Share{}, ErrNoShare
+	}
+	qLock := c.queue.Reserve("share:" + shareId)
+	defer qLock.Release()
+	share, err := c.shares.GetShare(ctx, shareId)
+	if err != nil {
+		return share, err
+	}
+	if share.ExpiresAt <= time.Now().Unix() {
+		c.dropShare(ctx, shareId)
+		return models.Share{}, ErrNoShare
+	}
+	if share.PassHash != nil && bcrypt.CompareHashAndPassword(share.PassHash, []byte(passphrase)) != nil {
+		share.Failures++
+		if share.Failures >= maxShareFailures {
+			log.Info("share destroyed after wrong passphrases")
+			c.dropShare(ctx, shareId)
+		} else if err := c.shares.ModifyShare(ctx, share); err != nil {
+			return models.Share{}, err
+		}
+		return models.Share{}, ErrSharePassphrase
+	}
+	share.ViewsLeft--
+	if share.ViewsLeft <= 0 {
+		if err := c.shares.DeleteShare(ctx, shareId); err != nil {
+			return models.Share{}, err
+		}
+	} else if err := c.shares.ModifyShare(ctx, share); err != nil {
+		return models.Share{}, err
+	}
+	log.Infof("share opened, %d views left", share.ViewsLeft)
+	return share, nil
+}
+
+// PurgeShares removes expired shares
+func (c *Controller) PurgeShares(ctx context.Context) error {
+	if c.shares == nil {
+		return nil
+	}
+	n, err := c.shares.DeleteExpiredShares(ctx, time.Now().Unix())
+	if n > 0 {
+		logger.Log().WithCtxRequestId(ctx).Infof("purged %d expired shares", n)
+	}
+	return err
+}
+
+// dropShare removes share, failures are only logged
+func (c *Controller) dropShare(ctx context.Context, shareId string) {
+	if err := c.shares.DeleteShare(ctx, shareId); err != nil && !errors.Is(err, ErrNoShare) {
+		logger.Log().WithCtxRequestId(ctx).Warnf("delete share %s failed: %s", shareId, err.Error())
+	}
+}
@@ -0,0 +1,116 @@
+package documents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestController_Shares(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db, documents.WithShares(db))
+	ctx := context.Background()
+
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: "user", Name: "wifi"}))
+	list := updates(t, c, "user", -1)
+	require.Len(t, list, 1)
+	note := list[0].(models.Note)
+	share := models.Share{UserId: "user", DocumentId: note.Id, Kind: documents.KindNote, Data: []byte("sealed")}
+
+	bad := []struct {
+		name    string
+		share   func(s models.Share) models.Share
+		ttl     time.Duration
+		wantErr error
+	}{
+		{"no data", func(s models.Share) models.Share { s.Data = nil; return s }, 0, documents.ErrBadRequest},
+		{"files", func(s models.Share) models.Share { s.Kind = documents.KindFile; return s }, 0, documents.ErrBadRequest},
+		{"long ttl", func(s models.Share) models.Share { return s }, 365 * 24 * time.Hour, documents.ErrBadRequest},
+		{"many views", func(s models.Share) models.Share { s.ViewsLeft = 1000; return s }, 0, documents.ErrBadRequest},
+		{"other user", func(s models.Share) models.Share { s.UserId = "other"; return s }, 0, documents.ErrNotFound},
+	}
+	for _, tt := range bad {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.CreateShare(ctx, tt.share(share), "", tt.ttl)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("views", func(t *testing.T) {
+		s := share
+		s.ViewsLeft = 2
+		created, err := c.CreateShare(ctx, s, "", time.Hour)
+		require.NoError(t, err)
+		require.NotEmpty(t, created.Id)
+		assert.Greater(t, created.ExpiresAt, time.Now().Unix())
+		got, err := c.OpenShare(ctx, created.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("sealed"), got.Data)
+		assert.Equal(t, 1, got.ViewsLeft)
+		got, err = c.OpenShare(ctx, created.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.ViewsLeft)
+		_, err = c.OpenShare(ctx, created.Id, "")
+		require.ErrorIs(t, err, documents.ErrNoShare, "share should be destroyed after last view")
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		created, err := c.CreateShare(ctx, share, "secret", 0)
+		require.NoError(t, err)
+		_, err = c.OpenShare(ctx, created.Id, "wrong")
+		require.ErrorIs(t, err, documents.ErrSharePassphrase)
+		got, err := c.OpenShare(ctx, created.Id, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, note.Id, got.DocumentId)
+
+		created, err = c.CreateShare(ctx, share, "secret", 0)
+		require.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			_, err = c.OpenShare(ctx, created.Id, "wrong")
+			require.ErrorIs(t, err, documents.ErrSharePassphrase)
+		}
+		_, err = c.OpenShare(ctx, created.Id, "secret")
+		require.ErrorIs(t, err, documents.ErrNoShare, "share should be destroyed after wrong passphrases")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := models.Share{Id: "expired", UserId: "user", Kind: documents.KindNote, Data: []byte("x"),
+			ExpiresAt: time.Now().Unix() - 1, ViewsLeft: 1}
+		require.NoError(t, db.AddShare(ctx, expired))
+		_, err := c.OpenShare(ctx, expired.Id, "")
+		require.ErrorIs(t, err, documents.ErrNoShare)
+		_, err = db.GetShare(ctx, expired.Id)
+		require.ErrorIs(t, err, documents.ErrNoShare, "expired share should be deleted on open")
+
+		expired.Id = "purged"
+		require.NoError(t, db.AddShare(ctx, expired))
+		require.NoError(t, c.PurgeShares(ctx))
+		_, err = db.GetShare(ctx, expired.Id)
+		require.ErrorIs(t, err, documents.ErrNoShare, "expired share should be purged")
+	})
+
+	t.Run("deleted document", func(t *testing.T) {
+		require.NoError(t, c.AddNote(ctx, models.Note{UserId: "removed", Name: "old"}))
+		n := updates(t, c, "removed", -1)[0].(models.Note)
+		require.NoError(t, c.DeleteNote(ctx, n))
+		_, err := c.CreateShare(ctx, models.Share{UserId: "removed", DocumentId: n.Id, Kind: documents.KindNote, Data: []byte("x")}, "", 0)
+		require.ErrorIs(t, err, documents.ErrDeleted)
+	})
+
+	t.Run("delete user data", func(t *testing.T) {
+		created, err := c.CreateShare(ctx, share, "", 0)
+		require.NoError(t, err)
+		require.NoError(t, c.DeleteUserData(ctx, "user"))
+		_, err = c.OpenShare(ctx, created.Id, "")
+		require.ErrorIs(t, err, documents.ErrNoShare, "shares should be deleted with user data")
+	})
+}
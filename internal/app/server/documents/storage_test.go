@@ -0,0 +1,220 @@
+package documents_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// updates returns all user documents, updated after minSerial. Clients request all documents with -1.
+func updates(t *testing.T, c *documents.Controller, userId string, minSerial int64) []interface{} {
+	t.Helper()
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go c.GetUpdatesStream(context.Background(), userId, minSerial, chData, chErr)
+	var list []interface{}
+	for doc := range chData {
+		list = append(list, doc)
+	}
+	require.NoError(t, <-chErr)
+	return list
+}
+
+// TestController_FileLifecycle checks controller behaviour with in-memory storage
+func TestController_FileLifecycle(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db, documents.WithTrashRetention(time.Hour))
+	ctx := context.Background()
+	readFile := func(docId string) []byte {
+		_, r, err := c.GetFile(ctx, docId, "user", 0, 0)
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return b
+	}
+
+	require.NoError(t, c.AddFile(ctx, models.File{UserId: "user", Name: "name", Filename: "file"}, bytes.NewReader([]byte("v1"))))
+	list := updates(t, c, "user", -1)
+	require.Len(t, list, 1)
+	file := list[0].(models.File)
+	assert.Equal(t, int64(2), file.Size)
+	assert.Equal(t, []byte("v1"), readFile(file.Id))
+	assert.Empty(t, updates(t, c, "other", -1), "documents of other user should not be streamed")
+
+	require.NoError(t, c.UpdateFile(ctx, file, bytes.NewReader([]byte("v2"))))
+	assert.Equal(t, []byte("v2"), readFile(file.Id))
+	require.ErrorIs(t, c.UpdateFile(ctx, file, bytes.NewReader([]byte("v3"))), documents.ErrChanged,
+		"update of outdated revision should be rejected")
+	list = updates(t, c, "user", file.Serial)
+	require.Len(t, list, 1)
+	updated := list[0].(models.File)
+
+	require.NoError(t, c.DeleteFile(ctx, updated))
+	require.NoError(t, c.Restore(ctx, documents.KindFile, file.Id, "user"))
+	assert.Equal(t, []byte("v2"), readFile(file.Id), "restored file should keep data")
+
+	// the second deletion of trashed file is permanent and drops data of all revisions
+	for i := 0; i < 2; i++ {
+		stored, err := db.GetFileInfo(ctx, file.Id, "user")
+		require.NoError(t, err)
+		require.NoError(t, c.DeleteFile(ctx, stored))
+	}
+	stored, err := db.GetFileInfo(ctx, file.Id, "user")
+	require.NoError(t, err)
+	assert.Equal(t, models.StateDeleted, stored.State)
+	for _, blobId := range []string{file.BlobId, updated.BlobId} {
+		r, err := db.OpenBlob(ctx, blobId, 0)
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Empty(t, b, "file data should be removed")
+	}
+}
+
+// TestController_HistoryLimit checks that the oldest revisions above limit are removed
+// with their file data, and data, referenced by file, is kept
+func TestController_HistoryLimit(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db, documents.WithHistoryLimit(1))
+	ctx := context.Background()
+	blobData := func(blobId string) []byte {
+		r, err := db.OpenBlob(ctx, blobId, 0)
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return b
+	}
+	current := func() models.File {
+		list := updates(t, c, "user", -1)
+		require.Len(t, list, 1)
+		return list[0].(models.File)
+	}
+
+	require.NoError(t, c.AddFile(ctx, models.File{UserId: "user", Name: "name"}, bytes.NewReader([]byte("v1"))))
+	v1 := current()
+	require.NoError(t, c.UpdateFile(ctx, v1, bytes.NewReader([]byte("v2"))))
+	v2 := current()
+	require.NoError(t, c.UpdateFile(ctx, v2, bytes.NewReader([]byte("v3"))))
+
+	revisions := history(t, c, v1.Id)
+	require.Len(t, revisions, 1, "only limited number of revisions should be kept")
+	assert.Equal(t, v2.Serial, revisions[0].(models.File).Serial)
+	assert.Empty(t, blobData(v1.BlobId), "data of removed revision should be dropped")
+	assert.Equal(t, []byte("v2"), blobData(v2.BlobId))
+
+	// restored revision shares data with current file
+	require.NoError(t, c.RestoreRevision(ctx, v2.Id, "user", v2.Serial))
+	revisions = history(t, c, v1.Id)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, []byte("v3"), blobData(revisions[0].(models.File).BlobId))
+	assert.Equal(t, []byte("v2"), blobData(current().BlobId), "data of current file should be kept")
+}
+
+// history returns previous revisions of user document
+func history(t *testing.T, c *documents.Controller, docId string) []interface{} {
+	t.Helper()
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go c.GetHistoryStream(context.Background(), docId, "user", chData, chErr)
+	var list []interface{}
+	for doc := range chData {
+		list = append(list, doc)
+	}
+	require.NoError(t, <-chErr)
+	return list
+}
+
+// TestController_PurgePlainHistory checks that revisions, saved before encryption was
+// introduced, are removed with their file data, and encrypted revisions are kept
+func TestController_PurgePlainHistory(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db)
+	ctx := context.Background()
+	blobData := func(blobId string) []byte {
+		r, err := db.OpenBlob(ctx, blobId, 0)
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return b
+	}
+	current := func(kind string) interface{} {
+		for _, doc := range updates(t, c, "user", -1) {
+			if _, ok := doc.(models.Note); ok == (kind == documents.KindNote) {
+				return doc
+			}
+		}
+		t.Fatalf("no %s found", kind)
+		return nil
+	}
+
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: "user", Name: "plain", Text: "plain"}))
+	note := current(documents.KindNote).(models.Note)
+	note.Name, note.Text = "pwk1:name", "pwk1:v1"
+	require.NoError(t, c.UpdateNote(ctx, note))
+	encrypted := current(documents.KindNote).(models.Note)
+	encrypted.Text = "pwk1:v2"
+	require.NoError(t, c.UpdateNote(ctx, encrypted))
+
+	require.NoError(t, c.AddFile(ctx, models.File{UserId: "user", Name: "plain", Filename: "file"}, bytes.NewReader([]byte("plain"))))
+	file := current(documents.KindFile).(models.File)
+	file.Name, file.Filename = "pwk1:name", "pwk1:file"
+	require.NoError(t, c.UpdateFile(ctx, file, bytes.NewReader([]byte("sealed"))))
+
+	require.NoError(t, c.PurgePlainHistory(ctx, "user"))
+	revisions := history(t, c, note.Id)
+	require.Len(t, revisions, 1, "plain text revision should be removed")
+	assert.Equal(t, encrypted.Serial, revisions[0].(models.Note).Serial, "encrypted revision should be kept")
+	assert.Empty(t, history(t, c, file.Id))
+	assert.Empty(t, blobData(file.BlobId), "data of plain text file revision should be dropped")
+	assert.Equal(t, []byte("sealed"), blobData(current(documents.KindFile).(models.File).BlobId))
+}
+
+// TestController_PlainTextRejected checks that documents with plain text payload
+// are rejected after user documents are migrated
+func TestController_PlainTextRejected(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	migrated := false
+	c := documents.New(db, documents.WithMigrated(func(_ context.Context, userId string) (bool, error) {
+		return migrated && userId == "user", nil
+	}))
+	ctx := context.Background()
+
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: "user", Name: "plain", Text: "plain"}),
+		"plain text is accepted until migration")
+	list := updates(t, c, "user", -1)
+	require.Len(t, list, 1)
+	plain := list[0].(models.Note)
+	encrypted := plain
+	encrypted.Name, encrypted.Text = "pwk1:name", "pwk1:text"
+	require.NoError(t, c.UpdateNote(ctx, encrypted))
+	migrated = true
+
+	require.ErrorIs(t, c.AddNote(ctx, models.Note{UserId: "user", Name: "pwk1:name", Text: "plain"}), documents.ErrPlainText)
+	require.ErrorIs(t, c.AddCard(ctx, models.Card{UserId: "user", Name: "pwk1:name", Number: "4111"}), documents.ErrPlainText)
+	require.ErrorIs(t, c.AddCredential(ctx, models.Credential{UserId: "user", Name: "pwk1:name",
+		Metadata: []models.Meta{{Key: "pwk1:key", Value: "plain"}}}), documents.ErrPlainText)
+	require.ErrorIs(t, c.AddFile(ctx, models.File{UserId: "user", Name: "pwk1:name", Tags: []string{"plain"}},
+		bytes.NewReader([]byte("data"))), documents.ErrPlainText)
+	require.NoError(t, c.AddCard(ctx, models.Card{UserId: "user", Name: "pwk1:name", Number: "pwk1:number"}),
+		"empty fields have no plain text")
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: "other", Name: "plain"}), "other user is not migrated")
+
+	require.ErrorIs(t, c.UpdateNote(ctx, plain), documents.ErrPlainText)
+	require.ErrorIs(t, c.RestoreRevision(ctx, plain.Id, "user", plain.Serial), documents.ErrPlainText,
+		"plain text revision should not be restored")
+}
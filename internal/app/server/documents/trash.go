@@ -0,0 +1,221 @@
+package documents
+
+import (
+	"context"
+	"time"
+
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Restore moves document of requested kind from trash back to active documents
+func (c *Controller) Restore(ctx context.Context, kind string, docId string, userId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", docId)
+	log.Debug("restore from trash request")
+
+	qLock := c.queue.Reserve(userId)
+	defer qLock.Release()
+
+	var doc interface{}
+	var err error
+	switch kind {
+	case KindNote:
+		doc, err = c.store.GetNote(ctx, docId, userId)
+	case KindCard:
+		doc, err = c.store.GetCard(ctx, docId, userId)
+	case KindCredential:
+		doc, err = c.store.GetCredential(ctx, docId, userId)
+	case KindFile:
+		doc, err = c.store.GetFileInfo(ctx, docId, userId)
+	default:
+		return ErrBadRequest
+	}
+	if err != nil {
+		return err
+	}
+	if docState(doc) != models.StateTrashed {
+		return ErrNotTrashed
+	}
+
+	s, err := serial.Next(ctx)
+	if err != nil {
+		return err
+	}
+	switch d := doc.(type) {
+	case models.Note:
+		d.Serial, d.State, d.TrashedAt = s, models.StateActive, 0
+		if err = c.store.ModifyNote(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	case models.Card:
+		d.Serial, d.State, d.TrashedAt = s, models.StateActive, 0
+		if err = c.store.ModifyCard(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	case models.Credential:
+		d.Serial, d.State, d.TrashedAt = s, models.StateActive, 0
+		if err = c.store.ModifyCredential(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	case models.File:
+		d.Serial, d.State, d.TrashedAt = s, models.StateActive, 0
+		if err = c.store.ModifyFile(ctx, d); err == nil {
+			c.broker.publish(userId, d)
+		}
+	}
+	if err != nil {
+		log.Warnf("restore from trash failed: %s", err.Error())
+	} else {
+		log.Info("document restored from trash")
+	}
+	return err
+}
+
+// PurgeTrash deletes documents, which stay in trash longer than retention period.
+// Purged documents become usual deleted documents and lose revisions history.
+func (c *Controller) PurgeTrash(ctx context.Context) error {
+	log := logger.Log().WithCtxRequestId(ctx)
+	before := time.Now().Add(-c.trashRetention).Unix()
+
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go func() {
+		defer close(chData)
+		chErr <- c.store.GetTrashStream(ctx, before, chData)
+	}()
+	var expired []interface{}
+	for doc := range chData {
+		expired = append(expired, doc)
+	}
+	if err := <-chErr; err != nil {
+		return err
+	}
+
+	purged := 0
+	for _, doc := range expired {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ok, err := c.purge(ctx, doc, before)
+		if err != nil {
+			log.Warnf("trash purge failed: %s", err.Error())
+			continue
+		}
+		if ok {
+			purged++
+		}
+	}
+	if purged > 0 {
+		log.Infof("purged %d documents from trash", purged)
+	}
+	return nil
+}
+
+// purge replaces expired trashed document with deleted one.
+// Document is checked again under user reservation, as it may be restored.
+func (c *Controller) purge(ctx context.Context, doc interface{}, before int64) (bool, error) {
+	docId, userId := docOwner(doc)
+	qLock := c.queue.Reserve(userId)
+	defer qLock.Release()
+
+	var stored interface{}
+	var err error
+	switch doc.(type) {
+	case models.Note:
+		stored, err = c.store.GetNote(ctx, docId, userId)
+	case models.Card:
+		stored, err = c.store.GetCard(ctx, docId, userId)
+	case models.Credential:
+		stored, err = c.store.GetCredential(ctx, docId, userId)
+	case models.File:
+		stored, err = c.store.GetFileInfo(ctx, docId, userId)
+	default:
+		return false, ErrBadRequest
+	}
+	if err != nil {
+		return false, err
+	}
+	if docState(stored) != models.StateTrashed || docTrashedAt(stored) >= before {
+		return false, nil
+	}
+
+	s, err := serial.Next(ctx)
+	if err != nil {
+		return false, err
+	}
+	var deleted interface{}
+	switch d := stored.(type) {
+	case models.Note:
+		n := models.Note{Id: d.Id, UserId: d.UserId, Name: d.Name, Serial: s, State: models.StateDeleted}
+		deleted, err = n, c.store.ModifyNote(ctx, n)
+	case models.Card:
+		n := models.Card{Id: d.Id, UserId: d.UserId, Name: d.Name, Serial: s, State: models.StateDeleted}
+		deleted, err = n, c.store.ModifyCard(ctx, n)
+	case models.Credential:
+		n := models.Credential{Id: d.Id, UserId: d.UserId, Name: d.Name, Serial: s, State: models.StateDeleted}
+		deleted, err = n, c.store.ModifyCredential(ctx, n)
+	case models.File:
+		n := models.File{Id: d.Id, UserId: d.UserId, Name: d.Name, Filename: d.Filename, Serial: s, State: models.StateDeleted}
+		deleted, err = n, c.store.ModifyFile(ctx, n)
+	}
+	if err != nil {
+		return false, err
+	}
+	c.broker.publish(userId, deleted)
+	if f, ok := stored.(models.File); ok {
+		c.dropFileData(ctx, f)
+	}
+	c.dropHistory(ctx, docId, userId)
+	return true, nil
+}
+
+// dropHistory removes revisions of permanently deleted document
+func (c *Controller) dropHistory(ctx context.Context, docId, userId string) {
+	if err := c.store.DeleteHistory(ctx, docId, userId); err != nil {
+		logger.Log().WithCtxRequestId(ctx).With("documentId", docId).
+			Warnf("failed to delete document history: %s", err.Error())
+	}
+}
+
+func docState(doc interface{}) string {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.State
+	case models.Card:
+		return d.State
+	case models.Credential:
+		return d.State
+	case models.File:
+		return d.State
+	}
+	return ""
+}
+
+func docTrashedAt(doc interface{}) int64 {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.TrashedAt
+	case models.Card:
+		return d.TrashedAt
+	case models.Credential:
+		return d.TrashedAt
+	case models.File:
+		return d.TrashedAt
+	}
+	return 0
+}
+
+func docOwner(doc interface{}) (string, string) {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.Id, d.UserId
+	case models.Card:
+		return d.Id, d.UserId
+	case models.Credential:
+		return d.Id, d.UserId
+	case models.File:
+		return d.Id, d.UserId
+	}
+	return "", ""
+}
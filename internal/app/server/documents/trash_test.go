@@ -0,0 +1,136 @@
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/mocks"
+)
+
+func TestController_DeleteToTrash(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+	serial.SetSource(new(serial.SimpleSerialSource))
+
+	c := New(docStore, WithTrashRetention(time.Hour))
+	ctx := context.Background()
+	stored := models.Note{Id: "id", UserId: "user", Name: "name", Text: "text", Serial: 1, State: models.StateActive}
+
+	t.Run("move to trash", func(t *testing.T) {
+		docStore.EXPECT().GetNote(ctx, "id", "user").Return(stored, nil)
+		docStore.EXPECT().ModifyNote(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, doc models.Note) error {
+				assert.Equal(t, models.StateTrashed, doc.State)
+				assert.Equal(t, stored.Text, doc.Text, "trashed document should keep payload")
+				assert.NotZero(t, doc.TrashedAt)
+				assert.Greater(t, doc.Serial, stored.Serial)
+				return nil
+			})
+		require.NoError(t, c.DeleteNote(ctx, models.Note{Id: "id", UserId: "user", Serial: 1}))
+	})
+
+	trashed := stored
+	trashed.State = models.StateTrashed
+	trashed.TrashedAt = time.Now().Unix()
+
+	t.Run("update trashed", func(t *testing.T) {
+		docStore.EXPECT().GetNote(ctx, "id", "user").Return(trashed, nil)
+		err := c.UpdateNote(ctx, models.Note{Id: "id", UserId: "user", Serial: 1})
+		require.ErrorIs(t, err, ErrDeleted)
+	})
+
+	t.Run("delete trashed", func(t *testing.T) {
+		docStore.EXPECT().GetNote(ctx, "id", "user").Return(trashed, nil)
+		docStore.EXPECT().ModifyNote(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, doc models.Note) error {
+				assert.Equal(t, models.StateDeleted, doc.State, "trashed document should be deleted")
+				assert.Empty(t, doc.Text)
+				return nil
+			})
+		docStore.EXPECT().DeleteHistory(ctx, "id", "user").Return(nil)
+		require.NoError(t, c.DeleteNote(ctx, models.Note{Id: "id", UserId: "user", Serial: 1}))
+	})
+}
+
+func TestController_Restore(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+	serial.SetSource(new(serial.SimpleSerialSource))
+
+	c := New(docStore, WithTrashRetention(time.Hour))
+	ctx := context.Background()
+
+	t.Run("restore", func(t *testing.T) {
+		trashed := models.Card{Id: "id", UserId: "user", Number: "1234", State: models.StateTrashed, TrashedAt: 1}
+		docStore.EXPECT().GetCard(ctx, "id", "user").Return(trashed, nil)
+		docStore.EXPECT().ModifyCard(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, doc models.Card) error {
+				assert.Equal(t, models.StateActive, doc.State)
+				assert.Zero(t, doc.TrashedAt)
+				assert.Equal(t, trashed.Number, doc.Number)
+				return nil
+			})
+		require.NoError(t, c.Restore(ctx, KindCard, "id", "user"))
+	})
+
+	t.Run("not trashed", func(t *testing.T) {
+		docStore.EXPECT().GetCredential(ctx, "id", "user").Return(models.Credential{State: models.StateActive}, nil)
+		require.ErrorIs(t, c.Restore(ctx, KindCredential, "id", "user"), ErrNotTrashed)
+	})
+
+	t.Run("bad kind", func(t *testing.T) {
+		require.ErrorIs(t, c.Restore(ctx, "unknown", "id", "user"), ErrBadRequest)
+	})
+}
+
+func TestController_PurgeTrash(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+	serial.SetSource(new(serial.SimpleSerialSource))
+
+	c := New(docStore, WithTrashRetention(time.Hour))
+	ctx := context.Background()
+	expired := time.Now().Add(-2 * time.Hour).Unix()
+
+	expiredFile := models.File{Id: "f1", UserId: "user", Filename: "file", BlobId: "b1", State: models.StateTrashed, TrashedAt: expired}
+	restoredNote := models.Note{Id: "n1", UserId: "user", State: models.StateTrashed, TrashedAt: expired}
+
+	docStore.EXPECT().GetTrashStream(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before int64, chData chan interface{}) error {
+			assert.Greater(t, before, expired)
+			chData <- expiredFile
+			chData <- restoredNote
+			return nil
+		})
+	docStore.EXPECT().GetFileInfo(ctx, "f1", "user").Return(expiredFile, nil)
+	docStore.EXPECT().ModifyFile(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, doc models.File) error {
+			assert.Equal(t, models.StateDeleted, doc.State)
+			assert.Equal(t, expiredFile.Filename, doc.Filename)
+			return nil
+		})
+	// data of the file and all its revisions is removed
+	docStore.EXPECT().GetHistoryStream(ctx, "f1", "user", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, chData chan interface{}) error {
+			chData <- models.File{Id: "f1", UserId: "user", BlobId: "b0"}
+			chData <- models.File{Id: "f1", UserId: "user", BlobId: "b1"}
+			return nil
+		})
+	docStore.EXPECT().DeleteBlob(gomock.Any(), "b0").Return(nil)
+	docStore.EXPECT().DeleteBlob(gomock.Any(), "b1").Return(nil)
+	docStore.EXPECT().DeleteHistory(ctx, "f1", "user").Return(nil)
+	// note was restored after trash stream was read
+	docStore.EXPECT().GetNote(ctx, "n1", "user").Return(models.Note{Id: "n1", UserId: "user", State: models.StateActive}, nil)
+
+	require.NoError(t, c.PurgeTrash(ctx))
+}
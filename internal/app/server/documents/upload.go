@@ -0,0 +1,195 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"time"
+
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload not found")
+	ErrUploadOffset   = errors.New("upload offset mismatch")
+	ErrUploadBusy     = errors.New("upload is in progress")
+	ErrChecksum       = errors.New("file data checksum mismatch")
+)
+
+// defaultUploadTTL is how long unfinished upload is kept since the last activity
+const defaultUploadTTL = 24 * time.Hour
+
+// WithUploadTTL sets how long unfinished upload is kept since the last activity
+func WithUploadTTL(ttl time.Duration) func(c *Controller) {
+	return func(c *Controller) {
+		c.uploadTTL = ttl
+	}
+}
+
+// CreateUpload starts resumable upload of file data and returns upload id.
+// New file is added when file id is empty, otherwise existing file is updated.
+// File Size and Sha265 should be set to the expected values, they are verified,
+// when all data is received.
+func (c *Controller) CreateUpload(ctx context.Context, file models.File) (string, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", file.Id)
+	log.Debug("create upload request")
+	if file.Size < 0 || len(file.Sha265) != 2*sha256.Size {
+		return "", ErrBadRequest
+	}
+	if err := c.checkEncrypted(ctx, file); err != nil {
+		return "", err
+	}
+	if file.Id != "" {
+		// early check, file is validated again when upload is finished
+		stored, err := c.validateFileUpdate(ctx, file)
+		if err != nil {
+			return "", err
+		}
+		if stored.State == models.StateTrashed {
+			return "", ErrDeleted
+		}
+	}
+	// data is appended to empty blob
+	blobId, err := c.store.PutBlob(ctx, file.UserId, bytes.NewReader(nil))
+	if err != nil {
+		return "", err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		c.dropBlob(ctx, blobId)
+		return "", err
+	}
+	u := models.Upload{
+		Id:        hex.EncodeToString(id),
+		UserId:    file.UserId,
+		File:      file,
+		BlobId:    blobId,
+		UpdatedAt: time.Now().Unix(),
+	}
+	if err := c.store.AddUpload(ctx, u); err != nil {
+		c.dropBlob(ctx, blobId)
+		return "", err
+	}
+	log.With("uploadId", u.Id).Info("upload created")
+	return u.Id, nil
+}
+
+// GetUpload returns number of bytes received by upload. Upload should be resumed from it.
+func (c *Controller) GetUpload(ctx context.Context, uploadId string, userId string) (int64, error) {
+	logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("uploadId", uploadId).Debug("get upload request")
+	u, err := c.store.GetUpload(ctx, uploadId)
+	if err != nil {
+		return 0, err
+	}
+	if u.UserId != userId {
+		return 0, ErrUploadNotFound
+	}
+	return u.Offset, nil
+}
+
+// WriteUpload reads file data from r and appends it to upload. Offset should be equal
+// to number of bytes already received. When r is finished and all file data is received,
+// stored data is verified and file is saved, otherwise upload is kept to be resumed.
+// Returns number of bytes received, which may be used to resume upload.
+func (c *Controller) WriteUpload(ctx context.Context, uploadId string, userId string, offset int64, r io.Reader) (int64, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("uploadId", uploadId)
+	log.Debug("write upload request")
+
+	// upload data is written by one request at a time
+	c.uploadsMu.Lock()
+	if _, ok := c.busy[uploadId]; ok {
+		c.uploadsMu.Unlock()
+		u, _ := c.store.GetUpload(ctx, uploadId)
+		return u.Offset, ErrUploadBusy
+	}
+	c.busy[uploadId] = struct{}{}
+	c.uploadsMu.Unlock()
+	defer func() {
+		c.uploadsMu.Lock()
+		delete(c.busy, uploadId)
+		c.uploadsMu.Unlock()
+	}()
+
+	u, err := c.store.GetUpload(ctx, uploadId)
+	switch {
+	case err != nil:
+		return 0, err
+	case u.UserId != userId:
+		return 0, ErrUploadNotFound
+	case u.Offset != offset:
+		return u.Offset, ErrUploadOffset
+	}
+
+	// one extra byte is allowed to detect data, exceeding expected size
+	size, err := c.store.AppendBlob(ctx, u.BlobId, userId, offset, io.LimitReader(r, u.File.Size-offset+1))
+	if err != nil {
+		log.Infof("upload interrupted at %d bytes: %s", size, err.Error())
+		if err := c.store.SetUploadOffset(ctx, uploadId, size, time.Now().Unix()); err != nil {
+			log.Errorf("failed to save upload offset: %s", err.Error())
+		}
+		return size, err
+	}
+	if size < u.File.Size {
+		// data stream ended before file size is reached, upload is kept to be resumed
+		log.Infof("upload paused at %d bytes", size)
+		if err := c.store.SetUploadOffset(ctx, uploadId, size, time.Now().Unix()); err != nil {
+			log.Errorf("failed to save upload offset: %s", err.Error())
+			return size, err
+		}
+		return size, nil
+	}
+	if err := c.store.DeleteUpload(ctx, uploadId); err != nil {
+		// upload is finished by concurrent request or purged
+		log.Warnf("upload removal failed: %s", err.Error())
+		return size, err
+	}
+
+	file := u.File
+	file.BlobId = u.BlobId
+	if err := c.verifyBlob(ctx, file); err != nil {
+		log.Warnf("upload verification failed: %s", err.Error())
+		c.dropBlob(ctx, file.BlobId)
+		return size, err
+	}
+	if file.Id == "" {
+		return size, c.addFile(ctx, file)
+	}
+	return size, c.updateFile(ctx, file)
+}
+
+// PurgeUploads removes uploads and their data, which were not active longer than upload ttl.
+// Uploads are stored, so uploads left by previous server runs are purged too.
+func (c *Controller) PurgeUploads(ctx context.Context) error {
+	log := logger.Log().WithCtxRequestId(ctx)
+	expired, err := c.store.GetExpiredUploads(ctx, time.Now().Add(-c.uploadTTL).Unix())
+	if err != nil {
+		log.Errorf("failed to get expired uploads: %s", err.Error())
+		return err
+	}
+	n := 0
+	for _, u := range expired {
+		c.uploadsMu.Lock()
+		_, busy := c.busy[u.Id]
+		c.uploadsMu.Unlock()
+		if busy {
+			continue
+		}
+		if err := c.store.DeleteUpload(ctx, u.Id); err != nil {
+			if !errors.Is(err, ErrUploadNotFound) {
+				log.With("uploadId", u.Id).Errorf("failed to remove expired upload: %s", err.Error())
+			}
+			continue
+		}
+		c.dropBlob(ctx, u.BlobId)
+		n++
+	}
+	if n > 0 {
+		log.Infof("purged %d expired uploads", n)
+	}
+	return nil
+}
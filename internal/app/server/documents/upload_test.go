@@ -0,0 +1,231 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	fake "github.com/brianvoe/gofakeit/v6"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/mocks"
+)
+
+// failingReader returns error after data is read
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+// expectUploads keeps uploads of mock storage in memory
+func expectUploads(docStore *mocks.MockDocStorage) {
+	var mu sync.Mutex
+	uploads := make(map[string]models.Upload)
+	docStore.EXPECT().AddUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, u models.Upload) error {
+			mu.Lock()
+			defer mu.Unlock()
+			uploads[u.Id] = u
+			return nil
+		}).AnyTimes()
+	docStore.EXPECT().GetUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id string) (models.Upload, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			u, ok := uploads[id]
+			if !ok {
+				return u, ErrUploadNotFound
+			}
+			return u, nil
+		}).AnyTimes()
+	docStore.EXPECT().SetUploadOffset(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id string, offset int64, updatedAt int64) error {
+			mu.Lock()
+			defer mu.Unlock()
+			u, ok := uploads[id]
+			if !ok {
+				return ErrUploadNotFound
+			}
+			u.Offset, u.UpdatedAt = offset, updatedAt
+			uploads[id] = u
+			return nil
+		}).AnyTimes()
+	docStore.EXPECT().DeleteUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := uploads[id]; !ok {
+				return ErrUploadNotFound
+			}
+			delete(uploads, id)
+			return nil
+		}).AnyTimes()
+	docStore.EXPECT().GetExpiredUploads(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before int64) ([]models.Upload, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var expired []models.Upload
+			for _, u := range uploads {
+				if u.UpdatedAt <= before {
+					expired = append(expired, u)
+				}
+			}
+			return expired, nil
+		}).AnyTimes()
+}
+
+func TestController_Upload(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	docStore := mocks.NewMockDocStorage(mockController)
+	serial.SetSource(new(serial.SimpleSerialSource))
+
+	c := New(docStore)
+	ctx := context.Background()
+	data := []byte(fake.Paragraph(3, 5, 10, " "))
+	file := models.File{
+		UserId:   "user",
+		Name:     "name",
+		Filename: "file",
+		Size:     int64(len(data)),
+		Sha265:   fmt.Sprintf("%x", sha256.Sum256(data)),
+	}
+
+	// stored blob keeps only data, which was fully received
+	var stored bytes.Buffer
+	docStore.EXPECT().PutBlob(ctx, "user", gomock.Any()).Return("blob", nil).AnyTimes()
+	docStore.EXPECT().AppendBlob(ctx, "blob", "user", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, offset int64, r io.Reader) (int64, error) {
+			assert.Equal(t, int64(stored.Len()), offset, "expect append to stored data")
+			b, err := io.ReadAll(r)
+			stored.Write(b)
+			return int64(stored.Len()), err
+		}).AnyTimes()
+	docStore.EXPECT().OpenBlob(ctx, "blob", int64(0)).
+		DoAndReturn(func(_ context.Context, _ string, _ int64) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(stored.Bytes())), nil
+		}).AnyTimes()
+	expectUploads(docStore)
+
+	t.Run("bad request", func(t *testing.T) {
+		bad := file
+		bad.Sha265 = ""
+		_, err := c.CreateUpload(ctx, bad)
+		require.ErrorIs(t, err, ErrBadRequest)
+	})
+
+	t.Run("resumed", func(t *testing.T) {
+		stored.Reset()
+		id, err := c.CreateUpload(ctx, file)
+		require.NoError(t, err)
+
+		half := int64(len(data) / 2)
+		lost := errors.New("connection lost")
+		offset, err := c.WriteUpload(ctx, id, "user", 0, failingReader{r: bytes.NewReader(data[:half]), err: lost})
+		require.ErrorIs(t, err, lost)
+		assert.Equal(t, half, offset)
+
+		offset, err = c.GetUpload(ctx, id, "user")
+		require.NoError(t, err)
+		assert.Equal(t, half, offset, "expect offset to resume from")
+		_, err = c.GetUpload(ctx, id, "other")
+		require.ErrorIs(t, err, ErrUploadNotFound, "upload of other user should not be found")
+
+		_, err = c.WriteUpload(ctx, id, "user", 0, bytes.NewReader(data))
+		require.ErrorIs(t, err, ErrUploadOffset)
+
+		docStore.EXPECT().AddFile(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, doc models.File) (string, error) {
+				assert.Equal(t, "blob", doc.BlobId)
+				assert.Equal(t, file.Sha265, doc.Sha265)
+				assert.Equal(t, models.StateActive, doc.State)
+				return "id", nil
+			}).Times(1)
+		offset, err = c.WriteUpload(ctx, id, "user", half, bytes.NewReader(data[half:]))
+		require.NoError(t, err)
+		assert.Equal(t, file.Size, offset)
+
+		_, err = c.GetUpload(ctx, id, "user")
+		require.ErrorIs(t, err, ErrUploadNotFound, "finished upload should be removed")
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		stored.Reset()
+		id, err := c.CreateUpload(ctx, file)
+		require.NoError(t, err)
+
+		half := int64(len(data) / 2)
+		offset, err := c.WriteUpload(ctx, id, "user", 0, bytes.NewReader(data[:half]))
+		require.NoError(t, err, "stream ended before file size is not an error")
+		assert.Equal(t, half, offset)
+
+		offset, err = c.GetUpload(ctx, id, "user")
+		require.NoError(t, err, "partial upload should be kept")
+		assert.Equal(t, half, offset)
+
+		docStore.EXPECT().AddFile(ctx, gomock.Any()).Return("id", nil).Times(1)
+		offset, err = c.WriteUpload(ctx, id, "user", half, bytes.NewReader(data[half:]))
+		require.NoError(t, err)
+		assert.Equal(t, file.Size, offset)
+
+		_, err = c.GetUpload(ctx, id, "user")
+		require.ErrorIs(t, err, ErrUploadNotFound, "finished upload should be removed")
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		stored.Reset()
+		id, err := c.CreateUpload(ctx, file)
+		require.NoError(t, err)
+		half := int64(len(data) / 2)
+		_, err = c.WriteUpload(ctx, id, "user", 0, failingReader{r: bytes.NewReader(data[:half]), err: io.ErrUnexpectedEOF})
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		restarted := New(docStore)
+		offset, err := restarted.GetUpload(ctx, id, "user")
+		require.NoError(t, err, "upload should be kept by storage")
+		assert.Equal(t, half, offset)
+		docStore.EXPECT().AddFile(ctx, gomock.Any()).Return("id", nil).Times(1)
+		_, err = restarted.WriteUpload(ctx, id, "user", half, bytes.NewReader(data[half:]))
+		require.NoError(t, err)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		stored.Reset()
+		id, err := c.CreateUpload(ctx, file)
+		require.NoError(t, err)
+		docStore.EXPECT().DeleteBlob(gomock.Any(), "blob").Return(nil).Times(1)
+		corrupted := append([]byte{}, data...)
+		corrupted[0]++
+		_, err = c.WriteUpload(ctx, id, "user", 0, bytes.NewReader(corrupted))
+		require.ErrorIs(t, err, ErrChecksum)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		c := New(docStore, WithUploadTTL(time.Millisecond))
+		id, err := c.CreateUpload(ctx, file)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		docStore.EXPECT().DeleteBlob(gomock.Any(), "blob").Return(nil).Times(1)
+		require.NoError(t, c.PurgeUploads(ctx))
+		_, err = c.GetUpload(ctx, id, "user")
+		require.ErrorIs(t, err, ErrUploadNotFound)
+	})
+}
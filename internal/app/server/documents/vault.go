@@ -0,0 +1,394 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+var (
+	ErrNoVault   = errors.New("vault not found")
+	ErrNoAccess  = errors.New("vault access denied")
+	ErrNoUser    = errors.New("user not found")
+	ErrMember    = errors.New("user is already vault member")
+	ErrNotMember = errors.New("user is not vault member")
+	ErrLastOwner = errors.New("vault should keep an owner")
+)
+
+// VaultsChanged is sent to subscriber, when user has joined or left a shared vault,
+// or vault members are changed. Subscription is finished after it, and should be
+// renewed to receive documents of actual vaults.
+type VaultsChanged struct{}
+
+// VaultStorage defines interface of shared vaults storage backend
+type VaultStorage interface {
+	AddVault(ctx context.Context, vault models.Vault) (string, error)
+	GetVault(ctx context.Context, vaultId string) (models.Vault, error)
+	ModifyVault(ctx context.Context, vault models.Vault) error
+	GetUserVaults(ctx context.Context, userId string) ([]models.Vault, error)
+	DeleteVault(ctx context.Context, vaultId string) error
+}
+
+// Users finds users, who become vault members
+type Users interface {
+	GetUserById(ctx context.Context, userId string) (models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (models.User, error)
+}
+
+// WithVaults enables shared vaults. Vault documents are stored with vault id as user id,
+// so they are available to every vault member.
+func WithVaults(store VaultStorage, users Users) func(c *Controller) {
+	return func(c *Controller) {
+		c.vaults = store
+		c.users = users
+	}
+}
+
+// Owner returns owner of documents, requested by user: user itself, or shared vault if
+// vaultId is set. ErrNoAccess is returned, if user is not a vault member, or if member
+// role does not allow to modify documents and write is requested.
+func (c *Controller) Owner(ctx context.Context, userId string, vaultId string, write bool) (string, error) {
+	if vaultId == "" {
+		return userId, nil
+	}
+	if c.vaults == nil {
+		return "", ErrNoAccess
+	}
+	vault, err := c.vaults.GetVault(ctx, vaultId)
+	if errors.Is(err, ErrNoVault) {
+		return "", ErrNoAccess
+	}
+	if err != nil {
+		return "", err
+	}
+	member, ok := vault.Member(userId)
+	if !ok || write && !member.CanWrite() {
+		return "", ErrNoAccess
+	}
+	return vault.Id, nil
+}
+
+// CreateVault creates shared vault with user as its owner.
+// Vault key should be sealed with user public key. Returns vault id.
+func (c *Controller) CreateVault(ctx context.Context, userId string, name string, key []byte) (string, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("create vault request")
+	if c.vaults == nil {
+		return "", ErrNoVault
+	}
+	if name == "" || len(key) == 0 {
+		return "", ErrBadRequest
+	}
+	user, err := c.users.GetUserById(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	s, err := serial.Next(ctx)
+	if err != nil {
+		return "", err
+	}
+	vault := models.Vault{
+		Name:    name,
+		Members: []models.Member{{UserId: userId, Login: user.Login, Role: models.RoleOwner, VaultKey: key, Serial: s}},
+	}
+	vaultId, err := c.vaults.AddVault(ctx, vault)
+	if err != nil {
+		log.Warnf("create vault failed: %s", err.Error())
+		return "", err
+	}
+	log.With("vaultId", vaultId).Info("vault created")
+	c.broker.publish(userId, VaultsChanged{})
+	return vaultId, nil
+}
+
+// ListVaults returns shared vaults of user. Vault keys of other members are not returned.
+func (c *Controller) ListVaults(ctx context.Context, userId string) ([]models.Vault, error) {
+	logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).Debug("list vaults request")
+	vaults, err := c.userVaults(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range vaults {
+		for j := range vaults[i].Members {
+			if vaults[i].Members[j].UserId != userId {
+				vaults[i].Members[j].VaultKey = nil
+			}
+		}
+	}
+	return vaults, nil
+}
+
+// RenameVault changes vault name, it is allowed to vault owners
+func (c *Controller) RenameVault(ctx context.Context, userId string, vaultId string, name string) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("vaultId", vaultId)
+	log.Debug("rename vault request")
+	if name == "" {
+		return ErrBadRequest
+	}
+	qLock := c.queue.Reserve(vaultId)
+	defer qLock.Release()
+	vault, err := c.ownedVault(ctx, userId, vaultId)
+	if err != nil {
+		return err
+	}
+	vault.Name = name
+	if err := c.vaults.ModifyVault(ctx, vault); err != nil {
+		log.Warnf("rename vault failed: %s", err.Error())
+		return err
+	}
+	log.Info("vault renamed")
+	c.notifyMembers(vault.Members...)
+	return nil
+}
+
+// AddMember shares vault with user with login. Vault key should be sealed with
+// the user public key. It is allowed to vault owners.
+func (c *Controller) AddMember(ctx context.Context, userId string, vaultId string, login string, role string, key []byte) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("vaultId", vaultId, "login", login)
+	log.Debug("add vault member request")
+	if !models.ValidRole(role) || len(key) == 0 {
+		return ErrBadRequest
+	}
+	qLock := c.queue.Reserve(vaultId)
+	defer qLock.Release()
+	vault, err := c.ownedVault(ctx, userId, vaultId)
+	if err != nil {
+		return err
+	}
+	user, err := c.users.GetUserByLogin(ctx, login)
+	if errors.Is(err, aaa.ErrNotFound) {
+		return ErrNoUser
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := vault.Member(user.Id); ok {
+		return ErrMember
+	}
+	s, err := serial.Next(ctx)
+	if err != nil {
+		return err
+	}
+	member := models.Member{UserId: user.Id, Login: user.Login, Role: role, VaultKey: key, Serial: s}
+	vault.Members = append(vault.Members, member)
+	if err := c.vaults.ModifyVault(ctx, vault); err != nil {
+		log.Warnf("add vault member failed: %s", err.Error())
+		return err
+	}
+	log.With("memberId", user.Id).Info("vault member added")
+	c.notifyMembers(vault.Members...)
+	return nil
+}
+
+// SetMemberRole changes role of vault member, it is allowed to vault owners.
+// The last vault owner may not be demoted.
+func (c *Controller) SetMemberRole(ctx context.Context, userId string, vaultId string, memberId string, role string) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("vaultId", vaultId, "memberId", memberId)
+	log.Debug("set vault member role request")
+	if !models.ValidRole(role) {
+		return ErrBadRequest
+	}
+	qLock := c.queue.Reserve(vaultId)
+	defer qLock.Release()
+	vault, err := c.ownedVault(ctx, userId, vaultId)
+	if err != nil {
+		return err
+	}
+	i := memberIndex(vault, memberId)
+	if i < 0 {
+		return ErrNotMember
+	}
+	vault.Members[i].Role = role
+	if !hasOwner(vault.Members) {
+		return ErrLastOwner
+	}
+	if err := c.vaults.ModifyVault(ctx, vault); err != nil {
+		log.Warnf("set vault member role failed: %s", err.Error())
+		return err
+	}
+	log.Infof("vault member role is set to %s", role)
+	c.notifyMembers(vault.Members...)
+	return nil
+}
+
+// RemoveMember removes user from vault members. Owners may remove any member,
+// other members may only leave the vault. The last vault owner may not leave,
+// vault should be deleted instead.
+func (c *Controller) RemoveMember(ctx context.Context, userId string, vaultId string, memberId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("vaultId", vaultId, "memberId", memberId)
+	log.Debug("remove vault member request")
+	if c.vaults == nil {
+		return ErrNoVault
+	}
+	qLock := c.queue.Reserve(vaultId)
+	defer qLock.Release()
+	vault, err := c.memberVault(ctx, userId, vaultId)
+	if err != nil {
+		return err
+	}
+	if member, _ := vault.Member(userId); memberId != userId && member.Role != models.RoleOwner {
+		return ErrNoAccess
+	}
+	i := memberIndex(vault, memberId)
+	if i < 0 {
+		return ErrNotMember
+	}
+	removed := vault.Members[i]
+	vault.Members = append(vault.Members[:i], vault.Members[i+1:]...)
+	if !hasOwner(vault.Members) {
+		return ErrLastOwner
+	}
+	if err := c.vaults.ModifyVault(ctx, vault); err != nil {
+		log.Warnf("remove vault member failed: %s", err.Error())
+		return err
+	}
+	log.Info("vault member removed")
+	c.notifyMembers(append(vault.Members, removed)...)
+	return nil
+}
+
+// DeleteVault permanently removes vault with all its documents, revisions and file data.
+// It is allowed to vault owners.
+func (c *Controller) DeleteVault(ctx context.Context, userId string, vaultId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("vaultId", vaultId)
+	log.Debug("delete vault request")
+	qLock := c.queue.Reserve(vaultId)
+	defer qLock.Release()
+	vault, err := c.ownedVault(ctx, userId, vaultId)
+	if err != nil {
+		return err
+	}
+	if err := c.deleteVault(ctx, vault); err != nil {
+		log.Warnf("delete vault failed: %s", err.Error())
+		return err
+	}
+	log.Info("vault deleted")
+	return nil
+}
+
+// deleteVault removes vault and its documents, should be called with vault reservation held
+func (c *Controller) deleteVault(ctx context.Context, vault models.Vault) error {
+	if err := c.deleteDocuments(ctx, vault.Id); err != nil {
+		return err
+	}
+	if err := c.vaults.DeleteVault(ctx, vault.Id); err != nil {
+		return err
+	}
+	c.notifyMembers(vault.Members...)
+	return nil
+}
+
+// leaveVaults removes user from all vaults on account deletion. Vaults without
+// members are deleted. If vault has no owner left, the earliest member becomes owner.
+func (c *Controller) leaveVaults(ctx context.Context, userId string) error {
+	vaults, err := c.userVaults(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, v := range vaults {
+		if err := c.leaveVault(ctx, userId, v.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) leaveVault(ctx context.Context, userId string, vaultId string) error {
+	log := logger.Log().WithCtxRequestId(ctx).With("userId", userId, "vaultId", vaultId)
+	qLock := c.queue.Reserve(vaultId)
+	defer qLock.Release()
+	vault, err := c.vaults.GetVault(ctx, vaultId)
+	if err != nil {
+		return err
+	}
+	i := memberIndex(vault, userId)
+	if i < 0 {
+		return nil
+	}
+	vault.Members = append(vault.Members[:i], vault.Members[i+1:]...)
+	if len(vault.Members) == 0 {
+		log.Info("vault without members is deleted")
+		return c.deleteVault(ctx, vault)
+	}
+	if !hasOwner(vault.Members) {
+		sort.SliceStable(vault.Members, func(i, j int) bool { return vault.Members[i].Serial < vault.Members[j].Serial })
+		vault.Members[0].Role = models.RoleOwner
+		log.With("memberId", vault.Members[0].UserId).Info("vault owner is assigned")
+	}
+	if err := c.vaults.ModifyVault(ctx, vault); err != nil {
+		return err
+	}
+	c.notifyMembers(vault.Members...)
+	return nil
+}
+
+// userVaults returns vaults of user ordered by id, nil if vaults are disabled
+func (c *Controller) userVaults(ctx context.Context, userId string) ([]models.Vault, error) {
+	if c.vaults == nil {
+		return nil, nil
+	}
+	vaults, err := c.vaults.GetUserVaults(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(vaults, func(i, j int) bool { return vaults[i].Id < vaults[j].Id })
+	return vaults, nil
+}
+
+// memberVault returns vault, if user is its member
+func (c *Controller) memberVault(ctx context.Context, userId string, vaultId string) (models.Vault, error) {
+	if c.vaults == nil {
+		return models.Vault{}, ErrNoVault
+	}
+	vault, err := c.vaults.GetVault(ctx, vaultId)
+	if err != nil {
+		return vault, err
+	}
+	if _, ok := vault.Member(userId); !ok {
+		// vault existence is not disclosed
+		return vault, ErrNoVault
+	}
+	return vault, nil
+}
+
+// ownedVault returns vault, if user is its owner
+func (c *Controller) ownedVault(ctx context.Context, userId string, vaultId string) (models.Vault, error) {
+	vault, err := c.memberVault(ctx, userId, vaultId)
+	if err != nil {
+		return vault, err
+	}
+	if member, _ := vault.Member(userId); member.Role != models.RoleOwner {
+		return vault, ErrNoAccess
+	}
+	return vault, nil
+}
+
+// notifyMembers sends VaultsChanged to subscribers of members
+func (c *Controller) notifyMembers(members ...models.Member) {
+	for _, m := range members {
+		c.broker.publish(m.UserId, VaultsChanged{})
+	}
+}
+
+func memberIndex(vault models.Vault, userId string) int {
+	for i, m := range vault.Members {
+		if m.UserId == userId {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasOwner(members []models.Member) bool {
+	for _, m := range members {
+		if m.Role == models.RoleOwner {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package documents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/app/server/serial"
+	"yap-pwkeeper/internal/pkg/memdb"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// addUsers registers users with logins and returns their ids
+func addUsers(t *testing.T, db *memdb.Memdb, logins ...string) []string {
+	t.Helper()
+	ids := make([]string, len(logins))
+	for i, login := range logins {
+		user, err := db.AddUser(context.Background(), models.User{Login: login, State: models.StateActive})
+		require.NoError(t, err)
+		ids[i] = user.Id
+	}
+	return ids
+}
+
+func TestController_Vaults(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db, documents.WithVaults(db, db))
+	ctx := context.Background()
+	ids := addUsers(t, db, "alice", "bob", "carol")
+	alice, bob, carol := ids[0], ids[1], ids[2]
+
+	vaultId, err := c.CreateVault(ctx, alice, "team", []byte("alice key"))
+	require.NoError(t, err)
+	_, err = c.CreateVault(ctx, alice, "", []byte("key"))
+	require.ErrorIs(t, err, documents.ErrBadRequest)
+
+	owner, err := c.Owner(ctx, alice, vaultId, true)
+	require.NoError(t, err)
+	assert.Equal(t, vaultId, owner, "vault documents should be owned by vault")
+	owner, err = c.Owner(ctx, alice, "", true)
+	require.NoError(t, err)
+	assert.Equal(t, alice, owner, "user documents should be owned by user")
+	_, err = c.Owner(ctx, bob, vaultId, false)
+	require.ErrorIs(t, err, documents.ErrNoAccess, "not member should not access vault")
+	_, err = c.Owner(ctx, bob, alice, false)
+	require.ErrorIs(t, err, documents.ErrNoAccess, "user documents should not be accessed as vault")
+
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: vaultId, Name: "staging db"}))
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: alice, Name: "personal"}))
+
+	require.ErrorIs(t, c.AddMember(ctx, bob, vaultId, "carol", models.RoleRead, []byte("key")), documents.ErrNoVault,
+		"not member should not manage vault")
+	require.ErrorIs(t, c.AddMember(ctx, alice, vaultId, "unknown", models.RoleRead, []byte("key")), documents.ErrNoUser)
+	require.ErrorIs(t, c.AddMember(ctx, alice, vaultId, "bob", "admin", []byte("key")), documents.ErrBadRequest)
+	require.NoError(t, c.AddMember(ctx, alice, vaultId, "bob", models.RoleRead, []byte("bob key")))
+	require.ErrorIs(t, c.AddMember(ctx, alice, vaultId, "bob", models.RoleRead, []byte("bob key")), documents.ErrMember)
+
+	vaults, err := c.ListVaults(ctx, bob)
+	require.NoError(t, err)
+	require.Len(t, vaults, 1)
+	require.Len(t, vaults[0].Members, 2)
+	for _, m := range vaults[0].Members {
+		if m.UserId == bob {
+			assert.Equal(t, []byte("bob key"), m.VaultKey)
+			assert.Equal(t, "bob", m.Login)
+		} else {
+			assert.Nil(t, m.VaultKey, "keys of other members should not be listed")
+		}
+	}
+
+	list := updates(t, c, bob, -1)
+	require.Len(t, list, 1, "vault documents should be streamed to member")
+	assert.Equal(t, "staging db", list[0].(models.Note).Name)
+	assert.Len(t, updates(t, c, alice, -1), 2)
+	joined := vaults[0].Members[1].Serial
+	assert.Len(t, updates(t, c, bob, joined-1), 1, "all vault documents should be streamed to new member")
+	assert.Empty(t, updates(t, c, bob, joined), "vault documents should be streamed once")
+
+	_, err = c.Owner(ctx, bob, vaultId, true)
+	require.ErrorIs(t, err, documents.ErrNoAccess, "reader should not modify documents")
+	require.ErrorIs(t, c.SetMemberRole(ctx, bob, vaultId, bob, models.RoleOwner), documents.ErrNoAccess,
+		"only owner should change roles")
+	require.NoError(t, c.SetMemberRole(ctx, alice, vaultId, bob, models.RoleWrite))
+	_, err = c.Owner(ctx, bob, vaultId, true)
+	require.NoError(t, err, "writer should modify documents")
+	require.ErrorIs(t, c.SetMemberRole(ctx, alice, vaultId, alice, models.RoleWrite), documents.ErrLastOwner)
+	require.ErrorIs(t, c.SetMemberRole(ctx, alice, vaultId, carol, models.RoleWrite), documents.ErrNotMember)
+	require.ErrorIs(t, c.RenameVault(ctx, bob, vaultId, "mine"), documents.ErrNoAccess)
+	require.NoError(t, c.RenameVault(ctx, alice, vaultId, "renamed"))
+
+	require.NoError(t, c.AddMember(ctx, alice, vaultId, "carol", models.RoleRead, []byte("carol key")))
+	require.ErrorIs(t, c.RemoveMember(ctx, bob, vaultId, carol), documents.ErrNoAccess, "only owner should remove others")
+	require.ErrorIs(t, c.RemoveMember(ctx, alice, vaultId, alice), documents.ErrLastOwner)
+	require.NoError(t, c.RemoveMember(ctx, carol, vaultId, carol), "member should leave vault")
+	assert.Empty(t, updates(t, c, carol, -1), "vault documents should not be streamed to former member")
+	_, err = c.Owner(ctx, carol, vaultId, false)
+	require.ErrorIs(t, err, documents.ErrNoAccess)
+
+	require.ErrorIs(t, c.DeleteVault(ctx, bob, vaultId), documents.ErrNoAccess)
+	require.NoError(t, c.DeleteVault(ctx, alice, vaultId))
+	assert.Empty(t, updates(t, c, bob, -1), "vault documents should be deleted")
+	assert.Len(t, updates(t, c, alice, -1), 1, "user documents should be kept")
+	vaults, err = c.ListVaults(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, vaults)
+}
+
+func TestController_VaultsDeleteUserData(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db, documents.WithVaults(db, db))
+	ctx := context.Background()
+	ids := addUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+
+	shared, err := c.CreateVault(ctx, alice, "shared", []byte("key"))
+	require.NoError(t, err)
+	require.NoError(t, c.AddMember(ctx, alice, shared, "bob", models.RoleRead, []byte("key")))
+	private, err := c.CreateVault(ctx, alice, "private", []byte("key"))
+	require.NoError(t, err)
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: private, Name: "private"}))
+
+	require.NoError(t, c.DeleteUserData(ctx, alice))
+	vaults, err := c.ListVaults(ctx, bob)
+	require.NoError(t, err)
+	require.Len(t, vaults, 1)
+	require.Len(t, vaults[0].Members, 1)
+	assert.Equal(t, models.RoleOwner, vaults[0].Members[0].Role, "remaining member should become owner")
+	_, err = db.GetVault(ctx, private)
+	require.ErrorIs(t, err, documents.ErrNoVault, "vault without members should be deleted")
+	assert.Empty(t, updates(t, c, private, -1), "documents of deleted vault should be deleted")
+}
+
+func TestController_SubscribeVaults(t *testing.T) {
+	db := memdb.New()
+	serial.SetSource(db)
+	c := documents.New(db, documents.WithVaults(db, db))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ids := addUsers(t, db, "alice", "bob")
+	alice := ids[0]
+	vaultId, err := c.CreateVault(ctx, alice, "team", []byte("key"))
+	require.NoError(t, err)
+
+	subscribe := func(userId string) chan interface{} {
+		chData := make(chan interface{})
+		go c.Subscribe(ctx, userId, -1, chData, make(chan error, 1))
+		assert.Equal(t, documents.SyncMark{}, <-chData)
+		return chData
+	}
+	next := func(chData chan interface{}) interface{} {
+		select {
+		case d := <-chData:
+			return d
+		case <-time.After(time.Second):
+			t.Fatal("live update timeout")
+		}
+		return nil
+	}
+
+	aliceData := subscribe(alice)
+	bobData := subscribe(ids[1])
+	require.NoError(t, c.AddNote(ctx, models.Note{UserId: vaultId, Name: "shared"}))
+	note, ok := next(aliceData).(models.Note)
+	require.True(t, ok, "vault document should be pushed to member")
+	assert.Equal(t, "shared", note.Name)
+
+	require.NoError(t, c.AddMember(ctx, alice, vaultId, "bob", models.RoleRead, []byte("key")))
+	assert.Equal(t, documents.VaultsChanged{}, next(bobData), "new member should be notified")
+	_, ok = <-bobData
+	assert.False(t, ok, "subscription should be finished on vaults change")
+	assert.Equal(t, documents.VaultsChanged{}, next(aliceData), "members should be notified")
+}
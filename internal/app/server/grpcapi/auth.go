@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/breached"
 	"yap-pwkeeper/internal/pkg/grpc/proto"
 	"yap-pwkeeper/internal/pkg/jwtToken"
 	"yap-pwkeeper/internal/pkg/logger"
@@ -20,6 +21,23 @@ type AAA interface {
 	Login(ctx context.Context, cred models.UserCredentials) (string, error)
 	Refresh(ctx context.Context, token string) (string, error)
 	Validate(ctx context.Context, token string) bool
+	GetVaultKey(ctx context.Context, userId string) ([]byte, error)
+	SetVaultKey(ctx context.Context, userId string, key []byte) error
+	GetKeyPair(ctx context.Context, userId string) (models.KeyPair, error)
+	SetKeyPair(ctx context.Context, userId string, keys models.KeyPair) error
+	GetPublicKey(ctx context.Context, login string) ([]byte, error)
+	EnrollTotp(ctx context.Context, userId string) (models.TotpEnrollment, error)
+	ConfirmTotp(ctx context.Context, userId string, code string) error
+	DisableTotp(ctx context.Context, userId string, code string) error
+	Logout(ctx context.Context, userId string, sessionId string) error
+	ListSessions(ctx context.Context, userId string, currentId string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userId string, sessionId string) error
+	ChangePassword(ctx context.Context, userId string, sessionId string, oldPassword, newPassword string, vaultKey []byte) error
+	DeleteAccount(ctx context.Context, userId string, password string) error
+	PasswordPolicy(ctx context.Context) models.PasswordPolicy
+	BreachedRange(ctx context.Context, prefix string) ([]string, error)
+	IsMigrated(ctx context.Context, userId string) (bool, error)
+	SetMigrated(ctx context.Context, userId string) error
 }
 
 type AuthHandlers struct {
@@ -44,6 +62,9 @@ func (a AuthHandlers) Register(ctx context.Context, in *proto.LoginCredentials)
 	switch {
 	case errors.Is(aaa.ErrDuplicate, err):
 		return response, status.Error(codes.AlreadyExists, aaa.ErrDuplicate.Error())
+	case errors.Is(err, aaa.ErrPolicy):
+		// rejection reasons are shown to user
+		return response, status.Error(codes.InvalidArgument, err.Error())
 	case err != nil:
 		return response, status.Error(codes.Internal, "server error")
 	}
@@ -58,11 +79,19 @@ func (a AuthHandlers) Login(ctx context.Context, in *proto.LoginCredentials) (*p
 	credentials := models.UserCredentials{
 		Login:    in.Login,
 		Password: in.Password,
+		Totp:     in.Totp,
+		Device:   in.Device,
 	}
 	token, err := a.auth.Login(ctx, credentials)
 	switch {
 	case errors.Is(aaa.ErrBadAuth, err):
 		return response, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case errors.Is(err, aaa.ErrTotpRequired):
+		return response, status.Error(codes.FailedPrecondition, aaa.ErrTotpRequired.Error())
+	case errors.Is(err, aaa.ErrLocked):
+		return response, status.Error(codes.ResourceExhausted, aaa.ErrLocked.Error())
+	case errors.Is(err, aaa.ErrLegacy):
+		return response, status.Error(codes.Aborted, aaa.ErrLegacy.Error())
 	case err != nil:
 		return response, status.Error(codes.Internal, "server error")
 	}
@@ -87,3 +116,296 @@ func (a AuthHandlers) Refresh(ctx context.Context, in *proto.Token) (*proto.Toke
 	response.Token = token
 	return response, nil
 }
+
+// GetVaultKey returns wrapped vault key of authorized user
+func (a AuthHandlers) GetVaultKey(ctx context.Context, _ *proto.Empty) (*proto.VaultKey, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("get vault key request")
+	userId, _ := logger.GetUserId(ctx)
+	key, err := a.auth.GetVaultKey(ctx, userId)
+	switch {
+	case errors.Is(aaa.ErrNoKey, err), errors.Is(aaa.ErrNotFound, err):
+		return nil, status.Error(codes.NotFound, aaa.ErrNoKey.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.VaultKey{Key: key}, nil
+}
+
+// SetVaultKey saves wrapped vault key of authorized user
+func (a AuthHandlers) SetVaultKey(ctx context.Context, in *proto.VaultKey) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("set vault key request")
+	userId, _ := logger.GetUserId(ctx)
+	err := a.auth.SetVaultKey(ctx, userId, in.GetKey())
+	switch {
+	case errors.Is(aaa.ErrKeyExists, err):
+		return nil, status.Error(codes.AlreadyExists, aaa.ErrKeyExists.Error())
+	case errors.Is(aaa.ErrNoKey, err):
+		return nil, status.Error(codes.InvalidArgument, "empty vault key")
+	case errors.Is(aaa.ErrNotFound, err):
+		return nil, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.Empty{}, nil
+}
+
+// GetKeyPair returns key pair of authorized user
+func (a AuthHandlers) GetKeyPair(ctx context.Context, _ *proto.Empty) (*proto.KeyPair, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("get key pair request")
+	userId, _ := logger.GetUserId(ctx)
+	keys, err := a.auth.GetKeyPair(ctx, userId)
+	switch {
+	case errors.Is(err, aaa.ErrNoKey), errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.NotFound, aaa.ErrNoKey.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.KeyPair{PublicKey: keys.Public, PrivateKey: keys.Private}, nil
+}
+
+// SetKeyPair saves key pair of authorized user, private key is sealed by client
+func (a AuthHandlers) SetKeyPair(ctx context.Context, in *proto.KeyPair) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("set key pair request")
+	userId, _ := logger.GetUserId(ctx)
+	err := a.auth.SetKeyPair(ctx, userId, models.KeyPair{Public: in.GetPublicKey(), Private: in.GetPrivateKey()})
+	switch {
+	case errors.Is(err, aaa.ErrKeyExists):
+		return nil, status.Error(codes.AlreadyExists, aaa.ErrKeyExists.Error())
+	case errors.Is(err, aaa.ErrNoKey):
+		return nil, status.Error(codes.InvalidArgument, "empty key")
+	case errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.Empty{}, nil
+}
+
+// GetPublicKey returns public key of user with login, so vault may be shared with the user
+func (a AuthHandlers) GetPublicKey(ctx context.Context, in *proto.UserLogin) (*proto.KeyPair, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("login", in.GetLogin())
+	log.Debug("get public key request")
+	key, err := a.auth.GetPublicKey(ctx, in.GetLogin())
+	switch {
+	case errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.NotFound, aaa.ErrNotFound.Error())
+	case errors.Is(err, aaa.ErrNoKey):
+		return nil, status.Error(codes.FailedPrecondition, "user has no key pair yet")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.KeyPair{PublicKey: key}, nil
+}
+
+// EnrollTotp generates second factor of authorized user, it should be confirmed to be enabled
+func (a AuthHandlers) EnrollTotp(ctx context.Context, _ *proto.Empty) (*proto.TotpEnrollment, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("enroll totp request")
+	userId, _ := logger.GetUserId(ctx)
+	enrollment, err := a.auth.EnrollTotp(ctx, userId)
+	if err != nil {
+		return nil, totpErr(err)
+	}
+	return &proto.TotpEnrollment{
+		Secret:        enrollment.Secret,
+		Uri:           enrollment.URI,
+		RecoveryCodes: enrollment.RecoveryCodes,
+	}, nil
+}
+
+// ConfirmTotp enables enrolled second factor of authorized user
+func (a AuthHandlers) ConfirmTotp(ctx context.Context, in *proto.TotpCode) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("confirm totp request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := a.auth.ConfirmTotp(ctx, userId, in.GetCode()); err != nil {
+		return nil, totpErr(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// DisableTotp removes second factor of authorized user
+func (a AuthHandlers) DisableTotp(ctx context.Context, in *proto.TotpCode) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("disable totp request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := a.auth.DisableTotp(ctx, userId, in.GetCode()); err != nil {
+		return nil, totpErr(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// totpErr converts second factor errors to grpc status
+func totpErr(err error) error {
+	switch {
+	case errors.Is(err, aaa.ErrTotpEnabled):
+		return status.Error(codes.AlreadyExists, aaa.ErrTotpEnabled.Error())
+	case errors.Is(err, aaa.ErrTotpNotEnrolled):
+		return status.Error(codes.FailedPrecondition, aaa.ErrTotpNotEnrolled.Error())
+	case errors.Is(err, aaa.ErrBadCode):
+		return status.Error(codes.InvalidArgument, aaa.ErrBadCode.Error())
+	case errors.Is(err, aaa.ErrNotFound):
+		return status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	}
+	return status.Error(codes.Internal, "server error")
+}
+
+// Logout finishes session of authorized request
+func (a AuthHandlers) Logout(ctx context.Context, _ *proto.Empty) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("logout request")
+	userId, _ := logger.GetUserId(ctx)
+	sessionId, _ := logger.GetSessionId(ctx)
+	if err := a.auth.Logout(ctx, userId, sessionId); err != nil {
+		return nil, sessionErr(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// ListSessions returns active sessions of authorized user
+func (a AuthHandlers) ListSessions(ctx context.Context, _ *proto.Empty) (*proto.Sessions, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("list sessions request")
+	userId, _ := logger.GetUserId(ctx)
+	sessionId, _ := logger.GetSessionId(ctx)
+	sessions, err := a.auth.ListSessions(ctx, userId, sessionId)
+	if err != nil {
+		return nil, sessionErr(err)
+	}
+	response := &proto.Sessions{Sessions: make([]*proto.Session, 0, len(sessions))}
+	for _, s := range sessions {
+		response.Sessions = append(response.Sessions, &proto.Session{
+			Id:        s.Id,
+			Device:    s.Device,
+			CreatedAt: s.CreatedAt,
+			LastSeen:  s.LastSeen,
+			Current:   s.Current,
+		})
+	}
+	return response, nil
+}
+
+// RevokeSession finishes session of authorized user
+func (a AuthHandlers) RevokeSession(ctx context.Context, in *proto.Session) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("sessionId", in.GetId())
+	log.Debug("revoke session request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := a.auth.RevokeSession(ctx, userId, in.GetId()); err != nil {
+		return nil, sessionErr(err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// ChangePassword replaces password and re-wrapped vault key of authorized user
+func (a AuthHandlers) ChangePassword(ctx context.Context, in *proto.PasswordChange) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("change password request")
+	userId, _ := logger.GetUserId(ctx)
+	sessionId, _ := logger.GetSessionId(ctx)
+	err := a.auth.ChangePassword(ctx, userId, sessionId, in.GetOldPassword(), in.GetNewPassword(), in.GetVaultKey())
+	switch {
+	case errors.Is(err, aaa.ErrBadAuth):
+		// Unauthenticated would terminate client session
+		return nil, status.Error(codes.PermissionDenied, "old password does not match")
+	case errors.Is(err, aaa.ErrLocked):
+		return nil, status.Error(codes.ResourceExhausted, aaa.ErrLocked.Error())
+	case errors.Is(err, aaa.ErrNoKey):
+		return nil, status.Error(codes.FailedPrecondition, "vault key should be re-wrapped with new password")
+	case errors.Is(err, aaa.ErrNoPassword):
+		return nil, status.Error(codes.InvalidArgument, aaa.ErrNoPassword.Error())
+	case errors.Is(err, aaa.ErrPolicy):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.Empty{}, nil
+}
+
+// DeleteAccount deletes account and all documents of authorized user
+func (a AuthHandlers) DeleteAccount(ctx context.Context, in *proto.Password) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("delete account request")
+	userId, _ := logger.GetUserId(ctx)
+	err := a.auth.DeleteAccount(ctx, userId, in.GetPassword())
+	switch {
+	case errors.Is(err, aaa.ErrBadAuth):
+		// Unauthenticated would terminate client session
+		return nil, status.Error(codes.PermissionDenied, "password does not match")
+	case errors.Is(err, aaa.ErrLocked):
+		return nil, status.Error(codes.ResourceExhausted, aaa.ErrLocked.Error())
+	case errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.Empty{}, nil
+}
+
+// GetPasswordPolicy returns requirements to master passwords, client checks them
+// before registration and password change, since master password never reaches server
+func (a AuthHandlers) GetPasswordPolicy(ctx context.Context, _ *proto.Empty) (*proto.PasswordPolicy, error) {
+	logger.Log().WithCtxRequestId(ctx).Debug("get password policy request")
+	policy := a.auth.PasswordPolicy(ctx)
+	return &proto.PasswordPolicy{
+		MinLength:  int32(policy.MinLength),
+		MinClasses: int32(policy.MinClasses),
+		Breached:   policy.Breached,
+	}, nil
+}
+
+// GetBreachedRange returns hash suffixes of breached passwords with hash prefix
+func (a AuthHandlers) GetBreachedRange(ctx context.Context, in *proto.HashPrefix) (*proto.HashSuffixes, error) {
+	logger.Log().WithCtxRequestId(ctx).With("prefix", in.GetPrefix()).Debug("get breached range request")
+	suffixes, err := a.auth.BreachedRange(ctx, in.GetPrefix())
+	switch {
+	case errors.Is(err, breached.ErrPrefix):
+		return nil, status.Error(codes.InvalidArgument, breached.ErrPrefix.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.HashSuffixes{Suffixes: suffixes}, nil
+}
+
+// GetMigrated shows whether documents of authorized user are all encrypted
+func (a AuthHandlers) GetMigrated(ctx context.Context, _ *proto.Empty) (*proto.Migrated, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("get migrated request")
+	userId, _ := logger.GetUserId(ctx)
+	migrated, err := a.auth.IsMigrated(ctx, userId)
+	switch {
+	case errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.Migrated{Migrated: migrated}, nil
+}
+
+// SetMigrated records that documents of authorized user are re-encrypted by client
+func (a AuthHandlers) SetMigrated(ctx context.Context, _ *proto.Empty) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("set migrated request")
+	userId, _ := logger.GetUserId(ctx)
+	err := a.auth.SetMigrated(ctx, userId)
+	switch {
+	case errors.Is(err, aaa.ErrNotFound):
+		return nil, status.Error(codes.Unauthenticated, aaa.ErrBadAuth.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, "server error")
+	}
+	return &proto.Empty{}, nil
+}
+
+// sessionErr converts session errors to grpc status
+func sessionErr(err error) error {
+	if errors.Is(err, aaa.ErrNoSession) {
+		return status.Error(codes.NotFound, aaa.ErrNoSession.Error())
+	}
+	return status.Error(codes.Internal, "server error")
+}
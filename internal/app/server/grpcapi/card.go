@@ -15,10 +15,12 @@ func (w DocsHandlers) AddCard(ctx context.Context, in *proto.Card) (*proto.Empty
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add card request")
 	card, err := in.ToCard()
-	card.UserId, _ = logger.GetUserId(ctx)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
+	if card.UserId, err = w.owner(ctx, card.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.AddCard(ctx, card); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -33,7 +35,9 @@ func (w DocsHandlers) DeleteCard(ctx context.Context, in *proto.Card) (*proto.Em
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	card.UserId, _ = logger.GetUserId(ctx)
+	if card.UserId, err = w.owner(ctx, card.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.DeleteCard(ctx, card); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -48,7 +52,9 @@ func (w DocsHandlers) UpdateCard(ctx context.Context, in *proto.Card) (*proto.Em
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	card.UserId, _ = logger.GetUserId(ctx)
+	if card.UserId, err = w.owner(ctx, card.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.UpdateCard(ctx, card); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -15,10 +15,12 @@ func (w DocsHandlers) AddCredential(ctx context.Context, in *proto.Credential) (
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add credential request")
 	credential, err := in.ToCredential()
-	credential.UserId, _ = logger.GetUserId(ctx)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
+	if credential.UserId, err = w.owner(ctx, credential.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.AddCredential(ctx, credential); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -33,7 +35,9 @@ func (w DocsHandlers) DeleteCredential(ctx context.Context, in *proto.Credential
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	credential.UserId, _ = logger.GetUserId(ctx)
+	if credential.UserId, err = w.owner(ctx, credential.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.DeleteCredential(ctx, credential); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -48,7 +52,9 @@ func (w DocsHandlers) UpdateCredential(ctx context.Context, in *proto.Credential
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	credential.UserId, _ = logger.GetUserId(ctx)
+	if credential.UserId, err = w.owner(ctx, credential.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.UpdateCredential(ctx, credential); err != nil {
 		return nil, respErr(ctx, err)
 	}
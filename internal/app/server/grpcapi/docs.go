@@ -2,10 +2,14 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"yap-pwkeeper/internal/app/server/documents"
 	pb "yap-pwkeeper/internal/pkg/grpc/proto"
 	"yap-pwkeeper/internal/pkg/logger"
 	"yap-pwkeeper/internal/pkg/models"
@@ -25,12 +29,34 @@ type Docs interface {
 	DeleteCredential(ctx context.Context, credential models.Credential) error
 	UpdateCredential(ctx context.Context, credential models.Credential) error
 
-	AddFile(ctx context.Context, file models.File) error
+	AddFile(ctx context.Context, file models.File, r io.Reader) error
 	DeleteFile(ctx context.Context, file models.File) error
-	UpdateFile(ctx context.Context, file models.File) error
-	GetFile(ctx context.Context, docId string, userId string) (models.File, error)
+	UpdateFile(ctx context.Context, file models.File, r io.Reader) error
+	UpdateFileInfo(ctx context.Context, file models.File) error
+	GetFile(ctx context.Context, docId string, userId string, offset, length int64) (models.File, io.ReadCloser, error)
+	CreateUpload(ctx context.Context, file models.File) (string, error)
+	GetUpload(ctx context.Context, uploadId string, userId string) (int64, error)
+	WriteUpload(ctx context.Context, uploadId string, userId string, offset int64, r io.Reader) (int64, error)
 
 	GetUpdatesStream(ctx context.Context, userId string, minSerial int64, chData chan interface{}, chErr chan error)
+	Subscribe(ctx context.Context, userId string, minSerial int64, chData chan interface{}, chErr chan error)
+
+	GetHistoryStream(ctx context.Context, docId string, userId string, chData chan interface{}, chErr chan error)
+	RestoreRevision(ctx context.Context, docId string, userId string, revision int64) error
+	Restore(ctx context.Context, kind string, docId string, userId string) error
+
+	ExportAll(ctx context.Context, userId string, w io.Writer) error
+
+	Owner(ctx context.Context, userId string, vaultId string, write bool) (string, error)
+	CreateVault(ctx context.Context, userId string, name string, key []byte) (string, error)
+	ListVaults(ctx context.Context, userId string) ([]models.Vault, error)
+	RenameVault(ctx context.Context, userId string, vaultId string, name string) error
+	DeleteVault(ctx context.Context, userId string, vaultId string) error
+	AddMember(ctx context.Context, userId string, vaultId string, login string, role string, key []byte) error
+	SetMemberRole(ctx context.Context, userId string, vaultId string, memberId string, role string) error
+	RemoveMember(ctx context.Context, userId string, vaultId string, memberId string) error
+
+	CreateShare(ctx context.Context, share models.Share, passphrase string, ttl time.Duration) (models.Share, error)
 }
 
 type DocsHandlers struct {
@@ -59,17 +85,8 @@ func (w DocsHandlers) GetUpdateStream(request *pb.UpdateRequest, stream pb.Docs_
 		if !ok {
 			break
 		}
-		response := new(pb.UpdateResponse)
-		switch data.(type) {
-		case models.Note:
-			response = &pb.UpdateResponse{Update: &pb.UpdateResponse_Note{Note: pb.FromNote(data.(models.Note))}}
-		case models.Card:
-			response = &pb.UpdateResponse{Update: &pb.UpdateResponse_Card{Card: pb.FromCard(data.(models.Card))}}
-		case models.Credential:
-			response = &pb.UpdateResponse{Update: &pb.UpdateResponse_Credential{Credential: pb.FromCredential(data.(models.Credential))}}
-		case models.File:
-			response = &pb.UpdateResponse{Update: &pb.UpdateResponse_File{File: pb.FromFile(data.(models.File))}}
-		default:
+		response, ok := toUpdateResponse(data, userId)
+		if !ok {
 			log.Warnf("invalid data type in updates stream")
 			continue
 		}
@@ -86,3 +103,86 @@ func (w DocsHandlers) GetUpdateStream(request *pb.UpdateRequest, stream pb.Docs_
 	logger.Log().Debug("update stream success")
 	return nil
 }
+
+// Subscribe handles live updates request. Stored updates since requested serial are
+// sent first, followed by synced mark. Then documents are sent as soon as they are
+// saved, until client cancels the request.
+func (w DocsHandlers) Subscribe(request *pb.UpdateRequest, stream pb.Docs_SubscribeServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("subscribe request")
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	userId, _ := logger.GetUserId(ctx)
+	go w.docs.Subscribe(ctx, userId, request.GetSerial(), chData, chErr)
+	for {
+		data, ok := <-chData
+		if !ok {
+			break
+		}
+		response, ok := toUpdateResponse(data, userId)
+		if !ok {
+			log.Warnf("invalid data type in subscription")
+			continue
+		}
+		if err := stream.Send(response); err != nil {
+			log.WithErr(err).Debug("subscription send failed")
+			cancel()
+			// drain channels to let producer finish
+			for range chData {
+			}
+			return status.Error(codes.Internal, "subscription send failed")
+		}
+	}
+	if err := <-chErr; err != nil {
+		if errors.Is(err, documents.ErrSubscriptionLost) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		if errors.Is(err, documents.ErrSessionClosed) {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		return status.Error(codes.Internal, "subscription failed")
+	}
+	log.Debug("subscription closed")
+	return nil
+}
+
+// toUpdateResponse converts document to update stream message of user.
+// Documents of shared vaults are marked with vault id.
+func toUpdateResponse(data interface{}, userId string) (*pb.UpdateResponse, bool) {
+	switch d := data.(type) {
+	case models.Note:
+		d.VaultId = vaultOf(d.UserId, userId)
+		return &pb.UpdateResponse{Update: &pb.UpdateResponse_Note{Note: pb.FromNote(d)}}, true
+	case models.Card:
+		d.VaultId = vaultOf(d.UserId, userId)
+		return &pb.UpdateResponse{Update: &pb.UpdateResponse_Card{Card: pb.FromCard(d)}}, true
+	case models.Credential:
+		d.VaultId = vaultOf(d.UserId, userId)
+		return &pb.UpdateResponse{Update: &pb.UpdateResponse_Credential{Credential: pb.FromCredential(d)}}, true
+	case models.File:
+		d.VaultId = vaultOf(d.UserId, userId)
+		return &pb.UpdateResponse{Update: &pb.UpdateResponse_File{File: pb.FromFile(d)}}, true
+	case documents.SyncMark:
+		return &pb.UpdateResponse{Update: &pb.UpdateResponse_Synced{Synced: &pb.Empty{}}}, true
+	case documents.VaultsChanged:
+		return &pb.UpdateResponse{Update: &pb.UpdateResponse_VaultsChanged{VaultsChanged: &pb.Empty{}}}, true
+	}
+	return nil, false
+}
+
+// vaultOf returns vault id of document owner, empty for documents of user
+func vaultOf(ownerId string, userId string) string {
+	if ownerId == userId {
+		return ""
+	}
+	return ownerId
+}
+
+// owner returns owner of requested documents: authorized user, or shared vault if
+// vaultId is set. Write requires member role, which allows to modify vault documents.
+func (w DocsHandlers) owner(ctx context.Context, vaultId string, write bool) (string, error) {
+	userId, _ := logger.GetUserId(ctx)
+	return w.docs.Owner(ctx, userId, vaultId, write)
+}
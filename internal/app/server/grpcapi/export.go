@@ -0,0 +1,40 @@
+package grpcapi
+
+import (
+	"bufio"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// exportChunkSize is a size of archive data sent in one message
+const exportChunkSize = 1 << 18
+
+// ExportAll sends zip archive with all documents and file data of authorized user.
+// The last chunk has eof flag, so client knows the archive is complete.
+func (w DocsHandlers) ExportAll(_ *proto.Empty, stream proto.Docs_ExportAllServer) error {
+	ctx := stream.Context()
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("export request")
+	userId, _ := logger.GetUserId(ctx)
+	bw := bufio.NewWriterSize(chunkWriter{stream: stream}, exportChunkSize)
+	if err := w.docs.ExportAll(ctx, userId, bw); err != nil {
+		return respErr(ctx, err)
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return stream.Send(&proto.FileChunk{Eof: true})
+}
+
+// chunkWriter sends written data as file chunks
+type chunkWriter struct {
+	stream proto.Docs_ExportAllServer
+}
+
+func (cw chunkWriter) Write(p []byte) (int, error) {
+	if err := cw.stream.Send(&proto.FileChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
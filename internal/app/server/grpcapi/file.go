@@ -1,9 +1,8 @@
 package grpcapi
 
 import (
-	"bytes"
 	"context"
-	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 
@@ -15,12 +14,15 @@ import (
 	"yap-pwkeeper/internal/pkg/models"
 )
 
+var errBadFileStream = errors.New("invalid file stream")
+
 // AddFile provides AddFile document service. Incoming files are sent in stream,
 // and should contain the following messages:
 // - File message with file metadada, but no binary. This message should be the furst.
 // - FileChunk message with binary data (up to 265k) and eof flag.
 // Eof flag MUST be set to true for the last chunk. If file has no  data (zero), then
-// FileChunk message MUST be sent after File message with zero data and eof = true
+// FileChunk message MUST be sent after File message with zero data and eof = true.
+// File data is passed to storage as it arrives, so file size is not limited.
 func (w DocsHandlers) AddFile(stream proto.Docs_AddFileServer) error {
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
@@ -39,63 +41,108 @@ func (w DocsHandlers) UpdateFile(stream proto.Docs_UpdateFileServer) error {
 	return w.withFileFromStream(stream, w.docs.UpdateFile)
 }
 
+// UpdateFileInfo provides UpdateFileInfo document service.
+// File name and metadata are updated, file data stays intact.
+func (w DocsHandlers) UpdateFileInfo(ctx context.Context, in *proto.File) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("update file info request")
+	file, err := in.ToFile()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request data")
+	}
+	if file.UserId, err = w.owner(ctx, file.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	if err := w.docs.UpdateFileInfo(ctx, file); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Empty{}, nil
+}
+
 // withFileFromStream gets file from stream and executes passed function
-func (w DocsHandlers) withFileFromStream(stream proto.Docs_AddFileServer, fn func(ctx context.Context, file models.File) error) error {
+// with reader of file data
+func (w DocsHandlers) withFileFromStream(stream proto.Docs_AddFileServer, fn func(ctx context.Context, file models.File, r io.Reader) error) error {
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("process file from stream")
-	var file models.File
-	eof := true
-	hash := sha256.New()
-	for {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	msg, ok := req.ChunkedFile.(*proto.FileStream_File)
+	if !ok {
+		return status.Error(codes.InvalidArgument, "chunk sent before file")
+	}
+	file, err := msg.File.ToFile()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if file.UserId, err = w.owner(ctx, file.VaultId, true); err != nil {
+		return respErr(ctx, err)
+	}
+	r := &chunkReader{recv: func() (*proto.FileChunk, error) {
 		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		msg, ok := req.ChunkedFile.(*proto.FileStream_Chunk)
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected message", errBadFileStream)
+		}
+		return msg.Chunk, nil
+	}}
+	if err := fn(ctx, file, r); err != nil {
+		return streamErr(ctx, err)
+	}
+	log.Debug("file stream ended")
+	return stream.SendAndClose(&proto.Empty{})
+}
+
+// streamErr returns response error for request with incoming data stream
+func streamErr(ctx context.Context, err error) error {
+	if errors.Is(err, errBadFileStream) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if s, ok := status.FromError(err); ok {
+		// stream receive error
+		return s.Err()
+	}
+	return respErr(ctx, err)
+}
+
+// chunkReader reads file data from FileChunk messages of incoming stream.
+// recv should return io.EOF, when stream is finished.
+type chunkReader struct {
+	recv func() (*proto.FileChunk, error)
+	buf  []byte
+	eof  bool
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		chunk, err := r.recv()
 		if err == io.EOF {
-			log.Debug("file stream ended")
-			// no chunk with oef flag
-			if !eof {
-				return status.Error(codes.InvalidArgument, "incomplete file data")
-			}
-			// processing result
-			file.Size = int64(len(file.Data))
-			file.Sha265 = fmt.Sprintf("%x", hash.Sum(nil))
-			file.UserId, _ = logger.GetUserId(ctx)
-			if err := fn(ctx, file); err != nil {
-				return respErr(ctx, err)
-			}
-			return stream.SendAndClose(&proto.Empty{})
-			//return file, err
+			// no chunk with eof flag
+			return 0, fmt.Errorf("%w: incomplete file data", errBadFileStream)
 		}
 		if err != nil {
-			return err
+			return 0, err
 		}
-		switch req.ChunkedFile.(type) {
-		case *proto.FileStream_File:
-			log.Debug("file message")
-			if !eof {
-				return status.Error(codes.InvalidArgument, "unexpected end of file")
-			}
-			file, err = req.ChunkedFile.(*proto.FileStream_File).File.ToFile()
-			if err != nil {
-				return status.Error(codes.InvalidArgument, err.Error())
-			}
-		case *proto.FileStream_Chunk:
-			log.Debug("chunk message")
-			if file.Filename == "" {
-				return status.Error(codes.InvalidArgument, "chunk sent before file")
-			}
-			chunk := req.ChunkedFile.(*proto.FileStream_Chunk).Chunk
-			eof = chunk.Eof
-			file.Data = append(file.Data, chunk.Data...)
-			if len(file.Data) > 14<<20 {
-				return status.Error(codes.InvalidArgument, "file too big")
-			}
-			_, err = hash.Write(chunk.Data)
-			if err != nil {
-				logger.Log().WithErr(err).Error("failed to get file hash")
+		r.buf, r.eof = chunk.Data, chunk.Eof
+		if r.eof {
+			// nothing is expected after the last chunk
+			if _, err := r.recv(); err != io.EOF {
+				return 0, fmt.Errorf("%w: unexpected message after end of file", errBadFileStream)
 			}
 		}
 	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
 }
 
 // DeleteFile provides DeleteFile document service
@@ -106,7 +153,9 @@ func (w DocsHandlers) DeleteFile(ctx context.Context, in *proto.File) (*proto.Em
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	file.UserId, _ = logger.GetUserId(ctx)
+	if file.UserId, err = w.owner(ctx, file.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.DeleteFile(ctx, file); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -117,16 +166,22 @@ func (w DocsHandlers) DeleteFile(ctx context.Context, in *proto.File) (*proto.Em
 // containing only one File message with FileInfo (it SHOULD be first).
 // Number of  up to 256k binary chunks, transporting file binary data.
 // The last chunk SHOULD have field `eof` with value `true`.
+// Request offset and length select range of data to be sent, e.g. to resume download.
 func (w DocsHandlers) GetFile(in *proto.DocumentRequest, stream proto.Docs_GetFileServer) error {
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("update stream request")
-	userId, _ := logger.GetUserId(ctx)
-	file, err := w.docs.GetFile(ctx, in.GetId(), userId)
+	owner, err := w.owner(ctx, in.GetVaultId(), false)
+	if err != nil {
+		return respErr(ctx, err)
+	}
+	file, r, err := w.docs.GetFile(ctx, in.GetId(), owner, in.GetOffset(), in.GetLength())
 	if err != nil {
 		return respErr(ctx, err)
 	}
+	file.VaultId = in.GetVaultId()
+	defer func() { _ = r.Close() }()
 	chunk := &proto.FileChunk{}
 	chunkMessage := &proto.FileStream{ChunkedFile: &proto.FileStream_Chunk{Chunk: chunk}}
 	fileMessage := &proto.FileStream{ChunkedFile: &proto.FileStream_File{File: proto.FromFile(file)}}
@@ -134,11 +189,10 @@ func (w DocsHandlers) GetFile(in *proto.DocumentRequest, stream proto.Docs_GetFi
 	if err != nil {
 		return err
 	}
-	r := bytes.NewReader(file.Data)
 	chunkBytes := make([]byte, 1<<18)
 	for {
-		n, err := r.Read(chunkBytes)
-		if err == io.EOF {
+		n, err := io.ReadFull(r, chunkBytes)
+		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
 			chunk.Eof = true
 			err = nil
 		}
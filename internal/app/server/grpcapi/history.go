@@ -0,0 +1,74 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// GetHistory sends previous revisions of the document, the newest first.
+// Files revisions do not contain binary data.
+func (w DocsHandlers) GetHistory(in *pb.DocumentRequest, stream pb.Docs_GetHistoryServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("history request")
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	userId, _ := logger.GetUserId(ctx)
+	owner, err := w.owner(ctx, in.GetVaultId(), false)
+	if err != nil {
+		return respErr(ctx, err)
+	}
+	go w.docs.GetHistoryStream(ctx, in.GetId(), owner, chData, chErr)
+	for data := range chData {
+		response, ok := toUpdateResponse(data, userId)
+		if !ok {
+			log.Warnf("invalid data type in history stream")
+			continue
+		}
+		if err := stream.Send(response); err != nil {
+			log.WithErr(err).Debug("history stream send failed")
+			cancel()
+			for range chData {
+			}
+			return status.Error(codes.Internal, "history stream send failed")
+		}
+	}
+	if err := <-chErr; err != nil {
+		return respErr(ctx, err)
+	}
+	return nil
+}
+
+// RestoreRevision saves requested document revision as a new one
+func (w DocsHandlers) RestoreRevision(ctx context.Context, in *pb.DocumentRequest) (*pb.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("restore revision request")
+	owner, err := w.owner(ctx, in.GetVaultId(), true)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	if err := w.docs.RestoreRevision(ctx, in.GetId(), owner, in.GetSerial()); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &pb.Empty{}, nil
+}
+
+// Restore moves document from trash back to active documents
+func (w DocsHandlers) Restore(ctx context.Context, in *pb.DocumentRequest) (*pb.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("restore from trash request")
+	owner, err := w.owner(ctx, in.GetVaultId(), true)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	if err := w.docs.Restore(ctx, in.GetKind(), in.GetId(), owner); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &pb.Empty{}, nil
+}
@@ -15,10 +15,12 @@ func (w DocsHandlers) AddNote(ctx context.Context, in *proto.Note) (*proto.Empty
 	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
 	log.Debug("add note request")
 	note, err := in.ToNote()
-	note.UserId, _ = logger.GetUserId(ctx)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
+	if note.UserId, err = w.owner(ctx, note.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.AddNote(ctx, note); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -33,7 +35,9 @@ func (w DocsHandlers) DeleteNote(ctx context.Context, in *proto.Note) (*proto.Em
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	note.UserId, _ = logger.GetUserId(ctx)
+	if note.UserId, err = w.owner(ctx, note.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.DeleteNote(ctx, note); err != nil {
 		return nil, respErr(ctx, err)
 	}
@@ -48,7 +52,9 @@ func (w DocsHandlers) UpdateNote(ctx context.Context, in *proto.Note) (*proto.Em
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid request data")
 	}
-	note.UserId, _ = logger.GetUserId(ctx)
+	if note.UserId, err = w.owner(ctx, note.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
 	if err := w.docs.UpdateNote(ctx, note); err != nil {
 		return nil, respErr(ctx, err)
 	}
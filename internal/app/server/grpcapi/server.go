@@ -2,8 +2,10 @@
 // as a mean of communication between server and client.
 // It consists of 2 services: Auth provides registration and authorization
 // services, along as token refresh. Auth methods are not protected with any
-// means. Docs service handle Documents operation requests. All it's methods
-// require token authorization.
+// means, except vault key methods, which require token authorization.
+// Docs service handle Documents operation requests. All it's methods
+// require token authorization. Share service opens shared documents by
+// share id without authorization.
 package grpcapi
 
 import (
@@ -27,6 +29,7 @@ type GRCPServer struct {
 	address            string
 	auth               *AuthHandlers
 	docs               *DocsHandlers
+	share              *ShareHandlers
 	unaryInterceptors  []grpc.UnaryServerInterceptor
 	streamInterceptors []grpc.StreamServerInterceptor
 	tlsCredentials     credentials.TransportCredentials
@@ -66,6 +69,9 @@ func New(opts ...func(gs *GRCPServer)) *GRCPServer {
 
 	pb.RegisterAuthServer(gs.Server, gs.auth)
 	pb.RegisterDocsServer(gs.Server, gs.docs)
+	if gs.share != nil {
+		pb.RegisterShareServer(gs.Server, gs.share)
+	}
 	return gs
 }
 
@@ -90,6 +96,13 @@ func WithDocsHandlers(h *DocsHandlers) func(gs *GRCPServer) {
 	}
 }
 
+// WithShareHandlers defines handlers for Share service
+func WithShareHandlers(h *ShareHandlers) func(gs *GRCPServer) {
+	return func(gs *GRCPServer) {
+		gs.share = h
+	}
+}
+
 // WithUnaryInterceptors adds unary server interceptors into the interceptors chain.
 // Execution order is the same as how they were added.
 func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) func(server *GRCPServer) {
@@ -124,8 +137,36 @@ func respErr(ctx context.Context, err error) error {
 		return status.Error(codes.FailedPrecondition, documents.ErrChanged.Error())
 	case errors.Is(documents.ErrDeleted, err):
 		return status.Error(codes.FailedPrecondition, documents.ErrDeleted.Error())
+	case errors.Is(documents.ErrNotTrashed, err):
+		return status.Error(codes.FailedPrecondition, documents.ErrNotTrashed.Error())
+	case errors.Is(err, documents.ErrPlainText):
+		return status.Error(codes.InvalidArgument, documents.ErrPlainText.Error())
 	case errors.Is(documents.ErrNotFound, err):
 		return status.Error(codes.NotFound, documents.ErrNotFound.Error())
+	case errors.Is(err, documents.ErrUploadNotFound):
+		return status.Error(codes.NotFound, documents.ErrUploadNotFound.Error())
+	case errors.Is(err, documents.ErrUploadOffset):
+		return status.Error(codes.FailedPrecondition, documents.ErrUploadOffset.Error())
+	case errors.Is(err, documents.ErrUploadBusy):
+		return status.Error(codes.Aborted, documents.ErrUploadBusy.Error())
+	case errors.Is(err, documents.ErrChecksum):
+		return status.Error(codes.DataLoss, documents.ErrChecksum.Error())
+	case errors.Is(err, documents.ErrNoAccess):
+		return status.Error(codes.PermissionDenied, documents.ErrNoAccess.Error())
+	case errors.Is(err, documents.ErrNoVault):
+		return status.Error(codes.NotFound, documents.ErrNoVault.Error())
+	case errors.Is(err, documents.ErrNoUser):
+		return status.Error(codes.NotFound, documents.ErrNoUser.Error())
+	case errors.Is(err, documents.ErrNotMember):
+		return status.Error(codes.NotFound, documents.ErrNotMember.Error())
+	case errors.Is(err, documents.ErrMember):
+		return status.Error(codes.AlreadyExists, documents.ErrMember.Error())
+	case errors.Is(err, documents.ErrLastOwner):
+		return status.Error(codes.FailedPrecondition, documents.ErrLastOwner.Error())
+	case errors.Is(err, documents.ErrNoShare):
+		return status.Error(codes.NotFound, documents.ErrNoShare.Error())
+	case errors.Is(err, documents.ErrSharePassphrase):
+		return status.Error(codes.PermissionDenied, documents.ErrSharePassphrase.Error())
 	default:
 		logger.Log().WithErr(err).WithCtxRequestId(ctx).Error("server error")
 		return status.Error(codes.Internal, "server error")
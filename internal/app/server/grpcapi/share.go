@@ -0,0 +1,58 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	pb "yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Shares are methods, implemented by Documents server to open shared documents
+type Shares interface {
+	OpenShare(ctx context.Context, shareId string, passphrase string) (models.Share, error)
+}
+
+type ShareHandlers struct {
+	pb.UnimplementedShareServer
+	shares Shares
+}
+
+func NewShareHandlers(shares Shares) *ShareHandlers {
+	return &ShareHandlers{shares: shares}
+}
+
+// CreateShare saves encrypted snapshot of the document, which may be opened by share id
+func (w DocsHandlers) CreateShare(ctx context.Context, in *pb.ShareRequest) (*pb.ShareInfo, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx).With("documentId", in.GetDocumentId())
+	log.Debug("create share request")
+	userId, _ := logger.GetUserId(ctx)
+	owner, err := w.docs.Owner(ctx, userId, in.GetVaultId(), false)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	share := models.Share{
+		UserId:     owner,
+		DocumentId: in.GetDocumentId(),
+		Kind:       in.GetKind(),
+		Data:       in.GetData(),
+		ViewsLeft:  int(in.GetMaxViews()),
+	}
+	share, err = w.docs.CreateShare(ctx, share, in.GetPassphrase(), time.Duration(in.GetTtl())*time.Second)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &pb.ShareInfo{Id: share.Id, ExpiresAt: share.ExpiresAt, ViewsLeft: int32(share.ViewsLeft)}, nil
+}
+
+// Open returns shared document and uses one of its views, authorization is not required
+func (w ShareHandlers) Open(ctx context.Context, in *pb.ShareOpen) (*pb.SharedDocument, error) {
+	log := logger.Log().WithCtxRequestId(ctx)
+	log.Debug("open share request")
+	share, err := w.shares.OpenShare(ctx, in.GetId(), in.GetPassphrase())
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &pb.SharedDocument{Kind: share.Kind, Data: share.Data, ViewsLeft: int32(share.ViewsLeft)}, nil
+}
@@ -0,0 +1,88 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// CreateUpload provides CreateUpload document service. It starts resumable upload
+// of new file (empty id) or of the existing file data. File size and sha256 are required,
+// they are verified when the whole data is received.
+func (w DocsHandlers) CreateUpload(ctx context.Context, in *proto.File) (*proto.Upload, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("create upload request")
+	file, err := in.ToFile()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request data")
+	}
+	if file.UserId, err = w.owner(ctx, file.VaultId, true); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	id, err := w.docs.CreateUpload(ctx, file)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Upload{Id: id, VaultId: in.GetVaultId()}, nil
+}
+
+// GetUpload provides GetUpload document service. Returns offset, upload should be resumed from.
+func (w DocsHandlers) GetUpload(ctx context.Context, in *proto.Upload) (*proto.Upload, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("get upload request")
+	owner, err := w.owner(ctx, in.GetVaultId(), true)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	offset, err := w.docs.GetUpload(ctx, in.GetId(), owner)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Upload{Id: in.GetId(), Offset: offset, VaultId: in.GetVaultId()}, nil
+}
+
+// UploadData provides UploadData document service. Stream should contain the following messages:
+// - Upload message with upload id and offset, equal to number of bytes received by server.
+// - FileChunk messages with binary data, the same as for AddFile method.
+// Eof flag MUST be set to true for the last chunk. When stream is interrupted,
+// upload may be resumed from offset, returned by GetUpload.
+func (w DocsHandlers) UploadData(stream proto.Docs_UploadDataServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("upload data stream request")
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	msg, ok := req.ChunkedUpload.(*proto.UploadStream_Upload)
+	if !ok {
+		return status.Error(codes.InvalidArgument, "chunk sent before upload")
+	}
+	owner, err := w.owner(ctx, msg.Upload.GetVaultId(), true)
+	if err != nil {
+		return respErr(ctx, err)
+	}
+	r := &chunkReader{recv: func() (*proto.FileChunk, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		msg, ok := req.ChunkedUpload.(*proto.UploadStream_Chunk)
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected message", errBadFileStream)
+		}
+		return msg.Chunk, nil
+	}}
+	offset, err := w.docs.WriteUpload(ctx, msg.Upload.GetId(), owner, msg.Upload.GetOffset(), r)
+	if err != nil {
+		return streamErr(ctx, err)
+	}
+	log.Debug("upload data stream ended")
+	return stream.SendAndClose(&proto.Upload{Id: msg.Upload.GetId(), Offset: offset, VaultId: msg.Upload.GetVaultId()})
+}
@@ -0,0 +1,91 @@
+package grpcapi
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// CreateVault creates shared vault, authorized user becomes its owner
+func (w DocsHandlers) CreateVault(ctx context.Context, in *proto.NewVault) (*proto.Vault, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("create vault request")
+	userId, _ := logger.GetUserId(ctx)
+	id, err := w.docs.CreateVault(ctx, userId, in.GetName(), in.GetVaultKey())
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Vault{Id: id, Name: in.GetName()}, nil
+}
+
+// ListVaults returns shared vaults of authorized user with their members
+func (w DocsHandlers) ListVaults(ctx context.Context, _ *proto.Empty) (*proto.Vaults, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("list vaults request")
+	userId, _ := logger.GetUserId(ctx)
+	vaults, err := w.docs.ListVaults(ctx, userId)
+	if err != nil {
+		return nil, respErr(ctx, err)
+	}
+	response := &proto.Vaults{Vaults: make([]*proto.Vault, len(vaults))}
+	for i, v := range vaults {
+		response.Vaults[i] = proto.FromVault(v)
+	}
+	return response, nil
+}
+
+// RenameVault changes name of shared vault
+func (w DocsHandlers) RenameVault(ctx context.Context, in *proto.Vault) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("rename vault request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := w.docs.RenameVault(ctx, userId, in.GetId(), in.GetName()); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// DeleteVault deletes shared vault with all its documents
+func (w DocsHandlers) DeleteVault(ctx context.Context, in *proto.Vault) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("delete vault request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := w.docs.DeleteVault(ctx, userId, in.GetId()); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// AddVaultMember shares vault with user, found by login
+func (w DocsHandlers) AddVaultMember(ctx context.Context, in *proto.VaultMember) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("add vault member request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := w.docs.AddMember(ctx, userId, in.GetVaultId(), in.GetLogin(), in.GetRole(), in.GetVaultKey()); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// UpdateVaultMember changes role of vault member
+func (w DocsHandlers) UpdateVaultMember(ctx context.Context, in *proto.VaultMember) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("update vault member request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := w.docs.SetMemberRole(ctx, userId, in.GetVaultId(), in.GetUserId(), in.GetRole()); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Empty{}, nil
+}
+
+// RemoveVaultMember removes member from vault, members may remove themselves to leave vault
+func (w DocsHandlers) RemoveVaultMember(ctx context.Context, in *proto.VaultMember) (*proto.Empty, error) {
+	log := logger.Log().WithCtxRequestId(ctx).WithCtxUserId(ctx)
+	log.Debug("remove vault member request")
+	userId, _ := logger.GetUserId(ctx)
+	if err := w.docs.RemoveMember(ctx, userId, in.GetVaultId(), in.GetUserId()); err != nil {
+		return nil, respErr(ctx, err)
+	}
+	return &proto.Empty{}, nil
+}
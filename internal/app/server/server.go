@@ -11,9 +11,18 @@ import (
 	"yap-pwkeeper/internal/pkg/logger"
 )
 
+// Purger removes expired documents from trash, expired unfinished uploads and shares
+type Purger interface {
+	PurgeTrash(ctx context.Context) error
+	PurgeUploads(ctx context.Context) error
+	PurgeShares(ctx context.Context) error
+}
+
 type App struct {
-	wg sync.WaitGroup
-	gs *grpcapi.GRCPServer
+	wg            sync.WaitGroup
+	gs            *grpcapi.GRCPServer
+	purger        Purger
+	purgeInterval time.Duration
 }
 
 // New is a new server instance constructor
@@ -31,6 +40,14 @@ func WithGRPCServer(gs *grpcapi.GRCPServer) func(app *App) {
 	}
 }
 
+// WithPurger enables periodical trash, uploads and shares purge
+func WithPurger(p Purger, interval time.Duration) func(app *App) {
+	return func(app *App) {
+		app.purger = p
+		app.purgeInterval = interval
+	}
+}
+
 // Run starts server instance
 func (a *App) Run(ctx context.Context) error {
 	select {
@@ -58,6 +75,15 @@ func (a *App) Run(ctx context.Context) error {
 		return
 	}(grpcError)
 
+	// trash, uploads and shares purger
+	if a.purger != nil && a.purgeInterval > 0 {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.runPurger(ctx)
+		}()
+	}
+
 	// waiting for main context to be cancelled
 	select {
 	case err := <-grpcError:
@@ -86,3 +112,27 @@ func (a *App) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// runPurger purges trash, uploads and shares periodically until ctx is done
+func (a *App) runPurger(ctx context.Context) {
+	logger.Log().Infof("starting purger with interval %s", a.purgeInterval)
+	ticker := time.NewTicker(a.purgeInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.purger.PurgeTrash(ctx); err != nil && ctx.Err() == nil {
+			logger.Log().WithErr(err).Warn("trash purge failed")
+		}
+		if err := a.purger.PurgeUploads(ctx); err != nil && ctx.Err() == nil {
+			logger.Log().WithErr(err).Warn("uploads purge failed")
+		}
+		if err := a.purger.PurgeShares(ctx); err != nil && ctx.Err() == nil {
+			logger.Log().WithErr(err).Warn("shares purge failed")
+		}
+		select {
+		case <-ctx.Done():
+			logger.Log().Info("purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
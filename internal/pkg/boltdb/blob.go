@@ -0,0 +1,134 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// chunkSize is a size of file data stored in one chunk
+const chunkSize = 255 << 10
+
+var ErrBadBlob = errors.New("file data is corrupted")
+
+// PutBlob reads data from r and stores it in chunks. Returns id of stored data.
+// Partially stored data is removed on error.
+func (db *Boltdb) PutBlob(ctx context.Context, userId string, r io.Reader) (string, error) {
+	blobId := newId()
+	if _, err := db.AppendBlob(ctx, blobId, userId, 0, r); err != nil {
+		if err := db.DeleteBlob(ctx, blobId); err != nil {
+			logger.Log().WithErr(err).Warnf("failed to delete chunks of blob %s", blobId)
+		}
+		return "", err
+	}
+	return blobId, nil
+}
+
+// AppendBlob reads data from r and appends it to stored data of size offset.
+// Data is stored by full chunks, the last short chunk is stored when r is finished.
+// Each chunk is stored in its own transaction, so database is not locked while data is read.
+// Returns size of stored data, on error it may be less than data read from r.
+func (db *Boltdb) AppendBlob(_ context.Context, blobId string, _ string, offset int64, r io.Reader) (int64, error) {
+	if offset%chunkSize != 0 {
+		// the last chunk is already stored
+		return offset, fmt.Errorf("%w: unable to append to complete data", ErrBadBlob)
+	}
+	buf := make([]byte, chunkSize)
+	for n := offset / chunkSize; ; n++ {
+		size, err := io.ReadFull(r, buf)
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return offset, err
+		}
+		err = db.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket([]byte(bktChunks)).Put(idKey(blobId, n), buf[:size])
+		})
+		if err != nil {
+			return offset, err
+		}
+		offset += int64(size)
+		if size < chunkSize {
+			return offset, nil
+		}
+	}
+}
+
+// OpenBlob returns reader of stored data, starting from offset.
+// Empty blobId is a file without data.
+func (db *Boltdb) OpenBlob(_ context.Context, blobId string, offset int64) (io.ReadCloser, error) {
+	if blobId == "" {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	return &blobReader{db: db.db, blobId: blobId, next: offset / chunkSize, skip: offset % chunkSize}, nil
+}
+
+// DeleteBlob removes stored data
+func (db *Boltdb) DeleteBlob(_ context.Context, blobId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		chunks := tx.Bucket([]byte(bktChunks))
+		var keys [][]byte
+		c := chunks.Cursor()
+		prefix := idPrefix(blobId)
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if err := chunks.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// blobReader reads chunks of stored data one by one, each in its own transaction
+type blobReader struct {
+	db     *bolt.DB
+	blobId string
+	next   int64
+	skip   int64 // bytes to skip in the first chunk
+	buf    []byte
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if err := r.db.View(r.readChunk); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// readChunk reads the next chunk into buffer
+func (r *blobReader) readChunk(tx *bolt.Tx) error {
+	c := tx.Bucket([]byte(bktChunks)).Cursor()
+	k, v := c.Seek(idKey(r.blobId, r.next))
+	if k == nil || !bytes.HasPrefix(k, idPrefix(r.blobId)) {
+		return io.EOF
+	}
+	if keyNumber(k) != r.next {
+		return fmt.Errorf("%w: chunk %d is missing", ErrBadBlob, r.next)
+	}
+	r.next++
+	// value is valid only during transaction
+	r.buf = append([]byte(nil), v...)
+	if r.skip > 0 {
+		r.buf = r.buf[min(r.skip, int64(len(r.buf))):]
+		r.skip = 0
+	}
+	return nil
+}
+
+func (r *blobReader) Close() error {
+	return nil
+}
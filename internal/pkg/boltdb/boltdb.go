@@ -0,0 +1,279 @@
+// Package boltdb is an embedded storage backend, based on bbolt key-value database.
+// Whole database is a single file, so server does not require any external database.
+//
+// Documents of each kind are stored in a separate bucket as JSON, keyed by document id.
+// Serial index bucket of each kind allows to query user documents by serial range.
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// Scheme is a prefix of database uri, which selects this backend: bolt:///path/to/file.db
+const Scheme = "bolt://"
+
+const (
+	openTimeout     = 5 * time.Second
+	bktUsers        = "users"
+	bktLogins       = "logins"
+	bktSerials      = "serial"
+	bktHistory      = "history"
+	bktChunks       = "chunks"
+	bktSessions     = "sessions"
+	bktVaults       = "vaults"
+	bktShares       = "shares"
+	bktUploads      = "uploads"
+	indexSuffix     = ".serial"
+	keySerial       = "next"
+	keySeparator    = 0
+	serialKeyLength = 8
+)
+
+// docBuckets are buckets of documents of each kind
+var docBuckets = []string{documents.KindNote, documents.KindCard, documents.KindCredential, documents.KindFile}
+
+type Boltdb struct {
+	path string
+	db   *bolt.DB
+}
+
+// New opens database file, file is created if it does not exist
+func New(path string) (*Boltdb, error) {
+	db := &Boltdb{path: path}
+	var err error
+	if db.db, err = bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout}); err != nil {
+		return nil, err
+	}
+	if err := db.createBuckets(); err != nil {
+		_ = db.db.Close()
+		return nil, fmt.Errorf("failed to create buckets: %w", err)
+	}
+	return db, nil
+}
+
+// Close closes database file
+func (db *Boltdb) Close(_ context.Context) error {
+	return db.db.Close()
+}
+
+// createBuckets creates all required buckets
+func (db *Boltdb) createBuckets() error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		names := []string{bktUsers, bktLogins, bktSerials, bktHistory, bktChunks, bktSessions, bktVaults, bktShares, bktUploads}
+		for _, kind := range docBuckets {
+			names = append(names, kind, kind+indexSuffix)
+		}
+		for _, name := range names {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+			logger.Log().Debugf("bucket %s is ready", name)
+		}
+		return nil
+	})
+}
+
+// newId returns random id, the same length as mongodb ObjectID
+func newId() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// header is a part of document, required for indexing
+type header struct {
+	UserId    string
+	Serial    int64
+	State     string
+	TrashedAt int64
+}
+
+func parseHeader(b []byte) (header, error) {
+	var h header
+	err := json.Unmarshal(b, &h)
+	return h, err
+}
+
+// idKey makes key of id and number, used for ordered keys with the same id prefix
+func idKey(id string, n int64) []byte {
+	key := make([]byte, 0, len(id)+1+serialKeyLength)
+	key = append(key, id...)
+	key = append(key, keySeparator)
+	return binary.BigEndian.AppendUint64(key, uint64(n))
+}
+
+// idPrefix is a prefix of all keys of id
+func idPrefix(id string) []byte {
+	return append([]byte(id), keySeparator)
+}
+
+// keyNumber returns number of key, made by idKey
+func keyNumber(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(key)-serialKeyLength:]))
+}
+
+// insert places new document of kind with id
+func (db *Boltdb) insert(kind string, id string, doc interface{}) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	h, err := parseHeader(b)
+	if err != nil {
+		return err
+	}
+	return db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(kind)).Put([]byte(id), b); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(kind+indexSuffix)).Put(idKey(h.UserId, h.Serial), []byte(id))
+	})
+}
+
+// get decodes document of kind with id to v, document should belong to user
+func (db *Boltdb) get(kind string, id string, userId string, v interface{}) error {
+	return db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(kind)).Get([]byte(id))
+		if b == nil {
+			return documents.ErrNotFound
+		}
+		h, err := parseHeader(b)
+		if err != nil {
+			return err
+		}
+		if h.UserId != userId {
+			return documents.ErrNotFound
+		}
+		return json.Unmarshal(b, v)
+	})
+}
+
+// replace replaces user document of kind with id. Previous document revision
+// is archived to history.
+func (db *Boltdb) replace(kind string, id string, userId string, doc interface{}) error {
+	return db.update(kind, id, userId, func([]byte) (interface{}, error) {
+		return doc, nil
+	})
+}
+
+// update replaces user document of kind with id by document, returned by fn.
+// fn receives stored document. Previous document revision is archived to history,
+// but deleted and trashed documents are not archived: the first has no payload,
+// and the second has the same payload as the previous revision.
+func (db *Boltdb) update(kind string, id string, userId string, fn func(stored []byte) (interface{}, error)) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		docs := tx.Bucket([]byte(kind))
+		stored := docs.Get([]byte(id))
+		if stored == nil {
+			return documents.ErrNotFound
+		}
+		old, err := parseHeader(stored)
+		if err != nil {
+			return err
+		}
+		if old.UserId != userId {
+			return documents.ErrNotFound
+		}
+		doc, err := fn(stored)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		h, err := parseHeader(b)
+		if err != nil {
+			return err
+		}
+		if old.State != models.StateDeleted && old.State != models.StateTrashed {
+			if err := archive(tx, kind, id, old, stored); err != nil {
+				return err
+			}
+		}
+		index := tx.Bucket([]byte(kind + indexSuffix))
+		if err := index.Delete(idKey(old.UserId, old.Serial)); err != nil {
+			return err
+		}
+		if err := index.Put(idKey(h.UserId, h.Serial), []byte(id)); err != nil {
+			return err
+		}
+		return docs.Put([]byte(id), b)
+	})
+}
+
+// streamRange produces stream of user documents of kind with serial between minSerial and maxSerial.
+// Documents are read first, so database transaction does not wait for stream reader.
+func (db *Boltdb) streamRange(ctx context.Context, kind string, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	var list []interface{}
+	err := db.db.View(func(tx *bolt.Tx) error {
+		docs := tx.Bucket([]byte(kind))
+		c := tx.Bucket([]byte(kind + indexSuffix)).Cursor()
+		prefix := idPrefix(userId)
+		for k, id := c.Seek(idKey(userId, max(minSerial+1, 0))); k != nil && bytes.HasPrefix(k, prefix); k, id = c.Next() {
+			if keyNumber(k) >= maxSerial {
+				break
+			}
+			doc, err := decodeDoc(kind, docs.Get(id))
+			if err != nil {
+				return err
+			}
+			list = append(list, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return send(ctx, list, chData)
+}
+
+// send sends documents to stream
+func send(ctx context.Context, list []interface{}, chData chan interface{}) error {
+	for _, doc := range list {
+		select {
+		case chData <- doc:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// decodeDoc decodes document of requested kind into document model
+func decodeDoc(kind string, b []byte) (interface{}, error) {
+	var err error
+	switch kind {
+	case documents.KindNote:
+		var doc models.Note
+		err = json.Unmarshal(b, &doc)
+		return doc, err
+	case documents.KindCard:
+		var doc models.Card
+		err = json.Unmarshal(b, &doc)
+		return doc, err
+	case documents.KindCredential:
+		var doc models.Credential
+		err = json.Unmarshal(b, &doc)
+		return doc, err
+	case documents.KindFile:
+		var doc models.File
+		err = json.Unmarshal(b, &doc)
+		return doc, err
+	}
+	return nil, errors.New("unknown document kind: " + kind)
+}
@@ -0,0 +1,54 @@
+package boltdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/models"
+	"yap-pwkeeper/internal/pkg/storagetest"
+)
+
+func newTestDb(t *testing.T, path string) *Boltdb {
+	t.Helper()
+	db, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close(context.Background()) })
+	return db
+}
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		return newTestDb(t, filepath.Join(t.TempDir(), "test.db"))
+	})
+}
+
+func TestBoltdb_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	db := newTestDb(t, path)
+
+	next, err := db.GetSerials(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), next)
+	user, err := db.AddUser(ctx, models.User{Login: "login", State: models.StateActive})
+	require.NoError(t, err)
+	note := models.Note{UserId: user.Id, Serial: 1, Name: "note", State: models.StateActive}
+	note.Id, err = db.AddNote(ctx, note)
+	require.NoError(t, err)
+	require.NoError(t, db.Close(ctx))
+
+	db = newTestDb(t, path)
+	next, err = db.GetSerials(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), next, "reserved serials should survive restart")
+	stored, err := db.GetUserByLogin(ctx, "login")
+	require.NoError(t, err)
+	assert.Equal(t, user, stored)
+	got, err := db.GetNote(ctx, note.Id, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, note, got)
+}
@@ -0,0 +1,35 @@
+package boltdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddCard places new Card in the database
+func (db *Boltdb) AddCard(_ context.Context, card models.Card) (string, error) {
+	card.Id = newId()
+	if err := db.insert(documents.KindCard, card.Id, card); err != nil {
+		return "", err
+	}
+	return card.Id, nil
+}
+
+// GetCard returns Card from database
+func (db *Boltdb) GetCard(_ context.Context, docId string, userId string) (models.Card, error) {
+	var card models.Card
+	err := db.get(documents.KindCard, docId, userId, &card)
+	return card, err
+}
+
+// ModifyCard replaces Card in database. Also called in delete action, because deleted
+// documents are only marked with a flag, but not actually deleted.
+func (db *Boltdb) ModifyCard(_ context.Context, card models.Card) error {
+	return db.replace(documents.KindCard, card.Id, card.UserId, card)
+}
+
+// GetCardsStream produces stream of Cards updates, happened between minSerial and maxSerial
+func (db *Boltdb) GetCardsStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindCard, userId, minSerial, maxSerial, chData)
+}
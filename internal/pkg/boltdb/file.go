@@ -0,0 +1,53 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddFile places new File in the database. File data is stored separately, see PutBlob.
+func (db *Boltdb) AddFile(_ context.Context, file models.File) (string, error) {
+	file.Id = newId()
+	if err := db.insert(documents.KindFile, file.Id, file); err != nil {
+		return "", err
+	}
+	return file.Id, nil
+}
+
+// GetFileInfo returns File from database
+func (db *Boltdb) GetFileInfo(_ context.Context, docId string, userId string) (models.File, error) {
+	var file models.File
+	err := db.get(documents.KindFile, docId, userId, &file)
+	return file, err
+}
+
+// ModifyFile replaces File in database. Used for update and for deletion
+func (db *Boltdb) ModifyFile(_ context.Context, file models.File) error {
+	return db.replace(documents.KindFile, file.Id, file.UserId, file)
+}
+
+// ModifyFileInfo updates File without touching file data and its size and hash
+func (db *Boltdb) ModifyFileInfo(_ context.Context, file models.File) error {
+	return db.update(documents.KindFile, file.Id, file.UserId, func(b []byte) (interface{}, error) {
+		var stored models.File
+		if err := json.Unmarshal(b, &stored); err != nil {
+			return nil, err
+		}
+		stored.Serial = file.Serial
+		stored.Name = file.Name
+		stored.Filename = file.Filename
+		stored.Metadata = file.Metadata
+		stored.Folder = file.Folder
+		stored.Tags = file.Tags
+		stored.State = file.State
+		return stored, nil
+	})
+}
+
+// GetFilesInfoStream produces stream of File updates, happened between minSerial and maxSerial
+func (db *Boltdb) GetFilesInfoStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindFile, userId, minSerial, maxSerial, chData)
+}
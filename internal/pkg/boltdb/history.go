@@ -0,0 +1,158 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// historyRecord is a previous revision of the document, keyed by document id and serial
+type historyRecord struct {
+	UserId string
+	Kind   string
+	Doc    json.RawMessage
+}
+
+// archive copies stored document revision to history. It should be called before
+// document is replaced.
+func archive(tx *bolt.Tx, kind string, docId string, h header, stored []byte) error {
+	b, err := json.Marshal(historyRecord{UserId: h.UserId, Kind: kind, Doc: stored})
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bktHistory)).Put(idKey(docId, h.Serial), b)
+}
+
+// GetHistoryStream produces stream of previous document revisions, the newest first.
+// Files are sent without binary data.
+func (db *Boltdb) GetHistoryStream(ctx context.Context, docId string, userId string, chData chan interface{}) error {
+	var list []interface{}
+	err := db.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bktHistory)).Cursor()
+		prefix := idPrefix(docId)
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			doc, err := decodeRecord(v, userId)
+			if err != nil {
+				return err
+			}
+			if doc != nil {
+				list = append(list, doc)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return send(ctx, list, chData)
+}
+
+// GetRevision returns document revision with requested serial from history
+func (db *Boltdb) GetRevision(_ context.Context, docId string, userId string, serial int64) (interface{}, error) {
+	var doc interface{}
+	err := db.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket([]byte(bktHistory)).Get(idKey(docId, serial))
+		if v == nil {
+			return documents.ErrNotFound
+		}
+		var err error
+		if doc, err = decodeRecord(v, userId); err != nil {
+			return err
+		}
+		if doc == nil {
+			return documents.ErrNotFound
+		}
+		return nil
+	})
+	return doc, err
+}
+
+// DeleteHistory removes all revisions of the document
+func (db *Boltdb) DeleteHistory(_ context.Context, docId string, userId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return deleteRevisions(tx.Bucket([]byte(bktHistory)), docId, userId)
+	})
+}
+
+// DeleteRevisions removes revisions of the document with requested serials
+func (db *Boltdb) DeleteRevisions(_ context.Context, docId string, userId string, serials []int64) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		history := tx.Bucket([]byte(bktHistory))
+		for _, serial := range serials {
+			key := idKey(docId, serial)
+			v := history.Get(key)
+			if v == nil {
+				continue
+			}
+			var record historyRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return err
+			}
+			if record.UserId != userId {
+				continue
+			}
+			if err := history.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TrimHistory removes the oldest revisions of the document, keeping keep newest ones,
+// and returns removed revisions. File revisions are returned without binary data.
+func (db *Boltdb) TrimHistory(_ context.Context, docId string, userId string, keep int) ([]interface{}, error) {
+	var removed []interface{}
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		removed = nil
+		history := tx.Bucket([]byte(bktHistory))
+		var keys [][]byte
+		var docs []interface{}
+		c := history.Cursor()
+		prefix := idPrefix(docId)
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			doc, err := decodeRecord(v, userId)
+			if err != nil {
+				return err
+			}
+			if doc != nil {
+				keys, docs = append(keys, k), append(docs, doc)
+			}
+		}
+		// revisions are ordered by serial, the oldest first
+		for i := 0; i < len(keys)-keep; i++ {
+			if err := history.Delete(keys[i]); err != nil {
+				return err
+			}
+			removed = append(removed, docs[i])
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// decodeRecord returns revision as a document model, or nil if revision belongs to other user.
+// File revisions are returned without binary data.
+func decodeRecord(v []byte, userId string) (interface{}, error) {
+	var record historyRecord
+	if err := json.Unmarshal(v, &record); err != nil {
+		return nil, err
+	}
+	if record.UserId != userId {
+		return nil, nil
+	}
+	doc, err := decodeDoc(record.Kind, record.Doc)
+	if file, ok := doc.(models.File); ok {
+		file.Data = nil
+		doc = file
+	}
+	return doc, err
+}
@@ -0,0 +1,35 @@
+package boltdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddNote places new Note in the database
+func (db *Boltdb) AddNote(_ context.Context, note models.Note) (string, error) {
+	note.Id = newId()
+	if err := db.insert(documents.KindNote, note.Id, note); err != nil {
+		return "", err
+	}
+	return note.Id, nil
+}
+
+// GetNote returns Note from database
+func (db *Boltdb) GetNote(_ context.Context, docId string, userId string) (models.Note, error) {
+	var note models.Note
+	err := db.get(documents.KindNote, docId, userId, &note)
+	return note, err
+}
+
+// ModifyNote replaces Note in database. Also called in delete action, because deleted
+// documents are only marked with a flag, but not actually deleted.
+func (db *Boltdb) ModifyNote(_ context.Context, note models.Note) error {
+	return db.replace(documents.KindNote, note.Id, note.UserId, note)
+}
+
+// GetNotesStream produces stream of Notes updates, happened between minSerial and maxSerial
+func (db *Boltdb) GetNotesStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindNote, userId, minSerial, maxSerial, chData)
+}
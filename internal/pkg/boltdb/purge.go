@@ -0,0 +1,62 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DeleteUserDocuments removes all user documents of every kind and their history
+// in one transaction. File data is not removed.
+func (db *Boltdb) DeleteUserDocuments(_ context.Context, userId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		prefix := idPrefix(userId)
+		history := tx.Bucket([]byte(bktHistory))
+		for _, kind := range docBuckets {
+			docs := tx.Bucket([]byte(kind))
+			index := tx.Bucket([]byte(kind + indexSuffix))
+			var keys, ids [][]byte
+			c := index.Cursor()
+			for k, id := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, id = c.Next() {
+				keys = append(keys, k)
+				ids = append(ids, id)
+			}
+			for i := range keys {
+				if err := deleteRevisions(history, string(ids[i]), userId); err != nil {
+					return err
+				}
+				if err := docs.Delete(ids[i]); err != nil {
+					return err
+				}
+				if err := index.Delete(keys[i]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// deleteRevisions removes history records of user document
+func deleteRevisions(history *bolt.Bucket, docId string, userId string) error {
+	var keys [][]byte
+	c := history.Cursor()
+	prefix := idPrefix(docId)
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var record historyRecord
+		if err := json.Unmarshal(v, &record); err != nil {
+			return err
+		}
+		if record.UserId == userId {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		if err := history.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
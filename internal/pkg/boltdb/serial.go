@@ -0,0 +1,21 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/binary"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// GetSerials returns next usable serial, reserves next n serials
+func (db *Boltdb) GetSerials(_ context.Context, n int) (int64, error) {
+	var next int64
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bktSerials))
+		if v := b.Get([]byte(keySerial)); v != nil {
+			next = int64(binary.BigEndian.Uint64(v))
+		}
+		return b.Put([]byte(keySerial), binary.BigEndian.AppendUint64(nil, uint64(next+int64(n))))
+	})
+	return next, err
+}
@@ -0,0 +1,94 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddSession stores new user session
+func (db *Boltdb) AddSession(_ context.Context, session models.Session) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return putSession(tx, session)
+	})
+}
+
+// GetSession returns session by id
+func (db *Boltdb) GetSession(_ context.Context, sessionId string) (models.Session, error) {
+	var session models.Session
+	err := db.db.View(func(tx *bolt.Tx) error {
+		var err error
+		session, err = getSession(tx, sessionId)
+		return err
+	})
+	return session, err
+}
+
+// GetSessions returns all user sessions, the oldest first.
+// Users have a few sessions, so the whole bucket is scanned.
+func (db *Boltdb) GetSessions(_ context.Context, userId string) ([]models.Session, error) {
+	var sessions []models.Session
+	err := db.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bktSessions)).ForEach(func(_, b []byte) error {
+			var session models.Session
+			if err := json.Unmarshal(b, &session); err != nil {
+				return err
+			}
+			if session.UserId == userId {
+				sessions = append(sessions, session)
+			}
+			return nil
+		})
+	})
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt < sessions[j].CreatedAt })
+	return sessions, err
+}
+
+// TouchSession updates time of the last session activity
+func (db *Boltdb) TouchSession(_ context.Context, sessionId string, lastSeen int64) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		session, err := getSession(tx, sessionId)
+		if err != nil {
+			return err
+		}
+		session.LastSeen = lastSeen
+		return putSession(tx, session)
+	})
+}
+
+// DeleteSession removes user session
+func (db *Boltdb) DeleteSession(_ context.Context, sessionId string, userId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		session, err := getSession(tx, sessionId)
+		if err != nil {
+			return err
+		}
+		if session.UserId != userId {
+			return aaa.ErrNoSession
+		}
+		return tx.Bucket([]byte(bktSessions)).Delete([]byte(sessionId))
+	})
+}
+
+func getSession(tx *bolt.Tx, sessionId string) (models.Session, error) {
+	var session models.Session
+	b := tx.Bucket([]byte(bktSessions)).Get([]byte(sessionId))
+	if b == nil {
+		return session, aaa.ErrNoSession
+	}
+	err := json.Unmarshal(b, &session)
+	return session, err
+}
+
+func putSession(tx *bolt.Tx, session models.Session) error {
+	b, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bktSessions)).Put([]byte(session.Id), b)
+}
@@ -0,0 +1,117 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddShare places new document share in the database
+func (db *Boltdb) AddShare(_ context.Context, share models.Share) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return putShare(tx, share)
+	})
+}
+
+// GetShare returns document share by id
+func (db *Boltdb) GetShare(_ context.Context, shareId string) (models.Share, error) {
+	var share models.Share
+	err := db.db.View(func(tx *bolt.Tx) error {
+		var err error
+		share, err = getShare(tx, shareId)
+		return err
+	})
+	return share, err
+}
+
+// ModifyShare replaces document share
+func (db *Boltdb) ModifyShare(_ context.Context, share models.Share) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		if _, err := getShare(tx, share.Id); err != nil {
+			return err
+		}
+		return putShare(tx, share)
+	})
+}
+
+// DeleteShare removes document share
+func (db *Boltdb) DeleteShare(_ context.Context, shareId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		if _, err := getShare(tx, shareId); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(bktShares)).Delete([]byte(shareId))
+	})
+}
+
+// DeleteExpiredShares removes shares, which expire at or before time, and returns their number
+func (db *Boltdb) DeleteExpiredShares(_ context.Context, before int64) (int, error) {
+	n := 0
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		n = 0
+		return deleteShares(tx, func(share models.Share) bool {
+			if share.ExpiresAt <= before {
+				n++
+				return true
+			}
+			return false
+		})
+	})
+	return n, err
+}
+
+// DeleteUserShares removes all shares of owner documents
+func (db *Boltdb) DeleteUserShares(_ context.Context, userId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return deleteShares(tx, func(share models.Share) bool {
+			return share.UserId == userId
+		})
+	})
+}
+
+// deleteShares removes shares, selected by match
+func deleteShares(tx *bolt.Tx, match func(share models.Share) bool) error {
+	bkt := tx.Bucket([]byte(bktShares))
+	var ids [][]byte
+	err := bkt.ForEach(func(k, b []byte) error {
+		var share models.Share
+		if err := json.Unmarshal(b, &share); err != nil {
+			return err
+		}
+		if match(share) {
+			ids = append(ids, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := bkt.Delete(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getShare(tx *bolt.Tx, shareId string) (models.Share, error) {
+	var share models.Share
+	b := tx.Bucket([]byte(bktShares)).Get([]byte(shareId))
+	if b == nil {
+		return share, documents.ErrNoShare
+	}
+	err := json.Unmarshal(b, &share)
+	return share, err
+}
+
+func putShare(tx *bolt.Tx, share models.Share) error {
+	b, err := json.Marshal(share)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bktShares)).Put([]byte(share.Id), b)
+}
@@ -0,0 +1,46 @@
+package boltdb
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// GetTrashStream produces stream of documents of all users and kinds, which were
+// moved to trash before trashedBefore unix time. Files are sent without binary data.
+func (db *Boltdb) GetTrashStream(ctx context.Context, trashedBefore int64, chData chan interface{}) error {
+	var list []interface{}
+	err := db.db.View(func(tx *bolt.Tx) error {
+		for _, kind := range docBuckets {
+			err := tx.Bucket([]byte(kind)).ForEach(func(_, v []byte) error {
+				h, err := parseHeader(v)
+				if err != nil {
+					return err
+				}
+				if h.State != models.StateTrashed || h.TrashedAt >= trashedBefore {
+					return nil
+				}
+				doc, err := decodeDoc(kind, v)
+				if err != nil {
+					return err
+				}
+				if file, ok := doc.(models.File); ok {
+					file.Data = nil
+					doc = file
+				}
+				list = append(list, doc)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return send(ctx, list, chData)
+}
@@ -0,0 +1,116 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddUpload places new file upload in the database
+func (db *Boltdb) AddUpload(_ context.Context, upload models.Upload) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return putUpload(tx, upload)
+	})
+}
+
+// GetUpload returns file upload by id
+func (db *Boltdb) GetUpload(_ context.Context, uploadId string) (models.Upload, error) {
+	var upload models.Upload
+	err := db.db.View(func(tx *bolt.Tx) error {
+		var err error
+		upload, err = getUpload(tx, uploadId)
+		return err
+	})
+	return upload, err
+}
+
+// SetUploadOffset saves size of received data and time of upload activity
+func (db *Boltdb) SetUploadOffset(_ context.Context, uploadId string, offset int64, updatedAt int64) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		upload, err := getUpload(tx, uploadId)
+		if err != nil {
+			return err
+		}
+		upload.Offset, upload.UpdatedAt = offset, updatedAt
+		return putUpload(tx, upload)
+	})
+}
+
+// DeleteUpload removes file upload, its data blob is kept
+func (db *Boltdb) DeleteUpload(_ context.Context, uploadId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		if _, err := getUpload(tx, uploadId); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(bktUploads)).Delete([]byte(uploadId))
+	})
+}
+
+// GetExpiredUploads returns uploads, which were active at or before time
+func (db *Boltdb) GetExpiredUploads(_ context.Context, before int64) ([]models.Upload, error) {
+	var expired []models.Upload
+	err := db.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bktUploads)).ForEach(func(_, b []byte) error {
+			var upload models.Upload
+			if err := json.Unmarshal(b, &upload); err != nil {
+				return err
+			}
+			if upload.UpdatedAt <= before {
+				expired = append(expired, upload)
+			}
+			return nil
+		})
+	})
+	return expired, err
+}
+
+// DeleteUserUploads removes all uploads of owner files and returns them, data blobs are kept
+func (db *Boltdb) DeleteUserUploads(_ context.Context, userId string) ([]models.Upload, error) {
+	var deleted []models.Upload
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		deleted = nil
+		bkt := tx.Bucket([]byte(bktUploads))
+		err := bkt.ForEach(func(_, b []byte) error {
+			var upload models.Upload
+			if err := json.Unmarshal(b, &upload); err != nil {
+				return err
+			}
+			if upload.UserId == userId {
+				deleted = append(deleted, upload)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, upload := range deleted {
+			if err := bkt.Delete([]byte(upload.Id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func getUpload(tx *bolt.Tx, uploadId string) (models.Upload, error) {
+	var upload models.Upload
+	b := tx.Bucket([]byte(bktUploads)).Get([]byte(uploadId))
+	if b == nil {
+		return upload, documents.ErrUploadNotFound
+	}
+	err := json.Unmarshal(b, &upload)
+	return upload, err
+}
+
+func putUpload(tx *bolt.Tx, upload models.Upload) error {
+	b, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bktUploads)).Put([]byte(upload.Id), b)
+}
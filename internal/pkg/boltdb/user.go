@@ -0,0 +1,203 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddUser creates new user in database, returns user with new id
+func (db *Boltdb) AddUser(_ context.Context, user models.User) (models.User, error) {
+	user.Id = newId()
+	b, err := json.Marshal(user)
+	if err != nil {
+		return user, err
+	}
+	err = db.db.Update(func(tx *bolt.Tx) error {
+		logins := tx.Bucket([]byte(bktLogins))
+		if logins.Get([]byte(user.Login)) != nil {
+			return aaa.ErrDuplicate
+		}
+		if err := logins.Put([]byte(user.Login), []byte(user.Id)); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(bktUsers)).Put([]byte(user.Id), b)
+	})
+	return user, err
+}
+
+// GetUserByLogin returns active user by specified login
+func (db *Boltdb) GetUserByLogin(_ context.Context, login string) (models.User, error) {
+	var user models.User
+	err := db.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket([]byte(bktLogins)).Get([]byte(login))
+		if id == nil {
+			return aaa.ErrNotFound
+		}
+		var err error
+		user, err = getUser(tx, string(id))
+		return err
+	})
+	return user, err
+}
+
+// GetUserById returns active user by id
+func (db *Boltdb) GetUserById(_ context.Context, userId string) (models.User, error) {
+	var user models.User
+	err := db.db.View(func(tx *bolt.Tx) error {
+		var err error
+		user, err = getUser(tx, userId)
+		return err
+	})
+	return user, err
+}
+
+// SetVaultKey saves user vault key, only if it was not set before
+func (db *Boltdb) SetVaultKey(_ context.Context, userId string, key []byte) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		if user.VaultKey != nil {
+			return aaa.ErrKeyExists
+		}
+		user.VaultKey = key
+		return putUser(tx, user)
+	})
+}
+
+// SetKeyPair saves user key pair, only if it was not set before
+func (db *Boltdb) SetKeyPair(_ context.Context, userId string, keys models.KeyPair) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		if user.PublicKey != nil {
+			return aaa.ErrKeyExists
+		}
+		user.PublicKey, user.PrivateKey = keys.Public, keys.Private
+		return putUser(tx, user)
+	})
+}
+
+// getUser returns active user by id
+func getUser(tx *bolt.Tx, userId string) (models.User, error) {
+	var user models.User
+	b := tx.Bucket([]byte(bktUsers)).Get([]byte(userId))
+	if b == nil {
+		return user, aaa.ErrNotFound
+	}
+	if err := json.Unmarshal(b, &user); err != nil {
+		return user, err
+	}
+	if user.State != models.StateActive {
+		return user, aaa.ErrNotFound
+	}
+	return user, nil
+}
+
+func putUser(tx *bolt.Tx, user models.User) error {
+	b, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bktUsers)).Put([]byte(user.Id), b)
+}
+
+// SetTotp saves user second factor, nil totp removes it
+func (db *Boltdb) SetTotp(_ context.Context, userId string, totp *models.Totp) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		user.Totp = totp
+		return putUser(tx, user)
+	})
+}
+
+// SetMigrated marks that all user documents are encrypted
+func (db *Boltdb) SetMigrated(_ context.Context, userId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		user.Migrated = true
+		return putUser(tx, user)
+	})
+}
+
+// SetPassword replaces user password hash and wrapped vault key in one update.
+// Password is marked as derived auth value. Nil vault key keeps stored one.
+func (db *Boltdb) SetPassword(_ context.Context, userId string, passwordHash string, vaultKey []byte) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash, user.Derived = passwordHash, true
+		if vaultKey != nil {
+			user.VaultKey = vaultKey
+		}
+		return putUser(tx, user)
+	})
+}
+
+// DeleteUser deactivates user and removes user secrets. Login stays reserved.
+func (db *Boltdb) DeleteUser(_ context.Context, userId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		return putUser(tx, models.User{Id: user.Id, Login: user.Login, State: models.StateDeleted})
+	})
+}
+
+// AddLoginFailure increments number of failed login attempts and returns new number
+func (db *Boltdb) AddLoginFailure(_ context.Context, userId string) (int, error) {
+	var failures int
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		user.FailedLogins++
+		failures = user.FailedLogins
+		return putUser(tx, user)
+	})
+	return failures, err
+}
+
+// LockUser sets lockout time of user, earlier time does not shorten existing lockout
+func (db *Boltdb) LockUser(_ context.Context, userId string, lockedUntil int64) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		if lockedUntil > user.LockedUntil {
+			user.LockedUntil = lockedUntil
+		}
+		return putUser(tx, user)
+	})
+}
+
+// SetLoginFailures saves number of failed login attempts and lockout time
+func (db *Boltdb) SetLoginFailures(_ context.Context, userId string, failures int, lockedUntil int64) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		user, err := getUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		user.FailedLogins, user.LockedUntil = failures, lockedUntil
+		return putUser(tx, user)
+	})
+}
@@ -0,0 +1,88 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddVault places new shared vault in the database
+func (db *Boltdb) AddVault(_ context.Context, vault models.Vault) (string, error) {
+	vault.Id = newId()
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		return putVault(tx, vault)
+	})
+	return vault.Id, err
+}
+
+// GetVault returns shared vault by id
+func (db *Boltdb) GetVault(_ context.Context, vaultId string) (models.Vault, error) {
+	var vault models.Vault
+	err := db.db.View(func(tx *bolt.Tx) error {
+		var err error
+		vault, err = getVault(tx, vaultId)
+		return err
+	})
+	return vault, err
+}
+
+// ModifyVault replaces shared vault name and members
+func (db *Boltdb) ModifyVault(_ context.Context, vault models.Vault) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		if _, err := getVault(tx, vault.Id); err != nil {
+			return err
+		}
+		return putVault(tx, vault)
+	})
+}
+
+// GetUserVaults returns shared vaults, user is member of.
+// Users have a few vaults, so the whole bucket is scanned.
+func (db *Boltdb) GetUserVaults(_ context.Context, userId string) ([]models.Vault, error) {
+	var vaults []models.Vault
+	err := db.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bktVaults)).ForEach(func(_, b []byte) error {
+			var vault models.Vault
+			if err := json.Unmarshal(b, &vault); err != nil {
+				return err
+			}
+			if _, ok := vault.Member(userId); ok {
+				vaults = append(vaults, vault)
+			}
+			return nil
+		})
+	})
+	return vaults, err
+}
+
+// DeleteVault removes shared vault. Vault documents should be deleted separately.
+func (db *Boltdb) DeleteVault(_ context.Context, vaultId string) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		if _, err := getVault(tx, vaultId); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(bktVaults)).Delete([]byte(vaultId))
+	})
+}
+
+func getVault(tx *bolt.Tx, vaultId string) (models.Vault, error) {
+	var vault models.Vault
+	b := tx.Bucket([]byte(bktVaults)).Get([]byte(vaultId))
+	if b == nil {
+		return vault, documents.ErrNoVault
+	}
+	err := json.Unmarshal(b, &vault)
+	return vault, err
+}
+
+func putVault(tx *bolt.Tx, vault models.Vault) error {
+	b, err := json.Marshal(vault)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bktVaults)).Put([]byte(vault.Id), b)
+}
@@ -0,0 +1,135 @@
+// Package breached checks passwords against a local list of SHA-1 hashes of passwords,
+// known from data breaches. List is a text file of lines HASH:COUNT, sorted by hash,
+// like the ordered downloads of Pwned Passwords. File is not loaded to memory: it is
+// indexed by 5 hex digits prefix of hash, so each check reads only lines of one prefix,
+// the same way as k-anonymity range queries. Range serves such queries to clients,
+// which check passwords, never sent to server, by hash prefix.
+package breached
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	PrefixLen = 5 // length of hash prefix of range queries
+	prefixes  = 1 << (4 * PrefixLen)
+)
+
+var (
+	ErrFormat = errors.New("invalid breached passwords file")
+	ErrPrefix = errors.New("invalid hash prefix")
+)
+
+// List is an indexed file of breached password hashes
+type List struct {
+	f       *os.File
+	offsets []int64 // offsets[p] is offset of the first line with prefix p or greater
+}
+
+// Open opens and indexes file of breached password hashes
+func Open(path string) (*List, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	l := &List{f: f, offsets: make([]int64, prefixes+1)}
+	if err := l.index(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Close closes file
+func (l *List) Close() error {
+	return l.f.Close()
+}
+
+// index reads whole file once and remembers offsets of prefixes
+func (l *List) index() error {
+	r := bufio.NewReaderSize(l.f, 1<<20)
+	var offset int64
+	next := 0
+	for n := 1; ; n++ {
+		line, err := r.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			return fmt.Errorf("%w: line %d is too long", ErrFormat, n)
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			p, perr := strconv.ParseUint(string(line[:min(PrefixLen, len(line))]), 16, 32)
+			if perr != nil || len(line) < 2*sha1.Size {
+				return fmt.Errorf("%w: line %d is not a hash", ErrFormat, n)
+			}
+			if int(p) < next-1 {
+				return fmt.Errorf("%w: line %d is not sorted", ErrFormat, n)
+			}
+			for ; next <= int(p); next++ {
+				l.offsets[next] = offset
+			}
+		}
+		offset += int64(len(line))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for ; next <= prefixes; next++ {
+		l.offsets[next] = offset
+	}
+	return nil
+}
+
+// Hash returns upper case hex SHA-1 hash of password, split to range prefix and suffix
+func Hash(password string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return hash[:PrefixLen], hash[PrefixLen:]
+}
+
+// Contains checks whether password hash is in the list
+func (l *List) Contains(ctx context.Context, password string) (bool, error) {
+	prefix, suffix := Hash(password)
+	suffixes, err := l.Range(ctx, prefix)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(suffixes, suffix), nil
+}
+
+// Range returns upper case hash suffixes of all breached passwords with hash prefix
+func (l *List) Range(_ context.Context, prefix string) ([]string, error) {
+	if len(prefix) != PrefixLen {
+		return nil, ErrPrefix
+	}
+	p, err := strconv.ParseUint(prefix, 16, 32)
+	if err != nil {
+		return nil, ErrPrefix
+	}
+	start, end := l.offsets[p], l.offsets[p+1]
+	if start == end {
+		return nil, nil
+	}
+	var suffixes []string
+	s := bufio.NewScanner(io.NewSectionReader(l.f, start, end-start))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if len(line) < 2*sha1.Size {
+			continue
+		}
+		suffixes = append(suffixes, strings.ToUpper(line[PrefixLen:2*sha1.Size]))
+	}
+	return suffixes, s.Err()
+}
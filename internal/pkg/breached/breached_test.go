@@ -0,0 +1,88 @@
+package breached
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hashLine(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:])) + ":42"
+}
+
+func TestList(t *testing.T) {
+	breachedPasswords := []string{"password", "123456", "qwerty", "letmein", "P@ssw0rd"}
+	lines := make([]string, 0, len(breachedPasswords))
+	for _, p := range breachedPasswords {
+		lines = append(lines, hashLine(p))
+	}
+	sort.Strings(lines)
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0600))
+
+	l, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	ctx := context.Background()
+	for _, p := range breachedPasswords {
+		ok, err := l.Contains(ctx, p)
+		require.NoError(t, err)
+		assert.True(t, ok, "password %q should be found", p)
+	}
+	for _, p := range []string{"", "correct horse battery staple", "Password"} {
+		ok, err := l.Contains(ctx, p)
+		require.NoError(t, err)
+		assert.False(t, ok, "password %q should not be found", p)
+	}
+}
+
+func TestList_Range(t *testing.T) {
+	lines := []string{hashLine("password"), hashLine("123456")}
+	sort.Strings(lines)
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+	l, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	ctx := context.Background()
+
+	prefix, suffix := Hash("password")
+	suffixes, err := l.Range(ctx, prefix)
+	require.NoError(t, err)
+	assert.Equal(t, []string{suffix}, suffixes, "range should have suffixes of prefix only")
+
+	suffixes, err = l.Range(ctx, "00000")
+	require.NoError(t, err)
+	assert.Empty(t, suffixes)
+
+	for _, bad := range []string{"", "0000", "000000", "XYZXY"} {
+		_, err = l.Range(ctx, bad)
+		assert.ErrorIs(t, err, ErrPrefix, "prefix %q should be rejected", bad)
+	}
+}
+
+func TestOpen_BadFormat(t *testing.T) {
+	dir := t.TempDir()
+	unsorted := filepath.Join(dir, "unsorted.txt")
+	require.NoError(t, os.WriteFile(unsorted, []byte(
+		"FFFFF00000000000000000000000000000000000:1\n00000000000000000000000000000000000000AA:1\n"), 0600))
+	_, err := Open(unsorted)
+	assert.ErrorIs(t, err, ErrFormat)
+
+	garbage := filepath.Join(dir, "garbage.txt")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a hash\n"), 0600))
+	_, err = Open(garbage)
+	assert.ErrorIs(t, err, ErrFormat)
+
+	_, err = Open(filepath.Join(dir, "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
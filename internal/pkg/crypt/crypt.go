@@ -0,0 +1,169 @@
+// Package crypt implements client side encryption of documents.
+// Each user has random vault key, which encrypts all documents payload.
+// Vault key itself is stored on server only wrapped (encrypted) with the key,
+// derived from user master password. This allows to change password without
+// re-encryption of all documents. Server login password is derived from master
+// password separately (see MasterKeys), so server never sees the wrapping key.
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	KeySize = 32 // vault key size
+
+	// key derivation (argon2id) parameters
+	kdfTime    = 3
+	kdfMemory  = 64 << 10
+	kdfThreads = 4
+	saltSize   = 16
+
+	wrapVersion  byte = 1
+	stringPrefix      = "pwk1:" // prefix of encrypted strings
+)
+
+var (
+	ErrDecrypt = errors.New("decryption failed")
+	ErrFormat  = errors.New("invalid encrypted data format")
+	ErrKey     = errors.New("invalid key")
+)
+
+// NewKey generates new random vault key
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// deriveKey derives key encryption key from password
+func deriveKey(password string, salt []byte) []byte {
+	return argon2.IDKey([]byte(password), salt, kdfTime, kdfMemory, kdfThreads, KeySize)
+}
+
+// WrapKey encrypts vault key with the key, derived from password and random salt.
+// Result contains everything needed to unwrap key, except password.
+// It is used for backup passphrases, vault keys are wrapped with MasterKeys.
+func WrapKey(key []byte, password string) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, ErrKey
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	c, err := New(deriveKey(password, salt))
+	if err != nil {
+		return nil, err
+	}
+	wrapped := append([]byte{wrapVersion}, salt...)
+	return append(wrapped, c.Seal(key)...), nil
+}
+
+// UnwrapKey decrypts vault key, wrapped with WrapKey.
+// Returns ErrDecrypt if password does not match.
+func UnwrapKey(wrapped []byte, password string) ([]byte, error) {
+	if len(wrapped) < 1+saltSize || wrapped[0] != wrapVersion {
+		return nil, ErrFormat
+	}
+	salt := wrapped[1 : 1+saltSize]
+	c, err := New(deriveKey(password, salt))
+	if err != nil {
+		return nil, err
+	}
+	key, err := c.Open(wrapped[1+saltSize:])
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != KeySize {
+		return nil, ErrKey
+	}
+	return key, nil
+}
+
+// Cipher encrypts and decrypts data with vault key (AES-256-GCM)
+type Cipher struct {
+	aead   cipher.AEAD
+	legacy bool // strings without encryption prefix are decrypted as is
+}
+
+// New is a Cipher constructor
+func New(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, ErrKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal encrypts data. Random nonce is prepended to result.
+func (c *Cipher) Seal(plain []byte) []byte {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plain)+c.aead.Overhead())
+	_, _ = rand.Read(nonce)
+	return c.aead.Seal(nonce, nonce, plain, nil)
+}
+
+// Open decrypts data encrypted with Seal
+func (c *Cipher) Open(data []byte) ([]byte, error) {
+	if len(data) < c.aead.NonceSize()+c.aead.Overhead() {
+		return nil, ErrFormat
+	}
+	plain, err := c.aead.Open(nil, data[:c.aead.NonceSize()], data[c.aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plain, nil
+}
+
+// EncryptString encrypts string into printable form
+func (c *Cipher) EncryptString(s string) string {
+	return stringPrefix + base64.RawStdEncoding.EncodeToString(c.Seal([]byte(s)))
+}
+
+// Legacy returns cipher with the same key, which returns strings without encryption
+// prefix as is: these are documents, saved before encryption was introduced.
+// It is used until such documents are re-encrypted.
+func (c *Cipher) Legacy() *Cipher {
+	return &Cipher{aead: c.aead, legacy: true}
+}
+
+// DecryptString decrypts string encrypted with EncryptString.
+// ErrFormat is returned for strings without encryption prefix, unless cipher is Legacy.
+// Empty string is returned as is: fields, added after encryption was introduced,
+// are empty in documents saved before.
+func (c *Cipher) DecryptString(s string) (string, error) {
+	if !IsEncrypted(s) {
+		if c.legacy || s == "" {
+			return s, nil
+		}
+		return "", ErrFormat
+	}
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(s, stringPrefix))
+	if err != nil {
+		return "", ErrFormat
+	}
+	plain, err := c.Open(data)
+	return string(plain), err
+}
+
+// IsEncrypted checks whether string was encrypted with EncryptString
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, stringPrefix)
+}
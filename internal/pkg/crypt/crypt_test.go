@@ -0,0 +1,149 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapKey(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	wrapped, err := WrapKey(key, "password")
+	require.NoError(t, err)
+
+	got, err := UnwrapKey(wrapped, "password")
+	require.NoError(t, err)
+	assert.Equal(t, key, got, "unwrapped key should match")
+
+	_, err = UnwrapKey(wrapped, "wrong password")
+	assert.ErrorIs(t, err, ErrDecrypt, "wrong password should fail")
+
+	_, err = UnwrapKey(wrapped[:10], "password")
+	assert.ErrorIs(t, err, ErrFormat, "truncated key should fail")
+}
+
+func TestDeriveMasterKeys(t *testing.T) {
+	m := DeriveMasterKeys("user", "password")
+	assert.Equal(t, m, DeriveMasterKeys("user", "password"), "derivation should be deterministic")
+	assert.Len(t, m.Auth, 2*KeySize)
+	assert.NotEqual(t, m.Auth, DeriveMasterKeys("other", "password").Auth, "login should salt derivation")
+	assert.NotEqual(t, m.Auth, DeriveMasterKeys("user", "other").Auth)
+	assert.NotEqual(t, m.Auth, hex.EncodeToString(m.enc), "auth value should not reveal encryption key")
+
+	key, _ := NewKey()
+	wrapped, err := m.WrapKey(key)
+	require.NoError(t, err)
+	assert.False(t, IsPasswordWrapped(wrapped))
+	got, err := m.UnwrapKey(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, got, "unwrapped key should match")
+
+	_, err = DeriveMasterKeys("user", "wrong password").UnwrapKey(wrapped)
+	assert.ErrorIs(t, err, ErrDecrypt, "wrong password should fail")
+
+	legacy, err := WrapKey(key, "password")
+	require.NoError(t, err)
+	assert.True(t, IsPasswordWrapped(legacy))
+	_, err = m.UnwrapKey(legacy)
+	assert.ErrorIs(t, err, ErrFormat, "key wrapped with password should be rejected")
+}
+
+func TestCipher_String(t *testing.T) {
+	key, _ := NewKey()
+	c, err := New(key)
+	require.NoError(t, err)
+
+	tests := []string{"", "some text", "многобайтовый текст"}
+	for _, tt := range tests {
+		enc := c.EncryptString(tt)
+		assert.True(t, IsEncrypted(enc), "string should be marked as encrypted")
+		assert.NotContains(t, enc, tt+"\x00", "ciphertext should not contain plaintext")
+		got, err := c.DecryptString(enc)
+		require.NoError(t, err)
+		assert.Equal(t, tt, got)
+	}
+
+	_, err = c.DecryptString("legacy plain text")
+	assert.ErrorIs(t, err, ErrFormat, "not encrypted strings should be rejected")
+	got, err := c.DecryptString("")
+	require.NoError(t, err)
+	assert.Equal(t, "", got, "empty string has no plain text")
+	got, err = c.Legacy().DecryptString("legacy plain text")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy plain text", got, "legacy cipher should return not encrypted strings as is")
+	got, err = c.Legacy().DecryptString(c.EncryptString("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got, "legacy cipher should decrypt encrypted strings")
+
+	other, _ := NewKey()
+	oc, _ := New(other)
+	_, err = oc.DecryptString(c.EncryptString("secret"))
+	assert.ErrorIs(t, err, ErrDecrypt, "other key should not decrypt")
+}
+
+func TestCipher_Stream(t *testing.T) {
+	key, _ := NewKey()
+	c, err := New(key)
+	require.NoError(t, err)
+
+	sizes := []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 100}
+	for _, size := range sizes {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+
+		r, err := c.NewReader(bytes.NewReader(plain))
+		require.NoError(t, err)
+		enc, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, CipherSize(int64(size)), int64(len(enc)), "cipher size mismatch for %d", size)
+		assert.Equal(t, int64(size), PlainSize(int64(len(enc))), "plain size mismatch for %d", size)
+
+		var out bytes.Buffer
+		w := c.NewWriter(&out)
+		// write in odd pieces to check buffering
+		for i := 0; i < len(enc); i += 1000 {
+			end := i + 1000
+			if end > len(enc) {
+				end = len(enc)
+			}
+			_, err := w.Write(enc[i:end])
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close(), "close should succeed for size %d", size)
+		assert.Equal(t, plain, append([]byte{}, out.Bytes()...), "decrypted data mismatch for size %d", size)
+
+		if len(enc) > streamHeader+tagSize+chunkSize {
+			w := c.NewWriter(io.Discard)
+			_, _ = w.Write(enc[:streamHeader+chunkSize+tagSize])
+			assert.Error(t, w.Close(), "truncated stream should fail for size %d", size)
+		}
+	}
+}
+
+func TestSealTo(t *testing.T) {
+	public, private, err := NewKeyPair()
+	require.NoError(t, err)
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	sealed, err := SealTo(public, key)
+	require.NoError(t, err)
+	got, err := OpenSealed(private, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, key, got, "opened key should match")
+
+	_, other, err := NewKeyPair()
+	require.NoError(t, err)
+	_, err = OpenSealed(other, sealed)
+	assert.ErrorIs(t, err, ErrDecrypt, "other private key should fail")
+	_, err = OpenSealed(private, sealed[:10])
+	assert.ErrorIs(t, err, ErrFormat, "truncated data should fail")
+	_, err = SealTo([]byte("short"), key)
+	assert.ErrorIs(t, err, ErrKey)
+}
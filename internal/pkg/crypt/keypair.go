@@ -0,0 +1,68 @@
+package crypt
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// publicKeySize is a size of X25519 public key
+const publicKeySize = 32
+
+// NewKeyPair generates X25519 key pair, used to share vault keys between users
+func NewKeyPair() (public, private []byte, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	return key.PublicKey().Bytes(), key.Bytes(), nil
+}
+
+// SealTo encrypts data, so it may be opened only with private key of the recipient.
+// Ephemeral public key is prepended to result.
+func SealTo(public []byte, plain []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(public)
+	if err != nil {
+		return nil, ErrKey
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	c, err := sharedCipher(ephemeral, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return append(ephemeral.PublicKey().Bytes(), c.Seal(plain)...), nil
+}
+
+// OpenSealed decrypts data, encrypted with SealTo
+func OpenSealed(private []byte, sealed []byte) ([]byte, error) {
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, ErrKey
+	}
+	if len(sealed) < publicKeySize {
+		return nil, ErrFormat
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(sealed[:publicKeySize])
+	if err != nil {
+		return nil, ErrFormat
+	}
+	c, err := sharedCipher(key, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	return c.Open(sealed[publicKeySize:])
+}
+
+// sharedCipher returns cipher with the key, derived from shared secret
+func sharedCipher(private *ecdh.PrivateKey, public *ecdh.PublicKey) (*Cipher, error) {
+	secret, err := private.ECDH(public)
+	if err != nil {
+		return nil, ErrKey
+	}
+	key := sha256.Sum256(secret)
+	return New(key[:])
+}
@@ -0,0 +1,79 @@
+package crypt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	masterWrapVersion byte = 2
+	saltContext            = "yap-pwkeeper/" // login is appended to make deterministic per-user salt
+)
+
+// MasterKeys are values derived from user master password. Auth is sent to server
+// as login password, encryption key wraps vault key and never leaves the client,
+// so server, which sees auth value, can't unwrap vault key with it.
+type MasterKeys struct {
+	Auth string // server login password
+	enc  []byte // key encryption key
+}
+
+// DeriveMasterKeys derives auth value and encryption key from login and master password:
+// argon2id master key is expanded with HKDF into independent "auth" and "enc" values.
+// Salt is derived from login, since it should be known before login.
+func DeriveMasterKeys(login, password string) MasterKeys {
+	salt := sha256.Sum256([]byte(saltContext + login))
+	master := deriveKey(password, salt[:saltSize])
+	return MasterKeys{
+		Auth: hex.EncodeToString(expand(master, "auth")),
+		enc:  expand(master, "enc"),
+	}
+}
+
+// expand derives KeySize bytes from master key with HKDF-SHA256 and label as info
+func expand(master []byte, label string) []byte {
+	key := make([]byte, KeySize)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, master, nil, []byte(label)), key)
+	return key
+}
+
+// WrapKey encrypts vault key with master encryption key
+func (m MasterKeys) WrapKey(key []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, ErrKey
+	}
+	c, err := New(m.enc)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{masterWrapVersion}, c.Seal(key)...), nil
+}
+
+// UnwrapKey decrypts vault key, wrapped with MasterKeys.WrapKey.
+// Returns ErrDecrypt if password does not match, ErrFormat for keys wrapped with password.
+func (m MasterKeys) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 1 || wrapped[0] != masterWrapVersion {
+		return nil, ErrFormat
+	}
+	c, err := New(m.enc)
+	if err != nil {
+		return nil, err
+	}
+	key, err := c.Open(wrapped[1:])
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != KeySize {
+		return nil, ErrKey
+	}
+	return key, nil
+}
+
+// IsPasswordWrapped checks whether key was wrapped with password by WrapKey,
+// such keys of accounts created before MasterKeys should be re-wrapped
+func IsPasswordWrapped(wrapped []byte) bool {
+	return len(wrapped) > 0 && wrapped[0] == wrapVersion
+}
@@ -0,0 +1,180 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// Stream format: header (magic + random nonce prefix), followed by chunks.
+// Each chunk is chunkSize bytes of plaintext sealed separately. Chunk nonce
+// is nonce prefix, chunk counter and last chunk flag, so chunks can't be
+// reordered, removed or truncated without decryption failure.
+// The last chunk is always shorter than chunkSize (it may be empty).
+const (
+	chunkSize    = 64 << 10
+	prefixSize   = 7
+	streamMagic  = "PWK1"
+	streamHeader = len(streamMagic) + prefixSize
+	tagSize      = 16
+)
+
+// CipherSize returns size of encrypted stream for plaintext of size n
+func CipherSize(n int64) int64 {
+	chunks := n/chunkSize + 1
+	return int64(streamHeader) + n + chunks*tagSize
+}
+
+// PlainSize returns size of plaintext for encrypted stream of size n.
+// It is reverse of CipherSize. Returns 0 for invalid size.
+func PlainSize(n int64) int64 {
+	n -= int64(streamHeader)
+	if n < tagSize {
+		return 0
+	}
+	chunks := (n-tagSize)/(chunkSize+tagSize) + 1
+	return n - chunks*tagSize
+}
+
+// chunkNonce makes nonce for chunk number i
+func chunkNonce(prefix []byte, i uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], i)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// encryptReader encrypts underlying reader on the fly
+type encryptReader struct {
+	c      *Cipher
+	r      io.Reader
+	prefix []byte
+	buf    bytes.Buffer // encrypted data ready to be read
+	next   []byte       // read ahead plaintext
+	chunk  uint32
+	done   bool
+	err    error
+}
+
+// NewReader returns reader, which produces encrypted stream of r data
+func (c *Cipher) NewReader(r io.Reader) (io.Reader, error) {
+	er := &encryptReader{
+		c:      c,
+		r:      r,
+		prefix: make([]byte, prefixSize),
+	}
+	if _, err := rand.Read(er.prefix); err != nil {
+		return nil, err
+	}
+	er.buf.WriteString(streamMagic)
+	er.buf.Write(er.prefix)
+	return er, nil
+}
+
+func (er *encryptReader) Read(p []byte) (int, error) {
+	for er.buf.Len() == 0 {
+		if er.err != nil {
+			return 0, er.err
+		}
+		if er.done {
+			return 0, io.EOF
+		}
+		er.sealNext()
+	}
+	return er.buf.Read(p)
+}
+
+// sealNext reads next plaintext chunk and encrypts it
+func (er *encryptReader) sealNext() {
+	// one extra byte is read ahead to find out whether chunk is the last one
+	plain := make([]byte, chunkSize+1)
+	n := copy(plain, er.next)
+	m, err := io.ReadFull(er.r, plain[n:])
+	n += m
+	last := false
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		last = n < chunkSize+1
+	case err != nil:
+		er.err = err
+		return
+	}
+	if last {
+		if n == chunkSize {
+			// exactly full chunk, empty last chunk follows
+			er.buf.Write(er.c.aead.Seal(nil, chunkNonce(er.prefix, er.chunk, false), plain[:n], nil))
+			er.chunk++
+			n = 0
+		}
+		er.buf.Write(er.c.aead.Seal(nil, chunkNonce(er.prefix, er.chunk, true), plain[:n], nil))
+		er.done = true
+		return
+	}
+	er.next = plain[chunkSize:]
+	er.buf.Write(er.c.aead.Seal(nil, chunkNonce(er.prefix, er.chunk, false), plain[:chunkSize], nil))
+	er.chunk++
+}
+
+// decryptWriter decrypts stream and writes plaintext to underlying writer
+type decryptWriter struct {
+	c      *Cipher
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+	chunk  uint32
+}
+
+// NewWriter returns writer, which decrypts stream, encrypted by NewReader and
+// writes plaintext to w. Close must be called to finish decryption: it verifies
+// that stream was not truncated.
+func (c *Cipher) NewWriter(w io.Writer) io.WriteCloser {
+	return &decryptWriter{c: c, w: w}
+}
+
+func (dw *decryptWriter) Write(p []byte) (int, error) {
+	dw.buf = append(dw.buf, p...)
+	if dw.prefix == nil {
+		if len(dw.buf) < streamHeader {
+			return len(p), nil
+		}
+		if string(dw.buf[:len(streamMagic)]) != streamMagic {
+			return 0, ErrFormat
+		}
+		dw.prefix = append([]byte{}, dw.buf[len(streamMagic):streamHeader]...)
+		dw.buf = dw.buf[streamHeader:]
+	}
+	// chunk is not the last one only if there is more data after it
+	for len(dw.buf) > chunkSize+tagSize {
+		if err := dw.openChunk(dw.buf[:chunkSize+tagSize], false); err != nil {
+			return 0, err
+		}
+		dw.buf = dw.buf[chunkSize+tagSize:]
+	}
+	return len(p), nil
+}
+
+// Close decrypts the last chunk
+func (dw *decryptWriter) Close() error {
+	if dw.prefix == nil || len(dw.buf) < tagSize {
+		return ErrFormat
+	}
+	if len(dw.buf) == chunkSize+tagSize {
+		// exactly full chunk without last empty one: stream truncated
+		return ErrDecrypt
+	}
+	return dw.openChunk(dw.buf, true)
+}
+
+func (dw *decryptWriter) openChunk(data []byte, last bool) error {
+	plain, err := dw.c.aead.Open(nil, chunkNonce(dw.prefix, dw.chunk, last), data, nil)
+	if err != nil {
+		return ErrDecrypt
+	}
+	dw.chunk++
+	_, err = dw.w.Write(plain)
+	return err
+}
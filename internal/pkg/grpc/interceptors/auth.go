@@ -43,6 +43,7 @@ func AuthStreamServer(valid func(context.Context, string) bool, applyTo ...strin
 			return status.Error(codes.Unauthenticated, "invalid token")
 		}
 		ctx = logger.WithUserId(ctx, jwtToken.GetTokenSubject(token))
+		ctx = logger.WithSessionId(ctx, jwtToken.GetTokenSession(token))
 		return handler(srv, &serverStreamWrapped{stream, ctx})
 	}
 }
@@ -72,6 +73,7 @@ func AuthUnaryServer(valid func(context.Context, string) bool, applyTo ...string
 			return nil, status.Error(codes.Unauthenticated, "invalid token")
 		}
 		ctx = logger.WithUserId(ctx, jwtToken.GetTokenSubject(token))
+		ctx = logger.WithSessionId(ctx, jwtToken.GetTokenSession(token))
 		return handler(ctx, req)
 	}
 }
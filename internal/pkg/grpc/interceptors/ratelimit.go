@@ -0,0 +1,76 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// Limiter throttles attempts of clients, see ratelimit.Limiter
+type Limiter interface {
+	Allow(keys ...string) time.Duration
+	Fail(keys ...string)
+	Success(keys ...string)
+}
+
+// failureCodes are responses, which are counted as failed attempts
+var failureCodes = map[codes.Code]bool{
+	codes.Unauthenticated:  true,
+	codes.PermissionDenied: true,
+	codes.AlreadyExists:    true,
+}
+
+// RateLimitUnaryServer throttles requests per peer address and per login, taken from
+// request. Failed attempts block further requests with exponential backoff, throttled
+// requests are rejected with ResourceExhausted. ApplyTo allows to set up gRPC services
+// and methods, where interceptor should be run.
+func RateLimitUnaryServer(limiter Limiter, applyTo ...string) func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	apply := make(map[string]bool)
+	for _, a := range applyTo {
+		apply[a] = true
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if !applicable(apply, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		keys := limitKeys(ctx, req)
+		if wait := limiter.Allow(keys...); wait > 0 {
+			logger.Log().WithCtxRequestId(ctx).With("keys", keys).Warn("request throttled")
+			return nil, status.Error(codes.ResourceExhausted,
+				fmt.Sprintf("too many attempts, retry in %s", wait.Round(time.Second)))
+		}
+		resp, err = handler(ctx, req)
+		switch {
+		case err == nil:
+			// only login is trusted after success, address may be shared by an attacker
+			limiter.Success(keys[1:]...)
+		case failureCodes[status.Code(err)]:
+			limiter.Fail(keys...)
+		}
+		return resp, err
+	}
+}
+
+// limitKeys returns peer address key, followed by login key if request has login
+func limitKeys(ctx context.Context, req interface{}) []string {
+	addr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+	}
+	keys := []string{"addr:" + addr}
+	if r, ok := req.(interface{ GetLogin() string }); ok && r.GetLogin() != "" {
+		keys = append(keys, "login:"+r.GetLogin())
+	}
+	return keys
+}
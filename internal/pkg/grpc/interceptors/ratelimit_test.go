@@ -0,0 +1,48 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"yap-pwkeeper/internal/pkg/grpc/proto"
+	"yap-pwkeeper/internal/pkg/ratelimit"
+)
+
+func TestRateLimitUnaryServer(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Policy{Free: 1, Base: time.Hour, Max: time.Hour})
+	interceptor := RateLimitUnaryServer(limiter, "Auth/Login")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	login := &grpc.UnaryServerInfo{FullMethod: "/proto.Auth/Login"}
+	badAuth := func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "bad auth")
+	}
+	ok := func(context.Context, interface{}) (interface{}, error) { return &proto.Token{}, nil }
+
+	req := &proto.LoginCredentials{Login: "user"}
+	for i := 0; i < 2; i++ {
+		_, err := interceptor(ctx, req, login, badAuth)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	_, err := interceptor(ctx, req, login, ok)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "login is blocked after failures")
+
+	other := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 5000}})
+	_, err = interceptor(other, req, login, ok)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "login is blocked from any address")
+	_, err = interceptor(ctx, &proto.LoginCredentials{Login: "other"}, login, ok)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "address is blocked for any login")
+	_, err = interceptor(other, &proto.LoginCredentials{Login: "other"}, login, ok)
+	assert.NoError(t, err)
+
+	_, err = interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/proto.Auth/Refresh"}, ok)
+	assert.NoError(t, err, "other methods are not limited")
+}
@@ -19,9 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Auth_Register_FullMethodName = "/grpcapi.Auth/Register"
-	Auth_Login_FullMethodName    = "/grpcapi.Auth/Login"
-	Auth_Refresh_FullMethodName  = "/grpcapi.Auth/Refresh"
+	Auth_Register_FullMethodName          = "/grpcapi.Auth/Register"
+	Auth_Login_FullMethodName             = "/grpcapi.Auth/Login"
+	Auth_Refresh_FullMethodName           = "/grpcapi.Auth/Refresh"
+	Auth_GetVaultKey_FullMethodName       = "/grpcapi.Auth/GetVaultKey"
+	Auth_SetVaultKey_FullMethodName       = "/grpcapi.Auth/SetVaultKey"
+	Auth_GetKeyPair_FullMethodName        = "/grpcapi.Auth/GetKeyPair"
+	Auth_SetKeyPair_FullMethodName        = "/grpcapi.Auth/SetKeyPair"
+	Auth_GetPublicKey_FullMethodName      = "/grpcapi.Auth/GetPublicKey"
+	Auth_EnrollTotp_FullMethodName        = "/grpcapi.Auth/EnrollTotp"
+	Auth_ConfirmTotp_FullMethodName       = "/grpcapi.Auth/ConfirmTotp"
+	Auth_DisableTotp_FullMethodName       = "/grpcapi.Auth/DisableTotp"
+	Auth_Logout_FullMethodName            = "/grpcapi.Auth/Logout"
+	Auth_ListSessions_FullMethodName      = "/grpcapi.Auth/ListSessions"
+	Auth_RevokeSession_FullMethodName     = "/grpcapi.Auth/RevokeSession"
+	Auth_ChangePassword_FullMethodName    = "/grpcapi.Auth/ChangePassword"
+	Auth_DeleteAccount_FullMethodName     = "/grpcapi.Auth/DeleteAccount"
+	Auth_GetPasswordPolicy_FullMethodName = "/grpcapi.Auth/GetPasswordPolicy"
+	Auth_GetBreachedRange_FullMethodName  = "/grpcapi.Auth/GetBreachedRange"
+	Auth_GetMigrated_FullMethodName       = "/grpcapi.Auth/GetMigrated"
+	Auth_SetMigrated_FullMethodName       = "/grpcapi.Auth/SetMigrated"
 )
 
 // AuthClient is the client API for Auth service.
@@ -31,6 +48,23 @@ type AuthClient interface {
 	Register(ctx context.Context, in *LoginCredentials, opts ...grpc.CallOption) (*Empty, error)
 	Login(ctx context.Context, in *LoginCredentials, opts ...grpc.CallOption) (*Token, error)
 	Refresh(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Token, error)
+	GetVaultKey(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VaultKey, error)
+	SetVaultKey(ctx context.Context, in *VaultKey, opts ...grpc.CallOption) (*Empty, error)
+	GetKeyPair(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeyPair, error)
+	SetKeyPair(ctx context.Context, in *KeyPair, opts ...grpc.CallOption) (*Empty, error)
+	GetPublicKey(ctx context.Context, in *UserLogin, opts ...grpc.CallOption) (*KeyPair, error)
+	EnrollTotp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TotpEnrollment, error)
+	ConfirmTotp(ctx context.Context, in *TotpCode, opts ...grpc.CallOption) (*Empty, error)
+	DisableTotp(ctx context.Context, in *TotpCode, opts ...grpc.CallOption) (*Empty, error)
+	Logout(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	ListSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Sessions, error)
+	RevokeSession(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Empty, error)
+	ChangePassword(ctx context.Context, in *PasswordChange, opts ...grpc.CallOption) (*Empty, error)
+	DeleteAccount(ctx context.Context, in *Password, opts ...grpc.CallOption) (*Empty, error)
+	GetPasswordPolicy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PasswordPolicy, error)
+	GetBreachedRange(ctx context.Context, in *HashPrefix, opts ...grpc.CallOption) (*HashSuffixes, error)
+	GetMigrated(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Migrated, error)
+	SetMigrated(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type authClient struct {
@@ -68,6 +102,159 @@ func (c *authClient) Refresh(ctx context.Context, in *Token, opts ...grpc.CallOp
 	return out, nil
 }
 
+func (c *authClient) GetVaultKey(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VaultKey, error) {
+	out := new(VaultKey)
+	err := c.cc.Invoke(ctx, Auth_GetVaultKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) SetVaultKey(ctx context.Context, in *VaultKey, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_SetVaultKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetKeyPair(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeyPair, error) {
+	out := new(KeyPair)
+	err := c.cc.Invoke(ctx, Auth_GetKeyPair_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) SetKeyPair(ctx context.Context, in *KeyPair, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_SetKeyPair_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetPublicKey(ctx context.Context, in *UserLogin, opts ...grpc.CallOption) (*KeyPair, error) {
+	out := new(KeyPair)
+	err := c.cc.Invoke(ctx, Auth_GetPublicKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) EnrollTotp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TotpEnrollment, error) {
+	out := new(TotpEnrollment)
+	err := c.cc.Invoke(ctx, Auth_EnrollTotp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) ConfirmTotp(ctx context.Context, in *TotpCode, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_ConfirmTotp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) DisableTotp(ctx context.Context, in *TotpCode, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_DisableTotp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) Logout(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_Logout_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) ListSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Sessions, error) {
+	out := new(Sessions)
+	err := c.cc.Invoke(ctx, Auth_ListSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) RevokeSession(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_RevokeSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) ChangePassword(ctx context.Context, in *PasswordChange, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_ChangePassword_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) DeleteAccount(ctx context.Context, in *Password, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_DeleteAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetPasswordPolicy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PasswordPolicy, error) {
+	out := new(PasswordPolicy)
+	err := c.cc.Invoke(ctx, Auth_GetPasswordPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetBreachedRange(ctx context.Context, in *HashPrefix, opts ...grpc.CallOption) (*HashSuffixes, error) {
+	out := new(HashSuffixes)
+	err := c.cc.Invoke(ctx, Auth_GetBreachedRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetMigrated(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Migrated, error) {
+	out := new(Migrated)
+	err := c.cc.Invoke(ctx, Auth_GetMigrated_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) SetMigrated(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Auth_SetMigrated_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility
@@ -75,6 +262,23 @@ type AuthServer interface {
 	Register(context.Context, *LoginCredentials) (*Empty, error)
 	Login(context.Context, *LoginCredentials) (*Token, error)
 	Refresh(context.Context, *Token) (*Token, error)
+	GetVaultKey(context.Context, *Empty) (*VaultKey, error)
+	SetVaultKey(context.Context, *VaultKey) (*Empty, error)
+	GetKeyPair(context.Context, *Empty) (*KeyPair, error)
+	SetKeyPair(context.Context, *KeyPair) (*Empty, error)
+	GetPublicKey(context.Context, *UserLogin) (*KeyPair, error)
+	EnrollTotp(context.Context, *Empty) (*TotpEnrollment, error)
+	ConfirmTotp(context.Context, *TotpCode) (*Empty, error)
+	DisableTotp(context.Context, *TotpCode) (*Empty, error)
+	Logout(context.Context, *Empty) (*Empty, error)
+	ListSessions(context.Context, *Empty) (*Sessions, error)
+	RevokeSession(context.Context, *Session) (*Empty, error)
+	ChangePassword(context.Context, *PasswordChange) (*Empty, error)
+	DeleteAccount(context.Context, *Password) (*Empty, error)
+	GetPasswordPolicy(context.Context, *Empty) (*PasswordPolicy, error)
+	GetBreachedRange(context.Context, *HashPrefix) (*HashSuffixes, error)
+	GetMigrated(context.Context, *Empty) (*Migrated, error)
+	SetMigrated(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -91,6 +295,57 @@ func (UnimplementedAuthServer) Login(context.Context, *LoginCredentials) (*Token
 func (UnimplementedAuthServer) Refresh(context.Context, *Token) (*Token, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
 }
+func (UnimplementedAuthServer) GetVaultKey(context.Context, *Empty) (*VaultKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultKey not implemented")
+}
+func (UnimplementedAuthServer) SetVaultKey(context.Context, *VaultKey) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultKey not implemented")
+}
+func (UnimplementedAuthServer) GetKeyPair(context.Context, *Empty) (*KeyPair, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyPair not implemented")
+}
+func (UnimplementedAuthServer) SetKeyPair(context.Context, *KeyPair) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyPair not implemented")
+}
+func (UnimplementedAuthServer) GetPublicKey(context.Context, *UserLogin) (*KeyPair, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedAuthServer) EnrollTotp(context.Context, *Empty) (*TotpEnrollment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTotp not implemented")
+}
+func (UnimplementedAuthServer) ConfirmTotp(context.Context, *TotpCode) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTotp not implemented")
+}
+func (UnimplementedAuthServer) DisableTotp(context.Context, *TotpCode) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTotp not implemented")
+}
+func (UnimplementedAuthServer) Logout(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAuthServer) ListSessions(context.Context, *Empty) (*Sessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAuthServer) RevokeSession(context.Context, *Session) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthServer) ChangePassword(context.Context, *PasswordChange) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedAuthServer) DeleteAccount(context.Context, *Password) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedAuthServer) GetPasswordPolicy(context.Context, *Empty) (*PasswordPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasswordPolicy not implemented")
+}
+func (UnimplementedAuthServer) GetBreachedRange(context.Context, *HashPrefix) (*HashSuffixes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBreachedRange not implemented")
+}
+func (UnimplementedAuthServer) GetMigrated(context.Context, *Empty) (*Migrated, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMigrated not implemented")
+}
+func (UnimplementedAuthServer) SetMigrated(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMigrated not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 
 // UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
@@ -158,651 +413,1697 @@ func _Auth_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
-// Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Auth_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "grpcapi.Auth",
-	HandlerType: (*AuthServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _Auth_Register_Handler,
-		},
-		{
-			MethodName: "Login",
-			Handler:    _Auth_Login_Handler,
-		},
-		{
-			MethodName: "Refresh",
-			Handler:    _Auth_Refresh_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "grpc.proto",
-}
-
-const (
-	Docs_GetUpdateStream_FullMethodName  = "/grpcapi.Docs/GetUpdateStream"
-	Docs_AddNote_FullMethodName          = "/grpcapi.Docs/AddNote"
-	Docs_DeleteNote_FullMethodName       = "/grpcapi.Docs/DeleteNote"
-	Docs_UpdateNote_FullMethodName       = "/grpcapi.Docs/UpdateNote"
-	Docs_AddCredential_FullMethodName    = "/grpcapi.Docs/AddCredential"
-	Docs_DeleteCredential_FullMethodName = "/grpcapi.Docs/DeleteCredential"
-	Docs_UpdateCredential_FullMethodName = "/grpcapi.Docs/UpdateCredential"
-	Docs_AddCard_FullMethodName          = "/grpcapi.Docs/AddCard"
-	Docs_DeleteCard_FullMethodName       = "/grpcapi.Docs/DeleteCard"
-	Docs_UpdateCard_FullMethodName       = "/grpcapi.Docs/UpdateCard"
-	Docs_AddFile_FullMethodName          = "/grpcapi.Docs/AddFile"
-	Docs_DeleteFile_FullMethodName       = "/grpcapi.Docs/DeleteFile"
-	Docs_UpdateFile_FullMethodName       = "/grpcapi.Docs/UpdateFile"
-	Docs_GetFile_FullMethodName          = "/grpcapi.Docs/GetFileInfo"
-)
-
-// DocsClient is the client API for Docs service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type DocsClient interface {
-	GetUpdateStream(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Docs_GetUpdateStreamClient, error)
-	AddNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error)
-	DeleteNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error)
-	UpdateNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error)
-	AddCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error)
-	DeleteCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error)
-	UpdateCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error)
-	AddCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error)
-	DeleteCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error)
-	UpdateCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error)
-	AddFile(ctx context.Context, opts ...grpc.CallOption) (Docs_AddFileClient, error)
-	DeleteFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*Empty, error)
-	UpdateFile(ctx context.Context, opts ...grpc.CallOption) (Docs_UpdateFileClient, error)
-	GetFile(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (Docs_GetFileClient, error)
-}
-
-type docsClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewDocsClient(cc grpc.ClientConnInterface) DocsClient {
-	return &docsClient{cc}
-}
-
-func (c *docsClient) GetUpdateStream(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Docs_GetUpdateStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[0], Docs_GetUpdateStream_FullMethodName, opts...)
-	if err != nil {
+func _Auth_GetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &docsGetUpdateStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(AuthServer).GetVaultKey(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_GetVaultKey_FullMethodName,
 	}
-	return x, nil
-}
-
-type Docs_GetUpdateStreamClient interface {
-	Recv() (*UpdateResponse, error)
-	grpc.ClientStream
-}
-
-type docsGetUpdateStreamClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetVaultKey(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *docsGetUpdateStreamClient) Recv() (*UpdateResponse, error) {
-	m := new(UpdateResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _Auth_SetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultKey)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(AuthServer).SetVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_SetVaultKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SetVaultKey(ctx, req.(*VaultKey))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) AddNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_AddNote_FullMethodName, in, out, opts...)
-	if err != nil {
+func _Auth_GetKeyPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthServer).GetKeyPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_GetKeyPair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetKeyPair(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) DeleteNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_DeleteNote_FullMethodName, in, out, opts...)
-	if err != nil {
+func _Auth_SetKeyPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyPair)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AuthServer).SetKeyPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_SetKeyPair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SetKeyPair(ctx, req.(*KeyPair))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) UpdateNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_UpdateNote_FullMethodName, in, out, opts...)
-	if err != nil {
+func _Auth_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLogin)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
+	if interceptor == nil {
+		return srv.(AuthServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetPublicKey(ctx, req.(*UserLogin))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_EnrollTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).EnrollTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_EnrollTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).EnrollTotp(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_ConfirmTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotpCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).ConfirmTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_ConfirmTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).ConfirmTotp(ctx, req.(*TotpCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_DisableTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotpCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).DisableTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_DisableTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).DisableTotp(ctx, req.(*TotpCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).Logout(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).ListSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Session)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).RevokeSession(ctx, req.(*Session))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).ChangePassword(ctx, req.(*PasswordChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Password)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).DeleteAccount(ctx, req.(*Password))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetPasswordPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetPasswordPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_GetPasswordPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetPasswordPolicy(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetBreachedRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashPrefix)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetBreachedRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_GetBreachedRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetBreachedRange(ctx, req.(*HashPrefix))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetMigrated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetMigrated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_GetMigrated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetMigrated(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_SetMigrated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).SetMigrated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_SetMigrated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SetMigrated(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Auth_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcapi.Auth",
+	HandlerType: (*AuthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _Auth_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _Auth_Login_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _Auth_Refresh_Handler,
+		},
+		{
+			MethodName: "GetVaultKey",
+			Handler:    _Auth_GetVaultKey_Handler,
+		},
+		{
+			MethodName: "SetVaultKey",
+			Handler:    _Auth_SetVaultKey_Handler,
+		},
+		{
+			MethodName: "GetKeyPair",
+			Handler:    _Auth_GetKeyPair_Handler,
+		},
+		{
+			MethodName: "SetKeyPair",
+			Handler:    _Auth_SetKeyPair_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _Auth_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "EnrollTotp",
+			Handler:    _Auth_EnrollTotp_Handler,
+		},
+		{
+			MethodName: "ConfirmTotp",
+			Handler:    _Auth_ConfirmTotp_Handler,
+		},
+		{
+			MethodName: "DisableTotp",
+			Handler:    _Auth_DisableTotp_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Auth_Logout_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Auth_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _Auth_RevokeSession_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _Auth_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _Auth_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "GetPasswordPolicy",
+			Handler:    _Auth_GetPasswordPolicy_Handler,
+		},
+		{
+			MethodName: "GetBreachedRange",
+			Handler:    _Auth_GetBreachedRange_Handler,
+		},
+		{
+			MethodName: "GetMigrated",
+			Handler:    _Auth_GetMigrated_Handler,
+		},
+		{
+			MethodName: "SetMigrated",
+			Handler:    _Auth_SetMigrated_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "grpc.proto",
+}
+
+const (
+	Docs_GetUpdateStream_FullMethodName   = "/grpcapi.Docs/GetUpdateStream"
+	Docs_Subscribe_FullMethodName         = "/grpcapi.Docs/Subscribe"
+	Docs_AddNote_FullMethodName           = "/grpcapi.Docs/AddNote"
+	Docs_DeleteNote_FullMethodName        = "/grpcapi.Docs/DeleteNote"
+	Docs_UpdateNote_FullMethodName        = "/grpcapi.Docs/UpdateNote"
+	Docs_AddCredential_FullMethodName     = "/grpcapi.Docs/AddCredential"
+	Docs_DeleteCredential_FullMethodName  = "/grpcapi.Docs/DeleteCredential"
+	Docs_UpdateCredential_FullMethodName  = "/grpcapi.Docs/UpdateCredential"
+	Docs_AddCard_FullMethodName           = "/grpcapi.Docs/AddCard"
+	Docs_DeleteCard_FullMethodName        = "/grpcapi.Docs/DeleteCard"
+	Docs_UpdateCard_FullMethodName        = "/grpcapi.Docs/UpdateCard"
+	Docs_AddFile_FullMethodName           = "/grpcapi.Docs/AddFile"
+	Docs_DeleteFile_FullMethodName        = "/grpcapi.Docs/DeleteFile"
+	Docs_UpdateFile_FullMethodName        = "/grpcapi.Docs/UpdateFile"
+	Docs_UpdateFileInfo_FullMethodName    = "/grpcapi.Docs/UpdateFileInfo"
+	Docs_GetFile_FullMethodName           = "/grpcapi.Docs/GetFile"
+	Docs_CreateUpload_FullMethodName      = "/grpcapi.Docs/CreateUpload"
+	Docs_GetUpload_FullMethodName         = "/grpcapi.Docs/GetUpload"
+	Docs_UploadData_FullMethodName        = "/grpcapi.Docs/UploadData"
+	Docs_GetHistory_FullMethodName        = "/grpcapi.Docs/GetHistory"
+	Docs_RestoreRevision_FullMethodName   = "/grpcapi.Docs/RestoreRevision"
+	Docs_Restore_FullMethodName           = "/grpcapi.Docs/Restore"
+	Docs_ExportAll_FullMethodName         = "/grpcapi.Docs/ExportAll"
+	Docs_CreateVault_FullMethodName       = "/grpcapi.Docs/CreateVault"
+	Docs_ListVaults_FullMethodName        = "/grpcapi.Docs/ListVaults"
+	Docs_RenameVault_FullMethodName       = "/grpcapi.Docs/RenameVault"
+	Docs_DeleteVault_FullMethodName       = "/grpcapi.Docs/DeleteVault"
+	Docs_AddVaultMember_FullMethodName    = "/grpcapi.Docs/AddVaultMember"
+	Docs_UpdateVaultMember_FullMethodName = "/grpcapi.Docs/UpdateVaultMember"
+	Docs_RemoveVaultMember_FullMethodName = "/grpcapi.Docs/RemoveVaultMember"
+	Docs_CreateShare_FullMethodName       = "/grpcapi.Docs/CreateShare"
+)
+
+// DocsClient is the client API for Docs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DocsClient interface {
+	GetUpdateStream(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Docs_GetUpdateStreamClient, error)
+	Subscribe(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Docs_SubscribeClient, error)
+	AddNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error)
+	DeleteNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error)
+	UpdateNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error)
+	AddCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error)
+	DeleteCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error)
+	UpdateCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error)
+	AddCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error)
+	DeleteCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error)
+	UpdateCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error)
+	AddFile(ctx context.Context, opts ...grpc.CallOption) (Docs_AddFileClient, error)
+	DeleteFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*Empty, error)
+	UpdateFile(ctx context.Context, opts ...grpc.CallOption) (Docs_UpdateFileClient, error)
+	UpdateFileInfo(ctx context.Context, in *File, opts ...grpc.CallOption) (*Empty, error)
+	GetFile(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (Docs_GetFileClient, error)
+	CreateUpload(ctx context.Context, in *File, opts ...grpc.CallOption) (*Upload, error)
+	GetUpload(ctx context.Context, in *Upload, opts ...grpc.CallOption) (*Upload, error)
+	UploadData(ctx context.Context, opts ...grpc.CallOption) (Docs_UploadDataClient, error)
+	GetHistory(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (Docs_GetHistoryClient, error)
+	RestoreRevision(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (*Empty, error)
+	Restore(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (*Empty, error)
+	ExportAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Docs_ExportAllClient, error)
+	CreateVault(ctx context.Context, in *NewVault, opts ...grpc.CallOption) (*Vault, error)
+	ListVaults(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Vaults, error)
+	RenameVault(ctx context.Context, in *Vault, opts ...grpc.CallOption) (*Empty, error)
+	DeleteVault(ctx context.Context, in *Vault, opts ...grpc.CallOption) (*Empty, error)
+	AddVaultMember(ctx context.Context, in *VaultMember, opts ...grpc.CallOption) (*Empty, error)
+	UpdateVaultMember(ctx context.Context, in *VaultMember, opts ...grpc.CallOption) (*Empty, error)
+	RemoveVaultMember(ctx context.Context, in *VaultMember, opts ...grpc.CallOption) (*Empty, error)
+	CreateShare(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareInfo, error)
+}
+
+type docsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDocsClient(cc grpc.ClientConnInterface) DocsClient {
+	return &docsClient{cc}
+}
+
+func (c *docsClient) GetUpdateStream(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Docs_GetUpdateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[0], Docs_GetUpdateStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsGetUpdateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Docs_GetUpdateStreamClient interface {
+	Recv() (*UpdateResponse, error)
+	grpc.ClientStream
+}
+
+type docsGetUpdateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsGetUpdateStreamClient) Recv() (*UpdateResponse, error) {
+	m := new(UpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) Subscribe(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (Docs_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[1], Docs_Subscribe_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Docs_SubscribeClient interface {
+	Recv() (*UpdateResponse, error)
+	grpc.ClientStream
+}
+
+type docsSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsSubscribeClient) Recv() (*UpdateResponse, error) {
+	m := new(UpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) AddNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_AddNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) DeleteNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_DeleteNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) UpdateNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_UpdateNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) AddCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_AddCredential_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) DeleteCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_DeleteCredential_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) UpdateCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_UpdateCredential_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) AddCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_AddCard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) DeleteCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_DeleteCard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) UpdateCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_UpdateCard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) AddFile(ctx context.Context, opts ...grpc.CallOption) (Docs_AddFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[2], Docs_AddFile_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsAddFileClient{stream}
+	return x, nil
+}
+
+type Docs_AddFileClient interface {
+	Send(*FileStream) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type docsAddFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsAddFileClient) Send(m *FileStream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *docsAddFileClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) DeleteFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_DeleteFile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) UpdateFile(ctx context.Context, opts ...grpc.CallOption) (Docs_UpdateFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[3], Docs_UpdateFile_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsUpdateFileClient{stream}
+	return x, nil
+}
+
+type Docs_UpdateFileClient interface {
+	Send(*FileStream) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type docsUpdateFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsUpdateFileClient) Send(m *FileStream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *docsUpdateFileClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) UpdateFileInfo(ctx context.Context, in *File, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_UpdateFileInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetFile(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (Docs_GetFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[4], Docs_GetFile_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsGetFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Docs_GetFileClient interface {
+	Recv() (*FileStream, error)
+	grpc.ClientStream
+}
+
+type docsGetFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsGetFileClient) Recv() (*FileStream, error) {
+	m := new(FileStream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) CreateUpload(ctx context.Context, in *File, opts ...grpc.CallOption) (*Upload, error) {
+	out := new(Upload)
+	err := c.cc.Invoke(ctx, Docs_CreateUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetUpload(ctx context.Context, in *Upload, opts ...grpc.CallOption) (*Upload, error) {
+	out := new(Upload)
+	err := c.cc.Invoke(ctx, Docs_GetUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) UploadData(ctx context.Context, opts ...grpc.CallOption) (Docs_UploadDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[5], Docs_UploadData_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsUploadDataClient{stream}
+	return x, nil
+}
+
+type Docs_UploadDataClient interface {
+	Send(*UploadStream) error
+	CloseAndRecv() (*Upload, error)
+	grpc.ClientStream
+}
+
+type docsUploadDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsUploadDataClient) Send(m *UploadStream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *docsUploadDataClient) CloseAndRecv() (*Upload, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Upload)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) GetHistory(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (Docs_GetHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[6], Docs_GetHistory_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsGetHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Docs_GetHistoryClient interface {
+	Recv() (*UpdateResponse, error)
+	grpc.ClientStream
+}
+
+type docsGetHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsGetHistoryClient) Recv() (*UpdateResponse, error) {
+	m := new(UpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) RestoreRevision(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_RestoreRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) Restore(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_Restore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) ExportAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Docs_ExportAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[7], Docs_ExportAll_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &docsExportAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Docs_ExportAllClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type docsExportAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *docsExportAllClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *docsClient) CreateVault(ctx context.Context, in *NewVault, opts ...grpc.CallOption) (*Vault, error) {
+	out := new(Vault)
+	err := c.cc.Invoke(ctx, Docs_CreateVault_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) ListVaults(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Vaults, error) {
+	out := new(Vaults)
+	err := c.cc.Invoke(ctx, Docs_ListVaults_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) RenameVault(ctx context.Context, in *Vault, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Docs_RenameVault_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (c *docsClient) AddCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error) {
+func (c *docsClient) DeleteVault(ctx context.Context, in *Vault, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_AddCredential_FullMethodName, in, out, opts...)
+	err := c.cc.Invoke(ctx, Docs_DeleteVault_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *docsClient) DeleteCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error) {
+func (c *docsClient) AddVaultMember(ctx context.Context, in *VaultMember, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_DeleteCredential_FullMethodName, in, out, opts...)
+	err := c.cc.Invoke(ctx, Docs_AddVaultMember_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *docsClient) UpdateCredential(ctx context.Context, in *Credential, opts ...grpc.CallOption) (*Empty, error) {
+func (c *docsClient) UpdateVaultMember(ctx context.Context, in *VaultMember, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_UpdateCredential_FullMethodName, in, out, opts...)
+	err := c.cc.Invoke(ctx, Docs_UpdateVaultMember_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *docsClient) AddCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error) {
+func (c *docsClient) RemoveVaultMember(ctx context.Context, in *VaultMember, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_AddCard_FullMethodName, in, out, opts...)
+	err := c.cc.Invoke(ctx, Docs_RemoveVaultMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) CreateShare(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareInfo, error) {
+	out := new(ShareInfo)
+	err := c.cc.Invoke(ctx, Docs_CreateShare_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *docsClient) DeleteCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_DeleteCard_FullMethodName, in, out, opts...)
-	if err != nil {
+// DocsServer is the server API for Docs service.
+// All implementations must embed UnimplementedDocsServer
+// for forward compatibility
+type DocsServer interface {
+	GetUpdateStream(*UpdateRequest, Docs_GetUpdateStreamServer) error
+	Subscribe(*UpdateRequest, Docs_SubscribeServer) error
+	AddNote(context.Context, *Note) (*Empty, error)
+	DeleteNote(context.Context, *Note) (*Empty, error)
+	UpdateNote(context.Context, *Note) (*Empty, error)
+	AddCredential(context.Context, *Credential) (*Empty, error)
+	DeleteCredential(context.Context, *Credential) (*Empty, error)
+	UpdateCredential(context.Context, *Credential) (*Empty, error)
+	AddCard(context.Context, *Card) (*Empty, error)
+	DeleteCard(context.Context, *Card) (*Empty, error)
+	UpdateCard(context.Context, *Card) (*Empty, error)
+	AddFile(Docs_AddFileServer) error
+	DeleteFile(context.Context, *File) (*Empty, error)
+	UpdateFile(Docs_UpdateFileServer) error
+	UpdateFileInfo(context.Context, *File) (*Empty, error)
+	GetFile(*DocumentRequest, Docs_GetFileServer) error
+	CreateUpload(context.Context, *File) (*Upload, error)
+	GetUpload(context.Context, *Upload) (*Upload, error)
+	UploadData(Docs_UploadDataServer) error
+	GetHistory(*DocumentRequest, Docs_GetHistoryServer) error
+	RestoreRevision(context.Context, *DocumentRequest) (*Empty, error)
+	Restore(context.Context, *DocumentRequest) (*Empty, error)
+	ExportAll(*Empty, Docs_ExportAllServer) error
+	CreateVault(context.Context, *NewVault) (*Vault, error)
+	ListVaults(context.Context, *Empty) (*Vaults, error)
+	RenameVault(context.Context, *Vault) (*Empty, error)
+	DeleteVault(context.Context, *Vault) (*Empty, error)
+	AddVaultMember(context.Context, *VaultMember) (*Empty, error)
+	UpdateVaultMember(context.Context, *VaultMember) (*Empty, error)
+	RemoveVaultMember(context.Context, *VaultMember) (*Empty, error)
+	CreateShare(context.Context, *ShareRequest) (*ShareInfo, error)
+	mustEmbedUnimplementedDocsServer()
+}
+
+// UnimplementedDocsServer must be embedded to have forward compatible implementations.
+type UnimplementedDocsServer struct {
+}
+
+func (UnimplementedDocsServer) GetUpdateStream(*UpdateRequest, Docs_GetUpdateStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetUpdateStream not implemented")
+}
+func (UnimplementedDocsServer) Subscribe(*UpdateRequest, Docs_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedDocsServer) AddNote(context.Context, *Note) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddNote not implemented")
+}
+func (UnimplementedDocsServer) DeleteNote(context.Context, *Note) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNote not implemented")
+}
+func (UnimplementedDocsServer) UpdateNote(context.Context, *Note) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNote not implemented")
+}
+func (UnimplementedDocsServer) AddCredential(context.Context, *Credential) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCredential not implemented")
+}
+func (UnimplementedDocsServer) DeleteCredential(context.Context, *Credential) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredential not implemented")
+}
+func (UnimplementedDocsServer) UpdateCredential(context.Context, *Credential) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCredential not implemented")
+}
+func (UnimplementedDocsServer) AddCard(context.Context, *Card) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCard not implemented")
+}
+func (UnimplementedDocsServer) DeleteCard(context.Context, *Card) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCard not implemented")
+}
+func (UnimplementedDocsServer) UpdateCard(context.Context, *Card) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCard not implemented")
+}
+func (UnimplementedDocsServer) AddFile(Docs_AddFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method AddFile not implemented")
+}
+func (UnimplementedDocsServer) DeleteFile(context.Context, *File) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedDocsServer) UpdateFile(Docs_UpdateFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+}
+func (UnimplementedDocsServer) UpdateFileInfo(context.Context, *File) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileInfo not implemented")
+}
+func (UnimplementedDocsServer) GetFile(*DocumentRequest, Docs_GetFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedDocsServer) CreateUpload(context.Context, *File) (*Upload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpload not implemented")
+}
+func (UnimplementedDocsServer) GetUpload(context.Context, *Upload) (*Upload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpload not implemented")
+}
+func (UnimplementedDocsServer) UploadData(Docs_UploadDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadData not implemented")
+}
+func (UnimplementedDocsServer) GetHistory(*DocumentRequest, Docs_GetHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedDocsServer) RestoreRevision(context.Context, *DocumentRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedDocsServer) Restore(context.Context, *DocumentRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedDocsServer) ExportAll(*Empty, Docs_ExportAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAll not implemented")
+}
+func (UnimplementedDocsServer) CreateVault(context.Context, *NewVault) (*Vault, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVault not implemented")
+}
+func (UnimplementedDocsServer) ListVaults(context.Context, *Empty) (*Vaults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVaults not implemented")
+}
+func (UnimplementedDocsServer) RenameVault(context.Context, *Vault) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameVault not implemented")
+}
+func (UnimplementedDocsServer) DeleteVault(context.Context, *Vault) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVault not implemented")
+}
+func (UnimplementedDocsServer) AddVaultMember(context.Context, *VaultMember) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVaultMember not implemented")
+}
+func (UnimplementedDocsServer) UpdateVaultMember(context.Context, *VaultMember) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVaultMember not implemented")
+}
+func (UnimplementedDocsServer) RemoveVaultMember(context.Context, *VaultMember) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVaultMember not implemented")
+}
+func (UnimplementedDocsServer) CreateShare(context.Context, *ShareRequest) (*ShareInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShare not implemented")
+}
+func (UnimplementedDocsServer) mustEmbedUnimplementedDocsServer() {}
+
+// UnsafeDocsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DocsServer will
+// result in compilation errors.
+type UnsafeDocsServer interface {
+	mustEmbedUnimplementedDocsServer()
+}
+
+func RegisterDocsServer(s grpc.ServiceRegistrar, srv DocsServer) {
+	s.RegisterService(&Docs_ServiceDesc, srv)
+}
+
+func _Docs_GetUpdateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocsServer).GetUpdateStream(m, &docsGetUpdateStreamServer{stream})
+}
+
+type Docs_GetUpdateStreamServer interface {
+	Send(*UpdateResponse) error
+	grpc.ServerStream
+}
+
+type docsGetUpdateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *docsGetUpdateStreamServer) Send(m *UpdateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Docs_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocsServer).Subscribe(m, &docsSubscribeServer{stream})
+}
+
+type Docs_SubscribeServer interface {
+	Send(*UpdateResponse) error
+	grpc.ServerStream
+}
+
+type docsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *docsSubscribeServer) Send(m *UpdateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Docs_AddNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Note)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).AddNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_AddNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).AddNote(ctx, req.(*Note))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Note)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).DeleteNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_DeleteNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).DeleteNote(ctx, req.(*Note))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_UpdateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Note)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).UpdateNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_UpdateNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).UpdateNote(ctx, req.(*Note))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_AddCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Credential)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).AddCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_AddCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).AddCredential(ctx, req.(*Credential))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_DeleteCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Credential)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).DeleteCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_DeleteCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).DeleteCredential(ctx, req.(*Credential))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_UpdateCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Credential)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).UpdateCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_UpdateCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).UpdateCredential(ctx, req.(*Credential))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_AddCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Card)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).AddCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_AddCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).AddCard(ctx, req.(*Card))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) UpdateCard(ctx context.Context, in *Card, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_UpdateCard_FullMethodName, in, out, opts...)
-	if err != nil {
+func _Docs_DeleteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Card)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).DeleteCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_DeleteCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).DeleteCard(ctx, req.(*Card))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) AddFile(ctx context.Context, opts ...grpc.CallOption) (Docs_AddFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[1], Docs_AddFile_FullMethodName, opts...)
-	if err != nil {
+func _Docs_UpdateCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Card)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &docsAddFileClient{stream}
-	return x, nil
+	if interceptor == nil {
+		return srv.(DocsServer).UpdateCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_UpdateCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).UpdateCard(ctx, req.(*Card))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Docs_AddFileClient interface {
-	Send(*FileStream) error
-	CloseAndRecv() (*Empty, error)
-	grpc.ClientStream
+func _Docs_AddFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DocsServer).AddFile(&docsAddFileServer{stream})
 }
 
-type docsAddFileClient struct {
-	grpc.ClientStream
+type Docs_AddFileServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*FileStream, error)
+	grpc.ServerStream
 }
 
-func (x *docsAddFileClient) Send(m *FileStream) error {
-	return x.ClientStream.SendMsg(m)
+type docsAddFileServer struct {
+	grpc.ServerStream
 }
 
-func (x *docsAddFileClient) CloseAndRecv() (*Empty, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(Empty)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (x *docsAddFileServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *docsAddFileServer) Recv() (*FileStream, error) {
+	m := new(FileStream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *docsClient) DeleteFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, Docs_DeleteFile_FullMethodName, in, out, opts...)
-	if err != nil {
+func _Docs_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(File)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).DeleteFile(ctx, req.(*File))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) UpdateFile(ctx context.Context, opts ...grpc.CallOption) (Docs_UpdateFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[2], Docs_UpdateFile_FullMethodName, opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &docsUpdateFileClient{stream}
-	return x, nil
+func _Docs_UpdateFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DocsServer).UpdateFile(&docsUpdateFileServer{stream})
 }
 
-type Docs_UpdateFileClient interface {
-	Send(*FileStream) error
-	CloseAndRecv() (*Empty, error)
-	grpc.ClientStream
+type Docs_UpdateFileServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*FileStream, error)
+	grpc.ServerStream
 }
 
-type docsUpdateFileClient struct {
-	grpc.ClientStream
+type docsUpdateFileServer struct {
+	grpc.ServerStream
 }
 
-func (x *docsUpdateFileClient) Send(m *FileStream) error {
-	return x.ClientStream.SendMsg(m)
+func (x *docsUpdateFileServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *docsUpdateFileClient) CloseAndRecv() (*Empty, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(Empty)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (x *docsUpdateFileServer) Recv() (*FileStream, error) {
+	m := new(FileStream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *docsClient) GetFile(ctx context.Context, in *DocumentRequest, opts ...grpc.CallOption) (Docs_GetFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Docs_ServiceDesc.Streams[3], Docs_GetFile_FullMethodName, opts...)
-	if err != nil {
+func _Docs_UpdateFileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(File)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &docsGetFileClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(DocsServer).UpdateFileInfo(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_UpdateFileInfo_FullMethodName,
 	}
-	return x, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).UpdateFileInfo(ctx, req.(*File))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Docs_GetFileClient interface {
-	Recv() (*FileStream, error)
-	grpc.ClientStream
+func _Docs_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DocumentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocsServer).GetFile(m, &docsGetFileServer{stream})
 }
 
-type docsGetFileClient struct {
-	grpc.ClientStream
+type Docs_GetFileServer interface {
+	Send(*FileStream) error
+	grpc.ServerStream
 }
 
-func (x *docsGetFileClient) Recv() (*FileStream, error) {
-	m := new(FileStream)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+type docsGetFileServer struct {
+	grpc.ServerStream
 }
 
-// DocsServer is the server API for Docs service.
-// All implementations must embed UnimplementedDocsServer
-// for forward compatibility
-type DocsServer interface {
-	GetUpdateStream(*UpdateRequest, Docs_GetUpdateStreamServer) error
-	AddNote(context.Context, *Note) (*Empty, error)
-	DeleteNote(context.Context, *Note) (*Empty, error)
-	UpdateNote(context.Context, *Note) (*Empty, error)
-	AddCredential(context.Context, *Credential) (*Empty, error)
-	DeleteCredential(context.Context, *Credential) (*Empty, error)
-	UpdateCredential(context.Context, *Credential) (*Empty, error)
-	AddCard(context.Context, *Card) (*Empty, error)
-	DeleteCard(context.Context, *Card) (*Empty, error)
-	UpdateCard(context.Context, *Card) (*Empty, error)
-	AddFile(Docs_AddFileServer) error
-	DeleteFile(context.Context, *File) (*Empty, error)
-	UpdateFile(Docs_UpdateFileServer) error
-	GetFile(*DocumentRequest, Docs_GetFileServer) error
-	mustEmbedUnimplementedDocsServer()
+func (x *docsGetFileServer) Send(m *FileStream) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-// UnimplementedDocsServer must be embedded to have forward compatible implementations.
-type UnimplementedDocsServer struct {
+func _Docs_CreateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(File)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).CreateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_CreateUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).CreateUpload(ctx, req.(*File))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedDocsServer) GetUpdateStream(*UpdateRequest, Docs_GetUpdateStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetUpdateStream not implemented")
-}
-func (UnimplementedDocsServer) AddNote(context.Context, *Note) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddNote not implemented")
-}
-func (UnimplementedDocsServer) DeleteNote(context.Context, *Note) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteNote not implemented")
-}
-func (UnimplementedDocsServer) UpdateNote(context.Context, *Note) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateNote not implemented")
-}
-func (UnimplementedDocsServer) AddCredential(context.Context, *Credential) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddCredential not implemented")
-}
-func (UnimplementedDocsServer) DeleteCredential(context.Context, *Credential) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredential not implemented")
-}
-func (UnimplementedDocsServer) UpdateCredential(context.Context, *Credential) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCredential not implemented")
-}
-func (UnimplementedDocsServer) AddCard(context.Context, *Card) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddCard not implemented")
-}
-func (UnimplementedDocsServer) DeleteCard(context.Context, *Card) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCard not implemented")
-}
-func (UnimplementedDocsServer) UpdateCard(context.Context, *Card) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCard not implemented")
-}
-func (UnimplementedDocsServer) AddFile(Docs_AddFileServer) error {
-	return status.Errorf(codes.Unimplemented, "method AddFile not implemented")
+func _Docs_GetUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Upload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetUpload(ctx, req.(*Upload))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDocsServer) DeleteFile(context.Context, *File) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+
+func _Docs_UploadData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DocsServer).UploadData(&docsUploadDataServer{stream})
 }
-func (UnimplementedDocsServer) UpdateFile(Docs_UpdateFileServer) error {
-	return status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+
+type Docs_UploadDataServer interface {
+	SendAndClose(*Upload) error
+	Recv() (*UploadStream, error)
+	grpc.ServerStream
 }
-func (UnimplementedDocsServer) GetFile(*DocumentRequest, Docs_GetFileServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetFileInfo not implemented")
+
+type docsUploadDataServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedDocsServer) mustEmbedUnimplementedDocsServer() {}
 
-// UnsafeDocsServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DocsServer will
-// result in compilation errors.
-type UnsafeDocsServer interface {
-	mustEmbedUnimplementedDocsServer()
+func (x *docsUploadDataServer) SendAndClose(m *Upload) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterDocsServer(s grpc.ServiceRegistrar, srv DocsServer) {
-	s.RegisterService(&Docs_ServiceDesc, srv)
+func (x *docsUploadDataServer) Recv() (*UploadStream, error) {
+	m := new(UploadStream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _Docs_GetUpdateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UpdateRequest)
+func _Docs_GetHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DocumentRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(DocsServer).GetUpdateStream(m, &docsGetUpdateStreamServer{stream})
+	return srv.(DocsServer).GetHistory(m, &docsGetHistoryServer{stream})
 }
 
-type Docs_GetUpdateStreamServer interface {
+type Docs_GetHistoryServer interface {
 	Send(*UpdateResponse) error
 	grpc.ServerStream
 }
 
-type docsGetUpdateStreamServer struct {
+type docsGetHistoryServer struct {
 	grpc.ServerStream
 }
 
-func (x *docsGetUpdateStreamServer) Send(m *UpdateResponse) error {
+func (x *docsGetHistoryServer) Send(m *UpdateResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Docs_AddNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Note)
+func _Docs_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).AddNote(ctx, in)
+		return srv.(DocsServer).RestoreRevision(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_AddNote_FullMethodName,
+		FullMethod: Docs_RestoreRevision_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).AddNote(ctx, req.(*Note))
+		return srv.(DocsServer).RestoreRevision(ctx, req.(*DocumentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Note)
+func _Docs_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).DeleteNote(ctx, in)
+		return srv.(DocsServer).Restore(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_DeleteNote_FullMethodName,
+		FullMethod: Docs_Restore_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).DeleteNote(ctx, req.(*Note))
+		return srv.(DocsServer).Restore(ctx, req.(*DocumentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_UpdateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Note)
+func _Docs_ExportAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocsServer).ExportAll(m, &docsExportAllServer{stream})
+}
+
+type Docs_ExportAllServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type docsExportAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *docsExportAllServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Docs_CreateVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewVault)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).UpdateNote(ctx, in)
+		return srv.(DocsServer).CreateVault(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_UpdateNote_FullMethodName,
+		FullMethod: Docs_CreateVault_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).UpdateNote(ctx, req.(*Note))
+		return srv.(DocsServer).CreateVault(ctx, req.(*NewVault))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_AddCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Credential)
+func _Docs_ListVaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).AddCredential(ctx, in)
+		return srv.(DocsServer).ListVaults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_AddCredential_FullMethodName,
+		FullMethod: Docs_ListVaults_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).AddCredential(ctx, req.(*Credential))
+		return srv.(DocsServer).ListVaults(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_DeleteCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Credential)
+func _Docs_RenameVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vault)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).DeleteCredential(ctx, in)
+		return srv.(DocsServer).RenameVault(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_DeleteCredential_FullMethodName,
+		FullMethod: Docs_RenameVault_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).DeleteCredential(ctx, req.(*Credential))
+		return srv.(DocsServer).RenameVault(ctx, req.(*Vault))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_UpdateCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Credential)
+func _Docs_DeleteVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vault)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).UpdateCredential(ctx, in)
+		return srv.(DocsServer).DeleteVault(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_UpdateCredential_FullMethodName,
+		FullMethod: Docs_DeleteVault_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).UpdateCredential(ctx, req.(*Credential))
+		return srv.(DocsServer).DeleteVault(ctx, req.(*Vault))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_AddCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Card)
+func _Docs_AddVaultMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultMember)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).AddCard(ctx, in)
+		return srv.(DocsServer).AddVaultMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_AddCard_FullMethodName,
+		FullMethod: Docs_AddVaultMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).AddCard(ctx, req.(*Card))
+		return srv.(DocsServer).AddVaultMember(ctx, req.(*VaultMember))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_DeleteCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Card)
+func _Docs_UpdateVaultMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultMember)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).DeleteCard(ctx, in)
+		return srv.(DocsServer).UpdateVaultMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_DeleteCard_FullMethodName,
+		FullMethod: Docs_UpdateVaultMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).DeleteCard(ctx, req.(*Card))
+		return srv.(DocsServer).UpdateVaultMember(ctx, req.(*VaultMember))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_UpdateCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Card)
+func _Docs_RemoveVaultMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultMember)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).UpdateCard(ctx, in)
+		return srv.(DocsServer).RemoveVaultMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_UpdateCard_FullMethodName,
+		FullMethod: Docs_RemoveVaultMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).UpdateCard(ctx, req.(*Card))
+		return srv.(DocsServer).RemoveVaultMember(ctx, req.(*VaultMember))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_AddFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(DocsServer).AddFile(&docsAddFileServer{stream})
-}
-
-type Docs_AddFileServer interface {
-	SendAndClose(*Empty) error
-	Recv() (*FileStream, error)
-	grpc.ServerStream
-}
-
-type docsAddFileServer struct {
-	grpc.ServerStream
-}
-
-func (x *docsAddFileServer) SendAndClose(m *Empty) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *docsAddFileServer) Recv() (*FileStream, error) {
-	m := new(FileStream)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-func _Docs_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(File)
+func _Docs_CreateShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).DeleteFile(ctx, in)
+		return srv.(DocsServer).CreateShare(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_DeleteFile_FullMethodName,
+		FullMethod: Docs_CreateShare_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).DeleteFile(ctx, req.(*File))
+		return srv.(DocsServer).CreateShare(ctx, req.(*ShareRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_UpdateFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(DocsServer).UpdateFile(&docsUpdateFileServer{stream})
-}
-
-type Docs_UpdateFileServer interface {
-	SendAndClose(*Empty) error
-	Recv() (*FileStream, error)
-	grpc.ServerStream
-}
-
-type docsUpdateFileServer struct {
-	grpc.ServerStream
-}
-
-func (x *docsUpdateFileServer) SendAndClose(m *Empty) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *docsUpdateFileServer) Recv() (*FileStream, error) {
-	m := new(FileStream)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-func _Docs_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DocumentRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(DocsServer).GetFile(m, &docsGetFileServer{stream})
-}
-
-type Docs_GetFileServer interface {
-	Send(*FileStream) error
-	grpc.ServerStream
-}
-
-type docsGetFileServer struct {
-	grpc.ServerStream
-}
-
-func (x *docsGetFileServer) Send(m *FileStream) error {
-	return x.ServerStream.SendMsg(m)
-}
-
 // Docs_ServiceDesc is the grpc.ServiceDesc for Docs service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -850,6 +2151,58 @@ var Docs_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFile",
 			Handler:    _Docs_DeleteFile_Handler,
 		},
+		{
+			MethodName: "UpdateFileInfo",
+			Handler:    _Docs_UpdateFileInfo_Handler,
+		},
+		{
+			MethodName: "CreateUpload",
+			Handler:    _Docs_CreateUpload_Handler,
+		},
+		{
+			MethodName: "GetUpload",
+			Handler:    _Docs_GetUpload_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _Docs_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Docs_Restore_Handler,
+		},
+		{
+			MethodName: "CreateVault",
+			Handler:    _Docs_CreateVault_Handler,
+		},
+		{
+			MethodName: "ListVaults",
+			Handler:    _Docs_ListVaults_Handler,
+		},
+		{
+			MethodName: "RenameVault",
+			Handler:    _Docs_RenameVault_Handler,
+		},
+		{
+			MethodName: "DeleteVault",
+			Handler:    _Docs_DeleteVault_Handler,
+		},
+		{
+			MethodName: "AddVaultMember",
+			Handler:    _Docs_AddVaultMember_Handler,
+		},
+		{
+			MethodName: "UpdateVaultMember",
+			Handler:    _Docs_UpdateVaultMember_Handler,
+		},
+		{
+			MethodName: "RemoveVaultMember",
+			Handler:    _Docs_RemoveVaultMember_Handler,
+		},
+		{
+			MethodName: "CreateShare",
+			Handler:    _Docs_CreateShare_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -857,6 +2210,11 @@ var Docs_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Docs_GetUpdateStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Docs_Subscribe_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "AddFile",
 			Handler:       _Docs_AddFile_Handler,
@@ -868,10 +2226,115 @@ var Docs_ServiceDesc = grpc.ServiceDesc{
 			ClientStreams: true,
 		},
 		{
-			StreamName:    "GetFileInfo",
+			StreamName:    "GetFile",
 			Handler:       _Docs_GetFile_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "UploadData",
+			Handler:       _Docs_UploadData_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetHistory",
+			Handler:       _Docs_GetHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportAll",
+			Handler:       _Docs_ExportAll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpc.proto",
+}
+
+const (
+	Share_Open_FullMethodName = "/grpcapi.Share/Open"
+)
+
+// ShareClient is the client API for Share service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShareClient interface {
+	Open(ctx context.Context, in *ShareOpen, opts ...grpc.CallOption) (*SharedDocument, error)
+}
+
+type shareClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShareClient(cc grpc.ClientConnInterface) ShareClient {
+	return &shareClient{cc}
+}
+
+func (c *shareClient) Open(ctx context.Context, in *ShareOpen, opts ...grpc.CallOption) (*SharedDocument, error) {
+	out := new(SharedDocument)
+	err := c.cc.Invoke(ctx, Share_Open_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShareServer is the server API for Share service.
+// All implementations must embed UnimplementedShareServer
+// for forward compatibility
+type ShareServer interface {
+	Open(context.Context, *ShareOpen) (*SharedDocument, error)
+	mustEmbedUnimplementedShareServer()
+}
+
+// UnimplementedShareServer must be embedded to have forward compatible implementations.
+type UnimplementedShareServer struct {
+}
+
+func (UnimplementedShareServer) Open(context.Context, *ShareOpen) (*SharedDocument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Open not implemented")
+}
+func (UnimplementedShareServer) mustEmbedUnimplementedShareServer() {}
+
+// UnsafeShareServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShareServer will
+// result in compilation errors.
+type UnsafeShareServer interface {
+	mustEmbedUnimplementedShareServer()
+}
+
+func RegisterShareServer(s grpc.ServiceRegistrar, srv ShareServer) {
+	s.RegisterService(&Share_ServiceDesc, srv)
+}
+
+func _Share_Open_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareOpen)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServer).Open(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Share_Open_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServer).Open(ctx, req.(*ShareOpen))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Share_ServiceDesc is the grpc.ServiceDesc for Share service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Share_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcapi.Share",
+	HandlerType: (*ShareServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Open",
+			Handler:    _Share_Open_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "grpc.proto",
 }
@@ -38,23 +38,31 @@ func (x *Note) ToNote() (models.Note, error) {
 		return models.Note{}, ErrBadRequest
 	}
 	return models.Note{
-		Id:       x.Id,
-		Serial:   x.Serial,
-		State:    x.State,
-		Name:     x.Name,
-		Text:     x.Text,
-		Metadata: toMetadata(x.Metadata),
+		Id:        x.Id,
+		Serial:    x.Serial,
+		State:     x.State,
+		TrashedAt: x.TrashedAt,
+		Name:      x.Name,
+		Text:      x.Text,
+		Metadata:  toMetadata(x.Metadata),
+		Folder:    x.Folder,
+		Tags:      x.Tags,
+		VaultId:   x.VaultId,
 	}, nil
 }
 
 func FromNote(x models.Note) *Note {
 	return &Note{
-		Id:       x.Id,
-		Serial:   x.Serial,
-		State:    x.State,
-		Name:     x.Name,
-		Text:     x.Text,
-		Metadata: fromMetadata(x.Metadata),
+		Id:        x.Id,
+		Serial:    x.Serial,
+		State:     x.State,
+		TrashedAt: x.TrashedAt,
+		Name:      x.Name,
+		Text:      x.Text,
+		Metadata:  fromMetadata(x.Metadata),
+		Folder:    x.Folder,
+		Tags:      x.Tags,
+		VaultId:   x.VaultId,
 	}
 }
 
@@ -63,25 +71,33 @@ func (x *Credential) ToCredential() (models.Credential, error) {
 		return models.Credential{}, ErrBadRequest
 	}
 	return models.Credential{
-		Id:       x.Id,
-		Serial:   x.Serial,
-		State:    x.State,
-		Name:     x.Name,
-		Login:    x.Login,
-		Password: x.Password,
-		Metadata: toMetadata(x.Metadata),
+		Id:        x.Id,
+		Serial:    x.Serial,
+		State:     x.State,
+		TrashedAt: x.TrashedAt,
+		Name:      x.Name,
+		Login:     x.Login,
+		Password:  x.Password,
+		Metadata:  toMetadata(x.Metadata),
+		Folder:    x.Folder,
+		Tags:      x.Tags,
+		VaultId:   x.VaultId,
 	}, nil
 }
 
 func FromCredential(x models.Credential) *Credential {
 	return &Credential{
-		Id:       x.Id,
-		Serial:   x.Serial,
-		State:    x.State,
-		Name:     x.Name,
-		Login:    x.Login,
-		Password: x.Password,
-		Metadata: fromMetadata(x.Metadata),
+		Id:        x.Id,
+		Serial:    x.Serial,
+		State:     x.State,
+		TrashedAt: x.TrashedAt,
+		Name:      x.Name,
+		Login:     x.Login,
+		Password:  x.Password,
+		Metadata:  fromMetadata(x.Metadata),
+		Folder:    x.Folder,
+		Tags:      x.Tags,
+		VaultId:   x.VaultId,
 	}
 }
 
@@ -93,6 +109,7 @@ func (x *Card) ToCard() (models.Card, error) {
 		Id:         x.Id,
 		Serial:     x.Serial,
 		State:      x.State,
+		TrashedAt:  x.TrashedAt,
 		Name:       x.Name,
 		Cardholder: x.Cardholder,
 		Number:     x.Number,
@@ -100,6 +117,9 @@ func (x *Card) ToCard() (models.Card, error) {
 		Pin:        x.Pin,
 		Code:       x.Code,
 		Metadata:   toMetadata(x.Metadata),
+		Folder:     x.Folder,
+		Tags:       x.Tags,
+		VaultId:    x.VaultId,
 	}, nil
 }
 
@@ -108,6 +128,7 @@ func FromCard(x models.Card) *Card {
 		Id:         x.Id,
 		Serial:     x.Serial,
 		State:      x.State,
+		TrashedAt:  x.TrashedAt,
 		Name:       x.Name,
 		Cardholder: x.Cardholder,
 		Number:     x.Number,
@@ -115,6 +136,9 @@ func FromCard(x models.Card) *Card {
 		Pin:        x.Pin,
 		Code:       x.Code,
 		Metadata:   fromMetadata(x.Metadata),
+		Folder:     x.Folder,
+		Tags:       x.Tags,
+		VaultId:    x.VaultId,
 	}
 }
 
@@ -126,26 +150,71 @@ func (x *File) ToFile() (models.File, error) {
 		return models.File{}, fmt.Errorf("%w: Fileame is empty", ErrBadRequest)
 	}
 	file := models.File{
-		Id:       x.Id,
-		Serial:   x.Serial,
-		State:    x.State,
-		Name:     x.Name,
-		Filename: x.Filename,
-		Size:     x.Size,
-		Data:     make([]byte, 0),
-		Metadata: toMetadata(x.Metadata),
+		Id:        x.Id,
+		Serial:    x.Serial,
+		State:     x.State,
+		TrashedAt: x.TrashedAt,
+		Name:      x.Name,
+		Filename:  x.Filename,
+		Size:      x.Size,
+		Sha265:    x.Sha256,
+		Data:      make([]byte, 0),
+		Metadata:  toMetadata(x.Metadata),
+		Folder:    x.Folder,
+		Tags:      x.Tags,
+		VaultId:   x.VaultId,
 	}
 	return file, nil
 }
 
 func FromFile(x models.File) *File {
 	return &File{
-		Id:       x.Id,
-		Serial:   x.Serial,
-		State:    x.State,
-		Name:     x.Name,
-		Filename: x.Filename,
-		Size:     x.Size,
-		Metadata: fromMetadata(x.Metadata),
+		Id:        x.Id,
+		Serial:    x.Serial,
+		State:     x.State,
+		TrashedAt: x.TrashedAt,
+		Name:      x.Name,
+		Filename:  x.Filename,
+		Size:      x.Size,
+		Sha256:    x.Sha265,
+		Metadata:  fromMetadata(x.Metadata),
+		Folder:    x.Folder,
+		Tags:      x.Tags,
+		VaultId:   x.VaultId,
+	}
+}
+
+func FromVault(x models.Vault) *Vault {
+	members := make([]*VaultMember, len(x.Members))
+	for i, m := range x.Members {
+		members[i] = &VaultMember{
+			VaultId:  x.Id,
+			UserId:   m.UserId,
+			Login:    m.Login,
+			Role:     m.Role,
+			VaultKey: m.VaultKey,
+		}
+	}
+	return &Vault{
+		Id:      x.Id,
+		Name:    x.Name,
+		Members: members,
+	}
+}
+
+func (x *Vault) ToVault() models.Vault {
+	members := make([]models.Member, len(x.Members))
+	for i, m := range x.Members {
+		members[i] = models.Member{
+			UserId:   m.UserId,
+			Login:    m.Login,
+			Role:     m.Role,
+			VaultKey: m.VaultKey,
+		}
+	}
+	return models.Vault{
+		Id:      x.Id,
+		Name:    x.Name,
+		Members: members,
 	}
 }
@@ -40,6 +40,11 @@ func SetTTL(ttl time.Duration) {
 	jwtTTL = ttl
 }
 
+// TTL returns JWT token lifetime
+func TTL() time.Duration {
+	return jwtTTL
+}
+
 type JWTClaims struct {
 	jwt.RegisteredClaims
 	Session string `json:"session,omitempty"`
@@ -45,3 +45,16 @@ func (sl SLogger) WithCtxUserId(ctx context.Context) SLogger {
 	}
 	return sl
 }
+
+type ctxSessionId struct{} // sessionId in context
+
+// WithSessionId returns context with auth sessionId value
+func WithSessionId(ctx context.Context, sessionId string) context.Context {
+	return context.WithValue(ctx, ctxSessionId{}, sessionId)
+}
+
+// GetSessionId returns auth sessionId from context
+func GetSessionId(ctx context.Context) (sessionId string, ok bool) {
+	sessionId, ok = ctx.Value(ctxSessionId{}).(string)
+	return
+}
@@ -68,3 +68,12 @@ func Test_RequestId(t *testing.T) {
 		})
 	}
 }
+
+func Test_SessionId(t *testing.T) {
+	ctx := context.Background()
+	_, ok := GetSessionId(ctx)
+	require.False(t, ok)
+	got, ok := GetSessionId(WithSessionId(ctx, "session"))
+	require.True(t, ok)
+	require.Equal(t, "session", got)
+}
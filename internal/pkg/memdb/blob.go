@@ -0,0 +1,75 @@
+package memdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// chunkSize is a size of file data appended at once, the same as persistent backends use
+const chunkSize = 255 << 10
+
+var ErrBadBlob = errors.New("file data is corrupted")
+
+// PutBlob reads data from r and stores it. Returns id of stored data.
+// Partially stored data is removed on error.
+func (db *Memdb) PutBlob(ctx context.Context, userId string, r io.Reader) (string, error) {
+	blobId := newId()
+	if _, err := db.AppendBlob(ctx, blobId, userId, 0, r); err != nil {
+		_ = db.DeleteBlob(ctx, blobId)
+		return "", err
+	}
+	return blobId, nil
+}
+
+// AppendBlob reads data from r and appends it to stored data of size offset.
+// Data is stored by full chunks, the last short chunk is stored when r is finished.
+// Returns size of stored data, on error it may be less than data read from r.
+func (db *Memdb) AppendBlob(_ context.Context, blobId string, _ string, offset int64, r io.Reader) (int64, error) {
+	if offset%chunkSize != 0 {
+		// the last chunk is already stored
+		return offset, fmt.Errorf("%w: unable to append to complete data", ErrBadBlob)
+	}
+	buf := make([]byte, chunkSize)
+	for {
+		size, err := io.ReadFull(r, buf)
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return offset, err
+		}
+		db.mu.Lock()
+		stored := db.blobs[blobId]
+		if int64(len(stored)) != offset {
+			db.mu.Unlock()
+			return offset, fmt.Errorf("%w: stored data size mismatch", ErrBadBlob)
+		}
+		db.blobs[blobId] = append(stored, buf[:size]...)
+		db.mu.Unlock()
+		offset += int64(size)
+		if size < chunkSize {
+			return offset, nil
+		}
+	}
+}
+
+// OpenBlob returns reader of stored data, starting from offset.
+// Empty blobId is a file without data.
+func (db *Memdb) OpenBlob(_ context.Context, blobId string, offset int64) (io.ReadCloser, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	data := db.blobs[blobId]
+	// stored data is only appended, so the slice is never changed
+	return io.NopCloser(bytes.NewReader(data[min(offset, int64(len(data))):])), nil
+}
+
+// DeleteBlob removes stored data
+func (db *Memdb) DeleteBlob(_ context.Context, blobId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	delete(db.blobs, blobId)
+	return nil
+}
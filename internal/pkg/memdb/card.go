@@ -0,0 +1,33 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddCard places new Card in the database
+func (db *Memdb) AddCard(_ context.Context, card models.Card) (string, error) {
+	card.Id = newId()
+	db.insert(documents.KindCard, card.Id, card)
+	return card.Id, nil
+}
+
+// GetCard returns Card from database
+func (db *Memdb) GetCard(_ context.Context, docId string, userId string) (models.Card, error) {
+	doc, err := db.get(documents.KindCard, docId, userId)
+	card, _ := doc.(models.Card)
+	return card, err
+}
+
+// ModifyCard replaces Card in database. Also called in delete action, because deleted
+// documents are only marked with a flag, but not actually deleted.
+func (db *Memdb) ModifyCard(_ context.Context, card models.Card) error {
+	return db.replace(documents.KindCard, card.Id, card.UserId, card)
+}
+
+// GetCardsStream produces stream of Cards updates, happened between minSerial and maxSerial
+func (db *Memdb) GetCardsStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindCard, userId, minSerial, maxSerial, chData)
+}
@@ -0,0 +1,33 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddCredential places new Credential in the database
+func (db *Memdb) AddCredential(_ context.Context, credential models.Credential) (string, error) {
+	credential.Id = newId()
+	db.insert(documents.KindCredential, credential.Id, credential)
+	return credential.Id, nil
+}
+
+// GetCredential returns Credential from database
+func (db *Memdb) GetCredential(_ context.Context, docId string, userId string) (models.Credential, error) {
+	doc, err := db.get(documents.KindCredential, docId, userId)
+	credential, _ := doc.(models.Credential)
+	return credential, err
+}
+
+// ModifyCredential replaces Credential in database. Also called in delete action, because deleted
+// documents are only marked with a flag, but not actually deleted.
+func (db *Memdb) ModifyCredential(_ context.Context, credential models.Credential) error {
+	return db.replace(documents.KindCredential, credential.Id, credential.UserId, credential)
+}
+
+// GetCredentialsStream produces stream of Credentials updates, happened between minSerial and maxSerial
+func (db *Memdb) GetCredentialsStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindCredential, userId, minSerial, maxSerial, chData)
+}
@@ -0,0 +1,47 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddFile places new File in the database. File data is stored separately, see PutBlob.
+func (db *Memdb) AddFile(_ context.Context, file models.File) (string, error) {
+	file.Id = newId()
+	db.insert(documents.KindFile, file.Id, file)
+	return file.Id, nil
+}
+
+// GetFileInfo returns File from database
+func (db *Memdb) GetFileInfo(_ context.Context, docId string, userId string) (models.File, error) {
+	doc, err := db.get(documents.KindFile, docId, userId)
+	file, _ := doc.(models.File)
+	return file, err
+}
+
+// ModifyFile replaces File in database. Used for update and for deletion
+func (db *Memdb) ModifyFile(_ context.Context, file models.File) error {
+	return db.replace(documents.KindFile, file.Id, file.UserId, file)
+}
+
+// ModifyFileInfo updates File without touching file data and its size and hash
+func (db *Memdb) ModifyFileInfo(_ context.Context, file models.File) error {
+	return db.update(documents.KindFile, file.Id, file.UserId, func(doc interface{}) interface{} {
+		stored := doc.(models.File)
+		stored.Serial = file.Serial
+		stored.Name = file.Name
+		stored.Filename = file.Filename
+		stored.Metadata = file.Metadata
+		stored.Folder = file.Folder
+		stored.Tags = file.Tags
+		stored.State = file.State
+		return stored
+	})
+}
+
+// GetFilesInfoStream produces stream of File updates, happened between minSerial and maxSerial
+func (db *Memdb) GetFilesInfoStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindFile, userId, minSerial, maxSerial, chData)
+}
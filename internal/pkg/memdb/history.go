@@ -0,0 +1,112 @@
+package memdb
+
+import (
+	"context"
+	"slices"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// GetHistoryStream produces stream of previous document revisions, the newest first.
+// Files are sent without binary data.
+func (db *Memdb) GetHistoryStream(ctx context.Context, docId string, userId string, chData chan interface{}) error {
+	db.mu.RLock()
+	var list []interface{}
+	revisions := db.history[docId]
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].userId == userId {
+			list = append(list, withoutData(clone(revisions[i].doc)))
+		}
+	}
+	db.mu.RUnlock()
+	return send(ctx, list, chData)
+}
+
+// GetRevision returns document revision with requested serial from history
+func (db *Memdb) GetRevision(_ context.Context, docId string, userId string, serial int64) (interface{}, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for _, r := range db.history[docId] {
+		if r.userId == userId && r.serial == serial {
+			return clone(r.doc), nil
+		}
+	}
+	return nil, documents.ErrNotFound
+}
+
+// DeleteHistory removes all revisions of the document
+func (db *Memdb) DeleteHistory(_ context.Context, docId string, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var kept []revision
+	for _, r := range db.history[docId] {
+		if r.userId != userId {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		delete(db.history, docId)
+	} else {
+		db.history[docId] = kept
+	}
+	return nil
+}
+
+// DeleteRevisions removes revisions of the document with requested serials
+func (db *Memdb) DeleteRevisions(_ context.Context, docId string, userId string, serials []int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var kept []revision
+	for _, r := range db.history[docId] {
+		if r.userId != userId || !slices.Contains(serials, r.serial) {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		delete(db.history, docId)
+	} else {
+		db.history[docId] = kept
+	}
+	return nil
+}
+
+// TrimHistory removes the oldest revisions of the document, keeping keep newest ones,
+// and returns removed revisions. File revisions are returned without binary data.
+func (db *Memdb) TrimHistory(_ context.Context, docId string, userId string, keep int) ([]interface{}, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	revisions := db.history[docId]
+	n := 0
+	for _, r := range revisions {
+		if r.userId == userId {
+			n++
+		}
+	}
+	var kept []revision
+	var removed []interface{}
+	// revisions are ordered by serial, the oldest first
+	for _, r := range revisions {
+		if r.userId == userId && n > keep {
+			removed = append(removed, withoutData(clone(r.doc)))
+			n--
+			continue
+		}
+		kept = append(kept, r)
+	}
+	if len(kept) == 0 {
+		delete(db.history, docId)
+	} else {
+		db.history[docId] = kept
+	}
+	return removed, nil
+}
+
+// withoutData strips binary data of file document
+func withoutData(doc interface{}) interface{} {
+	if file, ok := doc.(models.File); ok {
+		file.Data = nil
+		return file
+	}
+	return doc
+}
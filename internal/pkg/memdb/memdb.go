@@ -0,0 +1,208 @@
+// Package memdb is an in-memory storage backend. It keeps all data in process memory
+// and behaves like persistent backends, so it serves as a reference implementation
+// and as a storage for tests.
+package memdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+type Memdb struct {
+	mu       sync.RWMutex
+	docs     map[string]map[string]interface{} // documents by kind and id
+	history  map[string][]revision             // document revisions by document id, the oldest first
+	users    map[string]models.User
+	logins   map[string]string // user id by login
+	sessions map[string]models.Session
+	blobs    map[string][]byte
+	vaults   map[string]models.Vault
+	shares   map[string]models.Share
+	uploads  map[string]models.Upload
+	serial   int64
+}
+
+// revision is a previous revision of the document
+type revision struct {
+	kind   string
+	userId string
+	serial int64
+	doc    interface{}
+}
+
+// header is a part of document, required for indexing
+type header struct {
+	userId    string
+	serial    int64
+	state     string
+	trashedAt int64
+}
+
+// New returns empty database
+func New() *Memdb {
+	db := &Memdb{
+		docs:     make(map[string]map[string]interface{}),
+		history:  make(map[string][]revision),
+		users:    make(map[string]models.User),
+		logins:   make(map[string]string),
+		sessions: make(map[string]models.Session),
+		blobs:    make(map[string][]byte),
+		vaults:   make(map[string]models.Vault),
+		shares:   make(map[string]models.Share),
+		uploads:  make(map[string]models.Upload),
+	}
+	for _, kind := range []string{documents.KindNote, documents.KindCard, documents.KindCredential, documents.KindFile} {
+		db.docs[kind] = make(map[string]interface{})
+	}
+	return db
+}
+
+// Close does nothing, it allows Memdb to be used as server storage
+func (db *Memdb) Close(_ context.Context) error {
+	return nil
+}
+
+// newId returns random id, the same length as mongodb ObjectID
+func newId() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// insert places new document of kind with id
+func (db *Memdb) insert(kind string, id string, doc interface{}) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.docs[kind][id] = clone(doc)
+}
+
+// get returns document of kind with id, document should belong to user
+func (db *Memdb) get(kind string, id string, userId string) (interface{}, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	doc, ok := db.docs[kind][id]
+	if !ok || headerOf(doc).userId != userId {
+		return nil, documents.ErrNotFound
+	}
+	return clone(doc), nil
+}
+
+// update replaces user document of kind with id by document, returned by fn.
+// fn receives stored document. Previous document revision is archived to history,
+// but deleted and trashed documents are not archived: the first has no payload,
+// and the second has the same payload as the previous revision.
+func (db *Memdb) update(kind string, id string, userId string, fn func(stored interface{}) interface{}) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	stored, ok := db.docs[kind][id]
+	if !ok {
+		return documents.ErrNotFound
+	}
+	old := headerOf(stored)
+	if old.userId != userId {
+		return documents.ErrNotFound
+	}
+	if old.state != models.StateDeleted && old.state != models.StateTrashed {
+		db.history[id] = append(db.history[id], revision{kind: kind, userId: userId, serial: old.serial, doc: stored})
+	}
+	db.docs[kind][id] = clone(fn(clone(stored)))
+	return nil
+}
+
+// replace replaces user document of kind with id
+func (db *Memdb) replace(kind string, id string, userId string, doc interface{}) error {
+	return db.update(kind, id, userId, func(interface{}) interface{} {
+		return doc
+	})
+}
+
+// streamRange produces stream of user documents of kind with serial between minSerial and maxSerial.
+// Documents are collected first, so database is not locked while stream is read.
+func (db *Memdb) streamRange(ctx context.Context, kind string, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	db.mu.RLock()
+	var list []interface{}
+	for _, doc := range db.docs[kind] {
+		if h := headerOf(doc); h.userId == userId && h.serial > minSerial && h.serial < maxSerial {
+			list = append(list, clone(doc))
+		}
+	}
+	db.mu.RUnlock()
+	sort.Slice(list, func(i, j int) bool {
+		return headerOf(list[i]).serial < headerOf(list[j]).serial
+	})
+	return send(ctx, list, chData)
+}
+
+// send sends documents to stream
+func send(ctx context.Context, list []interface{}, chData chan interface{}) error {
+	for _, doc := range list {
+		select {
+		case chData <- doc:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// headerOf returns header of document model
+func headerOf(doc interface{}) header {
+	switch d := doc.(type) {
+	case models.Note:
+		return header{userId: d.UserId, serial: d.Serial, state: d.State, trashedAt: d.TrashedAt}
+	case models.Card:
+		return header{userId: d.UserId, serial: d.Serial, state: d.State, trashedAt: d.TrashedAt}
+	case models.Credential:
+		return header{userId: d.UserId, serial: d.Serial, state: d.State, trashedAt: d.TrashedAt}
+	case models.File:
+		return header{userId: d.UserId, serial: d.Serial, state: d.State, trashedAt: d.TrashedAt}
+	}
+	panic(errors.New("unknown document model"))
+}
+
+// clone returns deep copy of document model, so stored documents are not shared with callers
+func clone(doc interface{}) interface{} {
+	switch d := doc.(type) {
+	case models.Note:
+		d.Metadata = cloneMeta(d.Metadata)
+		d.Tags = cloneTags(d.Tags)
+		return d
+	case models.Card:
+		d.Metadata = cloneMeta(d.Metadata)
+		d.Tags = cloneTags(d.Tags)
+		return d
+	case models.Credential:
+		d.Metadata = cloneMeta(d.Metadata)
+		d.Tags = cloneTags(d.Tags)
+		return d
+	case models.File:
+		d.Metadata = cloneMeta(d.Metadata)
+		d.Tags = cloneTags(d.Tags)
+		if d.Data != nil {
+			d.Data = append([]byte{}, d.Data...)
+		}
+		return d
+	}
+	return doc
+}
+
+func cloneTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	return append([]string{}, tags...)
+}
+
+func cloneMeta(meta []models.Meta) []models.Meta {
+	if meta == nil {
+		return nil
+	}
+	return append([]models.Meta{}, meta...)
+}
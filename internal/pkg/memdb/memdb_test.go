@@ -0,0 +1,13 @@
+package memdb
+
+import (
+	"testing"
+
+	"yap-pwkeeper/internal/pkg/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		return New()
+	})
+}
@@ -0,0 +1,33 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddNote places new Note in the database
+func (db *Memdb) AddNote(_ context.Context, note models.Note) (string, error) {
+	note.Id = newId()
+	db.insert(documents.KindNote, note.Id, note)
+	return note.Id, nil
+}
+
+// GetNote returns Note from database
+func (db *Memdb) GetNote(_ context.Context, docId string, userId string) (models.Note, error) {
+	doc, err := db.get(documents.KindNote, docId, userId)
+	note, _ := doc.(models.Note)
+	return note, err
+}
+
+// ModifyNote replaces Note in database. Also called in delete action, because deleted
+// documents are only marked with a flag, but not actually deleted.
+func (db *Memdb) ModifyNote(_ context.Context, note models.Note) error {
+	return db.replace(documents.KindNote, note.Id, note.UserId, note)
+}
+
+// GetNotesStream produces stream of Notes updates, happened between minSerial and maxSerial
+func (db *Memdb) GetNotesStream(ctx context.Context, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+	return db.streamRange(ctx, documents.KindNote, userId, minSerial, maxSerial, chData)
+}
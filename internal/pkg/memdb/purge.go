@@ -0,0 +1,31 @@
+package memdb
+
+import "context"
+
+// DeleteUserDocuments removes all user documents of every kind and their history.
+// File data is not removed.
+func (db *Memdb) DeleteUserDocuments(_ context.Context, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, docs := range db.docs {
+		for id, doc := range docs {
+			if headerOf(doc).userId == userId {
+				delete(docs, id)
+			}
+		}
+	}
+	for id, revisions := range db.history {
+		var kept []revision
+		for _, r := range revisions {
+			if r.userId != userId {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(db.history, id)
+		} else {
+			db.history[id] = kept
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package memdb
+
+import "context"
+
+// GetSerials returns next usable serial, reserves next n serials
+func (db *Memdb) GetSerials(_ context.Context, n int) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	next := db.serial
+	db.serial += int64(n)
+	return next, nil
+}
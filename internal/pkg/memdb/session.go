@@ -0,0 +1,68 @@
+package memdb
+
+import (
+	"context"
+	"sort"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddSession stores new user session
+func (db *Memdb) AddSession(_ context.Context, session models.Session) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	session.Current = false
+	db.sessions[session.Id] = session
+	return nil
+}
+
+// GetSession returns session by id
+func (db *Memdb) GetSession(_ context.Context, sessionId string) (models.Session, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	session, ok := db.sessions[sessionId]
+	if !ok {
+		return session, aaa.ErrNoSession
+	}
+	return session, nil
+}
+
+// GetSessions returns all user sessions, the oldest first
+func (db *Memdb) GetSessions(_ context.Context, userId string) ([]models.Session, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var sessions []models.Session
+	for _, session := range db.sessions {
+		if session.UserId == userId {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt < sessions[j].CreatedAt })
+	return sessions, nil
+}
+
+// TouchSession updates time of the last session activity
+func (db *Memdb) TouchSession(_ context.Context, sessionId string, lastSeen int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	session, ok := db.sessions[sessionId]
+	if !ok {
+		return aaa.ErrNoSession
+	}
+	session.LastSeen = lastSeen
+	db.sessions[sessionId] = session
+	return nil
+}
+
+// DeleteSession removes user session
+func (db *Memdb) DeleteSession(_ context.Context, sessionId string, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	session, ok := db.sessions[sessionId]
+	if !ok || session.UserId != userId {
+		return aaa.ErrNoSession
+	}
+	delete(db.sessions, sessionId)
+	return nil
+}
@@ -0,0 +1,81 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddShare stores new document share
+func (db *Memdb) AddShare(_ context.Context, share models.Share) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.shares[share.Id] = cloneShare(share)
+	return nil
+}
+
+// GetShare returns document share by id
+func (db *Memdb) GetShare(_ context.Context, shareId string) (models.Share, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	share, ok := db.shares[shareId]
+	if !ok {
+		return share, documents.ErrNoShare
+	}
+	return cloneShare(share), nil
+}
+
+// ModifyShare replaces document share
+func (db *Memdb) ModifyShare(_ context.Context, share models.Share) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.shares[share.Id]; !ok {
+		return documents.ErrNoShare
+	}
+	db.shares[share.Id] = cloneShare(share)
+	return nil
+}
+
+// DeleteShare removes document share
+func (db *Memdb) DeleteShare(_ context.Context, shareId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.shares[shareId]; !ok {
+		return documents.ErrNoShare
+	}
+	delete(db.shares, shareId)
+	return nil
+}
+
+// DeleteExpiredShares removes shares, which expire at or before time, and returns their number
+func (db *Memdb) DeleteExpiredShares(_ context.Context, before int64) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	n := 0
+	for id, share := range db.shares {
+		if share.ExpiresAt <= before {
+			delete(db.shares, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// DeleteUserShares removes all shares of owner documents
+func (db *Memdb) DeleteUserShares(_ context.Context, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for id, share := range db.shares {
+		if share.UserId == userId {
+			delete(db.shares, id)
+		}
+	}
+	return nil
+}
+
+func cloneShare(share models.Share) models.Share {
+	share.Data = cloneBytes(share.Data)
+	share.PassHash = cloneBytes(share.PassHash)
+	return share
+}
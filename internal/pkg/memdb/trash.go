@@ -0,0 +1,23 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// GetTrashStream produces stream of documents of all users and kinds, which were
+// moved to trash before trashedBefore unix time. Files are sent without binary data.
+func (db *Memdb) GetTrashStream(ctx context.Context, trashedBefore int64, chData chan interface{}) error {
+	db.mu.RLock()
+	var list []interface{}
+	for _, docs := range db.docs {
+		for _, doc := range docs {
+			if h := headerOf(doc); h.state == models.StateTrashed && h.trashedAt < trashedBefore {
+				list = append(list, withoutData(clone(doc)))
+			}
+		}
+	}
+	db.mu.RUnlock()
+	return send(ctx, list, chData)
+}
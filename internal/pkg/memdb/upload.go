@@ -0,0 +1,83 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddUpload stores new file upload
+func (db *Memdb) AddUpload(_ context.Context, upload models.Upload) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.uploads[upload.Id] = cloneUpload(upload)
+	return nil
+}
+
+// GetUpload returns file upload by id
+func (db *Memdb) GetUpload(_ context.Context, uploadId string) (models.Upload, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	upload, ok := db.uploads[uploadId]
+	if !ok {
+		return upload, documents.ErrUploadNotFound
+	}
+	return cloneUpload(upload), nil
+}
+
+// SetUploadOffset saves size of received data and time of upload activity
+func (db *Memdb) SetUploadOffset(_ context.Context, uploadId string, offset int64, updatedAt int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	upload, ok := db.uploads[uploadId]
+	if !ok {
+		return documents.ErrUploadNotFound
+	}
+	upload.Offset, upload.UpdatedAt = offset, updatedAt
+	db.uploads[uploadId] = upload
+	return nil
+}
+
+// DeleteUpload removes file upload, its data blob is kept
+func (db *Memdb) DeleteUpload(_ context.Context, uploadId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.uploads[uploadId]; !ok {
+		return documents.ErrUploadNotFound
+	}
+	delete(db.uploads, uploadId)
+	return nil
+}
+
+// GetExpiredUploads returns uploads, which were active at or before time
+func (db *Memdb) GetExpiredUploads(_ context.Context, before int64) ([]models.Upload, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var expired []models.Upload
+	for _, upload := range db.uploads {
+		if upload.UpdatedAt <= before {
+			expired = append(expired, cloneUpload(upload))
+		}
+	}
+	return expired, nil
+}
+
+// DeleteUserUploads removes all uploads of owner files and returns them, data blobs are kept
+func (db *Memdb) DeleteUserUploads(_ context.Context, userId string) ([]models.Upload, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var deleted []models.Upload
+	for id, upload := range db.uploads {
+		if upload.UserId == userId {
+			deleted = append(deleted, cloneUpload(upload))
+			delete(db.uploads, id)
+		}
+	}
+	return deleted, nil
+}
+
+func cloneUpload(upload models.Upload) models.Upload {
+	upload.File = clone(upload.File).(models.File)
+	return upload
+}
@@ -0,0 +1,196 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddUser creates new user in database, returns user with new id
+func (db *Memdb) AddUser(_ context.Context, user models.User) (models.User, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.logins[user.Login]; ok {
+		return user, aaa.ErrDuplicate
+	}
+	user.Id = newId()
+	user.Totp = cloneTotp(user.Totp)
+	db.users[user.Id] = user
+	db.logins[user.Login] = user.Id
+	return user, nil
+}
+
+// GetUserByLogin returns active user by specified login
+func (db *Memdb) GetUserByLogin(_ context.Context, login string) (models.User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getUser(db.logins[login])
+}
+
+// GetUserById returns active user by id
+func (db *Memdb) GetUserById(_ context.Context, userId string) (models.User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getUser(userId)
+}
+
+// SetVaultKey saves user vault key, only if it was not set before
+func (db *Memdb) SetVaultKey(_ context.Context, userId string, key []byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	if user.VaultKey != nil {
+		return aaa.ErrKeyExists
+	}
+	user.VaultKey = append([]byte{}, key...)
+	db.users[userId] = user
+	return nil
+}
+
+// SetKeyPair saves user key pair, only if it was not set before
+func (db *Memdb) SetKeyPair(_ context.Context, userId string, keys models.KeyPair) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	if user.PublicKey != nil {
+		return aaa.ErrKeyExists
+	}
+	user.PublicKey = append([]byte{}, keys.Public...)
+	user.PrivateKey = append([]byte{}, keys.Private...)
+	db.users[userId] = user
+	return nil
+}
+
+// getUser returns active user by id, should be called with lock held
+func (db *Memdb) getUser(userId string) (models.User, error) {
+	user, ok := db.users[userId]
+	if !ok || user.State != models.StateActive {
+		return models.User{}, aaa.ErrNotFound
+	}
+	user.VaultKey = cloneBytes(user.VaultKey)
+	user.PublicKey = cloneBytes(user.PublicKey)
+	user.PrivateKey = cloneBytes(user.PrivateKey)
+	user.Totp = cloneTotp(user.Totp)
+	return user, nil
+}
+
+// SetTotp saves user second factor, nil totp removes it
+func (db *Memdb) SetTotp(_ context.Context, userId string, totp *models.Totp) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	user.Totp = cloneTotp(totp)
+	db.users[userId] = user
+	return nil
+}
+
+// SetMigrated marks that all user documents are encrypted
+func (db *Memdb) SetMigrated(_ context.Context, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	user.Migrated = true
+	db.users[userId] = user
+	return nil
+}
+
+// SetPassword replaces user password hash and wrapped vault key in one update.
+// Password is marked as derived auth value. Nil vault key keeps stored one.
+func (db *Memdb) SetPassword(_ context.Context, userId string, passwordHash string, vaultKey []byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash, user.Derived = passwordHash, true
+	if vaultKey != nil {
+		user.VaultKey = append([]byte{}, vaultKey...)
+	}
+	db.users[userId] = user
+	return nil
+}
+
+// DeleteUser deactivates user and removes user secrets. Login stays reserved.
+func (db *Memdb) DeleteUser(_ context.Context, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	db.users[userId] = models.User{Id: user.Id, Login: user.Login, State: models.StateDeleted}
+	return nil
+}
+
+// AddLoginFailure increments number of failed login attempts and returns new number
+func (db *Memdb) AddLoginFailure(_ context.Context, userId string) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return 0, err
+	}
+	user.FailedLogins++
+	db.users[userId] = user
+	return user.FailedLogins, nil
+}
+
+// LockUser sets lockout time of user, earlier time does not shorten existing lockout
+func (db *Memdb) LockUser(_ context.Context, userId string, lockedUntil int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	if lockedUntil > user.LockedUntil {
+		user.LockedUntil = lockedUntil
+	}
+	db.users[userId] = user
+	return nil
+}
+
+// SetLoginFailures saves number of failed login attempts and lockout time
+func (db *Memdb) SetLoginFailures(_ context.Context, userId string, failures int, lockedUntil int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	user, err := db.getUser(userId)
+	if err != nil {
+		return err
+	}
+	user.FailedLogins, user.LockedUntil = failures, lockedUntil
+	db.users[userId] = user
+	return nil
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+func cloneTotp(totp *models.Totp) *models.Totp {
+	if totp == nil {
+		return nil
+	}
+	c := *totp
+	if c.RecoveryCodes != nil {
+		c.RecoveryCodes = append([]string{}, c.RecoveryCodes...)
+	}
+	return &c
+}
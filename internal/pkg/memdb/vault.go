@@ -0,0 +1,73 @@
+package memdb
+
+import (
+	"context"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddVault stores new shared vault
+func (db *Memdb) AddVault(_ context.Context, vault models.Vault) (string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	vault.Id = newId()
+	db.vaults[vault.Id] = cloneVault(vault)
+	return vault.Id, nil
+}
+
+// GetVault returns shared vault by id
+func (db *Memdb) GetVault(_ context.Context, vaultId string) (models.Vault, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	vault, ok := db.vaults[vaultId]
+	if !ok {
+		return vault, documents.ErrNoVault
+	}
+	return cloneVault(vault), nil
+}
+
+// ModifyVault replaces shared vault name and members
+func (db *Memdb) ModifyVault(_ context.Context, vault models.Vault) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.vaults[vault.Id]; !ok {
+		return documents.ErrNoVault
+	}
+	db.vaults[vault.Id] = cloneVault(vault)
+	return nil
+}
+
+// GetUserVaults returns shared vaults, user is member of
+func (db *Memdb) GetUserVaults(_ context.Context, userId string) ([]models.Vault, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var vaults []models.Vault
+	for _, vault := range db.vaults {
+		if _, ok := vault.Member(userId); ok {
+			vaults = append(vaults, cloneVault(vault))
+		}
+	}
+	return vaults, nil
+}
+
+// DeleteVault removes shared vault. Vault documents should be deleted separately.
+func (db *Memdb) DeleteVault(_ context.Context, vaultId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.vaults[vaultId]; !ok {
+		return documents.ErrNoVault
+	}
+	delete(db.vaults, vaultId)
+	return nil
+}
+
+func cloneVault(vault models.Vault) models.Vault {
+	members := make([]models.Member, len(vault.Members))
+	for i, m := range vault.Members {
+		m.VaultKey = cloneBytes(m.VaultKey)
+		members[i] = m
+	}
+	vault.Members = members
+	return vault
+}
@@ -8,52 +8,69 @@ type Meta struct {
 
 // Note is a document, that contains simple test
 type Note struct {
-	Id       string `bson:"_id,omitempty"` // document id
-	UserId   string `bson:"user_id"`       // user id
-	Serial   int64  `bson:"serial"`        // update serial number
-	Name     string `bson:"name"`          // document name
-	Text     string `bson:"text"`          // saved text
-	Metadata []Meta `bson:"metadata"`      // document metadata
-	State    string `bson:"state"`         // document state
+	Id        string   `bson:"_id,omitempty"`        // document id
+	UserId    string   `bson:"user_id"`              // user id or shared vault id
+	VaultId   string   `bson:"-" json:",omitempty"`  // shared vault id in transport, empty for user documents
+	Serial    int64    `bson:"serial"`               // update serial number
+	Name      string   `bson:"name"`                 // document name
+	Text      string   `bson:"text"`                 // saved text
+	Metadata  []Meta   `bson:"metadata"`             // document metadata
+	Folder    string   `bson:"folder,omitempty"`     // folder path, names are separated by "/"
+	Tags      []string `bson:"tags,omitempty"`       // document tags
+	State     string   `bson:"state"`                // document state
+	TrashedAt int64    `bson:"trashed_at,omitempty"` // unix time document was moved to trash
 }
 
 // Credential is login-password pair
 type Credential struct {
-	Id       string `bson:"_id,omitempty"` // document id
-	UserId   string `bson:"user_id"`       // user id
-	Serial   int64  `bson:"serial"`        // update serial number
-	Name     string `bson:"name"`          // document name
-	Login    string `bson:"login"`         // saved login
-	Password string `bson:"password"`      // saved password
-	Metadata []Meta `bson:"metadata"`      // document metadata
-	State    string `bson:"state"`         // document state
+	Id        string   `bson:"_id,omitempty"`        // document id
+	UserId    string   `bson:"user_id"`              // user id or shared vault id
+	VaultId   string   `bson:"-" json:",omitempty"`  // shared vault id in transport, empty for user documents
+	Serial    int64    `bson:"serial"`               // update serial number
+	Name      string   `bson:"name"`                 // document name
+	Login     string   `bson:"login"`                // saved login
+	Password  string   `bson:"password"`             // saved password
+	Metadata  []Meta   `bson:"metadata"`             // document metadata
+	Folder    string   `bson:"folder,omitempty"`     // folder path, names are separated by "/"
+	Tags      []string `bson:"tags,omitempty"`       // document tags
+	State     string   `bson:"state"`                // document state
+	TrashedAt int64    `bson:"trashed_at,omitempty"` // unix time document was moved to trash
 }
 
 // Card carries credit cards data
 type Card struct {
-	Id         string `bson:"_id,omitempty"` // document id
-	UserId     string `bson:"user_id"`       // user id
-	Serial     int64  `bson:"serial"`        // update serial number
-	Name       string `bson:"name"`          // document name
-	Cardholder string `bson:"cardholder"`    // cardholder name
-	Number     string `bson:"number"`        // card number
-	Expires    string `bson:"expires"`       // card expiration date
-	Pin        string `bson:"pin"`           // card pin
-	Code       string `bson:"code"`          // card cvc/cvv2 code
-	Metadata   []Meta `bson:"metadata"`      // document metadata
-	State      string `bson:"state"`         // document state
+	Id         string   `bson:"_id,omitempty"`        // document id
+	UserId     string   `bson:"user_id"`              // user id or shared vault id
+	VaultId    string   `bson:"-" json:",omitempty"`  // shared vault id in transport, empty for user documents
+	Serial     int64    `bson:"serial"`               // update serial number
+	Name       string   `bson:"name"`                 // document name
+	Cardholder string   `bson:"cardholder"`           // cardholder name
+	Number     string   `bson:"number"`               // card number
+	Expires    string   `bson:"expires"`              // card expiration date
+	Pin        string   `bson:"pin"`                  // card pin
+	Code       string   `bson:"code"`                 // card cvc/cvv2 code
+	Metadata   []Meta   `bson:"metadata"`             // document metadata
+	Folder     string   `bson:"folder,omitempty"`     // folder path, names are separated by "/"
+	Tags       []string `bson:"tags,omitempty"`       // document tags
+	State      string   `bson:"state"`                // document state
+	TrashedAt  int64    `bson:"trashed_at,omitempty"` // unix time document was moved to trash
 }
 
 // File document is a named file with metadata
 type File struct {
-	Id       string `bson:"_id,omitempty"` // documentId
-	UserId   string `bson:"user_id"`
-	Serial   int64  `bson:"serial"`
-	Name     string `bson:"name"`
-	Filename string `bson:"filename"`
-	Size     int64  `bson:"size"`
-	Sha265   string `bson:"sha265"`
-	Data     []byte `bson:"data"`
-	Metadata []Meta `bson:"metadata"`
-	State    string `bson:"state"`
+	Id        string   `bson:"_id,omitempty"` // documentId
+	UserId    string   `bson:"user_id"`
+	VaultId   string   `bson:"-" json:",omitempty"`
+	Serial    int64    `bson:"serial"`
+	Name      string   `bson:"name"`
+	Filename  string   `bson:"filename"`
+	Size      int64    `bson:"size"`
+	Sha265    string   `bson:"sha265"`
+	BlobId    string   `bson:"blob_id,omitempty"` // file data in chunked storage
+	Data      []byte   `bson:"data,omitempty"`
+	Metadata  []Meta   `bson:"metadata"`
+	Folder    string   `bson:"folder,omitempty"`
+	Tags      []string `bson:"tags,omitempty"`
+	State     string   `bson:"state"`
+	TrashedAt int64    `bson:"trashed_at,omitempty"`
 }
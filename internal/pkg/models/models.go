@@ -3,5 +3,6 @@ package models
 // Document status definition
 const (
 	StateActive  = "Active"
+	StateTrashed = "Trashed" // deleted, but payload is kept until trash retention expires
 	StateDeleted = "Deleted"
 )
@@ -0,0 +1,11 @@
+package models
+
+// Session is a chain of authorization tokens, issued by one successful login
+type Session struct {
+	Id        string `bson:"_id"`
+	UserId    string `bson:"user_id"`
+	Device    string `bson:"device"`     // client description, sent on login
+	CreatedAt int64  `bson:"created_at"` // unix time of login
+	LastSeen  int64  `bson:"last_seen"`  // unix time of the last token refresh
+	Current   bool   `bson:"-" json:"-"` // session of the requester, not stored
+}
@@ -0,0 +1,16 @@
+package models
+
+// Share is a snapshot of the document, shared by link with a person without account.
+// Data is encrypted by client with the key, which is a part of share link and is
+// never sent to server. Share is destroyed, when it expires or all views are used.
+type Share struct {
+	Id         string `bson:"_id"`
+	UserId     string `bson:"user_id"`     // owner of the shared document: user or shared vault
+	DocumentId string `bson:"document_id"` // shared document
+	Kind       string `bson:"kind"`        // shared document kind
+	Data       []byte `bson:"data"`        // encrypted document snapshot
+	ExpiresAt  int64  `bson:"expires_at"`  // unix time
+	ViewsLeft  int    `bson:"views_left"`
+	PassHash   []byte `bson:"pass_hash,omitempty"` // hash of optional passphrase
+	Failures   int    `bson:"failures"`            // number of wrong passphrase attempts
+}
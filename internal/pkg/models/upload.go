@@ -0,0 +1,13 @@
+package models
+
+// Upload is a resumable upload of file data. Data is appended to the blob, until
+// expected file size is reached. Unfinished upload is removed with its blob, when
+// it is not active longer than upload ttl.
+type Upload struct {
+	Id        string `bson:"_id"`
+	UserId    string `bson:"user_id"`    // owner of the file: user or shared vault
+	File      File   `bson:"file"`       // Size and Sha265 are expected values of the whole data
+	BlobId    string `bson:"blob_id"`    // received data
+	Offset    int64  `bson:"offset"`     // size of received data
+	UpdatedAt int64  `bson:"updated_at"` // unix time of the last activity
+}
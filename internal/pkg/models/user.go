@@ -3,7 +3,9 @@ package models
 // UserCredentials are user login and password to register and login
 type UserCredentials struct {
 	Login    string
-	Password string
+	Password string // auth value, derived by client from master password
+	Totp     string // one-time code or recovery code, required if two-factor authentication is enabled
+	Device   string // client description to identify session
 }
 
 // User is user storage implementation
@@ -12,4 +14,41 @@ type User struct {
 	Login        string `bson:"login"`
 	PasswordHash string `bson:"password"`
 	State        string `bson:"state"`
+	VaultKey     []byte `bson:"vault_key,omitempty"`     // vault key, wrapped by client with key derived from master password
+	PublicKey    []byte `bson:"public_key,omitempty"`    // public key, shared vault keys are sealed with it
+	PrivateKey   []byte `bson:"private_key,omitempty"`   // private key, sealed by client with vault key
+	Totp         *Totp  `bson:"totp,omitempty"`          // second authentication factor
+	FailedLogins int    `bson:"failed_logins,omitempty"` // consecutive failed login attempts
+	LockedUntil  int64  `bson:"locked_until,omitempty"`  // unix time, login is rejected until it
+	Migrated     bool   `bson:"migrated,omitempty"`      // all documents are encrypted, client rejects plain text
+	Derived      bool   `bson:"derived,omitempty"`       // password is auth value, derived by client; accounts created before have master password
+}
+
+// KeyPair is user key pair, which is used to share vault keys between users
+type KeyPair struct {
+	Public  []byte
+	Private []byte // sealed by client with user vault key
+}
+
+// Totp is time-based one-time password second factor of user
+type Totp struct {
+	Secret        string   `bson:"secret"`                   // base32 encoded shared secret
+	Enabled       bool     `bson:"enabled"`                  // secret is confirmed by user with valid code
+	RecoveryCodes []string `bson:"recovery_codes,omitempty"` // hashes of unused recovery codes
+	LastStep      int64    `bson:"last_step"`                // time step of the last accepted code, codes may not be reused
+}
+
+// TotpEnrollment is a new second factor, which should be imported into authenticator application
+type TotpEnrollment struct {
+	Secret        string   // base32 encoded shared secret
+	URI           string   // otpauth key uri
+	RecoveryCodes []string // one-time codes to log in without authenticator
+}
+
+// PasswordPolicy is a set of requirements to master password, it is checked by client,
+// since master password is never sent to server
+type PasswordPolicy struct {
+	MinLength  int  // minimal password length in characters
+	MinClasses int  // minimal number of character classes: lowercase, uppercase letters, digits, other
+	Breached   bool // password should not be known from data breaches
 }
@@ -0,0 +1,45 @@
+package models
+
+// Member roles in shared vault
+const (
+	RoleOwner = "owner" // manages vault and its members
+	RoleWrite = "write" // reads and modifies documents
+	RoleRead  = "read"  // reads documents
+)
+
+// Vault is a collection of documents, shared between users. Vault documents
+// are stored with vault id as user id.
+type Vault struct {
+	Id      string   `bson:"_id,omitempty"`
+	Name    string   `bson:"name"` // encrypted by client with vault key
+	Members []Member `bson:"members"`
+}
+
+// Member is a user, who has access to shared vault
+type Member struct {
+	UserId   string `bson:"user_id"`
+	Login    string `bson:"login"`
+	Role     string `bson:"role"`
+	VaultKey []byte `bson:"vault_key,omitempty"` // vault key, sealed by client with member public key
+	Serial   int64  `bson:"serial"`              // serial, at which user has joined the vault
+}
+
+// Member returns vault member with user id
+func (v Vault) Member(userId string) (Member, bool) {
+	for _, m := range v.Members {
+		if m.UserId == userId {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+// CanWrite shows whether member may modify vault documents
+func (m Member) CanWrite() bool {
+	return m.Role == RoleOwner || m.Role == RoleWrite
+}
+
+// ValidRole reports whether role is one of member roles
+func ValidRole(role string) bool {
+	return role == RoleOwner || role == RoleWrite || role == RoleRead
+}
@@ -0,0 +1,207 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/logger"
+)
+
+// chunkSize is a size of file data stored in one chunk document
+const chunkSize = 255 << 10
+
+var ErrBadBlob = errors.New("file data is corrupted")
+
+// chunk is a part of file data
+type chunk struct {
+	BlobId primitive.ObjectID `bson:"blob_id"`
+	UserId string             `bson:"user_id"`
+	N      int64              `bson:"n"`
+	Data   []byte             `bson:"data"`
+}
+
+// PutBlob reads data from r and stores it in chunks. Returns id of stored data.
+// Partially stored data is removed on error.
+func (db *Mongodb) PutBlob(ctx context.Context, userId string, r io.Reader) (string, error) {
+	blobId := primitive.NewObjectID()
+	if _, err := db.AppendBlob(ctx, blobId.Hex(), userId, 0, r); err != nil {
+		db.deleteChunks(blobId)
+		return "", err
+	}
+	return blobId.Hex(), nil
+}
+
+// AppendBlob reads data from r and appends it to stored data of size offset.
+// Data is stored by full chunks, the last short chunk is stored when r is finished.
+// Returns size of stored data, on error it may be less than data read from r.
+func (db *Mongodb) AppendBlob(ctx context.Context, blobId string, userId string, offset int64, r io.Reader) (int64, error) {
+	id, err := primitive.ObjectIDFromHex(blobId)
+	if err != nil {
+		return offset, ErrBadId
+	}
+	if offset%chunkSize != 0 {
+		// the last chunk is already stored
+		return offset, fmt.Errorf("%w: unable to append to complete data", ErrBadBlob)
+	}
+	coll := db.client.Database(dbName).Collection(collChunks)
+	buf := make([]byte, chunkSize)
+	for n := offset / chunkSize; ; n++ {
+		size, err := io.ReadFull(r, buf)
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return offset, err
+		}
+		c := chunk{BlobId: id, UserId: userId, N: n, Data: buf[:size]}
+		if _, err := coll.InsertOne(ctx, c); err != nil {
+			return offset, err
+		}
+		offset += int64(size)
+		if size < chunkSize {
+			return offset, nil
+		}
+	}
+}
+
+// OpenBlob returns reader of stored data, starting from offset.
+// Empty blobId is a file without data.
+func (db *Mongodb) OpenBlob(ctx context.Context, blobId string, offset int64) (io.ReadCloser, error) {
+	if blobId == "" {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	id, err := primitive.ObjectIDFromHex(blobId)
+	if err != nil {
+		return nil, ErrBadId
+	}
+	coll := db.client.Database(dbName).Collection(collChunks)
+	first := offset / chunkSize
+	filter := bson.D{
+		{Key: "blob_id", Value: id},
+		{Key: "n", Value: bson.D{{Key: "$gte", Value: first}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "n", Value: 1}}).SetBatchSize(8)
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &blobReader{ctx: ctx, cursor: cursor, next: first, skip: offset % chunkSize}, nil
+}
+
+// DeleteBlob removes stored data
+func (db *Mongodb) DeleteBlob(ctx context.Context, blobId string) error {
+	id, err := primitive.ObjectIDFromHex(blobId)
+	if err != nil {
+		return documents.ErrBadRequest
+	}
+	coll := db.client.Database(dbName).Collection(collChunks)
+	_, err = coll.DeleteMany(ctx, bson.D{{Key: "blob_id", Value: id}})
+	return err
+}
+
+// deleteChunks cleans up after failed write
+func (db *Mongodb) deleteChunks(blobId primitive.ObjectID) {
+	ctx, cancel := context.WithTimeout(context.Background(), connTimeout)
+	defer cancel()
+	coll := db.client.Database(dbName).Collection(collChunks)
+	if _, err := coll.DeleteMany(ctx, bson.D{{Key: "blob_id", Value: blobId}}); err != nil {
+		logger.Log().WithErr(err).Warnf("failed to delete chunks of blob %s", blobId.Hex())
+	}
+}
+
+// blobReader reads chunks of stored data one by one
+type blobReader struct {
+	ctx    context.Context
+	cursor *mongo.Cursor
+	next   int64
+	skip   int64 // bytes to skip in the first chunk
+	buf    []byte
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.cursor.Next(r.ctx) {
+			if err := r.cursor.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		var c chunk
+		if err := r.cursor.Decode(&c); err != nil {
+			return 0, err
+		}
+		if c.N != r.next {
+			return 0, fmt.Errorf("%w: chunk %d is missing", ErrBadBlob, r.next)
+		}
+		r.next++
+		r.buf = c.Data
+		if r.skip > 0 {
+			r.buf = r.buf[min(r.skip, int64(len(r.buf))):]
+			r.skip = 0
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *blobReader) Close() error {
+	return r.cursor.Close(context.Background())
+}
+
+// migrateInlineFiles moves file data, stored inside file documents and their revisions
+// by previous versions, to chunks.
+func (db *Mongodb) migrateInlineFiles(ctx context.Context) error {
+	migrate := func(collName string, filter bson.D, prefix ...string) error {
+		key := func(k string) []string { return append(append([]string{}, prefix...), k) }
+		coll := db.client.Database(dbName).Collection(collName)
+		cursor, err := coll.Find(ctx, filter)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = cursor.Close(context.Background()) }()
+		migrated := 0
+		for cursor.Next(ctx) {
+			var doc bson.Raw
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			userId, _ := doc.Lookup(key("user_id")...).StringValueOK()
+			_, data := doc.Lookup(key("data")...).Binary()
+			blobId, err := db.PutBlob(ctx, userId, bytes.NewReader(data))
+			if err != nil {
+				return err
+			}
+			update := bson.D{
+				{Key: "$set", Value: bson.D{{Key: strings.Join(key("blob_id"), "."), Value: blobId}}},
+				{Key: "$unset", Value: bson.D{{Key: strings.Join(key("data"), "."), Value: ""}}},
+			}
+			if _, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: doc.Lookup("_id")}}, update); err != nil {
+				return err
+			}
+			migrated++
+		}
+		if migrated > 0 {
+			logger.Log().Infof("migrated data of %d files to chunks in collection %s", migrated, collName)
+		}
+		return cursor.Err()
+	}
+	binary := bson.D{{Key: "$type", Value: "binData"}}
+	if err := migrate(collFiles, bson.D{{Key: "data", Value: binary}}); err != nil {
+		return err
+	}
+	return migrate(collHistory, bson.D{
+		{Key: "kind", Value: documents.KindFile},
+		{Key: "doc.data", Value: binary},
+	}, "doc")
+}
@@ -63,12 +63,16 @@ func (db *Mongodb) ModifyCard(ctx context.Context, card models.Card) error {
 		{Key: "_id", Value: id},
 		{Key: "user_id", Value: card.UserId},
 	}
-	var result interface{}
-	err = coll.FindOneAndReplace(ctx, filter, newCard).Decode(&result)
+	var replaced bson.Raw
+	err = coll.FindOneAndReplace(ctx, filter, newCard).Decode(&replaced)
 	if errors.Is(mongo.ErrNoDocuments, err) {
-		err = documents.ErrNotFound
+		return documents.ErrNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	db.archive(ctx, documents.KindCard, replaced)
+	return nil
 }
 
 // GetCardsStream produces stream of Cards updates, happened between minSerial and maxSerial
@@ -76,8 +80,10 @@ func (db *Mongodb) GetCardsStream(ctx context.Context, userId string, minSerial,
 	coll := db.client.Database(dbName).Collection(collCards)
 	filter := bson.D{
 		{Key: "user_id", Value: userId},
-		{Key: "serial", Value: bson.D{{Key: "$gt", Value: minSerial}}},
-		{Key: "serial", Value: bson.D{{Key: "$lt", Value: maxSerial}}},
+		{Key: "serial", Value: bson.D{
+			{Key: "$gt", Value: minSerial},
+			{Key: "$lt", Value: maxSerial},
+		}},
 	}
 	cursor, err := coll.Find(ctx, filter)
 	if err != nil {
@@ -63,12 +63,16 @@ func (db *Mongodb) ModifyCredential(ctx context.Context, credential models.Crede
 		{Key: "_id", Value: id},
 		{Key: "user_id", Value: credential.UserId},
 	}
-	var result interface{}
-	err = coll.FindOneAndReplace(ctx, filter, newCredential).Decode(&result)
+	var replaced bson.Raw
+	err = coll.FindOneAndReplace(ctx, filter, newCredential).Decode(&replaced)
 	if errors.Is(mongo.ErrNoDocuments, err) {
-		err = documents.ErrNotFound
+		return documents.ErrNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	db.archive(ctx, documents.KindCredential, replaced)
+	return nil
 }
 
 // GetCredentialsStream produces stream of Credentials updates, happened between minSerial and maxSerial
@@ -76,8 +80,10 @@ func (db *Mongodb) GetCredentialsStream(ctx context.Context, userId string, minS
 	coll := db.client.Database(dbName).Collection(collCredentials)
 	filter := bson.D{
 		{Key: "user_id", Value: userId},
-		{Key: "serial", Value: bson.D{{Key: "$gt", Value: minSerial}}},
-		{Key: "serial", Value: bson.D{{Key: "$lt", Value: maxSerial}}},
+		{Key: "serial", Value: bson.D{
+			{Key: "$gt", Value: minSerial},
+			{Key: "$lt", Value: maxSerial},
+		}},
 	}
 	cursor, err := coll.Find(ctx, filter)
 	if err != nil {
@@ -7,7 +7,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"yap-pwkeeper/internal/app/server/documents"
 	"yap-pwkeeper/internal/pkg/models"
@@ -42,12 +41,16 @@ func (db *Mongodb) ModifyFile(ctx context.Context, file models.File) error {
 		{Key: "_id", Value: id},
 		{Key: "user_id", Value: file.UserId},
 	}
-	var result interface{}
-	err = coll.FindOneAndReplace(ctx, filter, newFile).Decode(&result)
+	var replaced bson.Raw
+	err = coll.FindOneAndReplace(ctx, filter, newFile).Decode(&replaced)
 	if errors.Is(mongo.ErrNoDocuments, err) {
-		err = documents.ErrNotFound
+		return documents.ErrNotFound
+	}
+	if err != nil {
+		return err
 	}
-	return err
+	db.archive(ctx, documents.KindFile, replaced)
+	return nil
 }
 
 // ModifyFileInfo updates record without touching file data and information.
@@ -63,31 +66,30 @@ func (db *Mongodb) ModifyFileInfo(ctx context.Context, file models.File) error {
 		{Key: "user_id", Value: file.UserId},
 	}
 	update := bson.D{
-		{Key: "$set", Value: bson.D{{Key: "serial", Value: file.Serial}}},
-		{Key: "$set", Value: bson.D{{Key: "name", Value: file.Name}}},
-		{Key: "$set", Value: bson.D{{Key: "metadata", Value: file.Metadata}}},
+		{Key: "$set", Value: bson.D{
+			{Key: "serial", Value: file.Serial},
+			{Key: "name", Value: file.Name},
+			{Key: "filename", Value: file.Filename},
+			{Key: "metadata", Value: file.Metadata},
+			{Key: "folder", Value: file.Folder},
+			{Key: "tags", Value: file.Tags},
+			{Key: "state", Value: file.State},
+		}},
+	}
+	var replaced bson.Raw
+	err = coll.FindOneAndUpdate(ctx, filter, update).Decode(&replaced)
+	if errors.Is(mongo.ErrNoDocuments, err) {
+		return documents.ErrNotFound
 	}
-	res, err := coll.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
-	if res.MatchedCount == 0 {
-		return documents.ErrNotFound
-	}
-	return err
-}
-
-// GetFile returns file with binary data
-func (db *Mongodb) GetFile(ctx context.Context, docId string, userId string) (models.File, error) {
-	return db.getFile(ctx, docId, userId, false)
+	db.archive(ctx, documents.KindFile, replaced)
+	return nil
 }
 
-// GetFileInfo returns file without binary data
+// GetFileInfo returns file document. File data is stored separately, see OpenBlob.
 func (db *Mongodb) GetFileInfo(ctx context.Context, docId string, userId string) (models.File, error) {
-	return db.getFile(ctx, docId, userId, true)
-}
-
-func (db *Mongodb) getFile(ctx context.Context, docId string, userId string, withoutData bool) (models.File, error) {
 	coll := db.client.Database(dbName).Collection(collFiles)
 	file := models.File{}
 	id, err := primitive.ObjectIDFromHex(docId)
@@ -98,11 +100,7 @@ func (db *Mongodb) getFile(ctx context.Context, docId string, userId string, wit
 		{Key: "_id", Value: id},
 		{Key: "user_id", Value: userId},
 	}
-	opts := options.FindOne()
-	if withoutData {
-		opts = opts.SetProjection(bson.D{{Key: "data", Value: 0}})
-	}
-	if err := coll.FindOne(ctx, filter, opts).Decode(&file); err != nil {
+	if err := coll.FindOne(ctx, filter).Decode(&file); err != nil {
 		if errors.Is(mongo.ErrNoDocuments, err) {
 			err = documents.ErrNotFound
 		}
@@ -116,11 +114,12 @@ func (db *Mongodb) GetFilesInfoStream(ctx context.Context, userId string, minSer
 	coll := db.client.Database(dbName).Collection(collFiles)
 	filter := bson.D{
 		{Key: "user_id", Value: userId},
-		{Key: "serial", Value: bson.D{{Key: "$gt", Value: minSerial}}},
-		{Key: "serial", Value: bson.D{{Key: "$lt", Value: maxSerial}}},
+		{Key: "serial", Value: bson.D{
+			{Key: "$gt", Value: minSerial},
+			{Key: "$lt", Value: maxSerial},
+		}},
 	}
-	opts := options.Find().SetProjection(bson.D{{Key: "data", Value: 0}})
-	cursor, err := coll.Find(ctx, filter, opts)
+	cursor, err := coll.Find(ctx, filter)
 	if err != nil {
 		return err
 	}
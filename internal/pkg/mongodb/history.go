@@ -0,0 +1,209 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/logger"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// historyRecord is a previous revision of the document
+type historyRecord struct {
+	DocId  primitive.ObjectID `bson:"doc_id"`
+	UserId string             `bson:"user_id"`
+	Kind   string             `bson:"kind"`
+	Serial int64              `bson:"serial"`
+	Doc    bson.Raw           `bson:"doc"`
+}
+
+// archive copies replaced document revision to history. It is called with the document,
+// returned by successful replacement, so failed update leaves no revision in history,
+// and concurrent updates archive exactly the revisions they replace. Deleted documents
+// have no payload and are not archived, trashed documents have the same payload as
+// the previous revision. Document is already replaced, so archive failure is only logged.
+func (db *Mongodb) archive(ctx context.Context, kind string, replaced bson.Raw) {
+	if state, _ := replaced.Lookup("state").StringValueOK(); state == models.StateDeleted || state == models.StateTrashed {
+		return
+	}
+	id, _ := replaced.Lookup("_id").ObjectIDOK()
+	userId, _ := replaced.Lookup("user_id").StringValueOK()
+	serial, _ := replaced.Lookup("serial").AsInt64OK()
+	record := historyRecord{
+		DocId:  id,
+		UserId: userId,
+		Kind:   kind,
+		Serial: serial,
+		Doc:    replaced,
+	}
+	if _, err := db.client.Database(dbName).Collection(collHistory).InsertOne(ctx, record); err != nil {
+		logger.Log().WithCtxRequestId(ctx).With("documentId", id.Hex(), "serial", serial).
+			Errorf("failed to archive document revision: %s", err.Error())
+	}
+}
+
+// GetHistoryStream produces stream of previous document revisions, the newest first.
+// Files are sent without binary data.
+func (db *Mongodb) GetHistoryStream(ctx context.Context, docId string, userId string, chData chan interface{}) error {
+	coll := db.client.Database(dbName).Collection(collHistory)
+	id, err := primitive.ObjectIDFromHex(docId)
+	if err != nil {
+		return documents.ErrBadRequest
+	}
+	filter := bson.D{
+		{Key: "user_id", Value: userId},
+		{Key: "doc_id", Value: id},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "serial", Value: -1}}).
+		SetProjection(bson.D{{Key: "doc.data", Value: 0}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cursor.Close(context.Background()) }()
+	for cursor.Next(ctx) {
+		var record historyRecord
+		if err := cursor.Decode(&record); err != nil {
+			return err
+		}
+		doc, err := record.decode()
+		if err != nil {
+			return err
+		}
+		chData <- doc
+	}
+	return cursor.Err()
+}
+
+// GetRevision returns document revision with requested serial from history
+func (db *Mongodb) GetRevision(ctx context.Context, docId string, userId string, serial int64) (interface{}, error) {
+	coll := db.client.Database(dbName).Collection(collHistory)
+	id, err := primitive.ObjectIDFromHex(docId)
+	if err != nil {
+		return nil, documents.ErrBadRequest
+	}
+	filter := bson.D{
+		{Key: "user_id", Value: userId},
+		{Key: "doc_id", Value: id},
+		{Key: "serial", Value: serial},
+	}
+	var record historyRecord
+	if err := coll.FindOne(ctx, filter).Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = documents.ErrNotFound
+		}
+		return nil, err
+	}
+	return record.decode()
+}
+
+// DeleteHistory removes all revisions of the document
+func (db *Mongodb) DeleteHistory(ctx context.Context, docId string, userId string) error {
+	coll := db.client.Database(dbName).Collection(collHistory)
+	id, err := primitive.ObjectIDFromHex(docId)
+	if err != nil {
+		return documents.ErrBadRequest
+	}
+	filter := bson.D{
+		{Key: "user_id", Value: userId},
+		{Key: "doc_id", Value: id},
+	}
+	_, err = coll.DeleteMany(ctx, filter)
+	return err
+}
+
+// DeleteRevisions removes revisions of the document with requested serials
+func (db *Mongodb) DeleteRevisions(ctx context.Context, docId string, userId string, serials []int64) error {
+	coll := db.client.Database(dbName).Collection(collHistory)
+	id, err := primitive.ObjectIDFromHex(docId)
+	if err != nil {
+		return documents.ErrBadRequest
+	}
+	filter := bson.D{
+		{Key: "user_id", Value: userId},
+		{Key: "doc_id", Value: id},
+		{Key: "serial", Value: bson.D{{Key: "$in", Value: serials}}},
+	}
+	_, err = coll.DeleteMany(ctx, filter)
+	return err
+}
+
+// TrimHistory removes the oldest revisions of the document, keeping keep newest ones,
+// and returns removed revisions. File revisions are returned without binary data.
+func (db *Mongodb) TrimHistory(ctx context.Context, docId string, userId string, keep int) ([]interface{}, error) {
+	coll := db.client.Database(dbName).Collection(collHistory)
+	id, err := primitive.ObjectIDFromHex(docId)
+	if err != nil {
+		return nil, documents.ErrBadRequest
+	}
+	filter := bson.D{
+		{Key: "user_id", Value: userId},
+		{Key: "doc_id", Value: id},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "serial", Value: -1}}).
+		SetSkip(int64(keep)).
+		SetProjection(bson.D{{Key: "doc.data", Value: 0}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var records []historyRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	removed := make([]interface{}, 0, len(records))
+	serials := make([]int64, 0, len(records))
+	for _, record := range records {
+		doc, err := record.decode()
+		if err != nil {
+			return nil, err
+		}
+		removed = append(removed, doc)
+		serials = append(serials, record.Serial)
+	}
+	filter = append(filter, bson.E{Key: "serial", Value: bson.D{{Key: "$in", Value: serials}}})
+	if _, err := coll.DeleteMany(ctx, filter); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// decode returns revision as a document model
+func (r historyRecord) decode() (interface{}, error) {
+	return decodeDoc(r.Kind, r.Doc)
+}
+
+// decodeDoc decodes raw document of requested kind into document model
+func decodeDoc(kind string, raw bson.Raw) (interface{}, error) {
+	var err error
+	switch kind {
+	case documents.KindNote:
+		var doc models.Note
+		err = bson.Unmarshal(raw, &doc)
+		return doc, err
+	case documents.KindCard:
+		var doc models.Card
+		err = bson.Unmarshal(raw, &doc)
+		return doc, err
+	case documents.KindCredential:
+		var doc models.Credential
+		err = bson.Unmarshal(raw, &doc)
+		return doc, err
+	case documents.KindFile:
+		var doc models.File
+		err = bson.Unmarshal(raw, &doc)
+		return doc, err
+	}
+	return nil, errors.New("unknown document kind: " + kind)
+}
@@ -23,6 +23,12 @@ const (
 	collCredentials               = "credentials"
 	collCards                     = "cards"
 	collFiles                     = "files"
+	collHistory                   = "history"
+	collChunks                    = "chunks"
+	collSessions                  = "sessions"
+	collVaults                    = "vaults"
+	collShares                    = "shares"
+	collUploads                   = "uploads"
 )
 
 var (
@@ -45,6 +51,9 @@ func New(ctx context.Context, uri string, opts ...func(db *Mongodb)) (*Mongodb,
 	if err := db.createIndexes(ctx); err != nil {
 		return nil, fmt.Errorf("failed to create index: %w", err)
 	}
+	if err := db.migrateInlineFiles(ctx); err != nil {
+		return nil, fmt.Errorf("failed to migrate files data: %w", err)
+	}
 	return db, nil
 }
 
@@ -80,6 +89,77 @@ func (db *Mongodb) createIndexes(ctx context.Context) error {
 		}
 	}
 
+	// trash search index
+	trash := mongo.IndexModel{
+		Keys: bson.D{{Key: "state", Value: 1}, {Key: "trashed_at", Value: 1}},
+	}
+	for _, v := range []string{collCards, collNotes, collCredentials, collFiles} {
+		coll = db.client.Database(dbName).Collection(v)
+		logger.Log().Infof("create index: state 1 trashed_at 1 for collection %s", v)
+		if _, err := coll.Indexes().CreateOne(ctx, trash); err != nil {
+			return err
+		}
+	}
+
+	// document history index
+	coll = db.client.Database(dbName).Collection(collHistory)
+	history := mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "doc_id", Value: 1}, {Key: "serial", Value: -1}},
+	}
+	logger.Log().Infof("create index: user_id 1 doc_id 1 serial -1 for collection %s", collHistory)
+	if _, err := coll.Indexes().CreateOne(ctx, history); err != nil {
+		return err
+	}
+
+	// file data chunks index
+	coll = db.client.Database(dbName).Collection(collChunks)
+	chunks := mongo.IndexModel{
+		Keys:    bson.D{{Key: "blob_id", Value: 1}, {Key: "n", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	logger.Log().Infof("create index: blob_id 1 n 1 unique for collection %s", collChunks)
+	if _, err := coll.Indexes().CreateOne(ctx, chunks); err != nil {
+		return err
+	}
+
+	// user sessions index
+	coll = db.client.Database(dbName).Collection(collSessions)
+	sessions := mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}},
+	}
+	logger.Log().Infof("create index: user_id 1 created_at 1 for collection %s", collSessions)
+	if _, err := coll.Indexes().CreateOne(ctx, sessions); err != nil {
+		return err
+	}
+
+	// vault members index
+	coll = db.client.Database(dbName).Collection(collVaults)
+	members := mongo.IndexModel{
+		Keys: bson.D{{Key: "members.user_id", Value: 1}},
+	}
+	logger.Log().Infof("create index: members.user_id 1 for collection %s", collVaults)
+	if _, err := coll.Indexes().CreateOne(ctx, members); err != nil {
+		return err
+	}
+
+	// shares indexes for owner deletion and expiration purge
+	coll = db.client.Database(dbName).Collection(collShares)
+	for _, key := range []string{"user_id", "expires_at"} {
+		logger.Log().Infof("create index: %s 1 for collection %s", key, collShares)
+		if _, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: key, Value: 1}}}); err != nil {
+			return err
+		}
+	}
+
+	// uploads indexes for owner deletion and expiration purge
+	coll = db.client.Database(dbName).Collection(collUploads)
+	for _, key := range []string{"user_id", "updated_at"} {
+		logger.Log().Infof("create index: %s 1 for collection %s", key, collUploads)
+		if _, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: key, Value: 1}}}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
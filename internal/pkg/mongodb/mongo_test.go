@@ -0,0 +1,25 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/storagetest"
+)
+
+// TestConformance runs against real database, set TEST_DB_URI to mongodb connection string to run it
+func TestConformance(t *testing.T) {
+	uri := os.Getenv("TEST_DB_URI")
+	if uri == "" {
+		t.Skip("TEST_DB_URI is not set")
+	}
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		db, err := New(context.Background(), uri)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close(context.Background()) })
+		return db
+	})
+}
@@ -63,12 +63,16 @@ func (db *Mongodb) ModifyNote(ctx context.Context, note models.Note) error {
 		{Key: "_id", Value: id},
 		{Key: "user_id", Value: note.UserId},
 	}
-	var result interface{}
-	err = coll.FindOneAndReplace(ctx, filter, newNote).Decode(&result)
+	var replaced bson.Raw
+	err = coll.FindOneAndReplace(ctx, filter, newNote).Decode(&replaced)
 	if errors.Is(mongo.ErrNoDocuments, err) {
-		err = documents.ErrNotFound
+		return documents.ErrNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	db.archive(ctx, documents.KindNote, replaced)
+	return nil
 }
 
 // GetNotesStream produces stream of Notes updates, happened between minSerial and maxSerial
@@ -76,8 +80,10 @@ func (db *Mongodb) GetNotesStream(ctx context.Context, userId string, minSerial,
 	coll := db.client.Database(dbName).Collection(collNotes)
 	filter := bson.D{
 		{Key: "user_id", Value: userId},
-		{Key: "serial", Value: bson.D{{Key: "$gt", Value: minSerial}}},
-		{Key: "serial", Value: bson.D{{Key: "$lt", Value: maxSerial}}},
+		{Key: "serial", Value: bson.D{
+			{Key: "$gt", Value: minSerial},
+			{Key: "$lt", Value: maxSerial},
+		}},
 	}
 	cursor, err := coll.Find(ctx, filter)
 	if err != nil {
@@ -0,0 +1,19 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DeleteUserDocuments removes all user documents of every kind and their history.
+// File data is not removed.
+func (db *Mongodb) DeleteUserDocuments(ctx context.Context, userId string) error {
+	filter := bson.D{{Key: "user_id", Value: userId}}
+	for _, name := range []string{collNotes, collCards, collCredentials, collFiles, collHistory} {
+		if _, err := db.client.Database(dbName).Collection(name).DeleteMany(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddSession stores new user session
+func (db *Mongodb) AddSession(ctx context.Context, session models.Session) error {
+	coll := db.client.Database(dbName).Collection(collSessions)
+	_, err := coll.InsertOne(ctx, session)
+	return err
+}
+
+// GetSession returns session by id
+func (db *Mongodb) GetSession(ctx context.Context, sessionId string) (models.Session, error) {
+	var session models.Session
+	coll := db.client.Database(dbName).Collection(collSessions)
+	err := coll.FindOne(ctx, bson.D{{Key: "_id", Value: sessionId}}).Decode(&session)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = aaa.ErrNoSession
+	}
+	return session, err
+}
+
+// GetSessions returns all user sessions, the oldest first
+func (db *Mongodb) GetSessions(ctx context.Context, userId string) ([]models.Session, error) {
+	coll := db.client.Database(dbName).Collection(collSessions)
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := coll.Find(ctx, bson.D{{Key: "user_id", Value: userId}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []models.Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TouchSession updates time of the last session activity
+func (db *Mongodb) TouchSession(ctx context.Context, sessionId string, lastSeen int64) error {
+	coll := db.client.Database(dbName).Collection(collSessions)
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "last_seen", Value: lastSeen}}}}
+	res, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: sessionId}}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNoSession
+	}
+	return nil
+}
+
+// DeleteSession removes user session
+func (db *Mongodb) DeleteSession(ctx context.Context, sessionId string, userId string) error {
+	coll := db.client.Database(dbName).Collection(collSessions)
+	res, err := coll.DeleteOne(ctx, bson.D{
+		{Key: "_id", Value: sessionId},
+		{Key: "user_id", Value: userId},
+	})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return aaa.ErrNoSession
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddShare places new document share in the database
+func (db *Mongodb) AddShare(ctx context.Context, share models.Share) error {
+	coll := db.client.Database(dbName).Collection(collShares)
+	_, err := coll.InsertOne(ctx, share)
+	return err
+}
+
+// GetShare returns document share by id
+func (db *Mongodb) GetShare(ctx context.Context, shareId string) (models.Share, error) {
+	coll := db.client.Database(dbName).Collection(collShares)
+	var share models.Share
+	err := coll.FindOne(ctx, bson.D{{Key: "_id", Value: shareId}}).Decode(&share)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = documents.ErrNoShare
+	}
+	return share, err
+}
+
+// ModifyShare replaces document share
+func (db *Mongodb) ModifyShare(ctx context.Context, share models.Share) error {
+	coll := db.client.Database(dbName).Collection(collShares)
+	res, err := coll.ReplaceOne(ctx, bson.D{{Key: "_id", Value: share.Id}}, share)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return documents.ErrNoShare
+	}
+	return nil
+}
+
+// DeleteShare removes document share
+func (db *Mongodb) DeleteShare(ctx context.Context, shareId string) error {
+	coll := db.client.Database(dbName).Collection(collShares)
+	res, err := coll.DeleteOne(ctx, bson.D{{Key: "_id", Value: shareId}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return documents.ErrNoShare
+	}
+	return nil
+}
+
+// DeleteExpiredShares removes shares, which expire at or before time, and returns their number
+func (db *Mongodb) DeleteExpiredShares(ctx context.Context, before int64) (int, error) {
+	coll := db.client.Database(dbName).Collection(collShares)
+	res, err := coll.DeleteMany(ctx, bson.D{{Key: "expires_at", Value: bson.D{{Key: "$lte", Value: before}}}})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.DeletedCount), nil
+}
+
+// DeleteUserShares removes all shares of owner documents
+func (db *Mongodb) DeleteUserShares(ctx context.Context, userId string) error {
+	coll := db.client.Database(dbName).Collection(collShares)
+	_, err := coll.DeleteMany(ctx, bson.D{{Key: "user_id", Value: userId}})
+	return err
+}
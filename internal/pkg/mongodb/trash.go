@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// GetTrashStream produces stream of documents of all users and kinds, which were
+// moved to trash before trashedBefore unix time. Files are sent without binary data.
+func (db *Mongodb) GetTrashStream(ctx context.Context, trashedBefore int64, chData chan interface{}) error {
+	filter := bson.D{
+		{Key: "state", Value: models.StateTrashed},
+		{Key: "trashed_at", Value: bson.D{{Key: "$lt", Value: trashedBefore}}},
+	}
+	opts := options.Find().SetProjection(bson.D{{Key: "data", Value: 0}})
+	collections := map[string]string{
+		collNotes:       documents.KindNote,
+		collCards:       documents.KindCard,
+		collCredentials: documents.KindCredential,
+		collFiles:       documents.KindFile,
+	}
+	for collName, kind := range collections {
+		if err := db.streamDocs(ctx, collName, kind, filter, opts, chData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamDocs sends documents found in collection as document models of kind
+func (db *Mongodb) streamDocs(ctx context.Context, collName, kind string, filter bson.D, opts *options.FindOptions, chData chan interface{}) error {
+	coll := db.client.Database(dbName).Collection(collName)
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cursor.Close(context.Background()) }()
+	for cursor.Next(ctx) {
+		doc, err := decodeDoc(kind, cursor.Current)
+		if err != nil {
+			return err
+		}
+		chData <- doc
+	}
+	return cursor.Err()
+}
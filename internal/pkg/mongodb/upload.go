@@ -0,0 +1,97 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddUpload places new file upload in the database
+func (db *Mongodb) AddUpload(ctx context.Context, upload models.Upload) error {
+	coll := db.client.Database(dbName).Collection(collUploads)
+	_, err := coll.InsertOne(ctx, upload)
+	return err
+}
+
+// GetUpload returns file upload by id
+func (db *Mongodb) GetUpload(ctx context.Context, uploadId string) (models.Upload, error) {
+	coll := db.client.Database(dbName).Collection(collUploads)
+	var upload models.Upload
+	err := coll.FindOne(ctx, bson.D{{Key: "_id", Value: uploadId}}).Decode(&upload)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = documents.ErrUploadNotFound
+	}
+	return upload, err
+}
+
+// SetUploadOffset saves size of received data and time of upload activity
+func (db *Mongodb) SetUploadOffset(ctx context.Context, uploadId string, offset int64, updatedAt int64) error {
+	coll := db.client.Database(dbName).Collection(collUploads)
+	set := bson.D{{Key: "offset", Value: offset}, {Key: "updated_at", Value: updatedAt}}
+	res, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: uploadId}}, bson.D{{Key: "$set", Value: set}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return documents.ErrUploadNotFound
+	}
+	return nil
+}
+
+// DeleteUpload removes file upload, its data blob is kept
+func (db *Mongodb) DeleteUpload(ctx context.Context, uploadId string) error {
+	coll := db.client.Database(dbName).Collection(collUploads)
+	res, err := coll.DeleteOne(ctx, bson.D{{Key: "_id", Value: uploadId}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return documents.ErrUploadNotFound
+	}
+	return nil
+}
+
+// GetExpiredUploads returns uploads, which were active at or before time
+func (db *Mongodb) GetExpiredUploads(ctx context.Context, before int64) ([]models.Upload, error) {
+	coll := db.client.Database(dbName).Collection(collUploads)
+	cursor, err := coll.Find(ctx, bson.D{{Key: "updated_at", Value: bson.D{{Key: "$lte", Value: before}}}})
+	if err != nil {
+		return nil, err
+	}
+	var expired []models.Upload
+	if err := cursor.All(ctx, &expired); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// DeleteUserUploads removes all uploads of owner files and returns them, data blobs are kept
+func (db *Mongodb) DeleteUserUploads(ctx context.Context, userId string) ([]models.Upload, error) {
+	coll := db.client.Database(dbName).Collection(collUploads)
+	filter := bson.D{{Key: "user_id", Value: userId}}
+	cursor, err := coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []models.Upload
+	if err := cursor.All(ctx, &deleted); err != nil {
+		return nil, err
+	}
+	if len(deleted) == 0 {
+		return nil, nil
+	}
+	// only found uploads are deleted, their blobs are dropped by caller
+	ids := make([]string, len(deleted))
+	for i, upload := range deleted {
+		ids[i] = upload.Id
+	}
+	if _, err := coll.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
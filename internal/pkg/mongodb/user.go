@@ -5,7 +5,9 @@ import (
 	"errors"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"yap-pwkeeper/internal/app/server/aaa"
 	"yap-pwkeeper/internal/pkg/models"
@@ -41,3 +43,253 @@ func (db *Mongodb) GetUserByLogin(ctx context.Context, login string) (models.Use
 	}
 	return user, err
 }
+
+// GetUserById returns active user by id
+func (db *Mongodb) GetUserById(ctx context.Context, userId string) (models.User, error) {
+	var user models.User
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return user, aaa.ErrNotFound
+	}
+	err = coll.FindOne(ctx,
+		bson.D{
+			{Key: "_id", Value: id},
+			{Key: "state", Value: models.StateActive},
+		}).Decode(&user)
+	if err != nil {
+		if errors.Is(mongo.ErrNoDocuments, err) {
+			err = aaa.ErrNotFound
+		}
+	}
+	return user, err
+}
+
+// SetVaultKey saves user vault key, only if it was not set before
+func (db *Mongodb) SetVaultKey(ctx context.Context, userId string, key []byte) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+		{Key: "vault_key", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "vault_key", Value: key}}},
+	}
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		if _, err := db.GetUserById(ctx, userId); err != nil {
+			return err
+		}
+		return aaa.ErrKeyExists
+	}
+	return nil
+}
+
+// SetKeyPair saves user key pair, only if it was not set before
+func (db *Mongodb) SetKeyPair(ctx context.Context, userId string, keys models.KeyPair) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+		{Key: "public_key", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "public_key", Value: keys.Public}, {Key: "private_key", Value: keys.Private}}},
+	}
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		if _, err := db.GetUserById(ctx, userId); err != nil {
+			return err
+		}
+		return aaa.ErrKeyExists
+	}
+	return nil
+}
+
+// SetTotp saves user second factor, nil totp removes it
+func (db *Mongodb) SetTotp(ctx context.Context, userId string, totp *models.Totp) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "totp", Value: totp}}}}
+	if totp == nil {
+		update = bson.D{{Key: "$unset", Value: bson.D{{Key: "totp", Value: ""}}}}
+	}
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNotFound
+	}
+	return nil
+}
+
+// SetMigrated marks that all user documents are encrypted
+func (db *Mongodb) SetMigrated(ctx context.Context, userId string) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "migrated", Value: true}}}}
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNotFound
+	}
+	return nil
+}
+
+// SetPassword replaces user password hash and wrapped vault key in one update.
+// Password is marked as derived auth value. Nil vault key keeps stored one.
+func (db *Mongodb) SetPassword(ctx context.Context, userId string, passwordHash string, vaultKey []byte) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	set := bson.D{{Key: "password", Value: passwordHash}, {Key: "derived", Value: true}}
+	if vaultKey != nil {
+		set = append(set, bson.E{Key: "vault_key", Value: vaultKey})
+	}
+	res, err := coll.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: set}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteUser deactivates user and removes user secrets. Login stays reserved.
+func (db *Mongodb) DeleteUser(ctx context.Context, userId string) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "state", Value: models.StateDeleted}, {Key: "password", Value: ""}}},
+		{Key: "$unset", Value: bson.D{
+			{Key: "vault_key", Value: ""},
+			{Key: "public_key", Value: ""},
+			{Key: "private_key", Value: ""},
+			{Key: "totp", Value: ""},
+		}},
+	}
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNotFound
+	}
+	return nil
+}
+
+// AddLoginFailure atomically increments number of failed login attempts and returns new number
+func (db *Mongodb) AddLoginFailure(ctx context.Context, userId string) (int, error) {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return 0, aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "failed_logins", Value: 1}}}}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.D{{Key: "failed_logins", Value: 1}})
+	var user models.User
+	err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, aaa.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return user.FailedLogins, nil
+}
+
+// LockUser sets lockout time of user, earlier time does not shorten existing lockout
+func (db *Mongodb) LockUser(ctx context.Context, userId string, lockedUntil int64) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	update := bson.D{{Key: "$max", Value: bson.D{{Key: "locked_until", Value: lockedUntil}}}}
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNotFound
+	}
+	return nil
+}
+
+// SetLoginFailures saves number of failed login attempts and lockout time
+func (db *Mongodb) SetLoginFailures(ctx context.Context, userId string, failures int, lockedUntil int64) error {
+	coll := db.client.Database(dbName).Collection(collUsers)
+	id, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return aaa.ErrNotFound
+	}
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "state", Value: models.StateActive},
+	}
+	set := bson.D{{Key: "failed_logins", Value: failures}, {Key: "locked_until", Value: lockedUntil}}
+	res, err := coll.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: set}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return aaa.ErrNotFound
+	}
+	return nil
+}
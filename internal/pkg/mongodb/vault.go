@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// AddVault places new shared vault in the database
+func (db *Mongodb) AddVault(ctx context.Context, vault models.Vault) (string, error) {
+	coll := db.client.Database(dbName).Collection(collVaults)
+	vault.Id = ""
+	res, err := coll.InsertOne(ctx, vault)
+	if err != nil {
+		return "", err
+	}
+	return oid2string(res.InsertedID)
+}
+
+// GetVault returns shared vault by id
+func (db *Mongodb) GetVault(ctx context.Context, vaultId string) (models.Vault, error) {
+	coll := db.client.Database(dbName).Collection(collVaults)
+	var vault models.Vault
+	id, err := primitive.ObjectIDFromHex(vaultId)
+	if err != nil {
+		return vault, documents.ErrNoVault
+	}
+	err = coll.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&vault)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = documents.ErrNoVault
+	}
+	return vault, err
+}
+
+// ModifyVault replaces shared vault name and members
+func (db *Mongodb) ModifyVault(ctx context.Context, vault models.Vault) error {
+	coll := db.client.Database(dbName).Collection(collVaults)
+	id, err := primitive.ObjectIDFromHex(vault.Id)
+	if err != nil {
+		return documents.ErrNoVault
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "name", Value: vault.Name},
+		{Key: "members", Value: vault.Members},
+	}}}
+	res, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return documents.ErrNoVault
+	}
+	return nil
+}
+
+// GetUserVaults returns shared vaults, user is member of
+func (db *Mongodb) GetUserVaults(ctx context.Context, userId string) ([]models.Vault, error) {
+	coll := db.client.Database(dbName).Collection(collVaults)
+	cursor, err := coll.Find(ctx, bson.D{{Key: "members.user_id", Value: userId}})
+	if err != nil {
+		return nil, err
+	}
+	var vaults []models.Vault
+	if err := cursor.All(ctx, &vaults); err != nil {
+		return nil, err
+	}
+	return vaults, nil
+}
+
+// DeleteVault removes shared vault. Vault documents should be deleted separately.
+func (db *Mongodb) DeleteVault(ctx context.Context, vaultId string) error {
+	coll := db.client.Database(dbName).Collection(collVaults)
+	id, err := primitive.ObjectIDFromHex(vaultId)
+	if err != nil {
+		return documents.ErrNoVault
+	}
+	res, err := coll.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return documents.ErrNoVault
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package pwpolicy checks master password against password policy of server.
+// Master password never reaches server, so it is checked by client. Breached
+// passwords are checked with range queries: only 5 hex digits prefix of password
+// hash is sent to server, and received hash suffixes are compared locally.
+package pwpolicy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"yap-pwkeeper/internal/pkg/breached"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+var ErrPolicy = errors.New("password does not match policy")
+
+// Check returns ErrPolicy with all reasons of rejection, when password does not match policy.
+// breachedRange returns hash suffixes of breached passwords by hash prefix, it is used if policy
+// requires. Error of breached password check is returned as is.
+func Check(policy models.PasswordPolicy, login, password string, breachedRange func(prefix string) ([]string, error)) error {
+	var reasons []string
+	if n := len([]rune(password)); n == 0 || n < policy.MinLength {
+		reasons = append(reasons, fmt.Sprintf("password should have at least %d characters", max(policy.MinLength, 1)))
+	}
+	if classes := charClasses(password); classes < policy.MinClasses {
+		reasons = append(reasons, fmt.Sprintf(
+			"password should have at least %d of: lowercase, uppercase letters, digits, other symbols", policy.MinClasses))
+	}
+	if password != "" && strings.EqualFold(password, login) {
+		reasons = append(reasons, "password should differ from login")
+	}
+	if len(reasons) == 0 && policy.Breached && breachedRange != nil {
+		prefix, suffix := breached.Hash(password)
+		suffixes, err := breachedRange(prefix)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(suffixes, suffix) {
+			reasons = append(reasons, "password is known from data breaches, choose another one")
+		}
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("%w: %s", ErrPolicy, strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+// charClasses counts character classes of password
+func charClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
@@ -0,0 +1,58 @@
+package pwpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/pkg/breached"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func TestCheck(t *testing.T) {
+	breachedRange := func(prefix string) ([]string, error) {
+		if p, suffix := breached.Hash("Password1"); p == prefix {
+			return []string{"0000000000000000000000000000000000A", suffix}, nil
+		}
+		return nil, nil
+	}
+	policy := models.PasswordPolicy{MinLength: 8, MinClasses: 3, Breached: true}
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		reasons  []string
+	}{
+		{name: "good", login: "user@example.com", password: "Good password 1"},
+		{name: "empty", reasons: []string{"at least 8 characters", "at least 3 of"}},
+		{name: "short", login: "user", password: "Ab1", reasons: []string{"at least 8 characters"}},
+		{name: "classes", login: "user", password: "lowercase only", reasons: []string{"at least 3 of"}},
+		{name: "same as login", login: "User.Name1", password: "user.name1", reasons: []string{"differ from login"}},
+		{name: "breached", login: "user", password: "Password1", reasons: []string{"known from data breaches"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(policy, tt.login, tt.password, breachedRange)
+			if len(tt.reasons) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrPolicy)
+			for _, reason := range tt.reasons {
+				assert.Contains(t, err.Error(), reason)
+			}
+		})
+	}
+
+	t.Run("breached check failed", func(t *testing.T) {
+		failure := errors.New("request failed")
+		fail := func(string) ([]string, error) { return nil, failure }
+		require.ErrorIs(t, Check(models.PasswordPolicy{Breached: true}, "user", "password", fail), failure)
+	})
+
+	t.Run("breached check disabled", func(t *testing.T) {
+		require.NoError(t, Check(models.PasswordPolicy{MinLength: 8}, "user", "Password1", breachedRange))
+	})
+}
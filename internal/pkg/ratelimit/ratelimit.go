@@ -0,0 +1,158 @@
+// Package ratelimit throttles repeated attempts of the same client. Each key (login,
+// peer address) has limited number of requests per window, and after several failures
+// it is blocked for exponentially growing delay.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Policy is exponential backoff of failed attempts
+type Policy struct {
+	Free int           // failures allowed without delay
+	Base time.Duration // delay after the first failure beyond free ones, doubled on each next one
+	Max  time.Duration // maximal delay
+}
+
+// Delay returns how long attempts are blocked after number of consecutive failures
+func (p Policy) Delay(failures int) time.Duration {
+	n := failures - p.Free
+	if n <= 0 || p.Base <= 0 {
+		return 0
+	}
+	d := p.Base
+	for i := 1; i < n && d < p.Max; i++ {
+		d *= 2
+	}
+	if p.Max > 0 {
+		d = min(d, p.Max)
+	}
+	return d
+}
+
+// Limiter keeps attempts of each key in memory
+type Limiter struct {
+	mu        sync.Mutex
+	policy    Policy
+	rate      int           // requests per window, 0 is unlimited
+	window    time.Duration // rate window
+	keys      map[string]*attempts
+	lastPurge time.Time
+	now       func() time.Time
+}
+
+// attempts are recent attempts of one key
+type attempts struct {
+	failures     int
+	blockedUntil time.Time
+	windowStart  time.Time
+	requests     int
+	last         time.Time
+}
+
+// New is Limiter constructor
+func New(policy Policy, options ...func(l *Limiter)) *Limiter {
+	l := &Limiter{
+		policy: policy,
+		window: time.Minute,
+		keys:   make(map[string]*attempts),
+		now:    time.Now,
+	}
+	for _, opt := range options {
+		opt(l)
+	}
+	return l
+}
+
+// WithRate limits number of requests of each key per window
+func WithRate(rate int, window time.Duration) func(l *Limiter) {
+	return func(l *Limiter) {
+		l.rate = rate
+		if window > 0 {
+			l.window = window
+		}
+	}
+}
+
+// Allow registers request of all keys. It returns zero if request is allowed,
+// otherwise it returns how long client should wait before the next attempt.
+// Rejected request is not counted.
+func (l *Limiter) Allow(keys ...string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.purge(now)
+	var wait time.Duration
+	for _, key := range keys {
+		a := l.get(key, now)
+		if a.blockedUntil.After(now) {
+			wait = max(wait, a.blockedUntil.Sub(now))
+		}
+		if l.rate > 0 && a.requests >= l.rate {
+			wait = max(wait, a.windowStart.Add(l.window).Sub(now))
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+	for _, key := range keys {
+		l.keys[key].requests++
+	}
+	return 0
+}
+
+// Fail registers failed attempt of all keys, keys are blocked according to policy
+func (l *Limiter) Fail(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for _, key := range keys {
+		a := l.get(key, now)
+		a.failures++
+		if d := l.policy.Delay(a.failures); d > 0 {
+			a.blockedUntil = now.Add(d)
+		}
+	}
+}
+
+// Success resets failures of all keys
+func (l *Limiter) Success(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if a, ok := l.keys[key]; ok {
+			a.failures = 0
+			a.blockedUntil = time.Time{}
+		}
+	}
+}
+
+// get returns attempts of key, starting new rate window if previous one is over
+func (l *Limiter) get(key string, now time.Time) *attempts {
+	a, ok := l.keys[key]
+	if !ok {
+		a = &attempts{windowStart: now}
+		l.keys[key] = a
+	}
+	if !now.Before(a.windowStart.Add(l.window)) {
+		a.windowStart, a.requests = now, 0
+	}
+	a.last = now
+	return a
+}
+
+// purge forgets keys, which were not active longer than the maximal delay,
+// it is done at most once per window
+func (l *Limiter) purge(now time.Time) {
+	if now.Before(l.lastPurge.Add(l.window)) {
+		return
+	}
+	l.lastPurge = now
+	idle := max(l.policy.Max, l.window)
+	for key, a := range l.keys {
+		if now.Sub(a.last) > idle && !a.blockedUntil.After(now) {
+			delete(l.keys, key)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{Free: 3, Base: time.Second, Max: 10 * time.Second}
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{3, 0},
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{7, 8 * time.Second},
+		{8, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, p.Delay(tt.failures), "failures %d", tt.failures)
+	}
+	assert.Zero(t, Policy{}.Delay(10), "zero policy never blocks")
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	t.Run("backoff", func(t *testing.T) {
+		l := New(Policy{Free: 1, Base: time.Second, Max: time.Minute})
+		l.now = clock
+		assert.Zero(t, l.Allow("login", "addr"))
+		l.Fail("login", "addr")
+		assert.Zero(t, l.Allow("login", "addr"), "free failure")
+		l.Fail("login", "addr")
+		assert.Equal(t, time.Second, l.Allow("login", "addr"))
+		assert.Equal(t, time.Second, l.Allow("login", "other"), "any blocked key blocks request")
+		assert.Zero(t, l.Allow("other"))
+
+		now = now.Add(time.Second)
+		assert.Zero(t, l.Allow("login", "addr"), "delay is over")
+		l.Fail("login", "addr")
+		assert.Equal(t, 2*time.Second, l.Allow("login"), "delay is doubled")
+
+		now = now.Add(2 * time.Second)
+		l.Success("login")
+		assert.Zero(t, l.Allow("login"))
+		l.Fail("login")
+		assert.Zero(t, l.Allow("login"), "success resets failures")
+		l.Fail("addr")
+		assert.Equal(t, 4*time.Second, l.Allow("addr"), "address failures are kept")
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		l := New(Policy{}, WithRate(2, time.Minute))
+		l.now = clock
+		assert.Zero(t, l.Allow("addr"))
+		assert.Zero(t, l.Allow("addr"))
+		now = now.Add(10 * time.Second)
+		assert.Equal(t, 50*time.Second, l.Allow("addr"))
+		assert.Zero(t, l.Allow("other"))
+		now = now.Add(50 * time.Second)
+		assert.Zero(t, l.Allow("addr"), "new window")
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		l := New(Policy{Free: 0, Base: time.Second, Max: time.Minute})
+		l.now = clock
+		l.Fail("login")
+		now = now.Add(2 * time.Minute)
+		l.Allow("other")
+		assert.NotContains(t, l.keys, "login", "idle key is forgotten")
+	})
+}
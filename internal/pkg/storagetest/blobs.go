@@ -0,0 +1,60 @@
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blobChunk is a size of data appended at once, backends store data by chunks of this size
+const blobChunk = 255 << 10
+
+func testBlobs(t *testing.T, s Storage) {
+	ctx := context.Background()
+	user := randomId()
+	data := bytes.Repeat([]byte("0123456789"), blobChunk/4)
+	read := func(blobId string, offset int64) []byte {
+		r, err := s.OpenBlob(ctx, blobId, offset)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, r.Close()) }()
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("put", func(t *testing.T) {
+		blobId, err := s.PutBlob(ctx, user, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.NotEmpty(t, blobId)
+		for _, offset := range []int64{0, 1, blobChunk, blobChunk + 1, int64(len(data))} {
+			assert.Equal(t, data[offset:], read(blobId, offset), "data from offset %d", offset)
+		}
+		require.NoError(t, s.DeleteBlob(ctx, blobId))
+		assert.Empty(t, read(blobId, 0), "deleted data should not be read")
+	})
+
+	t.Run("append", func(t *testing.T) {
+		blobId, err := s.PutBlob(ctx, user, bytes.NewReader(nil))
+		require.NoError(t, err)
+		size, err := s.AppendBlob(ctx, blobId, user, 0, bytes.NewReader(data[:blobChunk]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(blobChunk), size)
+		size, err = s.AppendBlob(ctx, blobId, user, size, bytes.NewReader(data[blobChunk:]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), size)
+		assert.Equal(t, data, read(blobId, 0))
+
+		_, err = s.AppendBlob(ctx, blobId, user, size, bytes.NewReader(data))
+		require.Error(t, err, "data, finished by short chunk, should not be appended")
+		assert.Equal(t, data, read(blobId, 0))
+		require.NoError(t, s.DeleteBlob(ctx, blobId))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, read("", 0), "file without data should have empty data")
+	})
+}
@@ -0,0 +1,373 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+// kind provides uniform access to documents of one kind
+type kind struct {
+	kind   string
+	make   func(id, userId, name string, serial int64, state string) interface{}
+	add    func(ctx context.Context, s Storage, doc interface{}) (string, error)
+	get    func(ctx context.Context, s Storage, docId, userId string) (interface{}, error)
+	modify func(ctx context.Context, s Storage, doc interface{}) error
+	stream func(ctx context.Context, s Storage, userId string, minSerial, maxSerial int64, chData chan interface{}) error
+}
+
+var (
+	meta   = []models.Meta{{Key: "key", Value: "value"}}
+	folder = "work/projects"
+	tags   = []string{"tag", "other"}
+)
+
+var kinds = []kind{
+	{
+		kind: documents.KindNote,
+		make: func(id, userId, name string, serial int64, state string) interface{} {
+			return models.Note{Id: id, UserId: userId, Name: name, Serial: serial, State: state, Text: "text", Metadata: meta,
+				Folder: folder, Tags: tags}
+		},
+		add: func(ctx context.Context, s Storage, doc interface{}) (string, error) {
+			return s.AddNote(ctx, doc.(models.Note))
+		},
+		get: func(ctx context.Context, s Storage, docId, userId string) (interface{}, error) {
+			return s.GetNote(ctx, docId, userId)
+		},
+		modify: func(ctx context.Context, s Storage, doc interface{}) error {
+			return s.ModifyNote(ctx, doc.(models.Note))
+		},
+		stream: func(ctx context.Context, s Storage, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+			return s.GetNotesStream(ctx, userId, minSerial, maxSerial, chData)
+		},
+	},
+	{
+		kind: documents.KindCard,
+		make: func(id, userId, name string, serial int64, state string) interface{} {
+			return models.Card{Id: id, UserId: userId, Name: name, Serial: serial, State: state,
+				Cardholder: "holder", Number: "4111111111111111", Expires: "01/30", Pin: "1234", Code: "123", Metadata: meta, Folder: folder, Tags: tags}
+		},
+		add: func(ctx context.Context, s Storage, doc interface{}) (string, error) {
+			return s.AddCard(ctx, doc.(models.Card))
+		},
+		get: func(ctx context.Context, s Storage, docId, userId string) (interface{}, error) {
+			return s.GetCard(ctx, docId, userId)
+		},
+		modify: func(ctx context.Context, s Storage, doc interface{}) error {
+			return s.ModifyCard(ctx, doc.(models.Card))
+		},
+		stream: func(ctx context.Context, s Storage, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+			return s.GetCardsStream(ctx, userId, minSerial, maxSerial, chData)
+		},
+	},
+	{
+		kind: documents.KindCredential,
+		make: func(id, userId, name string, serial int64, state string) interface{} {
+			return models.Credential{Id: id, UserId: userId, Name: name, Serial: serial, State: state,
+				Login: "login", Password: "password", Metadata: meta, Folder: folder, Tags: tags}
+		},
+		add: func(ctx context.Context, s Storage, doc interface{}) (string, error) {
+			return s.AddCredential(ctx, doc.(models.Credential))
+		},
+		get: func(ctx context.Context, s Storage, docId, userId string) (interface{}, error) {
+			return s.GetCredential(ctx, docId, userId)
+		},
+		modify: func(ctx context.Context, s Storage, doc interface{}) error {
+			return s.ModifyCredential(ctx, doc.(models.Credential))
+		},
+		stream: func(ctx context.Context, s Storage, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+			return s.GetCredentialsStream(ctx, userId, minSerial, maxSerial, chData)
+		},
+	},
+	{
+		kind: documents.KindFile,
+		make: func(id, userId, name string, serial int64, state string) interface{} {
+			return models.File{Id: id, UserId: userId, Name: name, Serial: serial, State: state,
+				Filename: "file", Size: 4, Sha265: "hash", BlobId: "blob-" + name, Metadata: meta, Folder: folder, Tags: tags}
+		},
+		add: func(ctx context.Context, s Storage, doc interface{}) (string, error) {
+			return s.AddFile(ctx, doc.(models.File))
+		},
+		get: func(ctx context.Context, s Storage, docId, userId string) (interface{}, error) {
+			return s.GetFileInfo(ctx, docId, userId)
+		},
+		modify: func(ctx context.Context, s Storage, doc interface{}) error {
+			return s.ModifyFile(ctx, doc.(models.File))
+		},
+		stream: func(ctx context.Context, s Storage, userId string, minSerial, maxSerial int64, chData chan interface{}) error {
+			return s.GetFilesInfoStream(ctx, userId, minSerial, maxSerial, chData)
+		},
+	},
+}
+
+func testDocuments(t *testing.T, s Storage, k kind) {
+	ctx := context.Background()
+	user, other := randomId(), randomId()
+
+	// serials 1..5 of user and 3 of other user
+	ids := make(map[int64]string)
+	for serial := int64(1); serial <= 5; serial++ {
+		id, err := k.add(ctx, s, k.make("", user, "doc", serial, models.StateActive))
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+		ids[serial] = id
+	}
+	otherId, err := k.add(ctx, s, k.make("", other, "doc", 3, models.StateActive))
+	require.NoError(t, err)
+	assert.NotEqual(t, ids[3], otherId, "ids should be unique")
+
+	t.Run("get", func(t *testing.T) {
+		doc, err := k.get(ctx, s, ids[1], user)
+		require.NoError(t, err)
+		assert.Equal(t, k.make(ids[1], user, "doc", 1, models.StateActive), doc)
+		_, err = k.get(ctx, s, ids[1], other)
+		require.ErrorIs(t, err, documents.ErrNotFound, "document of other user should not be found")
+		_, err = k.get(ctx, s, randomId(), user)
+		require.ErrorIs(t, err, documents.ErrNotFound)
+	})
+
+	t.Run("stream serial range", func(t *testing.T) {
+		list := collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return k.stream(ctx, s, user, 1, 5, chData)
+		})
+		assert.ElementsMatch(t, []interface{}{
+			k.make(ids[2], user, "doc", 2, models.StateActive),
+			k.make(ids[3], user, "doc", 3, models.StateActive),
+			k.make(ids[4], user, "doc", 4, models.StateActive),
+		}, list, "range should exclude both bounds and documents of other user")
+		list = collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return k.stream(ctx, s, randomId(), 0, 100, chData)
+		})
+		assert.Empty(t, list)
+	})
+
+	t.Run("modify", func(t *testing.T) {
+		modified := k.make(ids[1], user, "modified", 10, models.StateActive)
+		require.NoError(t, k.modify(ctx, s, modified))
+		doc, err := k.get(ctx, s, ids[1], user)
+		require.NoError(t, err)
+		assert.Equal(t, modified, doc)
+
+		list := collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return k.stream(ctx, s, user, 5, 100, chData)
+		})
+		assert.Equal(t, []interface{}{modified}, list, "modified document should be found by new serial")
+		list = collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return k.stream(ctx, s, user, 0, 2, chData)
+		})
+		assert.Empty(t, list, "modified document should not be found by old serial")
+
+		err = k.modify(ctx, s, k.make(ids[2], other, "stolen", 11, models.StateActive))
+		require.ErrorIs(t, err, documents.ErrNotFound, "document of other user should not be modified")
+		err = k.modify(ctx, s, k.make(randomId(), user, "unknown", 11, models.StateActive))
+		require.ErrorIs(t, err, documents.ErrNotFound)
+	})
+
+	t.Run("tombstone", func(t *testing.T) {
+		deleted := k.make(ids[2], user, "", 12, models.StateDeleted)
+		require.NoError(t, k.modify(ctx, s, deleted))
+		doc, err := k.get(ctx, s, ids[2], user)
+		require.NoError(t, err)
+		assert.Equal(t, deleted, doc, "deleted document should be kept with the flag")
+		list := collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return k.stream(ctx, s, user, 11, 100, chData)
+		})
+		assert.Equal(t, []interface{}{deleted}, list, "deletion should be streamed")
+	})
+}
+
+func testHistory(t *testing.T, s Storage, k kind) {
+	ctx := context.Background()
+	user := randomId()
+
+	id, err := k.add(ctx, s, k.make("", user, "v1", 1, models.StateActive))
+	require.NoError(t, err)
+	v1 := k.make(id, user, "v1", 1, models.StateActive)
+	v2 := k.make(id, user, "v2", 2, models.StateActive)
+	require.NoError(t, k.modify(ctx, s, v2))
+	// trashed revision has the same payload as the previous one and is not archived
+	require.NoError(t, k.modify(ctx, s, k.make(id, user, "v2", 3, models.StateTrashed)))
+	require.NoError(t, k.modify(ctx, s, k.make(id, user, "v3", 4, models.StateActive)))
+
+	list := collect(t, func(ctx context.Context, chData chan interface{}) error {
+		return s.GetHistoryStream(ctx, id, user, chData)
+	})
+	assert.Equal(t, []interface{}{v2, v1}, list, "revisions should be streamed newest first")
+	list = collect(t, func(ctx context.Context, chData chan interface{}) error {
+		return s.GetHistoryStream(ctx, id, randomId(), chData)
+	})
+	assert.Empty(t, list, "revisions of other user should not be streamed")
+
+	rev, err := s.GetRevision(ctx, id, user, 1)
+	require.NoError(t, err)
+	assert.Equal(t, v1, rev)
+	_, err = s.GetRevision(ctx, id, user, 3)
+	require.ErrorIs(t, err, documents.ErrNotFound)
+	_, err = s.GetRevision(ctx, id, randomId(), 1)
+	require.ErrorIs(t, err, documents.ErrNotFound)
+
+	removed, err := s.TrimHistory(ctx, id, randomId(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, removed, "revisions of other user should not be removed")
+	removed, err = s.TrimHistory(ctx, id, user, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{v1}, removed, "the oldest revision should be removed")
+	list = collect(t, func(ctx context.Context, chData chan interface{}) error {
+		return s.GetHistoryStream(ctx, id, user, chData)
+	})
+	assert.Equal(t, []interface{}{v2}, list, "the newest revision should be kept")
+	removed, err = s.TrimHistory(ctx, id, user, 1)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	v3 := k.make(id, user, "v3", 4, models.StateActive)
+	require.NoError(t, k.modify(ctx, s, k.make(id, user, "v4", 5, models.StateActive)))
+	require.NoError(t, s.DeleteRevisions(ctx, id, randomId(), []int64{2, 4}))
+	require.NoError(t, s.DeleteRevisions(ctx, id, user, []int64{2, 7}))
+	list = collect(t, func(ctx context.Context, chData chan interface{}) error {
+		return s.GetHistoryStream(ctx, id, user, chData)
+	})
+	assert.Equal(t, []interface{}{v3}, list, "only requested revisions of user should be removed")
+
+	require.NoError(t, s.DeleteHistory(ctx, id, user))
+	_, err = s.GetRevision(ctx, id, user, 1)
+	require.ErrorIs(t, err, documents.ErrNotFound)
+	list = collect(t, func(ctx context.Context, chData chan interface{}) error {
+		return s.GetHistoryStream(ctx, id, user, chData)
+	})
+	assert.Empty(t, list)
+}
+
+func testDeleteUserDocuments(t *testing.T, s Storage) {
+	ctx := context.Background()
+	user, other := randomId(), randomId()
+	type stored struct {
+		k  kind
+		id string
+	}
+	var users, others []stored
+	for _, k := range kinds {
+		id, err := k.add(ctx, s, k.make("", user, "v1", 1, models.StateActive))
+		require.NoError(t, err)
+		require.NoError(t, k.modify(ctx, s, k.make(id, user, "v2", 2, models.StateActive)))
+		users = append(users, stored{k, id})
+		id, err = k.add(ctx, s, k.make("", other, "v1", 1, models.StateActive))
+		require.NoError(t, err)
+		require.NoError(t, k.modify(ctx, s, k.make(id, other, "v2", 2, models.StateActive)))
+		others = append(others, stored{k, id})
+	}
+
+	require.NoError(t, s.DeleteUserDocuments(ctx, user))
+	for _, d := range users {
+		_, err := d.k.get(ctx, s, d.id, user)
+		require.ErrorIs(t, err, documents.ErrNotFound, "%s should be deleted", d.k.kind)
+		_, err = s.GetRevision(ctx, d.id, user, 1)
+		require.ErrorIs(t, err, documents.ErrNotFound, "%s history should be deleted", d.k.kind)
+		list := collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return d.k.stream(ctx, s, user, -1, 10, chData)
+		})
+		assert.Empty(t, list)
+	}
+	for _, d := range others {
+		_, err := d.k.get(ctx, s, d.id, other)
+		require.NoError(t, err, "%s of other user should be kept", d.k.kind)
+		_, err = s.GetRevision(ctx, d.id, other, 1)
+		require.NoError(t, err, "%s history of other user should be kept", d.k.kind)
+	}
+	require.NoError(t, s.DeleteUserDocuments(ctx, randomId()), "user without documents is not an error")
+}
+
+func testTrash(t *testing.T, s Storage) {
+	ctx := context.Background()
+	user := randomId()
+
+	var trashed []interface{}
+	for i, k := range kinds {
+		id, err := k.add(ctx, s, k.make("", user, "doc", int64(i+1), models.StateActive))
+		require.NoError(t, err)
+		doc := k.make(id, user, "doc", int64(i+10), models.StateTrashed)
+		switch d := doc.(type) {
+		case models.Note:
+			d.TrashedAt = 100
+			doc = d
+		case models.Card:
+			d.TrashedAt = 200
+			doc = d
+		case models.Credential:
+			d.TrashedAt = 300
+			doc = d
+		case models.File:
+			d.TrashedAt = 400
+			doc = d
+		}
+		require.NoError(t, k.modify(ctx, s, doc))
+		trashed = append(trashed, doc)
+	}
+	_, err := s.AddNote(ctx, models.Note{UserId: user, Serial: 20, State: models.StateActive})
+	require.NoError(t, err)
+
+	// trash stream is not scoped by user
+	userDocs := func(before int64) []interface{} {
+		var docs []interface{}
+		for _, doc := range collect(t, func(ctx context.Context, chData chan interface{}) error {
+			return s.GetTrashStream(ctx, before, chData)
+		}) {
+			if userOf(doc) == user {
+				docs = append(docs, doc)
+			}
+		}
+		return docs
+	}
+	assert.Empty(t, userDocs(100), "trashed at the bound should not be streamed")
+	assert.ElementsMatch(t, trashed[:2], userDocs(201))
+	assert.ElementsMatch(t, trashed, userDocs(1000))
+}
+
+func testFileInfo(t *testing.T, s Storage) {
+	ctx := context.Background()
+	user := randomId()
+
+	file := models.File{UserId: user, Name: "name", Filename: "file", Serial: 1, Size: 5,
+		Sha265: "hash", BlobId: "blob", Metadata: meta, Folder: folder, Tags: tags, State: models.StateActive}
+	id, err := s.AddFile(ctx, file)
+	require.NoError(t, err)
+	file.Id = id
+
+	info := models.File{Id: id, UserId: user, Name: "renamed", Filename: "renamed", Serial: 2,
+		Metadata: []models.Meta{{Key: "new", Value: "meta"}}, Folder: "moved", Tags: []string{"new"}, State: models.StateActive}
+	require.NoError(t, s.ModifyFileInfo(ctx, info))
+	stored, err := s.GetFileInfo(ctx, id, user)
+	require.NoError(t, err)
+	expected := file
+	expected.Name, expected.Filename, expected.Serial, expected.Metadata = info.Name, info.Filename, info.Serial, info.Metadata
+	expected.Folder, expected.Tags = info.Folder, info.Tags
+	assert.Equal(t, expected, stored, "file data reference, size and hash should be kept")
+
+	rev, err := s.GetRevision(ctx, id, user, 1)
+	require.NoError(t, err)
+	assert.Equal(t, file, rev, "previous revision should be archived")
+
+	info.UserId = randomId()
+	require.ErrorIs(t, s.ModifyFileInfo(ctx, info), documents.ErrNotFound)
+}
+
+// userOf returns user id of document model
+func userOf(doc interface{}) string {
+	switch d := doc.(type) {
+	case models.Note:
+		return d.UserId
+	case models.Card:
+		return d.UserId
+	case models.Credential:
+		return d.UserId
+	case models.File:
+		return d.UserId
+	}
+	return ""
+}
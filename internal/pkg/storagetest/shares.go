@@ -0,0 +1,63 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func testShares(t *testing.T, s Storage) {
+	ctx := context.Background()
+	userId, otherId := randomId(), randomId()
+	now := time.Now().Unix()
+	share := models.Share{Id: randomId(), UserId: userId, DocumentId: randomId(), Kind: documents.KindNote,
+		Data: []byte("sealed"), ExpiresAt: now + 3600, ViewsLeft: 2, PassHash: []byte("hash")}
+
+	require.NoError(t, s.AddShare(ctx, share))
+	got, err := s.GetShare(ctx, share.Id)
+	require.NoError(t, err)
+	assert.Equal(t, share, got)
+	_, err = s.GetShare(ctx, randomId())
+	require.ErrorIs(t, err, documents.ErrNoShare)
+
+	share.ViewsLeft = 1
+	share.Failures = 2
+	require.NoError(t, s.ModifyShare(ctx, share))
+	got, err = s.GetShare(ctx, share.Id)
+	require.NoError(t, err)
+	assert.Equal(t, share, got)
+	require.ErrorIs(t, s.ModifyShare(ctx, models.Share{Id: randomId()}), documents.ErrNoShare)
+
+	require.NoError(t, s.DeleteShare(ctx, share.Id))
+	_, err = s.GetShare(ctx, share.Id)
+	require.ErrorIs(t, err, documents.ErrNoShare)
+	require.ErrorIs(t, s.DeleteShare(ctx, share.Id), documents.ErrNoShare)
+
+	// expired shares
+	expired := models.Share{Id: randomId(), UserId: userId, Kind: documents.KindNote, Data: []byte("x"), ExpiresAt: now - 10, ViewsLeft: 1}
+	active := models.Share{Id: randomId(), UserId: userId, Kind: documents.KindNote, Data: []byte("x"), ExpiresAt: now + 3600, ViewsLeft: 1}
+	require.NoError(t, s.AddShare(ctx, expired))
+	require.NoError(t, s.AddShare(ctx, active))
+	n, err := s.DeleteExpiredShares(ctx, now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 1)
+	_, err = s.GetShare(ctx, expired.Id)
+	require.ErrorIs(t, err, documents.ErrNoShare, "expired share should be deleted")
+	_, err = s.GetShare(ctx, active.Id)
+	require.NoError(t, err, "active share should be kept")
+
+	// user shares
+	other := models.Share{Id: randomId(), UserId: otherId, Kind: documents.KindNote, Data: []byte("x"), ExpiresAt: now + 3600, ViewsLeft: 1}
+	require.NoError(t, s.AddShare(ctx, other))
+	require.NoError(t, s.DeleteUserShares(ctx, userId))
+	_, err = s.GetShare(ctx, active.Id)
+	require.ErrorIs(t, err, documents.ErrNoShare, "user shares should be deleted")
+	_, err = s.GetShare(ctx, other.Id)
+	require.NoError(t, err, "shares of other users should be kept")
+}
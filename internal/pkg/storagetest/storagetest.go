@@ -0,0 +1,71 @@
+// Package storagetest is a conformance test suite for storage backends. Every backend
+// should pass it to be used by server controllers.
+//
+// Tests do not expect storage to be empty and use random users, so the suite may be run
+// against a shared database.
+package storagetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/app/server/serial"
+)
+
+// Storage is a complete storage backend
+type Storage interface {
+	documents.DocStorage
+	documents.VaultStorage
+	documents.ShareStorage
+	aaa.UserStorage
+	serial.Source
+}
+
+// Run runs conformance tests against storage, returned by newStorage
+func Run(t *testing.T, newStorage func(t *testing.T) Storage) {
+	t.Run("users", func(t *testing.T) { testUsers(t, newStorage(t)) })
+	t.Run("sessions", func(t *testing.T) { testSessions(t, newStorage(t)) })
+	t.Run("serials", func(t *testing.T) { testSerials(t, newStorage(t)) })
+	for _, k := range kinds {
+		k := k
+		t.Run(k.kind, func(t *testing.T) { testDocuments(t, newStorage(t), k) })
+		t.Run(k.kind+" history", func(t *testing.T) { testHistory(t, newStorage(t), k) })
+	}
+	t.Run("trash", func(t *testing.T) { testTrash(t, newStorage(t)) })
+	t.Run("user documents", func(t *testing.T) { testDeleteUserDocuments(t, newStorage(t)) })
+	t.Run("file info", func(t *testing.T) { testFileInfo(t, newStorage(t)) })
+	t.Run("blobs", func(t *testing.T) { testBlobs(t, newStorage(t)) })
+	t.Run("vaults", func(t *testing.T) { testVaults(t, newStorage(t)) })
+	t.Run("shares", func(t *testing.T) { testShares(t, newStorage(t)) })
+	t.Run("uploads", func(t *testing.T) { testUploads(t, newStorage(t)) })
+}
+
+// randomId returns unique id, used for users and logins
+func randomId() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// collect reads stream into list
+func collect(t *testing.T, stream func(ctx context.Context, chData chan interface{}) error) []interface{} {
+	t.Helper()
+	chData := make(chan interface{})
+	chErr := make(chan error, 1)
+	go func() {
+		defer close(chData)
+		chErr <- stream(context.Background(), chData)
+	}()
+	var list []interface{}
+	for doc := range chData {
+		list = append(list, doc)
+	}
+	if err := <-chErr; err != nil {
+		t.Fatalf("stream failed: %s", err.Error())
+	}
+	return list
+}
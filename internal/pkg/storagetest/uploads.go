@@ -0,0 +1,74 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func testUploads(t *testing.T, s Storage) {
+	ctx := context.Background()
+	userId, otherId := randomId(), randomId()
+	now := time.Now().Unix()
+	upload := models.Upload{
+		Id:        randomId(),
+		UserId:    userId,
+		File:      models.File{UserId: userId, Name: "name", Filename: "file", Size: 10, Sha265: "sha", State: models.StateActive},
+		BlobId:    randomId(),
+		UpdatedAt: now,
+	}
+
+	require.NoError(t, s.AddUpload(ctx, upload))
+	got, err := s.GetUpload(ctx, upload.Id)
+	require.NoError(t, err)
+	assert.Equal(t, upload, got)
+	_, err = s.GetUpload(ctx, randomId())
+	require.ErrorIs(t, err, documents.ErrUploadNotFound)
+
+	upload.Offset, upload.UpdatedAt = 5, now+1
+	require.NoError(t, s.SetUploadOffset(ctx, upload.Id, upload.Offset, upload.UpdatedAt))
+	got, err = s.GetUpload(ctx, upload.Id)
+	require.NoError(t, err)
+	assert.Equal(t, upload, got)
+	require.ErrorIs(t, s.SetUploadOffset(ctx, randomId(), 1, now), documents.ErrUploadNotFound)
+
+	require.NoError(t, s.DeleteUpload(ctx, upload.Id))
+	_, err = s.GetUpload(ctx, upload.Id)
+	require.ErrorIs(t, err, documents.ErrUploadNotFound)
+	require.ErrorIs(t, s.DeleteUpload(ctx, upload.Id), documents.ErrUploadNotFound)
+
+	// storage may keep uploads of other tests, so only own uploads are checked
+	ids := func(uploads []models.Upload) []string {
+		var list []string
+		for _, u := range uploads {
+			if u.UserId == userId || u.UserId == otherId {
+				list = append(list, u.Id)
+			}
+		}
+		return list
+	}
+	stale := models.Upload{Id: randomId(), UserId: userId, BlobId: randomId(), UpdatedAt: 1000}
+	active := models.Upload{Id: randomId(), UserId: userId, BlobId: randomId(), UpdatedAt: now}
+	other := models.Upload{Id: randomId(), UserId: otherId, BlobId: randomId(), UpdatedAt: 1000}
+	for _, u := range []models.Upload{stale, active, other} {
+		require.NoError(t, s.AddUpload(ctx, u))
+	}
+	expired, err := s.GetExpiredUploads(ctx, 2000)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{stale.Id, other.Id}, ids(expired))
+
+	deleted, err := s.DeleteUserUploads(ctx, userId)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{stale.Id, active.Id}, ids(deleted))
+	_, err = s.GetUpload(ctx, active.Id)
+	require.ErrorIs(t, err, documents.ErrUploadNotFound)
+	_, err = s.GetUpload(ctx, other.Id)
+	require.NoError(t, err, "uploads of other user should be kept")
+	require.NoError(t, s.DeleteUpload(ctx, other.Id))
+}
@@ -0,0 +1,193 @@
+package storagetest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/aaa"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func testUsers(t *testing.T, s Storage) {
+	ctx := context.Background()
+	login := randomId()
+
+	user, err := s.AddUser(ctx, models.User{Login: login, PasswordHash: "hash", State: models.StateActive})
+	require.NoError(t, err)
+	require.NotEmpty(t, user.Id)
+	_, err = s.AddUser(ctx, models.User{Login: login, PasswordHash: "other", State: models.StateActive})
+	require.ErrorIs(t, err, aaa.ErrDuplicate, "login should be unique")
+
+	got, err := s.GetUserByLogin(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, user, got)
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, user, got)
+	_, err = s.GetUserByLogin(ctx, randomId())
+	require.ErrorIs(t, err, aaa.ErrNotFound)
+	_, err = s.GetUserById(ctx, randomId())
+	require.ErrorIs(t, err, aaa.ErrNotFound)
+
+	inactive, err := s.AddUser(ctx, models.User{Login: randomId(), State: models.StateDeleted})
+	require.NoError(t, err)
+	_, err = s.GetUserById(ctx, inactive.Id)
+	require.ErrorIs(t, err, aaa.ErrNotFound, "only active users should be found")
+	_, err = s.GetUserByLogin(ctx, inactive.Login)
+	require.ErrorIs(t, err, aaa.ErrNotFound, "only active users should be found")
+
+	require.NoError(t, s.SetVaultKey(ctx, user.Id, []byte("key")))
+	require.ErrorIs(t, s.SetVaultKey(ctx, user.Id, []byte("other")), aaa.ErrKeyExists, "vault key should be set once")
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), got.VaultKey)
+	require.ErrorIs(t, s.SetVaultKey(ctx, randomId(), []byte("key")), aaa.ErrNotFound)
+
+	keys := models.KeyPair{Public: []byte("public"), Private: []byte("private")}
+	require.NoError(t, s.SetKeyPair(ctx, user.Id, keys))
+	require.ErrorIs(t, s.SetKeyPair(ctx, user.Id, models.KeyPair{Public: []byte("other"), Private: []byte("other")}),
+		aaa.ErrKeyExists, "key pair should be set once")
+	got, err = s.GetUserByLogin(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, keys.Public, got.PublicKey)
+	assert.Equal(t, keys.Private, got.PrivateKey)
+	assert.Equal(t, []byte("key"), got.VaultKey, "other user data should be kept")
+	require.ErrorIs(t, s.SetKeyPair(ctx, randomId(), keys), aaa.ErrNotFound)
+
+	totp := &models.Totp{Secret: "SECRET", RecoveryCodes: []string{"a", "b"}, LastStep: 1}
+	require.NoError(t, s.SetTotp(ctx, user.Id, totp))
+	got, err = s.GetUserByLogin(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, totp, got.Totp)
+	assert.Equal(t, []byte("key"), got.VaultKey, "other user data should be kept")
+	totp.Enabled, totp.RecoveryCodes = true, []string{"b"}
+	require.NoError(t, s.SetTotp(ctx, user.Id, totp))
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, totp, got.Totp)
+	require.NoError(t, s.SetTotp(ctx, user.Id, nil))
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Nil(t, got.Totp, "second factor should be removed")
+	require.ErrorIs(t, s.SetTotp(ctx, randomId(), totp), aaa.ErrNotFound)
+
+	require.NoError(t, s.SetMigrated(ctx, user.Id))
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.True(t, got.Migrated)
+	assert.Equal(t, []byte("key"), got.VaultKey, "other user data should be kept")
+	require.ErrorIs(t, s.SetMigrated(ctx, randomId()), aaa.ErrNotFound)
+
+	require.NoError(t, s.SetPassword(ctx, user.Id, "new hash", []byte("rewrapped")))
+	got, err = s.GetUserByLogin(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, "new hash", got.PasswordHash)
+	assert.Equal(t, []byte("rewrapped"), got.VaultKey)
+	assert.True(t, got.Derived, "changed password should be marked as derived")
+	require.NoError(t, s.SetPassword(ctx, user.Id, "other hash", nil))
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "other hash", got.PasswordHash)
+	assert.Equal(t, []byte("rewrapped"), got.VaultKey, "nil vault key should keep stored one")
+	require.ErrorIs(t, s.SetPassword(ctx, randomId(), "hash", nil), aaa.ErrNotFound)
+
+	require.NoError(t, s.SetLoginFailures(ctx, user.Id, 3, 1700000000))
+	got, err = s.GetUserByLogin(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.FailedLogins)
+	assert.Equal(t, int64(1700000000), got.LockedUntil)
+	require.NoError(t, s.SetLoginFailures(ctx, user.Id, 0, 0))
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Zero(t, got.FailedLogins, "failures should be reset")
+	assert.Zero(t, got.LockedUntil, "lockout should be reset")
+	require.ErrorIs(t, s.SetLoginFailures(ctx, randomId(), 1, 0), aaa.ErrNotFound)
+
+	var wg sync.WaitGroup
+	counts := make([]int, 10)
+	for i := range counts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			counts[i], err = s.AddLoginFailure(ctx, user.Id)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, counts, "concurrent failures should be counted once each")
+	require.NoError(t, s.LockUser(ctx, user.Id, 1700000000))
+	require.NoError(t, s.LockUser(ctx, user.Id, 1600000000))
+	got, err = s.GetUserById(ctx, user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 10, got.FailedLogins)
+	assert.Equal(t, int64(1700000000), got.LockedUntil, "earlier time should not shorten lockout")
+	require.NoError(t, s.SetLoginFailures(ctx, user.Id, 0, 0))
+	_, err = s.AddLoginFailure(ctx, randomId())
+	require.ErrorIs(t, err, aaa.ErrNotFound)
+	require.ErrorIs(t, s.LockUser(ctx, randomId(), 1), aaa.ErrNotFound)
+
+	require.NoError(t, s.DeleteUser(ctx, user.Id))
+	_, err = s.GetUserById(ctx, user.Id)
+	require.ErrorIs(t, err, aaa.ErrNotFound, "deleted user should not be found")
+	_, err = s.GetUserByLogin(ctx, login)
+	require.ErrorIs(t, err, aaa.ErrNotFound, "deleted user should not be found")
+	require.ErrorIs(t, s.DeleteUser(ctx, user.Id), aaa.ErrNotFound)
+	_, err = s.AddUser(ctx, models.User{Login: login, State: models.StateActive})
+	require.ErrorIs(t, err, aaa.ErrDuplicate, "login of deleted user should stay reserved")
+}
+
+func testSessions(t *testing.T, s Storage) {
+	ctx := context.Background()
+	userId, otherId := randomId(), randomId()
+	first := models.Session{Id: randomId(), UserId: userId, Device: "laptop", CreatedAt: 100, LastSeen: 100}
+	second := models.Session{Id: randomId(), UserId: userId, Device: "phone", CreatedAt: 200, LastSeen: 200}
+	other := models.Session{Id: randomId(), UserId: otherId, CreatedAt: 150, LastSeen: 150}
+	for _, session := range []models.Session{second, first, other} {
+		require.NoError(t, s.AddSession(ctx, session))
+	}
+
+	got, err := s.GetSession(ctx, first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, first, got)
+	_, err = s.GetSession(ctx, randomId())
+	require.ErrorIs(t, err, aaa.ErrNoSession)
+
+	list, err := s.GetSessions(ctx, userId)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Session{first, second}, list, "user sessions should be ordered by creation")
+	list, err = s.GetSessions(ctx, randomId())
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	require.NoError(t, s.TouchSession(ctx, first.Id, 300))
+	got, err = s.GetSession(ctx, first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), got.LastSeen)
+	require.ErrorIs(t, s.TouchSession(ctx, randomId(), 300), aaa.ErrNoSession)
+
+	require.ErrorIs(t, s.DeleteSession(ctx, first.Id, otherId), aaa.ErrNoSession, "session of other user should not be deleted")
+	require.NoError(t, s.DeleteSession(ctx, first.Id, userId))
+	_, err = s.GetSession(ctx, first.Id)
+	require.ErrorIs(t, err, aaa.ErrNoSession)
+	require.ErrorIs(t, s.DeleteSession(ctx, first.Id, userId), aaa.ErrNoSession)
+	list, err = s.GetSessions(ctx, userId)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Session{second}, list)
+}
+
+func testSerials(t *testing.T, s Storage) {
+	ctx := context.Background()
+	first, err := s.GetSerials(ctx, 10)
+	require.NoError(t, err)
+	second, err := s.GetSerials(ctx, 5)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, second, first+10, "reserved serials should not be returned again")
+	third, err := s.GetSerials(ctx, 1)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, third, second+5, "reserved serials should not be returned again")
+}
@@ -0,0 +1,61 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yap-pwkeeper/internal/app/server/documents"
+	"yap-pwkeeper/internal/pkg/models"
+)
+
+func testVaults(t *testing.T, s Storage) {
+	ctx := context.Background()
+	ownerId, memberId := randomId(), randomId()
+	owner := models.Member{UserId: ownerId, Login: "owner", Role: models.RoleOwner, VaultKey: []byte("key"), Serial: 1}
+	vault := models.Vault{Name: "team", Members: []models.Member{owner}}
+
+	id, err := s.AddVault(ctx, vault)
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+	vault.Id = id
+	got, err := s.GetVault(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, vault, got)
+	_, err = s.GetVault(ctx, randomId())
+	require.ErrorIs(t, err, documents.ErrNoVault)
+
+	other, err := s.AddVault(ctx, models.Vault{Name: "other", Members: []models.Member{{UserId: randomId(), Role: models.RoleOwner}}})
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+
+	vault.Name = "renamed"
+	vault.Members = append(vault.Members, models.Member{UserId: memberId, Login: "member", Role: models.RoleRead, VaultKey: []byte("sealed"), Serial: 2})
+	require.NoError(t, s.ModifyVault(ctx, vault))
+	got, err = s.GetVault(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, vault, got)
+	require.ErrorIs(t, s.ModifyVault(ctx, models.Vault{Id: randomId(), Name: "missing"}), documents.ErrNoVault)
+
+	list, err := s.GetUserVaults(ctx, memberId)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Vault{vault}, list, "vaults of member should be found")
+	list, err = s.GetUserVaults(ctx, ownerId)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Vault{vault}, list)
+	list, err = s.GetUserVaults(ctx, randomId())
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	require.NoError(t, s.DeleteVault(ctx, id))
+	_, err = s.GetVault(ctx, id)
+	require.ErrorIs(t, err, documents.ErrNoVault)
+	require.ErrorIs(t, s.DeleteVault(ctx, id), documents.ErrNoVault)
+	list, err = s.GetUserVaults(ctx, memberId)
+	require.NoError(t, err)
+	assert.Empty(t, list, "deleted vault should not be found")
+	_, err = s.GetVault(ctx, other)
+	require.NoError(t, err, "other vault should be kept")
+}
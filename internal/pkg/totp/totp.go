@@ -0,0 +1,110 @@
+// Package totp implements time-based one-time passwords (RFC 6238), compatible with
+// authenticator applications: HMAC-SHA1, 30 seconds time step and 6 digits codes.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Step is a time step, each step has its own code
+	Step = 30 * time.Second
+	// Digits is a length of code
+	Digits = 6
+	// skew is number of steps around current one, which codes are accepted to tolerate clock drift
+	skew = 1
+	// secretSize is a length of shared secret, recommended for HMAC-SHA1
+	secretSize = 20
+)
+
+var (
+	ErrBadSecret = errors.New("invalid totp secret")
+	encoding     = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// NewSecret returns new random shared secret, base32 encoded
+func NewSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns key uri to be imported into authenticator application, usually as QR code
+func URI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(int(Step.Seconds())))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// Code returns code of time step, which t belongs to
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(StepOf(t)), Digits), nil
+}
+
+// Validate checks code at time t and returns time step, which code belongs to.
+// Caller should reject steps, which were already used, so codes may not be replayed.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+	current := StepOf(t)
+	for step := current - skew; step <= current+skew; step++ {
+		if hmac.Equal([]byte(hotp(key, uint64(step), Digits)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// StepOf returns time step number of t
+func StepOf(t time.Time) int64 {
+	return t.Unix() / int64(Step.Seconds())
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrBadSecret
+	}
+	return key, nil
+}
+
+// hotp is HMAC-based one-time password of counter (RFC 4226)
+func hotp(key []byte, counter uint64, digits int) string {
+	mac := hmac.New(sha1.New, key)
+	_ = binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
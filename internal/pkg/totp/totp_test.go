@@ -0,0 +1,77 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcKey is a secret of test vectors of RFC 4226 and RFC 6238
+var rfcKey = []byte("12345678901234567890")
+
+func TestHotp(t *testing.T) {
+	// RFC 4226 Appendix D
+	expected := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, code := range expected {
+		assert.Equal(t, code, hotp(rfcKey, uint64(counter), 6), "counter %d", counter)
+	}
+}
+
+func TestCode(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString(rfcKey)
+	// RFC 6238 Appendix B, SHA1 test vectors truncated to 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		code, err := Code(secret, time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code, "time %d", tt.unix)
+	}
+	_, err := Code("not base32!", time.Now())
+	require.ErrorIs(t, err, ErrBadSecret)
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	now := time.Now()
+	code, err := Code(secret, now)
+	require.NoError(t, err)
+
+	step, ok := Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, StepOf(now), step)
+	_, ok = Validate(secret, code, now.Add(Step))
+	assert.True(t, ok, "code of previous step should be accepted")
+	_, ok = Validate(secret, code, now.Add(3*Step))
+	assert.False(t, ok, "outdated code should be rejected")
+	_, ok = Validate(secret, "", now)
+	assert.False(t, ok)
+	other, err := NewSecret()
+	require.NoError(t, err)
+	_, ok = Validate(other, code, now)
+	assert.False(t, ok, "code of other secret should be rejected")
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("issuer", "user", "SECRET"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/issuer:user", u.Path)
+	assert.Equal(t, "SECRET", u.Query().Get("secret"))
+	assert.Equal(t, "issuer", u.Query().Get("issuer"))
+}
@@ -35,6 +35,35 @@ func (m *MockUserStorage) EXPECT() *MockUserStorageMockRecorder {
 	return m.recorder
 }
 
+// AddLoginFailure mocks base method.
+func (m *MockUserStorage) AddLoginFailure(ctx context.Context, userId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockUserStorageMockRecorder) AddLoginFailure(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockUserStorage)(nil).AddLoginFailure), ctx, userId)
+}
+
+// AddSession mocks base method.
+func (m *MockUserStorage) AddSession(ctx context.Context, session models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockUserStorageMockRecorder) AddSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockUserStorage)(nil).AddSession), ctx, session)
+}
+
 // AddUser mocks base method.
 func (m *MockUserStorage) AddUser(ctx context.Context, user models.User) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +79,79 @@ func (mr *MockUserStorageMockRecorder) AddUser(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserStorage)(nil).AddUser), ctx, user)
 }
 
+// DeleteSession mocks base method.
+func (m *MockUserStorage) DeleteSession(ctx context.Context, sessionId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, sessionId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockUserStorageMockRecorder) DeleteSession(ctx, sessionId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockUserStorage)(nil).DeleteSession), ctx, sessionId, userId)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserStorage) DeleteUser(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserStorageMockRecorder) DeleteUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserStorage)(nil).DeleteUser), ctx, userId)
+}
+
+// GetSession mocks base method.
+func (m *MockUserStorage) GetSession(ctx context.Context, sessionId string) (models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionId)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockUserStorageMockRecorder) GetSession(ctx, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockUserStorage)(nil).GetSession), ctx, sessionId)
+}
+
+// GetSessions mocks base method.
+func (m *MockUserStorage) GetSessions(ctx context.Context, userId string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", ctx, userId)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockUserStorageMockRecorder) GetSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockUserStorage)(nil).GetSessions), ctx, userId)
+}
+
+// GetUserById mocks base method.
+func (m *MockUserStorage) GetUserById(ctx context.Context, userId string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", ctx, userId)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUserStorageMockRecorder) GetUserById(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserStorage)(nil).GetUserById), ctx, userId)
+}
+
 // GetUserByLogin mocks base method.
 func (m *MockUserStorage) GetUserByLogin(ctx context.Context, login string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +166,115 @@ func (mr *MockUserStorageMockRecorder) GetUserByLogin(ctx, login interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockUserStorage)(nil).GetUserByLogin), ctx, login)
 }
+
+// LockUser mocks base method.
+func (m *MockUserStorage) LockUser(ctx context.Context, userId string, lockedUntil int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", ctx, userId, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockUserStorageMockRecorder) LockUser(ctx, userId, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockUserStorage)(nil).LockUser), ctx, userId, lockedUntil)
+}
+
+// SetKeyPair mocks base method.
+func (m *MockUserStorage) SetKeyPair(ctx context.Context, userId string, keys models.KeyPair) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyPair", ctx, userId, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockUserStorageMockRecorder) SetKeyPair(ctx, userId, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockUserStorage)(nil).SetKeyPair), ctx, userId, keys)
+}
+
+// SetLoginFailures mocks base method.
+func (m *MockUserStorage) SetLoginFailures(ctx context.Context, userId string, failures int, lockedUntil int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginFailures", ctx, userId, failures, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginFailures indicates an expected call of SetLoginFailures.
+func (mr *MockUserStorageMockRecorder) SetLoginFailures(ctx, userId, failures, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginFailures", reflect.TypeOf((*MockUserStorage)(nil).SetLoginFailures), ctx, userId, failures, lockedUntil)
+}
+
+// SetMigrated mocks base method.
+func (m *MockUserStorage) SetMigrated(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMigrated", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMigrated indicates an expected call of SetMigrated.
+func (mr *MockUserStorageMockRecorder) SetMigrated(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMigrated", reflect.TypeOf((*MockUserStorage)(nil).SetMigrated), ctx, userId)
+}
+
+// SetPassword mocks base method.
+func (m *MockUserStorage) SetPassword(ctx context.Context, userId, passwordHash string, vaultKey []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, userId, passwordHash, vaultKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockUserStorageMockRecorder) SetPassword(ctx, userId, passwordHash, vaultKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockUserStorage)(nil).SetPassword), ctx, userId, passwordHash, vaultKey)
+}
+
+// SetTotp mocks base method.
+func (m *MockUserStorage) SetTotp(ctx context.Context, userId string, totp *models.Totp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTotp", ctx, userId, totp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTotp indicates an expected call of SetTotp.
+func (mr *MockUserStorageMockRecorder) SetTotp(ctx, userId, totp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTotp", reflect.TypeOf((*MockUserStorage)(nil).SetTotp), ctx, userId, totp)
+}
+
+// SetVaultKey mocks base method.
+func (m *MockUserStorage) SetVaultKey(ctx context.Context, userId string, key []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVaultKey", ctx, userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVaultKey indicates an expected call of SetVaultKey.
+func (mr *MockUserStorageMockRecorder) SetVaultKey(ctx, userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVaultKey", reflect.TypeOf((*MockUserStorage)(nil).SetVaultKey), ctx, userId, key)
+}
+
+// TouchSession mocks base method.
+func (m *MockUserStorage) TouchSession(ctx context.Context, sessionId string, lastSeen int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sessionId, lastSeen)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockUserStorageMockRecorder) TouchSession(ctx, sessionId, lastSeen interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockUserStorage)(nil).TouchSession), ctx, sessionId, lastSeen)
+}
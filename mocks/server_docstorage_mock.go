@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	models "yap-pwkeeper/internal/pkg/models"
 
@@ -95,6 +96,120 @@ func (mr *MockDocStorageMockRecorder) AddNote(ctx, note interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockDocStorage)(nil).AddNote), ctx, note)
 }
 
+// AddUpload mocks base method.
+func (m *MockDocStorage) AddUpload(ctx context.Context, upload models.Upload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUpload", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUpload indicates an expected call of AddUpload.
+func (mr *MockDocStorageMockRecorder) AddUpload(ctx, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpload", reflect.TypeOf((*MockDocStorage)(nil).AddUpload), ctx, upload)
+}
+
+// AppendBlob mocks base method.
+func (m *MockDocStorage) AppendBlob(ctx context.Context, blobId, userId string, offset int64, r io.Reader) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendBlob", ctx, blobId, userId, offset, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendBlob indicates an expected call of AppendBlob.
+func (mr *MockDocStorageMockRecorder) AppendBlob(ctx, blobId, userId, offset, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBlob", reflect.TypeOf((*MockDocStorage)(nil).AppendBlob), ctx, blobId, userId, offset, r)
+}
+
+// DeleteBlob mocks base method.
+func (m *MockDocStorage) DeleteBlob(ctx context.Context, blobId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlob", ctx, blobId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlob indicates an expected call of DeleteBlob.
+func (mr *MockDocStorageMockRecorder) DeleteBlob(ctx, blobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockDocStorage)(nil).DeleteBlob), ctx, blobId)
+}
+
+// DeleteHistory mocks base method.
+func (m *MockDocStorage) DeleteHistory(ctx context.Context, docId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHistory", ctx, docId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHistory indicates an expected call of DeleteHistory.
+func (mr *MockDocStorageMockRecorder) DeleteHistory(ctx, docId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistory", reflect.TypeOf((*MockDocStorage)(nil).DeleteHistory), ctx, docId, userId)
+}
+
+// DeleteRevisions mocks base method.
+func (m *MockDocStorage) DeleteRevisions(ctx context.Context, docId, userId string, serials []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRevisions", ctx, docId, userId, serials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRevisions indicates an expected call of DeleteRevisions.
+func (mr *MockDocStorageMockRecorder) DeleteRevisions(ctx, docId, userId, serials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRevisions", reflect.TypeOf((*MockDocStorage)(nil).DeleteRevisions), ctx, docId, userId, serials)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockDocStorage) DeleteUpload(ctx context.Context, uploadId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, uploadId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockDocStorageMockRecorder) DeleteUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockDocStorage)(nil).DeleteUpload), ctx, uploadId)
+}
+
+// DeleteUserDocuments mocks base method.
+func (m *MockDocStorage) DeleteUserDocuments(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserDocuments", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserDocuments indicates an expected call of DeleteUserDocuments.
+func (mr *MockDocStorageMockRecorder) DeleteUserDocuments(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserDocuments", reflect.TypeOf((*MockDocStorage)(nil).DeleteUserDocuments), ctx, userId)
+}
+
+// DeleteUserUploads mocks base method.
+func (m *MockDocStorage) DeleteUserUploads(ctx context.Context, userId string) ([]models.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserUploads", ctx, userId)
+	ret0, _ := ret[0].([]models.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserUploads indicates an expected call of DeleteUserUploads.
+func (mr *MockDocStorageMockRecorder) DeleteUserUploads(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserUploads", reflect.TypeOf((*MockDocStorage)(nil).DeleteUserUploads), ctx, userId)
+}
+
 // GetCard mocks base method.
 func (m *MockDocStorage) GetCard(ctx context.Context, docId, userId string) (models.Card, error) {
 	m.ctrl.T.Helper()
@@ -153,19 +268,19 @@ func (mr *MockDocStorageMockRecorder) GetCredentialsStream(ctx, userId, minSeria
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialsStream", reflect.TypeOf((*MockDocStorage)(nil).GetCredentialsStream), ctx, userId, minSerial, maxSerial, chData)
 }
 
-// GetFile mocks base method.
-func (m *MockDocStorage) GetFile(ctx context.Context, docId, userId string) (models.File, error) {
+// GetExpiredUploads mocks base method.
+func (m *MockDocStorage) GetExpiredUploads(ctx context.Context, before int64) ([]models.Upload, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFile", ctx, docId, userId)
-	ret0, _ := ret[0].(models.File)
+	ret := m.ctrl.Call(m, "GetExpiredUploads", ctx, before)
+	ret0, _ := ret[0].([]models.Upload)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFile indicates an expected call of GetFile.
-func (mr *MockDocStorageMockRecorder) GetFile(ctx, docId, userId interface{}) *gomock.Call {
+// GetExpiredUploads indicates an expected call of GetExpiredUploads.
+func (mr *MockDocStorageMockRecorder) GetExpiredUploads(ctx, before interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockDocStorage)(nil).GetFile), ctx, docId, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredUploads", reflect.TypeOf((*MockDocStorage)(nil).GetExpiredUploads), ctx, before)
 }
 
 // GetFileInfo mocks base method.
@@ -197,6 +312,20 @@ func (mr *MockDocStorageMockRecorder) GetFilesInfoStream(ctx, userId, minSerial,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilesInfoStream", reflect.TypeOf((*MockDocStorage)(nil).GetFilesInfoStream), ctx, userId, minSerial, maxSerial, chData)
 }
 
+// GetHistoryStream mocks base method.
+func (m *MockDocStorage) GetHistoryStream(ctx context.Context, docId, userId string, chData chan interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryStream", ctx, docId, userId, chData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetHistoryStream indicates an expected call of GetHistoryStream.
+func (mr *MockDocStorageMockRecorder) GetHistoryStream(ctx, docId, userId, chData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryStream", reflect.TypeOf((*MockDocStorage)(nil).GetHistoryStream), ctx, docId, userId, chData)
+}
+
 // GetNote mocks base method.
 func (m *MockDocStorage) GetNote(ctx context.Context, docId, userId string) (models.Note, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +355,50 @@ func (mr *MockDocStorageMockRecorder) GetNotesStream(ctx, userId, minSerial, max
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesStream", reflect.TypeOf((*MockDocStorage)(nil).GetNotesStream), ctx, userId, minSerial, maxSerial, chData)
 }
 
+// GetRevision mocks base method.
+func (m *MockDocStorage) GetRevision(ctx context.Context, docId, userId string, serial int64) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, docId, userId, serial)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockDocStorageMockRecorder) GetRevision(ctx, docId, userId, serial interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockDocStorage)(nil).GetRevision), ctx, docId, userId, serial)
+}
+
+// GetTrashStream mocks base method.
+func (m *MockDocStorage) GetTrashStream(ctx context.Context, trashedBefore int64, chData chan interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashStream", ctx, trashedBefore, chData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTrashStream indicates an expected call of GetTrashStream.
+func (mr *MockDocStorageMockRecorder) GetTrashStream(ctx, trashedBefore, chData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashStream", reflect.TypeOf((*MockDocStorage)(nil).GetTrashStream), ctx, trashedBefore, chData)
+}
+
+// GetUpload mocks base method.
+func (m *MockDocStorage) GetUpload(ctx context.Context, uploadId string) (models.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, uploadId)
+	ret0, _ := ret[0].(models.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockDocStorageMockRecorder) GetUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockDocStorage)(nil).GetUpload), ctx, uploadId)
+}
+
 // ModifyCard mocks base method.
 func (m *MockDocStorage) ModifyCard(ctx context.Context, card models.Card) error {
 	m.ctrl.T.Helper()
@@ -295,3 +468,62 @@ func (mr *MockDocStorageMockRecorder) ModifyNote(ctx, note interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyNote", reflect.TypeOf((*MockDocStorage)(nil).ModifyNote), ctx, note)
 }
+
+// OpenBlob mocks base method.
+func (m *MockDocStorage) OpenBlob(ctx context.Context, blobId string, offset int64) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenBlob", ctx, blobId, offset)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenBlob indicates an expected call of OpenBlob.
+func (mr *MockDocStorageMockRecorder) OpenBlob(ctx, blobId, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenBlob", reflect.TypeOf((*MockDocStorage)(nil).OpenBlob), ctx, blobId, offset)
+}
+
+// PutBlob mocks base method.
+func (m *MockDocStorage) PutBlob(ctx context.Context, userId string, r io.Reader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBlob", ctx, userId, r)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutBlob indicates an expected call of PutBlob.
+func (mr *MockDocStorageMockRecorder) PutBlob(ctx, userId, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBlob", reflect.TypeOf((*MockDocStorage)(nil).PutBlob), ctx, userId, r)
+}
+
+// SetUploadOffset mocks base method.
+func (m *MockDocStorage) SetUploadOffset(ctx context.Context, uploadId string, offset, updatedAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUploadOffset", ctx, uploadId, offset, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUploadOffset indicates an expected call of SetUploadOffset.
+func (mr *MockDocStorageMockRecorder) SetUploadOffset(ctx, uploadId, offset, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUploadOffset", reflect.TypeOf((*MockDocStorage)(nil).SetUploadOffset), ctx, uploadId, offset, updatedAt)
+}
+
+// TrimHistory mocks base method.
+func (m *MockDocStorage) TrimHistory(ctx context.Context, docId, userId string, keep int) ([]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrimHistory", ctx, docId, userId, keep)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrimHistory indicates an expected call of TrimHistory.
+func (mr *MockDocStorageMockRecorder) TrimHistory(ctx, docId, userId, keep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrimHistory", reflect.TypeOf((*MockDocStorage)(nil).TrimHistory), ctx, docId, userId, keep)
+}